@@ -0,0 +1,100 @@
+package apiextensions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/iotaledger/wasp/clients/apiclient"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+)
+
+// The backups of the chains can be large, while the generated API client
+// buffers the whole request and response bodies. Hence the backup endpoints
+// are called directly, streaming the body to/from the caller.
+
+func newStreamRequest(ctx context.Context, client *apiclient.APIClient, method, path string, body io.Reader) (*http.Request, error) {
+	config := client.GetConfig()
+	baseURL, err := config.ServerURLWithContext(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	for header, value := range config.DefaultHeader {
+		req.Header.Set(header, value)
+	}
+	if config.UserAgent != "" {
+		req.Header.Set("User-Agent", config.UserAgent)
+	}
+
+	return req, nil
+}
+
+func doStreamRequest(client *apiclient.APIClient, req *http.Request) (*http.Response, error) {
+	res, err := client.GetConfig().HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode >= 300 {
+		defer res.Body.Close()
+		body, _ := io.ReadAll(res.Body)
+
+		var detailError APIDetailError
+		if json.Unmarshal(body, &detailError) == nil && detailError.Message != "" {
+			return nil, fmt.Errorf("%s: %s: %s", res.Status, detailError.Message, detailError.Error)
+		}
+		return nil, fmt.Errorf("%s: %s", res.Status, body)
+	}
+
+	return res, nil
+}
+
+// BackupChain downloads the backup of the chain from the node and writes it to w.
+func BackupChain(ctx context.Context, client *apiclient.APIClient, chainID string, w io.Writer) error {
+	req, err := newStreamRequest(ctx, client, http.MethodGet, "/v1/node/backup/"+url.PathEscape(chainID), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+
+	res, err := doStreamRequest(client, req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	_, err = io.Copy(w, res.Body)
+	return err
+}
+
+// RestoreChain uploads the backup of the chain, read from r, to the node.
+func RestoreChain(ctx context.Context, client *apiclient.APIClient, chainID string, r io.Reader) (*models.ChainRestoreResponse, error) {
+	req, err := newStreamRequest(ctx, client, http.MethodPost, "/v1/node/restore/"+url.PathEscape(chainID), r)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := doStreamRequest(client, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var response models.ChainRestoreResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
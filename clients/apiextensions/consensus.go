@@ -0,0 +1,36 @@
+package apiextensions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/iotaledger/wasp/clients/apiclient"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+)
+
+// GetConsensusStatus queries the consensus status of a chain from the node. The endpoint is not
+// part of the generated API client yet, hence it is called directly.
+func GetConsensusStatus(ctx context.Context, client *apiclient.APIClient, chainID string) (*models.ConsensusStatusResponse, error) {
+	path := fmt.Sprintf("/v1/chains/%s/consensus", url.PathEscape(chainID))
+	req, err := newStreamRequest(ctx, client, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := doStreamRequest(client, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var response models.ConsensusStatusResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,68 @@
+package apiextensions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/clients/apiclient"
+	"github.com/iotaledger/wasp/packages/eventindex"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+)
+
+// GetIndexedEvents queries the event index of the node. The index is not part of the generated
+// API client yet, hence it is called directly. To fetch the next page, pass the NextCursor of the
+// response as the cursor of the query.
+func GetIndexedEvents(ctx context.Context, client *apiclient.APIClient, chainID isc.ChainID, query *eventindex.Query) (*models.IndexedEventsResponse, error) {
+	values := url.Values{}
+	if query.Contract != 0 {
+		values.Set("contractHname", query.Contract.String())
+	}
+	if query.Topic != "" {
+		values.Set("topic", query.Topic)
+	}
+	if query.Sender != nil {
+		values.Set("sender", query.Sender.String())
+	}
+	if query.FromBlock != 0 {
+		values.Set("fromBlock", strconv.FormatUint(uint64(query.FromBlock), 10))
+	}
+	if query.ToBlock != 0 {
+		values.Set("toBlock", strconv.FormatUint(uint64(query.ToBlock), 10))
+	}
+	if query.Cursor != nil {
+		values.Set("cursor", iotago.EncodeHex(query.Cursor))
+	}
+	if query.Limit != 0 {
+		values.Set("limit", strconv.Itoa(query.Limit))
+	}
+
+	path := fmt.Sprintf("/v1/chains/%s/events", url.PathEscape(chainID.String()))
+	if len(values) > 0 {
+		path += "?" + values.Encode()
+	}
+
+	req, err := newStreamRequest(ctx, client, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := doStreamRequest(client, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var response models.IndexedEventsResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,50 @@
+package apiextensions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/clients/apiclient"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+)
+
+// The proof endpoints are not part of the generated API client yet, hence they are called directly.
+// The returned proofs are not verified, use the lightclient package to verify them against L1.
+
+func getProof(ctx context.Context, client *apiclient.APIClient, path string) (*models.StateProofResponse, error) {
+	req, err := newStreamRequest(ctx, client, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := doStreamRequest(client, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var response models.StateProofResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// GetStateProof fetches the Merkle proof of a key in the state of the given block.
+func GetStateProof(ctx context.Context, client *apiclient.APIClient, chainID isc.ChainID, blockIndex uint32, key []byte) (*models.StateProofResponse, error) {
+	return getProof(ctx, client, fmt.Sprintf("/v1/chains/%s/proofs/%d/state/%s",
+		url.PathEscape(chainID.String()), blockIndex, iotago.EncodeHex(key)))
+}
+
+// GetReceiptProof fetches the Merkle proof of the receipt of a request in the state of the given block.
+func GetReceiptProof(ctx context.Context, client *apiclient.APIClient, chainID isc.ChainID, blockIndex uint32, requestID isc.RequestID) (*models.StateProofResponse, error) {
+	return getProof(ctx, client, fmt.Sprintf("/v1/chains/%s/proofs/%d/receipts/%s",
+		url.PathEscape(chainID.String()), blockIndex, requestID.String()))
+}
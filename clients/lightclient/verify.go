@@ -0,0 +1,169 @@
+// Package lightclient verifies the Merkle proofs returned by the proof endpoints of a wasp node
+// against the alias output of the chain on L1, without trusting the node.
+package lightclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/transaction"
+	"github.com/iotaledger/wasp/packages/trie"
+	"github.com/iotaledger/wasp/packages/util"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+)
+
+var ErrInvalidProof = errors.New("invalid proof")
+
+// L1OutputFetcher fetches the outputs from a trusted L1 node. It is implemented by the nodeclient of iota.go.
+type L1OutputFetcher interface {
+	OutputByID(ctx context.Context, outputID iotago.OutputID) (iotago.Output, error)
+}
+
+// VerifiedProof is the content of a proof, which was verified against L1.
+type VerifiedProof struct {
+	BlockIndex   uint32
+	Key          []byte
+	Value        []byte // nil, if the key is not in the state
+	L1Commitment *state.L1Commitment
+	AliasOutput  *isc.AliasOutputWithID
+}
+
+func invalidProof(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidProof, fmt.Sprintf(format, args...))
+}
+
+// VerifyStateProof verifies the proof of a key in the state of the chain. The value is trusted only if:
+//   - the alias output in the response exists on L1, belongs to the chain and anchors the block of the response,
+//   - the L1 commitment in the alias output is the one in the response,
+//   - the Merkle proof is about the requested key and proves the value (or its absence) against the trie root of the L1 commitment.
+func VerifyStateProof(ctx context.Context, l1 L1OutputFetcher, chainID isc.ChainID, key []byte, response *models.StateProofResponse) (*VerifiedProof, error) {
+	responseKey, err := iotago.DecodeHex(response.Key)
+	if err != nil {
+		return nil, invalidProof("key: %v", err)
+	}
+	if !bytes.Equal(responseKey, key) {
+		return nil, invalidProof("the proof is about key %s, expected %s", response.Key, iotago.EncodeHex(key))
+	}
+	value, err := iotago.DecodeHex(response.Value)
+	if err != nil {
+		return nil, invalidProof("value: %v", err)
+	}
+	if len(value) == 0 {
+		value = nil
+	}
+	proofBytes, err := iotago.DecodeHex(response.Proof)
+	if err != nil {
+		return nil, invalidProof("proof: %v", err)
+	}
+	proof, err := trie.MerkleProofFromBytes(proofBytes)
+	if err != nil {
+		return nil, invalidProof("proof: %v", err)
+	}
+	l1cBytes, err := iotago.DecodeHex(response.L1Commitment)
+	if err != nil {
+		return nil, invalidProof("L1 commitment: %v", err)
+	}
+	l1c, err := state.L1CommitmentFromBytes(l1cBytes)
+	if err != nil {
+		return nil, invalidProof("L1 commitment: %v", err)
+	}
+	aliasOutputID, err := iotago.OutputIDFromHex(response.AliasOutputID)
+	if err != nil {
+		return nil, invalidProof("alias output ID: %v", err)
+	}
+
+	aliasOutput, err := anchoringAliasOutput(ctx, l1, chainID, aliasOutputID)
+	if err != nil {
+		return nil, err
+	}
+	if aliasOutput.GetStateIndex() != response.BlockIndex {
+		return nil, invalidProof("the alias output anchors block %d, expected %d", aliasOutput.GetStateIndex(), response.BlockIndex)
+	}
+	anchoredL1C, err := transaction.L1CommitmentFromAliasOutput(aliasOutput.GetAliasOutput())
+	if err != nil {
+		return nil, invalidProof("the alias output has no L1 commitment: %v", err)
+	}
+	if !anchoredL1C.Equals(l1c) {
+		return nil, invalidProof("the L1 commitment %s is not the one anchored on L1: %s", l1c, anchoredL1C)
+	}
+
+	if !proof.IsProofOfKey(key) {
+		return nil, invalidProof("the Merkle proof is not about key %s", iotago.EncodeHex(key))
+	}
+	if value == nil {
+		if err := proof.Validate(l1c.TrieRoot().Bytes()); err != nil {
+			return nil, invalidProof("%v", err)
+		}
+		if !proof.IsProofOfAbsence() {
+			return nil, invalidProof("the key is in the state, but no value was returned")
+		}
+	} else if err := proof.ValidateValue(l1c.TrieRoot(), value); err != nil {
+		return nil, invalidProof("%v", err)
+	}
+
+	return &VerifiedProof{
+		BlockIndex:   response.BlockIndex,
+		Key:          key,
+		Value:        value,
+		L1Commitment: l1c,
+		AliasOutput:  aliasOutput,
+	}, nil
+}
+
+// VerifyReceiptProof verifies the proof of the receipt of a request, and returns the receipt.
+// See VerifyStateProof for the checks made.
+func VerifyReceiptProof(ctx context.Context, l1 L1OutputFetcher, chainID isc.ChainID, requestID isc.RequestID, response *models.StateProofResponse) (*blocklog.RequestReceipt, error) {
+	// the key of the receipt is made of the index of the block, in which the request was processed,
+	// and the index of the request in that block
+	key, err := iotago.DecodeHex(response.Key)
+	if err != nil {
+		return nil, invalidProof("key: %v", err)
+	}
+	var lookupKey blocklog.RequestLookupKey
+	if len(key) < len(lookupKey) {
+		return nil, invalidProof("%s is not a key of a receipt", response.Key)
+	}
+	copy(lookupKey[:], key[len(key)-len(lookupKey):])
+	receiptKey := append(blocklog.Contract.Hname().Bytes(), blocklog.RequestReceiptKey(lookupKey)...)
+	if !bytes.Equal(key, receiptKey) {
+		return nil, invalidProof("%s is not a key of a receipt", response.Key)
+	}
+
+	verified, err := VerifyStateProof(ctx, l1, chainID, receiptKey, response)
+	if err != nil {
+		return nil, err
+	}
+	if verified.Value == nil {
+		return nil, invalidProof("the receipt is not in the state")
+	}
+	receipt, err := blocklog.RequestReceiptFromBytes(verified.Value, lookupKey.BlockIndex(), lookupKey.RequestIndex())
+	if err != nil {
+		return nil, invalidProof("receipt: %v", err)
+	}
+	if !receipt.Request.ID().Equals(requestID) {
+		return nil, invalidProof("the receipt is of request %s, expected %s", receipt.Request.ID(), requestID)
+	}
+	return receipt, nil
+}
+
+// anchoringAliasOutput fetches the alias output from L1 and checks that it belongs to the chain.
+func anchoringAliasOutput(ctx context.Context, l1 L1OutputFetcher, chainID isc.ChainID, outputID iotago.OutputID) (*isc.AliasOutputWithID, error) {
+	output, err := l1.OutputByID(ctx, outputID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the alias output %s from L1: %w", outputID.ToHex(), err)
+	}
+	aliasOutput, ok := output.(*iotago.AliasOutput)
+	if !ok {
+		return nil, invalidProof("output %s is not an alias output", outputID.ToHex())
+	}
+	if util.AliasIDFromAliasOutput(aliasOutput, outputID) != chainID.AsAliasID() {
+		return nil, invalidProof("alias output %s does not belong to chain %s", outputID.ToHex(), chainID)
+	}
+	return isc.NewAliasOutputWithID(aliasOutput, outputID), nil
+}
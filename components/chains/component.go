@@ -104,6 +104,7 @@ func provide(c *dig.Container) error {
 				deps.NetworkProvider,
 				deps.TrustedNetworkManager,
 				deps.ChainStateDatabaseManager.ChainStateKVStore,
+				deps.ChainStateDatabaseManager.FlushChainState,
 				ParamsWAL.LoadToStore,
 				ParamsWAL.Enabled,
 				ParamsWAL.Path,
@@ -118,8 +119,19 @@ func provide(c *dig.Container) error {
 				ParamsSnapshotManager.SnapshotsToLoad,
 				ParamsSnapshotManager.Period,
 				ParamsSnapshotManager.Delay,
+				ParamsSnapshotManager.DeltaPeriod,
 				ParamsSnapshotManager.LocalPath,
 				ParamsSnapshotManager.NetworkPaths,
+				ParamsSnapshotManager.UploadPaths,
+				ParamsSnapshotManager.RequireSignedManifests,
+				ParamsPruning.Policy,
+				ParamsPruning.KeepLastBlocks,
+				ParamsPruning.KeepTimeWindow,
+				ParamsPruning.CheckpointPeriod,
+				ParamsPruning.Period,
+				ParamsPruning.MaxStatesPerSecond,
+				ParamsPruning.FlushThreshold,
+				ParamsChains.ArchiveMode,
 				deps.ChainRecordRegistryProvider,
 				deps.DKShareRegistryProvider,
 				deps.NodeIdentityProvider,
@@ -127,6 +139,10 @@ func provide(c *dig.Container) error {
 				deps.ChainListener,
 				ParamsChains.MempoolTTL,
 				ParamsChains.BroadcastInterval,
+				ParamsChains.MempoolJournalEnabled,
+				ParamsChains.MempoolOrderingPolicy,
+				ParamsChains.MempoolMaxProposalSize,
+				ParamsChains.MempoolMaxRequestsPerAccount,
 				shutdown.NewCoordinator("chains", Component.Logger().Named("Shutdown")),
 				deps.ChainMetricsProvider,
 			),
@@ -20,6 +20,11 @@ type ParametersChains struct {
 	ConsensusInstsInAdvance          int           `default:"3" usage:""`
 	AwaitReceiptCleanupEvery         int           `default:"100" usage:"for every this number AwaitReceipt will be cleaned up"`
 	MempoolTTL                       time.Duration `default:"24h" usage:"Time that requests are allowed to sit in the mempool without being processed"`
+	MempoolJournalEnabled            bool          `default:"true" usage:"whether the off-ledger requests in the mempool are persisted to survive node restarts"`
+	MempoolOrderingPolicy            []string      `default:"fifo" usage:"which requests are proposed first: 'fifo', 'feePriority' (highest fee first) or 'roundRobin' (one request of each sender at a time); can be either a single policy for all the chains or list of '<chainID>:<policy>' to configure many chains"`
+	MempoolMaxProposalSize           int           `default:"100" usage:"the maximal number of requests proposed to a single consensus instance, the ordering policy only matters if it is set; 0 means unlimited"`
+	MempoolMaxRequestsPerAccount     int           `default:"0" usage:"the maximal number of off-ledger requests of a single account waiting in the mempool; 0 means unlimited"`
+	ArchiveMode                      bool          `default:"false" usage:"whether the states of all the blocks are kept; if enabled, 'stateManager.pruningMinStatesToKeep' and 'pruning.policy' are ignored"`
 }
 
 type ParametersWAL struct {
@@ -39,16 +44,29 @@ type ParametersStateManager struct {
 	StateManagerGetBlockRetry         time.Duration `default:"3s" usage:"how often get block requests should be repeated"`
 	StateManagerRequestCleaningPeriod time.Duration `default:"1s" usage:"how often requests waiting for response should be checked for expired context"`
 	StateManagerTimerTickPeriod       time.Duration `default:"1s" usage:"how often timer tick fires in state manager"`
-	PruningMinStatesToKeep            int           `default:"10000" usage:"this number of states will always be available in the store; if 0 - store pruning is disabled"`
+	PruningMinStatesToKeep            int           `default:"10000" usage:"this number of states will always be available in the store; if 0 - store pruning is disabled; ignored if pruning service is enabled"`
 	PruningMaxStatesToDelete          int           `default:"1000" usage:"on single store pruning attempt at most this number of states will be deleted"`
 }
 
 type ParametersSnapshotManager struct {
-	SnapshotsToLoad []string `default:"" usage:"list of snapshots to load; can be either single block hash of a snapshot (if a single chain has to be configured) or list of '<chainID>:<blockHash>' to configure many chains"`
-	Period          uint32   `default:"0" usage:"how often state snapshots should be made: 1000 meaning \"every 1000th state\", 0 meaning \"making snapshots is disabled\""`
-	Delay           uint32   `default:"20" usage:"how many states should pass before snapshot is produced"`
-	LocalPath       string   `default:"waspdb/snap" usage:"the path to the snapshots folder in this node's disk"`
-	NetworkPaths    []string `default:"" usage:"the list of paths to the remote (http(s)) snapshot locations; each of listed locations must contain 'INDEX' file with list of snapshot files"`
+	SnapshotsToLoad        []string `default:"" usage:"list of snapshots to load; can be either single block hash of a snapshot (if a single chain has to be configured) or list of '<chainID>:<blockHash>' to configure many chains"`
+	Period                 uint32   `default:"0" usage:"how often state snapshots should be made: 1000 meaning \"every 1000th state\", 0 meaning \"making snapshots is disabled\""`
+	Delay                  uint32   `default:"20" usage:"how many states should pass before snapshot is produced"`
+	DeltaPeriod            uint32   `default:"0" usage:"how often delta snapshots should be made between the full ones: 100 meaning \"every 100th state\", 0 meaning \"making delta snapshots is disabled\""`
+	LocalPath              string   `default:"waspdb/snap" usage:"the path to the snapshots folder in this node's disk"`
+	NetworkPaths           []string `default:"" usage:"the list of paths to the remote (file or http(s)) snapshot locations; each of listed http(s) locations must contain 'INDEX' file with list of snapshot files"`
+	UploadPaths            []string `default:"" usage:"the list of paths to the remote (file or http(s) supporting PUT) snapshot locations, where created snapshots are uploaded to"`
+	RequireSignedManifests bool     `default:"true" usage:"whether only the snapshots with a manifest, signed by a trusted peer, may be loaded from the network paths; the snapshots in the local path are loaded without it"`
+}
+
+type ParametersPruning struct {
+	Policy             string        `default:"none" usage:"which states should be pruned by the background pruning service: 'none' (pruning service is disabled), 'keepLastBlocks', 'keepTimeWindow' or 'keepCheckpoints'"`
+	KeepLastBlocks     uint32        `default:"10000" usage:"how many latest states should be kept by 'keepLastBlocks' and 'keepCheckpoints' policies"`
+	KeepTimeWindow     time.Duration `default:"720h" usage:"states not older than this should be kept by 'keepTimeWindow' policy"`
+	CheckpointPeriod   uint32        `default:"10000" usage:"states with index divisible by this number should be kept by 'keepCheckpoints' policy"`
+	Period             time.Duration `default:"1m" usage:"how often the pruning service should run"`
+	MaxStatesPerSecond int           `default:"100" usage:"at most this number of states should be pruned per second; 0 meaning \"unlimited\""`
+	FlushThreshold     int           `default:"10000" usage:"the database is flushed if at least this number of states is pruned in a single run, so that the background compactions reclaim the space sooner; 0 meaning \"flushing is disabled\""`
 }
 
 var (
@@ -57,6 +75,7 @@ var (
 	ParamsValidator       = &ParametersValidator{}
 	ParamsStateManager    = &ParametersStateManager{}
 	ParamsSnapshotManager = &ParametersSnapshotManager{}
+	ParamsPruning         = &ParametersPruning{}
 )
 
 var params = &app.ComponentParams{
@@ -66,6 +85,7 @@ var params = &app.ComponentParams{
 		"validator":    ParamsValidator,
 		"stateManager": ParamsStateManager,
 		"snapshots":    ParamsSnapshotManager,
+		"pruning":      ParamsPruning,
 	},
 	Masked: nil,
 }
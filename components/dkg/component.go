@@ -29,7 +29,9 @@ func provide(c *dig.Container) error {
 
 		NodeIdentityProvider    registry.NodeIdentityProvider
 		DKShareRegistryProvider registry.DKShareRegistryProvider
-		NetworkProvider         peering.NetworkProvider `name:"networkProvider"`
+		DKGAuditLogProvider     registry.DKGAuditLogProvider
+		NetworkProvider         peering.NetworkProvider       `name:"networkProvider"`
+		TrustedNetworkManager   peering.TrustedNetworkManager `name:"trustedNetworkManager"`
 	}
 
 	type nodeResult struct {
@@ -42,7 +44,9 @@ func provide(c *dig.Container) error {
 		node, err := dkg.NewNode(
 			deps.NodeIdentityProvider.NodeIdentity(),
 			deps.NetworkProvider,
+			deps.TrustedNetworkManager,
 			deps.DKShareRegistryProvider,
+			deps.DKGAuditLogProvider,
 			Component.Logger(),
 		)
 		if err != nil {
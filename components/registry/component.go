@@ -4,12 +4,14 @@
 package registry
 
 import (
+	"errors"
+	"os"
 	"path"
+	"path/filepath"
 
 	"go.uber.org/dig"
 
 	"github.com/iotaledger/hive.go/app"
-	hivep2p "github.com/iotaledger/hive.go/crypto/p2p"
 	"github.com/iotaledger/hive.go/runtime/ioutils"
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/chain/cmt_log"
@@ -28,8 +30,23 @@ func init() {
 var Component *app.Component
 
 func provide(c *dig.Container) error {
+	keyEncryption, err := registry.NewKeyEncryptionFromParams(ParamsKeyEncryption.Passphrase, ParamsKeyEncryption.KeyFilePath)
+	if err != nil {
+		Component.LogPanicf("unable to initialize the encryption of the keys: %s", err)
+	}
+	if keyEncryption != nil {
+		warnPlainTextKeys()
+	}
+
+	// nil, if the keys are stored in plain text
+	if err := c.Provide(func() *registry.KeyEncryption {
+		return keyEncryption
+	}); err != nil {
+		Component.LogPanic(err)
+	}
+
 	if err := c.Provide(func() registry.NodeIdentityProvider {
-		return nodeIdentityRegistry()
+		return nodeIdentityRegistry(keyEncryption)
 	}); err != nil {
 		Component.LogPanic(err)
 	}
@@ -68,8 +85,9 @@ func provide(c *dig.Container) error {
 	}
 
 	if err := c.Provide(func(deps dkSharesRegistryDeps) registry.DKShareRegistryProvider {
-		dkSharesRegistry, err := registry.NewDKSharesRegistry(ParamsRegistries.DKShares.Path, deps.NodeIdentityProvider.NodeIdentity().GetPrivateKey(), deps.NodeConnection.GetBech32HRP())
+		dkSharesRegistry, err := registry.NewDKSharesRegistry(ParamsRegistries.DKShares.Path, deps.NodeIdentityProvider.NodeIdentity().GetPrivateKey(), deps.NodeConnection.GetBech32HRP(), keyEncryption)
 		if err != nil {
+			panicIfKeysLocked(err)
 			Component.LogPanic(err)
 		}
 		return dkSharesRegistry
@@ -77,6 +95,22 @@ func provide(c *dig.Container) error {
 		Component.LogPanic(err)
 	}
 
+	type dkgAuditLogDeps struct {
+		dig.In
+
+		NodeConnection chain.NodeConnection
+	}
+
+	if err := c.Provide(func(deps dkgAuditLogDeps) registry.DKGAuditLogProvider {
+		dkgAuditLog, err := registry.NewDKGAuditLog(ParamsRegistries.DKGAuditLog.FilePath, deps.NodeConnection.GetBech32HRP())
+		if err != nil {
+			Component.LogPanic(err)
+		}
+		return dkgAuditLog
+	}); err != nil {
+		Component.LogPanic(err)
+	}
+
 	if err := c.Provide(func() registry.TrustedPeersRegistryProvider {
 		trustedPeersRegistryProvider, err := registry.NewTrustedPeersRegistryImpl(ParamsRegistries.TrustedPeers.FilePath)
 		if err != nil {
@@ -90,7 +124,35 @@ func provide(c *dig.Container) error {
 	return nil
 }
 
-func nodeIdentityRegistry() *registry.NodeIdentity {
+// panicIfKeysLocked makes it clear, how to start a node with the encrypted keys.
+func panicIfKeysLocked(err error) {
+	switch {
+	case errors.Is(err, registry.ErrKeysLocked):
+		Component.LogPanicf(`the node is locked: %s, set either "keyEncryption.passphrase" or "keyEncryption.keyFilePath" to unlock it`, err)
+	case errors.Is(err, registry.ErrKeysWrongSecret):
+		Component.LogPanicf(`the node cannot be unlocked: %s, check the "keyEncryption.passphrase" or "keyEncryption.keyFilePath" parameter`, err)
+	}
+}
+
+// warnPlainTextKeys reminds to migrate the keys stored before the encryption was enabled.
+func warnPlainTextKeys() {
+	files := []string{ParamsP2P.Identity.FilePath}
+	if dkShareFiles, err := filepath.Glob(filepath.Join(ParamsRegistries.DKShares.Path, "*.json")); err == nil {
+		files = append(files, dkShareFiles...)
+	}
+	plainTextCount := 0
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err == nil && !registry.IsEncrypted(data) {
+			plainTextCount++
+		}
+	}
+	if plainTextCount > 0 {
+		Component.LogWarnf(`WARNING: the key encryption is enabled, but %d key file(s) are stored in plain text, use "wasp tools encrypt-keys" to encrypt them`, plainTextCount)
+	}
+}
+
+func nodeIdentityRegistry(keyEncryption *registry.KeyEncryption) *registry.NodeIdentity {
 	if err := ioutils.CreateDirectory(ParamsP2P.Database.Path, 0o700); err != nil {
 		Component.LogPanicf("could not create peer store database dir '%s': %w", ParamsP2P.Database.Path, err)
 	}
@@ -99,8 +161,9 @@ func nodeIdentityRegistry() *registry.NodeIdentity {
 	Component.LogInfof(`WARNING: never share your "%s" or "%s" folder as both contain your node's private key!`, ParamsP2P.Database.Path, path.Dir(ParamsP2P.Identity.FilePath))
 
 	// load up the previously generated identity or create a new one
-	privKey, newlyCreated, err := hivep2p.LoadOrCreateIdentityPrivateKey(ParamsP2P.Identity.FilePath, ParamsP2P.Identity.PrivateKey)
+	waspPrivKey, newlyCreated, err := registry.LoadOrCreateNodeIdentityPrivateKey(ParamsP2P.Identity.FilePath, ParamsP2P.Identity.PrivateKey, keyEncryption)
 	if err != nil {
+		panicIfKeysLocked(err)
 		Component.LogPanic(err)
 	}
 
@@ -110,16 +173,6 @@ func nodeIdentityRegistry() *registry.NodeIdentity {
 		Component.LogInfof(`loaded existing private key for peer identity from "%s"`, ParamsP2P.Identity.FilePath)
 	}
 
-	privKeyBytes, err := privKey.Raw()
-	if err != nil {
-		Component.LogPanicf("unable to convert private key for peer identity: %s", err)
-	}
-
-	waspPrivKey, err := cryptolib.PrivateKeyFromBytes(privKeyBytes)
-	if err != nil {
-		Component.LogPanicf("unable to convert private key for peer identity: %s", err)
-	}
-
 	waspKeyPair := cryptolib.KeyPairFromPrivateKey(waspPrivKey)
 	Component.LogInfof("this node identity: %v", waspKeyPair.GetPublicKey())
 	return registry.NewNodeIdentity(waspKeyPair)
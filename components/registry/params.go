@@ -17,6 +17,9 @@ type ParametersRegistries struct {
 	ConsensusState struct {
 		Path string `default:"waspdb/chains/consensus" usage:"the path to the consensus state registries folder"`
 	}
+	DKGAuditLog struct {
+		FilePath string `default:"waspdb/dkg_audit.log" usage:"the path to the log of the DKG procedures"`
+	} `name:"dkgAuditLog"`
 }
 
 // ParametersP2P contains the definition of the parameters used by p2p.
@@ -32,15 +35,24 @@ type ParametersP2P struct {
 	} `name:"db"`
 }
 
+// ParametersKeyEncryption contains the definition of the parameters used to encrypt the node identity and the DKShares.
+// The DKShares included in the chain backups are encrypted with the same secret.
+type ParametersKeyEncryption struct {
+	Passphrase  string `default:"" usage:"the passphrase used to encrypt the node identity and the distributed key shares (optional, set it in the config file or the environment, not on the command line)"`
+	KeyFilePath string `default:"" usage:"the path to the file holding the secret used to encrypt the node identity and the distributed key shares (optional)"`
+}
+
 var (
-	ParamsRegistries = &ParametersRegistries{}
-	ParamsP2P        = &ParametersP2P{}
+	ParamsRegistries    = &ParametersRegistries{}
+	ParamsP2P           = &ParametersP2P{}
+	ParamsKeyEncryption = &ParametersKeyEncryption{}
 )
 
 var params = &app.ComponentParams{
 	Params: map[string]any{
-		"registries": ParamsRegistries,
-		"p2p":        ParamsP2P,
+		"registries":    ParamsRegistries,
+		"p2p":           ParamsP2P,
+		"keyEncryption": ParamsKeyEncryption,
 	},
-	Masked: []string{"p2p.identity.privateKey"},
+	Masked: []string{"p2p.identity.privateKey", "keyEncryption.passphrase"},
 }
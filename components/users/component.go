@@ -7,6 +7,7 @@ import (
 
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/hive.go/app/configuration"
+	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
 	"github.com/iotaledger/wasp/packages/users"
 )
 
@@ -44,6 +45,7 @@ func provide(c *dig.Container) error {
 					PasswordHash: hex.EncodeToString(u.PasswordHash),
 					PasswordSalt: hex.EncodeToString(u.PasswordSalt),
 					Permissions:  u.PermissionsSlice(),
+					APIKeys:      apiKeysFromUser(u),
 				}
 			}
 
@@ -56,11 +58,22 @@ func provide(c *dig.Container) error {
 
 		// add users from config file to the user manager
 		for name, u := range ParamsUsers.Users {
+			for _, permission := range u.Permissions {
+				if err := permissions.Validate(permission); err != nil {
+					Component.LogWarnf("ignoring permission of user %s: %s", name, err)
+				}
+			}
+
 			user, err := users.NewUser(name, u.PasswordHash, u.PasswordSalt, u.PermissionsMap())
 			if err != nil {
 				Component.LogPanicf("unable to add user to user manager %s: %s", name, err)
 			}
 
+			user.APIKeys, err = u.APIKeysMap()
+			if err != nil {
+				Component.LogPanicf("unable to add user to user manager %s: %s", name, err)
+			}
+
 			if err := userManager.AddUser(user); err != nil {
 				Component.LogPanicf("unable to add user to user manager %s: %s", name, err)
 			}
@@ -1,8 +1,13 @@
 package users
 
 import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
+	"github.com/iotaledger/wasp/packages/users"
 )
 
 const (
@@ -10,9 +15,78 @@ const (
 )
 
 type User struct {
-	PasswordHash string   `default:"0000000000000000000000000000000000000000000000000000000000000000" usage:"the auth password+salt as a scrypt hash"`
-	PasswordSalt string   `default:"0000000000000000000000000000000000000000000000000000000000000000" usage:"the auth salt used for hashing the password"`
-	Permissions  []string `default:"" usage:"permissions of the user"`
+	PasswordHash string             `default:"0000000000000000000000000000000000000000000000000000000000000000" usage:"the auth password+salt as a scrypt hash"`
+	PasswordSalt string             `default:"0000000000000000000000000000000000000000000000000000000000000000" usage:"the auth salt used for hashing the password"`
+	Permissions  []string           `default:"" usage:"roles of the user, optionally restricted to a chain (role@chainID)"`
+	APIKeys      map[string]*APIKey `noflag:"true" usage:"the API keys of the user, by ID"`
+}
+
+type APIKey struct {
+	Name        string   `usage:"the name of the API key"`
+	SecretHash  string   `usage:"the SHA-256 hash of the secret of the API key"`
+	Permissions []string `usage:"the roles granted by the API key, a subset of the roles of the user"`
+	CreatedAt   int64    `usage:"the creation time of the API key (unix seconds)"`
+	ExpiresAt   int64    `usage:"the expiry time of the API key (unix seconds), 0 if the key doesn't expire"`
+	LastUsedAt  int64    `usage:"the last time the API key was used (unix seconds), 0 if it was never used"`
+}
+
+func unixToTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+func timeToUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// APIKeysMap returns the API keys of the user as they are used by the user manager.
+func (u *User) APIKeysMap() (map[string]*users.APIKey, error) {
+	apiKeys := make(map[string]*users.APIKey, len(u.APIKeys))
+	for id, k := range u.APIKeys {
+		secretHash, err := hex.DecodeString(k.SecretHash)
+		if err != nil {
+			return nil, fmt.Errorf("API key %s: secret hash must be hex encoded", id)
+		}
+
+		keyPermissions := make(map[string]struct{}, len(k.Permissions))
+		for _, permission := range k.Permissions {
+			keyPermissions[permission] = struct{}{}
+		}
+
+		apiKeys[id] = &users.APIKey{
+			ID:          id,
+			Name:        k.Name,
+			SecretHash:  secretHash,
+			Permissions: keyPermissions,
+			CreatedAt:   unixToTime(k.CreatedAt),
+			ExpiresAt:   unixToTime(k.ExpiresAt),
+			LastUsedAt:  unixToTime(k.LastUsedAt),
+		}
+	}
+
+	return apiKeys, nil
+}
+
+// apiKeysFromUser returns the API keys of a user of the user manager as they are stored in the config.
+func apiKeysFromUser(u *users.User) map[string]*APIKey {
+	apiKeys := make(map[string]*APIKey, len(u.APIKeys))
+	for id, k := range u.APIKeys {
+		apiKeys[id] = &APIKey{
+			Name:        k.Name,
+			SecretHash:  hex.EncodeToString(k.SecretHash),
+			Permissions: k.PermissionsSlice(),
+			CreatedAt:   timeToUnix(k.CreatedAt),
+			ExpiresAt:   timeToUnix(k.ExpiresAt),
+			LastUsedAt:  timeToUnix(k.LastUsedAt),
+		}
+	}
+
+	return apiKeys
 }
 
 // PermissionsMap returns the permissions of the user as a map.
@@ -36,6 +36,9 @@ import (
 	"github.com/iotaledger/wasp/packages/webapi"
 	"github.com/iotaledger/wasp/packages/webapi/apierrors"
 	"github.com/iotaledger/wasp/packages/webapi/controllers/controllerutils"
+	"github.com/iotaledger/wasp/packages/webapi/interfaces"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+	"github.com/iotaledger/wasp/packages/webapi/services"
 	"github.com/iotaledger/wasp/packages/webapi/websocket"
 )
 
@@ -69,6 +72,7 @@ type dependencies struct {
 	WebsocketHub       *websockethub.Hub   `name:"websocketHub"`
 	NodeConnection     chain.NodeConnection
 	WebsocketPublisher *websocket.Service `name:"websocketService"`
+	EventIndexService  interfaces.EventIndexService
 }
 
 func initConfigParams(c *dig.Container) error {
@@ -88,6 +92,28 @@ func initConfigParams(c *dig.Container) error {
 	return nil
 }
 
+// newIPExtractor returns the extractor of the client IP addresses. The X-Forwarded-For header is only
+// trusted if it is set by one of the trusted proxies, otherwise anyone could choose the IP address
+// used for the rate limits.
+func newIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range trustedProxies {
+		_, ipRange, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, echo.TrustIPRange(ipRange))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
 //nolint:funlen
 func NewEcho(params *ParametersWebAPI, metrics *metrics.ChainMetricsProvider, log *logger.Logger) *echo.Echo {
 	e := httpserver.NewEcho(
@@ -102,6 +128,12 @@ func NewEcho(params *ParametersWebAPI, metrics *metrics.ChainMetricsProvider, lo
 	e.HidePort = true
 	e.HTTPErrorHandler = apierrors.HTTPErrorHandler()
 
+	ipExtractor, err := newIPExtractor(params.TrustedProxies)
+	if err != nil {
+		log.Panicf("failed to parse the trusted proxies: %s", err)
+	}
+	e.IPExtractor = ipExtractor
+
 	webapi.ConfirmedStateLagThreshold = params.Limits.ConfirmedStateLagThreshold
 	authentication.DefaultJWTDuration = params.Auth.JWTConfig.Duration
 
@@ -142,10 +174,22 @@ func NewEcho(params *ParametersWebAPI, metrics *metrics.ChainMetricsProvider, lo
 		}
 	})
 
+	isBackupRequest := func(c echo.Context) bool {
+		return routes.IsBackupPath(c.Path())
+	}
+
 	// timeout middleware
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			timeoutCtx, cancel := context.WithTimeout(c.Request().Context(), params.Limits.Timeout)
+			timeout := params.Limits.Timeout
+			if isBackupRequest(c) {
+				// the backups are transferred in the bodies, so the server timeouts are extended as well
+				timeout = params.Limits.Backup.Timeout
+				if err := extendDeadlines(c, time.Now().Add(timeout)); err != nil {
+					return err
+				}
+			}
+			timeoutCtx, cancel := context.WithTimeout(c.Request().Context(), timeout)
 			defer cancel()
 
 			c.SetRequest(c.Request().WithContext(timeoutCtx))
@@ -177,7 +221,14 @@ func NewEcho(params *ParametersWebAPI, metrics *metrics.ChainMetricsProvider, lo
 		}
 	})
 
-	e.Use(middleware.BodyLimit(params.Limits.MaxBodyLength))
+	e.Use(middleware.BodyLimitWithConfig(middleware.BodyLimitConfig{
+		Skipper: isBackupRequest,
+		Limit:   params.Limits.MaxBodyLength,
+	}))
+	e.Use(middleware.BodyLimitWithConfig(middleware.BodyLimitConfig{
+		Skipper: func(c echo.Context) bool { return !isBackupRequest(c) },
+		Limit:   params.Limits.Backup.MaxBodyLength,
+	}))
 
 	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
 		Format: `${time_rfc3339_nano} ${remote_ip} ${method} ${uri} ${status} error="${error}"` + "\n",
@@ -193,6 +244,18 @@ func NewEcho(params *ParametersWebAPI, metrics *metrics.ChainMetricsProvider, lo
 	return e
 }
 
+// extendDeadlines replaces the read and write timeouts of the server for a single request.
+func extendDeadlines(c echo.Context, deadline time.Time) error {
+	rc := http.NewResponseController(c.Response())
+	if err := rc.SetReadDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	if err := rc.SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
 func CreateEchoSwagger(e *echo.Echo, version string) echoswagger.ApiRoot {
 	echoSwagger := echoswagger.New(e, "/doc", &echoswagger.Info{
 		Title:       "Wasp API",
@@ -223,7 +286,9 @@ func provide(c *dig.Container) error {
 		ChainMetricsProvider        *metrics.ChainMetricsProvider
 		ChainRecordRegistryProvider registry.ChainRecordRegistryProvider
 		DKShareRegistryProvider     registry.DKShareRegistryProvider
+		DKGAuditLogProvider         registry.DKGAuditLogProvider
 		NodeIdentityProvider        registry.NodeIdentityProvider
+		KeyEncryption               *registry.KeyEncryption
 		NetworkProvider             peering.NetworkProvider       `name:"networkProvider"`
 		TrustedNetworkManager       peering.TrustedNetworkManager `name:"trustedNetworkManager"`
 		Node                        *dkg.Node
@@ -238,6 +303,7 @@ func provide(c *dig.Container) error {
 		EchoSwagger        echoswagger.ApiRoot `name:"webapiServer"`
 		WebsocketHub       *websockethub.Hub   `name:"websocketHub"`
 		WebsocketPublisher *websocket.Service  `name:"websocketService"`
+		EventIndexService  interfaces.EventIndexService
 	}
 
 	if err := c.Provide(func(deps webapiServerDeps) webapiServerResult {
@@ -268,7 +334,22 @@ func provide(c *dig.Container) error {
 			}))
 		}
 
-		webapi.Init(
+		offLedgerLimits := ParamsWebAPI.Limits.OffLedger
+		offLedgerRateLimitParams, err := services.NewOffLedgerRateLimitParameters(
+			services.OffLedgerRateLimits{
+				Global:    services.RateLimit{RequestsPerSecond: offLedgerLimits.GlobalRequestsPerSecond, Burst: offLedgerLimits.GlobalBurst},
+				PerIP:     services.RateLimit{RequestsPerSecond: offLedgerLimits.IPRequestsPerSecond, Burst: offLedgerLimits.IPBurst},
+				PerSender: services.RateLimit{RequestsPerSecond: offLedgerLimits.SenderRequestsPerSecond, Burst: offLedgerLimits.SenderBurst},
+			},
+			offLedgerLimits.ChainRequestsPerSecond,
+			offLedgerLimits.ClientCleanupDuration,
+			offLedgerLimits.MaxClients,
+		)
+		if err != nil {
+			Component.LogPanicf("failed to parse the off-ledger rate limits: %s", err)
+		}
+
+		eventIndexService := webapi.Init(
 			logger,
 			echoSwagger,
 			deps.AppInfo.Version,
@@ -278,7 +359,9 @@ func provide(c *dig.Container) error {
 			deps.UserManager,
 			deps.ChainRecordRegistryProvider,
 			deps.DKShareRegistryProvider,
+			deps.DKGAuditLogProvider,
 			deps.NodeIdentityProvider,
+			deps.KeyEncryption,
 			func() *chains.Chains {
 				return deps.Chains
 			},
@@ -291,6 +374,8 @@ func provide(c *dig.Container) error {
 			deps.APICacheTTL,
 			websocketService,
 			ParamsWebAPI.IndexDbPath,
+			ParamsWebAPI.EventIndex.Enabled,
+			ParamsWebAPI.EventIndex.DbPath,
 			deps.Publisher,
 			jsonrpc.NewParameters(
 				ParamsWebAPI.Limits.Jsonrpc.MaxBlocksInLogsFilterRange,
@@ -299,13 +384,16 @@ func provide(c *dig.Container) error {
 				ParamsWebAPI.Limits.Jsonrpc.WebsocketRateLimitBurst,
 				ParamsWebAPI.Limits.Jsonrpc.WebsocketConnectionCleanupDuration,
 				ParamsWebAPI.Limits.Jsonrpc.WebsocketClientBlockDuration,
+				ParamsWebAPI.Limits.Jsonrpc.FilterTimeout,
 			),
+			offLedgerRateLimitParams,
 		)
 
 		return webapiServerResult{
 			EchoSwagger:        echoSwagger,
 			WebsocketHub:       hub,
 			WebsocketPublisher: websocketService,
+			EventIndexService:  eventIndexService,
 		}
 	}); err != nil {
 		Component.LogPanic(err)
@@ -348,6 +436,7 @@ func run() error {
 		if err := deps.EchoSwagger.Echo().Shutdown(shutdownCtx); err != nil {
 			Component.LogWarn(err)
 		}
+		deps.EventIndexService.Close()
 
 		Component.LogInfof("Stopping %s server ... done", Component.Name)
 	}, daemon.PriorityWebAPI); err != nil {
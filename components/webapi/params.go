@@ -10,12 +10,19 @@ import (
 type ParametersWebAPI struct {
 	Enabled                   bool                             `default:"true" usage:"whether the web api plugin is enabled"`
 	BindAddress               string                           `default:"0.0.0.0:9090" usage:"the bind address for the node web api"`
+	TrustedProxies            []string                         `default:"" usage:"list of the IP ranges (CIDR) of the reverse proxies whose X-Forwarded-For header is trusted to get the IP address of the client; if empty, the IP address of the connection is used"`
 	Auth                      authentication.AuthConfiguration `usage:"configures the authentication for the API service"`
 	IndexDbPath               string                           `default:"waspdb/chains/index" usage:"directory for storing indexes of historical data (only archive nodes will create/use them)"`
+	EventIndex                ParametersEventIndex
 	Limits                    ParametersWebAPILimits
 	DebugRequestLoggerEnabled bool `default:"false" usage:"whether the debug logging for requests should be enabled"`
 }
 
+type ParametersEventIndex struct {
+	Enabled bool   `default:"false" usage:"whether the events issued by the contracts are indexed, so they can be queried after the blocks are pruned"`
+	DbPath  string `default:"waspdb/chains/events" usage:"directory for storing the event index"`
+}
+
 type ParametersWebAPILimits struct {
 	Timeout                        time.Duration `default:"30s" usage:"the timeout after which a long running operation will be canceled"`
 	ReadTimeout                    time.Duration `default:"10s" usage:"the read timeout for the HTTP request body"`
@@ -24,6 +31,26 @@ type ParametersWebAPILimits struct {
 	MaxTopicSubscriptionsPerClient int           `default:"0" usage:"defines the max amount of subscriptions per client. 0 = deactivated (default)"`
 	ConfirmedStateLagThreshold     uint32        `default:"2" usage:"the threshold that define a chain is unsynchronized"`
 	Jsonrpc                        ParametersJSONRPC
+	OffLedger                      ParametersOffLedgerLimits
+	Backup                         ParametersBackupLimits
+}
+
+// ParametersBackupLimits replace the limits above for the routes transferring the chain backups.
+type ParametersBackupLimits struct {
+	Timeout       time.Duration `default:"1h" usage:"the timeout of the chain backup and restore requests, including the transfer of the backup"`
+	MaxBodyLength string        `default:"10G" usage:"the maximum size of a chain backup, that can be restored"`
+}
+
+type ParametersOffLedgerLimits struct {
+	GlobalRequestsPerSecond int           `default:"0" usage:"the number of off-ledger requests accepted per second for a chain; 0 = deactivated (default)"`
+	GlobalBurst             int           `default:"100" usage:"the burst of the global off-ledger rate limit"`
+	IPRequestsPerSecond     int           `default:"0" usage:"the number of off-ledger requests accepted per second for a chain from a single IP address; 0 = deactivated (default)"`
+	IPBurst                 int           `default:"20" usage:"the burst of the per-IP off-ledger rate limit"`
+	SenderRequestsPerSecond int           `default:"0" usage:"the number of off-ledger requests accepted per second for a chain from a single sender; 0 = deactivated (default)"`
+	SenderBurst             int           `default:"10" usage:"the burst of the per-sender off-ledger rate limit"`
+	ChainRequestsPerSecond  []string      `default:"" usage:"list of '<chainID>:<global>:<per-IP>:<per-sender>' requests per second to override the off-ledger rate limits of single chains"`
+	ClientCleanupDuration   time.Duration `default:"5m" usage:"defines after which idle time the rate limits of a client are reset"`
+	MaxClients              int           `default:"10000" usage:"the maximal number of IP addresses and senders with their own rate limits for a chain, the ones above it share a single rate limit; 0 = unlimited"`
 }
 
 type ParametersJSONRPC struct {
@@ -34,6 +61,8 @@ type ParametersJSONRPC struct {
 	WebsocketRateLimitBurst             int           `default:"5" usage:"the websocket burst limit"`
 	WebsocketConnectionCleanupDuration  time.Duration `default:"5m" usage:"defines in which interval stale connections will be cleaned up"`
 	WebsocketClientBlockDuration        time.Duration `default:"5m" usage:"the duration a misbehaving client will be blocked"`
+
+	FilterTimeout time.Duration `default:"5m" usage:"the duration after which a filter that is not polled (eth_getFilterChanges) is uninstalled"`
 }
 
 var ParamsWebAPI = &ParametersWebAPI{
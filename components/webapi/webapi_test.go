@@ -1,9 +1,12 @@
 package webapi_test
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -34,6 +37,7 @@ func TestInternalServerErrors(t *testing.T) {
 			MaxTopicSubscriptionsPerClient: 0,
 			ConfirmedStateLagThreshold:     2,
 			Jsonrpc:                        webapi.ParametersJSONRPC{},
+			Backup:                         webapi.ParametersBackupLimits{Timeout: time.Hour, MaxBodyLength: "1G"},
 		},
 		DebugRequestLoggerEnabled: true,
 	},
@@ -70,3 +74,98 @@ func TestInternalServerErrors(t *testing.T) {
 	require.Len(t, logEntries, 1)
 	require.Contains(t, logEntries[0].Message, exceptionText)
 }
+
+func TestClientIP(t *testing.T) {
+	realIP := func(trustedProxies []string, remoteAddr string) string {
+		e := webapi.NewEcho(&webapi.ParametersWebAPI{
+			TrustedProxies: trustedProxies,
+			Limits: webapi.ParametersWebAPILimits{
+				MaxBodyLength: "1M",
+				Backup:        webapi.ParametersBackupLimits{MaxBodyLength: "1G"},
+			},
+		}, nil, zap.NewNop().Sugar())
+		req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(echo.HeaderXForwardedFor, "1.1.1.1")
+		return e.NewContext(req, httptest.NewRecorder()).RealIP()
+	}
+
+	// the header is ignored without trusted proxies
+	require.Equal(t, "10.0.0.1", realIP(nil, "10.0.0.1:1234"))
+
+	// the header is only trusted if it is set by a trusted proxy
+	trustedProxies := []string{"10.0.0.0/24"}
+	require.Equal(t, "1.1.1.1", realIP(trustedProxies, "10.0.0.1:1234"))
+	require.Equal(t, "10.0.1.1", realIP(trustedProxies, "10.0.1.1:1234"))
+}
+
+func TestBackupLimits(t *testing.T) {
+	e := webapi.NewEcho(&webapi.ParametersWebAPI{
+		Limits: webapi.ParametersWebAPILimits{
+			Timeout:       100 * time.Millisecond,
+			ReadTimeout:   100 * time.Millisecond,
+			WriteTimeout:  time.Minute,
+			MaxBodyLength: "1K",
+			Backup:        webapi.ParametersBackupLimits{Timeout: time.Minute, MaxBodyLength: "1M"},
+		},
+	}, nil, zap.NewNop().Sugar())
+	// the handler reads the body, and outlives the global timeout
+	handler := func(c echo.Context) error {
+		n, err := io.Copy(io.Discard, c.Request().Body)
+		if err != nil {
+			return err
+		}
+		time.Sleep(200 * time.Millisecond)
+		if c.Request().Context().Err() != nil {
+			return c.NoContent(http.StatusGatewayTimeout)
+		}
+		return c.String(http.StatusOK, fmt.Sprint(n))
+	}
+	e.POST("/v1/node/restore/:chainID", handler)
+	e.POST("/v1/node/peers", handler)
+	server := httptest.NewUnstartedServer(e)
+	server.Config.ReadTimeout = e.Server.ReadTimeout
+	server.Config.WriteTimeout = e.Server.WriteTimeout
+	server.Start()
+	defer server.Close()
+
+	postBody := func(path string, body io.Reader) (int, string) {
+		res, err := http.Post(server.URL+path, echo.MIMEOctetStream, body)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		resBody, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res.StatusCode, string(resBody)
+	}
+	post := func(path string, bodyLength int) (int, string) {
+		return postBody(path, bytes.NewReader(make([]byte, bodyLength)))
+	}
+
+	// the global limits apply to the other routes
+	status, _ := post("/v1/node/peers", 100*1024)
+	require.Equal(t, http.StatusRequestEntityTooLarge, status)
+	status, _ = post("/v1/node/peers", 10)
+	require.Equal(t, http.StatusGatewayTimeout, status)
+
+	// the backups are larger than the global body limit, and take longer than the global timeout
+	status, body := post("/v1/node/restore/foo", 100*1024)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "102400", body)
+
+	// the body of a backup can be sent slower than the global read timeout
+	slowBody, slowBodyWriter := io.Pipe()
+	go func() {
+		for i := 0; i < 3; i++ {
+			time.Sleep(100 * time.Millisecond)
+			_, _ = slowBodyWriter.Write(make([]byte, 1024))
+		}
+		slowBodyWriter.Close()
+	}()
+	status, body = postBody("/v1/node/restore/foo", slowBody)
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "3072", body)
+
+	// but they have their own body limit
+	status, _ = post("/v1/node/restore/foo", 2*1024*1024)
+	require.Equal(t, http.StatusRequestEntityTooLarge, status)
+}
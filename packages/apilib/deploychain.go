@@ -26,7 +26,7 @@ type CreateChainParams struct {
 	CommitteeAPIHosts    []string
 	N                    uint16
 	T                    uint16
-	OriginatorKeyPair    *cryptolib.KeyPair
+	OriginatorKeyPair    cryptolib.VariantKeyPair
 	Textout              io.Writer
 	Prefix               string
 	InitParams           dict.Dict
@@ -79,7 +79,7 @@ func utxoIDsFromUtxoMap(utxoMap iotago.OutputSet) iotago.OutputIDs {
 // CreateChainOrigin creates and confirms origin transaction of the chain and init request transaction to initialize state of it
 func CreateChainOrigin(
 	layer1Client l1connection.Client,
-	originator *cryptolib.KeyPair,
+	originator cryptolib.VariantKeyPair,
 	stateController iotago.Address,
 	governanceController iotago.Address,
 	initParams dict.Dict,
@@ -1,6 +1,10 @@
 package authentication
 
-import "github.com/labstack/echo/v4"
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
+)
 
 type AuthContext struct {
 	echo.Context
@@ -17,3 +21,11 @@ func (a *AuthContext) Name() string {
 func (a *AuthContext) Scheme() string {
 	return a.scheme
 }
+
+// CanGrant returns true if the authenticated user is allowed to grant the permission to other users.
+func (a *AuthContext) CanGrant(permission string) bool {
+	if a.scheme == AuthNone {
+		return true
+	}
+	return a.claims != nil && permissions.CanGrant(a.claims.Permissions, permission)
+}
@@ -68,18 +68,13 @@ type WaspClaims struct {
 	Permissions map[string]struct{} `json:"permissions"`
 }
 
-func (c *WaspClaims) HasPermission(permission string) bool {
-	_, exists := c.Permissions[permission]
-
-	if exists {
-		return true
-	}
-
-	if permission == permissions.Read {
-		// If a user only has write permissions, it should still be able to read.
-		_, exists = c.Permissions[permissions.Write]
-
-		return exists
+// HasPermission returns true if any of the granted roles includes the given permission.
+// chainID is the chain the permission is checked for, roles restricted to other chains are ignored.
+func (c *WaspClaims) HasPermission(permission, chainID string) bool {
+	for granted := range c.Permissions {
+		if permissions.Grants(granted, permission, chainID) {
+			return true
+		}
 	}
 
 	return false
@@ -13,7 +13,9 @@ import (
 
 	"github.com/iotaledger/wasp/packages/authentication"
 	"github.com/iotaledger/wasp/packages/authentication/shared"
+	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
 	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/users"
 )
 
@@ -158,3 +160,174 @@ func TestJWTAuthIssueAndVerify(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, res.Code)
 }
+
+func TestWaspClaimsHasPermission(t *testing.T) {
+	chainID := isc.RandomChainID()
+	otherChainID := isc.RandomChainID()
+
+	claims := &authentication.WaspClaims{
+		Permissions: map[string]struct{}{
+			permissions.MetricsReader:                              {},
+			permissions.Scoped(permissions.ChainOperator, chainID): {},
+		},
+	}
+
+	require.True(t, claims.HasPermission(permissions.MetricsRead, ""))
+	require.True(t, claims.HasPermission(permissions.MetricsRead, otherChainID.String()))
+	require.True(t, claims.HasPermission(permissions.ChainWrite, chainID.String()))
+	require.False(t, claims.HasPermission(permissions.ChainWrite, otherChainID.String()))
+	require.False(t, claims.HasPermission(permissions.ChainRead, ""))
+	require.False(t, claims.HasPermission(permissions.PeeringWrite, ""))
+	require.False(t, claims.HasPermission(permissions.DKGWrite, chainID.String()))
+
+	// write grants everything, read only the read permissions
+	writer := &authentication.WaspClaims{Permissions: map[string]struct{}{permissions.Write: {}}}
+	reader := &authentication.WaspClaims{Permissions: map[string]struct{}{permissions.Read: {}}}
+	for _, permission := range []string{permissions.ChainRead, permissions.PeeringRead, permissions.DKGRead, permissions.UsersRead} {
+		require.True(t, writer.HasPermission(permission, chainID.String()))
+		require.True(t, reader.HasPermission(permission, chainID.String()))
+	}
+	for _, permission := range []string{permissions.ChainWrite, permissions.PeeringWrite, permissions.DKGWrite, permissions.NodeWrite} {
+		require.True(t, writer.HasPermission(permission, ""))
+		require.False(t, reader.HasPermission(permission, ""))
+	}
+}
+
+func TestValidatePermissions(t *testing.T) {
+	chainID := isc.RandomChainID()
+
+	require.NoError(t, permissions.Validate(permissions.API))
+	require.NoError(t, permissions.Validate(permissions.Write))
+	require.NoError(t, permissions.Validate(permissions.DKGAdmin))
+	require.NoError(t, permissions.Validate(permissions.Scoped(permissions.ChainOperator, chainID)))
+	require.Error(t, permissions.Validate("superuser"))
+	require.Error(t, permissions.Validate(permissions.ChainOperator+"@"))
+	require.Error(t, permissions.Validate(permissions.ChainOperator+"@abc"))
+	require.Error(t, permissions.Validate(permissions.API+"@"+chainID.String()))
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	userManager := users.NewUserManager((func(users []*users.User) error {
+		return nil
+	}))
+	require.NoError(t, userManager.AddUser(&users.User{
+		Name:        "bot",
+		Permissions: map[string]struct{}{permissions.ChainOperator: {}, permissions.MetricsReader: {}},
+	}))
+
+	e := echo.New()
+	e.GET("/test-route", func(c echo.Context) error {
+		token := c.Get(authentication.JWTContextKey).(*jwt.Token)
+		return c.JSON(http.StatusOK, token.Claims)
+	})
+
+	nodeIDKeypair := cryptolib.KeyPairFromSeed(cryptolib.SeedFromBytes([]byte("abc")))
+	_, middleware := authentication.GetJWTAuthMiddleware(
+		authentication.JWTAuthConfiguration{},
+		nodeIDKeypair,
+		userManager,
+	)
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("auth", &authentication.AuthContext{})
+			return next(c)
+		}
+	})
+	e.Use(middleware)
+
+	request := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/test-route", http.NoBody)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		res := httptest.NewRecorder()
+		e.ServeHTTP(res, req)
+		return res
+	}
+
+	// the permissions of a key must be granted to the user
+	_, _, err := userManager.AddAPIKey("bot", "ci", map[string]struct{}{permissions.Write: {}}, time.Time{})
+	require.Error(t, err)
+
+	chainID := isc.RandomChainID()
+	apiKey, token, err := userManager.AddAPIKey("bot", "ci", map[string]struct{}{permissions.Scoped(permissions.ChainOperator, chainID): {}}, time.Time{})
+	require.NoError(t, err)
+	_, _, err = userManager.AddAPIKey("bot", "ci", nil, time.Time{})
+	require.Error(t, err)
+
+	res := request(token)
+	require.Equal(t, http.StatusOK, res.Code)
+	require.Contains(t, res.Body.String(), `"sub":"bot"`)
+	require.Contains(t, res.Body.String(), permissions.Scoped(permissions.ChainOperator, chainID))
+	require.NotContains(t, res.Body.String(), permissions.MetricsReader)
+
+	user, err := userManager.User("bot")
+	require.NoError(t, err)
+	require.False(t, user.APIKeys[apiKey.ID].LastUsedAt.IsZero())
+
+	// wrong secret
+	wrongToken := []byte(token)
+	wrongToken[len(wrongToken)-1] ^= 1
+	require.Equal(t, http.StatusUnauthorized, request(string(wrongToken)).Code)
+
+	// expired key
+	_, expiredToken, err := userManager.AddAPIKey("bot", "expired", nil, time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnauthorized, request(expiredToken).Code)
+
+	// revoked key
+	require.NoError(t, userManager.RevokeAPIKey("bot", apiKey.ID))
+	require.Error(t, userManager.RevokeAPIKey("bot", apiKey.ID))
+	require.Equal(t, http.StatusUnauthorized, request(token).Code)
+
+	// a broad role issues the narrower ones it includes
+	require.NoError(t, userManager.AddUser(&users.User{
+		Name:        "admin",
+		Permissions: map[string]struct{}{permissions.Write: {}},
+	}))
+	_, metricsToken, err := userManager.AddAPIKey("admin", "metrics", map[string]struct{}{permissions.MetricsReader: {}}, time.Time{})
+	require.NoError(t, err)
+	res = request(metricsToken)
+	require.Equal(t, http.StatusOK, res.Code)
+	require.Contains(t, res.Body.String(), `"sub":"admin"`)
+	require.Contains(t, res.Body.String(), permissions.MetricsReader)
+
+	_, operatorToken, err := userManager.AddAPIKey("admin", "operator", map[string]struct{}{
+		permissions.ChainOperator:                              {},
+		permissions.Scoped(permissions.ChainOperator, chainID): {},
+	}, time.Time{})
+	require.NoError(t, err)
+	res = request(operatorToken)
+	require.Equal(t, http.StatusOK, res.Code)
+	require.Contains(t, res.Body.String(), `"`+permissions.ChainOperator+`"`)
+	require.Contains(t, res.Body.String(), permissions.Scoped(permissions.ChainOperator, chainID))
+	require.NotContains(t, res.Body.String(), permissions.Write)
+
+	// and a narrow role does not issue the broader ones
+	_, _, err = userManager.AddAPIKey("bot", "write", map[string]struct{}{permissions.ChainOperator: {}, permissions.Write: {}}, time.Time{})
+	require.Error(t, err)
+}
+
+func TestCanGrant(t *testing.T) {
+	chainID := isc.RandomChainID()
+	otherChainID := isc.RandomChainID()
+
+	usersAdmin := map[string]struct{}{
+		permissions.API:        {},
+		permissions.UsersAdmin: {},
+		permissions.Scoped(permissions.ChainOperator, chainID): {},
+	}
+	require.True(t, permissions.CanGrant(usersAdmin, permissions.API))
+	require.True(t, permissions.CanGrant(usersAdmin, permissions.UsersAdmin))
+	require.True(t, permissions.CanGrant(usersAdmin, permissions.Scoped(permissions.ChainOperator, chainID)))
+	require.False(t, permissions.CanGrant(usersAdmin, permissions.Scoped(permissions.ChainOperator, otherChainID)))
+	require.False(t, permissions.CanGrant(usersAdmin, permissions.ChainOperator))
+	require.False(t, permissions.CanGrant(usersAdmin, permissions.Write))
+	require.False(t, permissions.CanGrant(usersAdmin, permissions.Read))
+
+	writer := map[string]struct{}{permissions.Write: {}}
+	for _, role := range permissions.Roles() {
+		require.True(t, permissions.CanGrant(writer, role))
+		require.True(t, permissions.CanGrant(writer, permissions.Scoped(role, chainID)))
+	}
+	require.False(t, permissions.CanGrant(writer, permissions.API))
+	require.False(t, permissions.CanGrant(writer, "superuser"))
+}
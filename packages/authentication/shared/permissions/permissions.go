@@ -1,7 +1,150 @@
 package permissions
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/iotaledger/wasp/packages/isc"
+)
+
 const (
 	API   = "api"
 	Read  = "read"
 	Write = "write"
 )
+
+// Roles that can be granted to a user.
+// Read and Write are roles too: Read grants all read permissions, Write grants every permission.
+const (
+	ChainOperator = "chain-operator"
+	PeeringAdmin  = "peering-admin"
+	MetricsReader = "metrics-reader"
+	DKGAdmin      = "dkg-admin"
+	UsersAdmin    = "users-admin"
+)
+
+// Permissions required by the routes of the WebAPI.
+const (
+	ChainRead    = "chain.read"
+	ChainWrite   = "chain.write"
+	PeeringRead  = "peering.read"
+	PeeringWrite = "peering.write"
+	DKGRead      = "dkg.read"
+	DKGWrite     = "dkg.write"
+	MetricsRead  = "metrics.read"
+	NodeRead     = "node.read"
+	NodeWrite    = "node.write"
+	UsersRead    = "users.read"
+	UsersWrite   = "users.write"
+)
+
+// ChainScopeSeparator separates a role from the chain it is restricted to, e.g. "chain-operator@<chainID>".
+const ChainScopeSeparator = "@"
+
+var readPermissions = []string{ChainRead, PeeringRead, DKGRead, MetricsRead, NodeRead, UsersRead}
+
+var roles = map[string][]string{
+	Read:          readPermissions,
+	Write:         append([]string{ChainWrite, PeeringWrite, DKGWrite, NodeWrite, UsersWrite}, readPermissions...),
+	ChainOperator: {ChainRead, ChainWrite},
+	PeeringAdmin:  {PeeringRead, PeeringWrite},
+	MetricsReader: {MetricsRead},
+	DKGAdmin:      {DKGRead, DKGWrite},
+	UsersAdmin:    {UsersRead, UsersWrite},
+}
+
+// Roles returns the names of all known roles, sorted.
+func Roles() []string {
+	ret := make([]string, 0, len(roles))
+	for role := range roles {
+		ret = append(ret, role)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// RolePermissions returns the permissions granted by a role, sorted.
+func RolePermissions(role string) []string {
+	ret := append([]string{}, roles[role]...)
+	sort.Strings(ret)
+	return ret
+}
+
+// Scoped restricts a role to a single chain.
+func Scoped(role string, chainID isc.ChainID) string {
+	return role + ChainScopeSeparator + chainID.String()
+}
+
+// Split splits a granted permission into its role and the chain it is restricted to (empty if not restricted).
+func Split(granted string) (role, chainID string) {
+	role, chainID, _ = strings.Cut(granted, ChainScopeSeparator)
+	return role, chainID
+}
+
+// Validate checks that a permission that is about to be granted to a user is known to Wasp.
+func Validate(granted string) error {
+	role, chainID := Split(granted)
+	if role == API {
+		if chainID != "" {
+			return fmt.Errorf("permission %q can't be restricted to a chain", role)
+		}
+		return nil
+	}
+	if _, ok := roles[role]; !ok {
+		return fmt.Errorf("unknown role %q", role)
+	}
+	if strings.Contains(granted, ChainScopeSeparator) {
+		if _, err := isc.ChainIDFromString(chainID); err != nil {
+			return fmt.Errorf("invalid chain ID in %q: %w", granted, err)
+		}
+	}
+	return nil
+}
+
+// CanGrant returns true if the granted permissions include everything the given permission grants,
+// so nobody can grant to another user (or an API key) more than they have themselves.
+func CanGrant(granted map[string]struct{}, toGrant string) bool {
+	role, chainID := Split(toGrant)
+	required, ok := roles[role]
+	if role == API {
+		required, ok = []string{API}, true
+	}
+	if !ok {
+		return false
+	}
+	for _, permission := range required {
+		if !grantsAny(granted, permission, chainID) {
+			return false
+		}
+	}
+	return true
+}
+
+func grantsAny(granted map[string]struct{}, required, chainID string) bool {
+	for permission := range granted {
+		if Grants(permission, required, chainID) {
+			return true
+		}
+	}
+	return false
+}
+
+// Grants returns true if the granted permission includes the required one.
+// A permission restricted to a chain only grants access to the routes of that chain,
+// chainID being the chain the route applies to (empty if the route is not bound to a chain).
+func Grants(granted, required, chainID string) bool {
+	role, scope := Split(granted)
+	if scope != "" && scope != chainID {
+		return false
+	}
+	if role == required {
+		return true
+	}
+	for _, permission := range roles[role] {
+		if permission == required {
+			return true
+		}
+	}
+	return false
+}
@@ -47,6 +47,10 @@ func GetJWTAuthMiddleware(
 		SigningKey:  jwtAuth.secret,
 		TokenLookup: "header:Authorization:Bearer ,cookie:jwt",
 		ParseTokenFunc: func(c echo.Context, auth string) (interface{}, error) {
+			if users.IsAPIKeyToken(auth) {
+				return parseAPIKeyToken(c, auth, userManager)
+			}
+
 			keyFunc := func(t *jwt.Token) (interface{}, error) {
 				if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 					return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
@@ -88,6 +92,7 @@ func GetJWTAuthMiddleware(
 
 			authContext := c.Get("auth").(*AuthContext)
 			authContext.claims = claims
+			authContext.name = claims.Subject
 
 			return token, nil
 		},
@@ -96,6 +101,30 @@ func GetJWTAuthMiddleware(
 	return jwtAuth, authMiddleware
 }
 
+// parseAPIKeyToken authenticates a request with an API key instead of a JWT.
+// The request is handled as if it was sent with a JWT of the owner of the key,
+// restricted to the permissions of the key.
+func parseAPIKeyToken(c echo.Context, auth string, userManager *users.UserManager) (interface{}, error) {
+	user, permissions, err := userManager.AuthenticateAPIKey(auth)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &WaspClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:  user.Name,
+			Audience: jwt.ClaimStrings{user.Name},
+		},
+		Permissions: permissions,
+	}
+
+	authContext := c.Get("auth").(*AuthContext)
+	authContext.claims = claims
+	authContext.name = user.Name
+
+	return &jwt.Token{Claims: claims, Valid: true}, nil
+}
+
 func GetNoneAuthMiddleware() echo.MiddlewareFunc {
 	// Adds a middleware to set the authContext to authenticated.
 	// All routes will be open to everyone, so use it in private environments only.
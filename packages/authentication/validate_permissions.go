@@ -6,12 +6,24 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
+const chainIDParam = "chainID"
+
 type ValidationError struct {
 	MissingPermission string `json:"missingPermission" swagger:"required"`
 	Error             string `json:"error" swagger:"required"`
 }
 
 func ValidatePermissions(permissions []string) func(next echo.HandlerFunc) echo.HandlerFunc {
+	return validatePermissions(permissions, true)
+}
+
+// ValidateNodePermissions is like ValidatePermissions, but the roles restricted to a chain are not
+// accepted, even if the route is bound to that chain. It protects the routes that affect the whole node.
+func ValidateNodePermissions(permissions []string) func(next echo.HandlerFunc) echo.HandlerFunc {
+	return validatePermissions(permissions, false)
+}
+
+func validatePermissions(permissions []string, allowChainScope bool) func(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(e echo.Context) error {
 			auth := e.Get("auth")
@@ -28,8 +40,14 @@ func ValidatePermissions(permissions []string) func(next echo.HandlerFunc) echo.
 				return next(e)
 			}
 
+			// routes of a specific chain can also be accessed with roles restricted to that chain
+			chainID := ""
+			if allowChainScope {
+				chainID = e.Param(chainIDParam)
+			}
+
 			for _, permission := range permissions {
-				if !authContext.claims.HasPermission(permission) {
+				if !authContext.claims.HasPermission(permission, chainID) {
 					return e.JSON(http.StatusUnauthorized, ValidationError{MissingPermission: permission, Error: "Missing permission"})
 				}
 			}
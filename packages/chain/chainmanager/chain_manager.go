@@ -150,11 +150,23 @@ type NeedPublishTX struct {
 
 type ChainMgr interface {
 	AsGPA() gpa.GPA
+	// Status of the committee logs, for the diagnostic purposes.
+	// It has to be called from the same thread, as the GPA.
+	CmtLogStatus() []*CmtLogStatus
+}
+
+// CmtLogStatus is a snapshot of a committee log, maintained by this node.
+type CmtLogStatus struct {
+	CommitteeAddr iotago.Ed25519Address
+	CommitteeKeys []*cryptolib.PublicKey // Public keys of the committee nodes.
+	Active        bool                   // True for the latest active committee.
+	Status        *cmt_log.Status
 }
 
 type cmtLogInst struct {
 	committeeAddr iotago.Ed25519Address
 	dkShare       tcrypto.DKShare
+	cmtLog        cmt_log.CmtLog
 	gpaInstance   gpa.GPA
 	pendingMsgs   []gpa.Message
 }
@@ -517,6 +529,20 @@ func (cmi *chainMgrImpl) Output() gpa.Output {
 	return cmi.output
 }
 
+// Implements the ChainMgr interface.
+func (cmi *chainMgrImpl) CmtLogStatus() []*CmtLogStatus {
+	statuses := make([]*CmtLogStatus, 0, len(cmi.cmtLogs))
+	for ca, cli := range cmi.cmtLogs {
+		statuses = append(statuses, &CmtLogStatus{
+			CommitteeAddr: ca,
+			CommitteeKeys: cli.dkShare.GetNodePubKeys(),
+			Active:        cmi.latestActiveCmt != nil && cmi.latestActiveCmt.Equal(&ca),
+			Status:        cli.cmtLog.Status(),
+		})
+	}
+	return statuses
+}
+
 // Implements the gpa.GPA interface.
 func (cmi *chainMgrImpl) StatusString() string { // TODO: Call it periodically. Show the active committee.
 	return fmt.Sprintf("{ChainMgr,confirmedAO=%v,activeAO=%v}",
@@ -589,6 +615,7 @@ func (cmi *chainMgrImpl) ensureCmtLog(committeeAddr iotago.Ed25519Address) (*cmt
 	cli := &cmtLogInst{
 		committeeAddr: committeeAddr,
 		dkShare:       dkShare,
+		cmtLog:        clInst,
 		gpaInstance:   clGPA,
 		pendingMsgs:   []gpa.Message{},
 	}
@@ -77,6 +77,7 @@ func testChainMgrBasic(t *testing.T, n, f int) {
 	//
 	// Construct the nodes.
 	nodes := map[gpa.NodeID]gpa.GPA{}
+	chainMgrs := map[gpa.NodeID]chainmanager.ChainMgr{}
 	stores := map[gpa.NodeID]state.Store{}
 	for i, nid := range nodeIDs {
 		consensusStateRegistry := testutil.NewConsensusStateRegistry()
@@ -102,6 +103,7 @@ func testChainMgrBasic(t *testing.T, n, f int) {
 		)
 		require.NoError(t, err)
 		nodes[nid] = cm.AsGPA()
+		chainMgrs[nid] = cm
 	}
 	tc := gpa.NewTestContext(nodes)
 	tc.PrintAllStatusStrings("Started", t.Logf)
@@ -122,6 +124,15 @@ func testChainMgrBasic(t *testing.T, n, f int) {
 		require.Equal(t, uint32(1), out.NeedConsensus().LogIndex.AsUint32())
 		require.Equal(t, cmtAddrA, &out.NeedConsensus().CommitteeAddr)
 	}
+	for nid, cm := range chainMgrs {
+		cmtLogStatus := cm.CmtLogStatus()
+		require.Len(t, cmtLogStatus, 1)
+		require.True(t, cmtLogStatus[0].Active)
+		require.Equal(t, cmtAddrA, &cmtLogStatus[0].CommitteeAddr)
+		require.Equal(t, uint32(1), cmtLogStatus[0].Status.AgreedLogIndex.AsUint32())
+		require.Equal(t, originAO, cmtLogStatus[0].Status.LocalView.Confirmed)
+		require.Len(t, cmtLogStatus[0].Status.PeerLogIndexes, len(chainMgrs), "node=%v", nid)
+	}
 	//
 	// Provide consensus output.
 	step2AO, step2TX := tcl.FakeRotationTX(originAO, cmtAddrA)
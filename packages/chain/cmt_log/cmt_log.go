@@ -108,6 +108,17 @@ import (
 // Public interface for this algorithm.
 type CmtLog interface {
 	AsGPA() gpa.GPA
+	Status() *Status
+}
+
+// Status is a snapshot of the committee log, as seen by this node.
+// It is meant for diagnostics only, e.g. to find out, why a chain stalls.
+type Status struct {
+	MinLogIndex    LogIndex                // Minimal LI at which this node can participate.
+	AgreedLogIndex LogIndex                // LI agreed by the committee, NIL if there is none yet.
+	Output         *Output                 // The consensus instance to run now, nil if there is none.
+	LocalView      *LocalViewStatus        // The pending AOs, as tracked by this node.
+	PeerLogIndexes map[gpa.NodeID]LogIndex // The highest LI proposed by each of the peers.
 }
 
 type State struct {
@@ -349,6 +360,17 @@ func (cl *cmtLogImpl) Output() gpa.Output {
 	return out
 }
 
+// Implements the CmtLog interface.
+func (cl *cmtLogImpl) Status() *Status {
+	return &Status{
+		MinLogIndex:    cl.varLogIndex.MinLogIndex(),
+		AgreedLogIndex: cl.varLogIndex.Value(),
+		Output:         cl.varOutput.Value(),
+		LocalView:      cl.varLocalView.Status(),
+		PeerLogIndexes: cl.varLogIndex.PeerLogIndexes(),
+	}
+}
+
 // Implements the gpa.GPA interface.
 func (cl *cmtLogImpl) StatusString() string {
 	return fmt.Sprintf(
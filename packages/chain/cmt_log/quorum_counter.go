@@ -70,6 +70,14 @@ func (qc *QuorumCounter) HaveVoteFrom(from gpa.NodeID) bool {
 	return have
 }
 
+// PeerVote returns the highest LI voted by the peer, or NilLogIndex, if there is no vote from it.
+func (qc *QuorumCounter) PeerVote(from gpa.NodeID) LogIndex {
+	if vote, have := qc.maxPeerVotes[from]; have {
+		return vote.NextLogIndex
+	}
+	return NilLogIndex()
+}
+
 func (qc *QuorumCounter) EnoughVotes(quorum int) LogIndex {
 	countsLI := map[LogIndex]int{}
 	for _, vote := range qc.maxPeerVotes {
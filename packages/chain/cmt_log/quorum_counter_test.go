@@ -40,4 +40,7 @@ func TestQuorumCounter(t *testing.T) {
 
 	require.True(t, qc.HaveVoteFrom(nodeIDs[4]))
 	require.False(t, qc.HaveVoteFrom(nodeIDs[5]))
+
+	require.Equal(t, li8, qc.PeerVote(nodeIDs[4]))
+	require.Equal(t, lin, qc.PeerVote(nodeIDs[5]))
 }
@@ -69,6 +69,7 @@ package cmt_log
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/samber/lo"
 
@@ -100,6 +101,21 @@ type VarLocalView interface {
 	//
 	// Support functions.
 	StatusString() string
+	Status() *LocalViewStatus
+}
+
+// LocalViewStatus is a snapshot of the local view, for the diagnostic purposes.
+type LocalViewStatus struct {
+	Confirmed *isc.AliasOutputWithID  // The latest AO confirmed by L1, nil if unclear.
+	Tip       *isc.AliasOutputWithID  // The AO to build the next TX on, nil if we have to wait.
+	Pending   []*LocalViewEntryStatus // AOs produced by this committee, but not confirmed yet. Ordered by the StateIndex.
+}
+
+type LocalViewEntryStatus struct {
+	Output   *isc.AliasOutputWithID // The AO published.
+	Consumed iotago.OutputID        // The AO used as an input for the TX.
+	Rejected bool                   // True, if the AO was rejected.
+	LogIndex LogIndex               // LogIndex of the consensus produced the output, if any.
 }
 
 type varLocalViewEntry struct {
@@ -302,6 +318,29 @@ func (lvi *varLocalViewImpl) StatusString() string {
 	return fmt.Sprintf("{varLocalView: confirmed=%v, tip=%v, |pendingSIs|=%v}", lvi.confirmed, lvi.findLatestPending(), lvi.pending.Size())
 }
 
+func (lvi *varLocalViewImpl) Status() *LocalViewStatus {
+	pending := []*LocalViewEntryStatus{}
+	lvi.pending.ForEach(func(_ uint32, entries []*varLocalViewEntry) bool {
+		for _, e := range entries {
+			pending = append(pending, &LocalViewEntryStatus{
+				Output:   e.output,
+				Consumed: e.consumed,
+				Rejected: e.rejected,
+				LogIndex: e.logIndex,
+			})
+		}
+		return true
+	})
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].Output.GetStateIndex() < pending[j].Output.GetStateIndex()
+	})
+	return &LocalViewStatus{
+		Confirmed: lvi.confirmed,
+		Tip:       lvi.findLatestPending(),
+		Pending:   pending,
+	}
+}
+
 // Latest pending AO is only considered existing, if the current pending
 // set of AOs is a chain, with no gaps, or alternatives, and all the AOs
 // are not rejected.
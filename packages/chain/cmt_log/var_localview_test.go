@@ -31,4 +31,9 @@ func TestVarLocalView(t *testing.T) {
 	require.NotNil(t, tipAO)
 	require.NotNil(t, j.Value())
 	require.Equal(t, tipAO, j.Value())
+
+	status := j.Status()
+	require.Equal(t, tipAO, status.Confirmed)
+	require.Equal(t, tipAO, status.Tip)
+	require.Empty(t, status.Pending)
 }
@@ -48,6 +48,13 @@ type VarLogIndex interface {
 	// Summary of the internal state.
 	StatusString() string
 
+	// Minimal LI at which this node can participate.
+	MinLogIndex() LogIndex
+
+	// The highest LI proposed by each of the peers, as received from them.
+	// The peers, from which nothing was received yet, are not included.
+	PeerLogIndexes() map[gpa.NodeID]LogIndex
+
 	// Returns the latest agreed LI.
 	// There is no output value, if LogIndex=⊥.
 	Value() LogIndex
@@ -180,6 +187,26 @@ func (vli *varLogIndexImpl) StatusString() string {
 	)
 }
 
+func (vli *varLogIndexImpl) MinLogIndex() LogIndex {
+	return vli.minLI
+}
+
+func (vli *varLogIndexImpl) PeerLogIndexes() map[gpa.NodeID]LogIndex {
+	peerLIs := map[gpa.NodeID]LogIndex{}
+	for _, nodeID := range vli.nodeIDs {
+		peerLI := NilLogIndex()
+		for _, qc := range []*QuorumCounter{vli.qcConsOut, vli.qcL1AORep, vli.qcRecover, vli.qcStarted} {
+			if voteLI := qc.PeerVote(nodeID); voteLI > peerLI {
+				peerLI = voteLI
+			}
+		}
+		if !peerLI.IsNil() {
+			peerLIs[nodeID] = peerLI
+		}
+	}
+	return peerLIs
+}
+
 func (vli *varLogIndexImpl) Value() LogIndex {
 	if vli.agreedLI < vli.minLI {
 		return NilLogIndex()
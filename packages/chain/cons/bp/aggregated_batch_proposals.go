@@ -57,7 +57,8 @@ func AggregateBatchProposals(inputs map[gpa.NodeID][]byte, nodeIDs []gpa.NodeID,
 		decidedRequestRefs:     bps.decidedRequestRefs(f, decidedBaseAliasOutput),
 		aggregatedTime:         aggregatedTime,
 	}
-	if abp.decidedBaseAliasOutput == nil || len(abp.decidedRequestRefs) == 0 || abp.aggregatedTime.IsZero() {
+	noRequests := len(abp.decidedRequestRefs) == 0 && !bps.emptyBatchProposed(f, decidedBaseAliasOutput)
+	if abp.decidedBaseAliasOutput == nil || noRequests || abp.aggregatedTime.IsZero() {
 		log.Debugf(
 			"Cant' aggregate batch proposal: decidedBaseAliasOutput=%v, |decidedRequestRefs|=%v, aggregatedTime=%v",
 			abp.decidedBaseAliasOutput, len(abp.decidedRequestRefs), abp.aggregatedTime,
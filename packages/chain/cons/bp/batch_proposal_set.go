@@ -95,6 +95,18 @@ func (bps batchProposalSet) decidedRequestRefs(f int, ao *isc.AliasOutputWithID)
 	return decided
 }
 
+// The mempool only proposes an empty batch, if a call scheduled by a contract is due.
+// The block is produced without requests then, if at least F+1 nodes have proposed so.
+func (bps batchProposalSet) emptyBatchProposed(f int, ao *isc.AliasOutputWithID) bool {
+	count := 0
+	for _, bp := range bps {
+		if bp.baseAliasOutput.Equals(ao) && len(bp.requestRefs) == 0 {
+			count++
+		}
+	}
+	return count >= f+1
+}
+
 // Returns zero time, if fails to aggregate the time.
 func (bps batchProposalSet) aggregatedTime(f int) time.Time {
 	ts := make([]time.Time, 0, len(bps))
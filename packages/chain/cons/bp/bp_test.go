@@ -25,28 +25,7 @@ import (
 func TestOffLedgerOrdering(t *testing.T) {
 	log := testlogger.NewLogger(t)
 	nodeIDs := gpa.MakeTestNodeIDs(1)
-	//
-	// Produce an alias output.
-	cmtKP := cryptolib.NewKeyPair()
-	utxoDB := utxodb.New(utxodb.DefaultInitParams())
-	originator := cryptolib.NewKeyPair()
-	_, err := utxoDB.GetFundsFromFaucet(originator.Address())
-	require.NoError(t, err)
-	outputs, outIDs := utxoDB.GetUnspentOutputs(originator.Address())
-	originTX, _, chainID, err := origin.NewChainOriginTransaction(
-		originator,
-		cmtKP.Address(),
-		originator.Address(),
-		0,
-		nil,
-		outputs,
-		outIDs,
-		allmigrations.DefaultScheme.LatestSchemaVersion(),
-	)
-	require.NoError(t, err)
-	stateAnchor, aliasOutput, err := transaction.GetAnchorFromTransaction(originTX)
-	require.NoError(t, err)
-	ao0 := isc.NewAliasOutputWithID(aliasOutput, stateAnchor.OutputID)
+	ao0, chainID := makeTestAliasOutput(t)
 	//
 	// Create some requests.
 	senderKP := cryptolib.NewKeyPair()
@@ -98,3 +77,66 @@ func TestOffLedgerOrdering(t *testing.T) {
 		}
 	}
 }
+
+func TestEmptyBatchProposals(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	nodeIDs := gpa.MakeTestNodeIDs(4)
+	ao0, chainID := makeTestAliasOutput(t)
+	senderKP := cryptolib.NewKeyPair()
+	contract := governance.Contract.Hname()
+	entryPoint := governance.FuncAddCandidateNode.Hname()
+	gasBudget := gas.LimitsDefault.MaxGasPerRequest
+	r0 := isc.NewOffLedgerRequest(chainID, contract, entryPoint, nil, 0, gasBudget).Sign(senderKP)
+	r1 := isc.NewOffLedgerRequest(chainID, contract, entryPoint, nil, 1, gasBudget).Sign(senderKP)
+	proposal := func(nodeIndex uint16, reqs ...isc.Request) []byte {
+		return bp.NewBatchProposal(
+			nodeIndex,
+			ao0,
+			util.NewFixedSizeBitVector(4).SetBits([]int{0, 1, 2, 3}),
+			time.Now(),
+			isc.NewRandomAgentID(),
+			isc.RequestRefsFromRequests(reqs),
+		).Bytes()
+	}
+	//
+	// The mempool proposes an empty batch, if a scheduled call is due. F+1 such proposals make a block.
+	abp := bp.AggregateBatchProposals(map[gpa.NodeID][]byte{
+		nodeIDs[0]: proposal(0),
+		nodeIDs[1]: proposal(1),
+		nodeIDs[2]: proposal(2, r0),
+		nodeIDs[3]: proposal(3, r1),
+	}, nodeIDs, 1, log)
+	require.False(t, abp.ShouldBeSkipped())
+	require.Empty(t, abp.DecidedRequestRefs())
+	//
+	// Otherwise there is nothing to do, if no request is proposed by F+1 nodes.
+	abp = bp.AggregateBatchProposals(map[gpa.NodeID][]byte{
+		nodeIDs[0]: proposal(0),
+		nodeIDs[1]: proposal(1, r0),
+		nodeIDs[2]: proposal(2, r1),
+	}, nodeIDs, 1, log)
+	require.True(t, abp.ShouldBeSkipped())
+}
+
+func makeTestAliasOutput(t *testing.T) (*isc.AliasOutputWithID, isc.ChainID) {
+	cmtKP := cryptolib.NewKeyPair()
+	utxoDB := utxodb.New(utxodb.DefaultInitParams())
+	originator := cryptolib.NewKeyPair()
+	_, err := utxoDB.GetFundsFromFaucet(originator.Address())
+	require.NoError(t, err)
+	outputs, outIDs := utxoDB.GetUnspentOutputs(originator.Address())
+	originTX, _, chainID, err := origin.NewChainOriginTransaction(
+		originator,
+		cmtKP.Address(),
+		originator.Address(),
+		0,
+		nil,
+		outputs,
+		outIDs,
+		allmigrations.DefaultScheme.LatestSchemaVersion(),
+	)
+	require.NoError(t, err)
+	stateAnchor, aliasOutput, err := transaction.GetAnchorFromTransaction(originTX)
+	require.NoError(t, err)
+	return isc.NewAliasOutputWithID(aliasOutput, stateAnchor.OutputID), chainID
+}
@@ -82,6 +82,22 @@ import (
 
 type Cons interface {
 	AsGPA() gpa.GPA
+	// Status of the instance, for the diagnostic purposes.
+	// It has to be called from the same thread, as the GPA.
+	Status() *Status
+}
+
+// Status is a snapshot of a consensus instance. Each of the Sync* fields
+// describes a synchronization step: either it is done, or what it is waiting for.
+type Status struct {
+	Output     OutputStatus
+	Terminated bool
+	SyncACS    string
+	SyncDSS    string
+	SyncMP     string
+	SyncSM     string
+	SyncVM     string
+	SyncTX     string
 }
 
 type OutputStatus byte
@@ -360,6 +376,20 @@ func (c *consImpl) Output() gpa.Output {
 	return c.output // Always non-nil.
 }
 
+// Implements the Cons interface.
+func (c *consImpl) Status() *Status {
+	return &Status{
+		Output:     c.output.Status,
+		Terminated: c.output.Terminated,
+		SyncACS:    c.subACS.String(),
+		SyncDSS:    c.subDSS.String(),
+		SyncMP:     c.subMP.String(),
+		SyncSM:     c.subSM.String(),
+		SyncVM:     c.subVM.String(),
+		SyncTX:     c.subTX.String(),
+	}
+}
+
 func (c *consImpl) StatusString() string {
 	// We con't include RND here, maybe that's less important, and visible from the VM status.
 	return fmt.Sprintf("{consImpl⟨%v⟩,%v,%v,%v,%v,%v,%v}",
@@ -570,8 +600,8 @@ func (c *consImpl) uponVMInputsReceived(aggregatedProposals *bp.AggregatedBatchP
 
 func (c *consImpl) uponVMOutputReceived(vmResult *vm.VMTaskResult) gpa.OutMessages {
 	c.output.NeedVMResult = nil
-	if len(vmResult.RequestResults) == 0 {
-		// No requests were processed, don't have what to do.
+	if len(vmResult.RequestResults) == 0 && len(vmResult.ScheduledCallResults) == 0 {
+		// No requests nor scheduled calls were processed, don't have what to do.
 		// Will need to retry the consensus with the next log index some time later.
 		c.log.Infof("Terminating consensus with status=Skipped, 0 requests processed.")
 		c.output.Status = Skipped
@@ -96,9 +96,25 @@ type input struct {
 	recoverCB       func()
 }
 
+// Status is a snapshot of the consensus instance, for the diagnostic purposes.
+type Status struct {
+	InputReceived   bool                     // False, if the instance is waiting for the chain to provide the input.
+	OutputReady     bool                     // True, if the output was provided to the chain already.
+	Cons            *cons.Status             // State of the consensus protocol.
+	PeerLastMessage map[gpa.NodeID]time.Time // When the last message was received from each of the peers.
+}
+
+type statusQuery struct {
+	ctx    context.Context
+	respCh chan *Status
+}
+
 type ConsGr struct {
 	me                          gpa.NodeID
 	consInst                    gpa.AckHandler
+	consInstRaw                 cons.Cons
+	statusQueryCh               chan *statusQuery
+	peerLastMessage             map[gpa.NodeID]time.Time
 	inputCh                     chan *input
 	inputReceived               *atomic.Bool
 	inputTimeCh                 chan time.Time
@@ -163,6 +179,9 @@ func New(
 	cgr := &ConsGr{
 		me:                me,
 		consInst:          nil, // Set bellow.
+		consInstRaw:       nil, // Set bellow.
+		statusQueryCh:     make(chan *statusQuery),
+		peerLastMessage:   map[gpa.NodeID]time.Time{},
 		inputCh:           make(chan *input, 1),
 		inputReceived:     atomic.NewBool(false),
 		inputTimeCh:       make(chan time.Time, 1),
@@ -185,7 +204,7 @@ func New(
 
 	pipeMetrics.TrackPipeLenMax("cons-gr-netRecvPipe", netPeeringID.String(), cgr.netRecvPipe.Len)
 
-	consInstRaw := cons.New(chainID,
+	cgr.consInstRaw = cons.New(chainID,
 		chainStore,
 		me,
 		myNodeIdentity.GetPrivateKey(),
@@ -195,8 +214,8 @@ func New(
 		gpa.NodeIDFromPublicKey,
 		validatorAgentID,
 		log,
-	).AsGPA()
-	cgr.consInst = gpa.NewAckHandler(me, consInstRaw, redeliveryPeriod)
+	)
+	cgr.consInst = gpa.NewAckHandler(me, cgr.consInstRaw.AsGPA(), redeliveryPeriod)
 
 	unhook := net.Attach(&netPeeringID, peering.ReceiverChainCons, func(recv *peering.PeerMessageIn) {
 		if recv.MsgType != msgTypeCons {
@@ -229,6 +248,22 @@ func (cgr *ConsGr) Time(t time.Time) {
 	cgr.inputTimeCh <- t
 }
 
+// StatusAsync returns the status of this consensus instance. The channel is closed
+// without a value, if the instance has terminated or the context is done.
+func (cgr *ConsGr) StatusAsync(ctx context.Context) <-chan *Status {
+	respCh := make(chan *Status, 1)
+	go func() {
+		select {
+		case cgr.statusQueryCh <- &statusQuery{ctx: ctx, respCh: respCh}:
+		case <-ctx.Done():
+			close(respCh)
+		case <-cgr.ctx.Done():
+			close(respCh)
+		}
+	}()
+	return respCh
+}
+
 func (cgr *ConsGr) run() { //nolint:gocyclo,funlen
 	defer util.ExecuteIfNotNil(cgr.netDisconnect)
 	defer func() {
@@ -329,6 +364,8 @@ func (cgr *ConsGr) run() { //nolint:gocyclo,funlen
 			cgr.recoverCB()
 			cgr.recoverCB = nil
 			// Don't terminate, maybe output is still needed. // TODO: Reconsider it.
+		case query := <-cgr.statusQueryCh:
+			cgr.handleStatusQuery(query)
 		case <-printStatusCh:
 			printStatusCh = time.After(cgr.printStatusPeriod)
 			cgr.log.Debugf("Consensus Instance: %v", cgr.consInst.StatusString())
@@ -345,6 +382,23 @@ func (cgr *ConsGr) handleConsInput(inp gpa.Input) {
 	cgr.tryHandleOutput()
 }
 
+func (cgr *ConsGr) handleStatusQuery(query *statusQuery) {
+	defer close(query.respCh)
+	if query.ctx.Err() != nil {
+		return
+	}
+	peerLastMessage := make(map[gpa.NodeID]time.Time, len(cgr.peerLastMessage))
+	for nodeID, t := range cgr.peerLastMessage {
+		peerLastMessage[nodeID] = t
+	}
+	query.respCh <- &Status{
+		InputReceived:   cgr.outputCB != nil,
+		OutputReady:     cgr.outputReady,
+		Cons:            cgr.consInstRaw.Status(),
+		PeerLastMessage: peerLastMessage,
+	}
+}
+
 func (cgr *ConsGr) handleRedeliveryTick(t time.Time) {
 	outMsgs := cgr.consInst.Input(cgr.consInst.MakeTickInput(t))
 	cgr.sendMessages(outMsgs)
@@ -357,7 +411,9 @@ func (cgr *ConsGr) handleNetMessage(recv *peering.PeerMessageIn) {
 		cgr.log.Warnf("cannot parse message: %v", err)
 		return
 	}
-	msg.SetSender(gpa.NodeIDFromPublicKey(recv.SenderPubKey))
+	sender := gpa.NodeIDFromPublicKey(recv.SenderPubKey)
+	cgr.peerLastMessage[sender] = time.Now()
+	msg.SetSender(sender)
 	outMsgs := cgr.consInst.Message(msg)
 	cgr.sendMessages(outMsgs)
 	cgr.tryHandleOutput()
@@ -17,6 +17,7 @@ import (
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/contracts/native/inccounter"
 	"github.com/iotaledger/wasp/packages/chain/cmt_log"
+	"github.com/iotaledger/wasp/packages/chain/cons"
 	consGR "github.com/iotaledger/wasp/packages/chain/cons/cons_gr"
 	"github.com/iotaledger/wasp/packages/cryptolib"
 	"github.com/iotaledger/wasp/packages/hashing"
@@ -133,6 +134,14 @@ func testGrBasic(t *testing.T, n, f int, reliable bool) {
 		)
 	}
 	//
+	// The instances are waiting for the input.
+	for _, node := range nodes {
+		status := <-node.StatusAsync(ctx)
+		require.NotNil(t, status)
+		require.False(t, status.InputReceived)
+		require.Equal(t, cons.Running, status.Cons.Output)
+	}
+	//
 	// Start the consensus in all nodes.
 	outputChs := make([]chan *consGR.Output, len(nodes))
 	for i, n := range nodes {
@@ -160,6 +169,16 @@ func testGrBasic(t *testing.T, n, f int, reliable bool) {
 		}
 		require.Equal(t, firstOutput.Result.Transaction, output.Result.Transaction)
 	}
+	for _, node := range nodes {
+		status := <-node.StatusAsync(ctx)
+		require.NotNil(t, status)
+		require.True(t, status.InputReceived)
+		require.True(t, status.OutputReady)
+		require.Equal(t, cons.Completed, status.Cons.Output)
+		if n > 1 {
+			require.NotEmpty(t, status.PeerLastMessage)
+		}
+	}
 }
 
 ////////////////////////////////////////////////////////////////////////////////
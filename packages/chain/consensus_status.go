@@ -0,0 +1,207 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package chain
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/iotaledger/hive.go/ds/shrinkingmap"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/chain/cmt_log"
+	consGR "github.com/iotaledger/wasp/packages/chain/cons/cons_gr"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/gpa"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/peering"
+)
+
+// ConsensusStatus is a snapshot of the consensus related state of this node.
+// It is meant for debugging, e.g. to find out, why a chain has stalled.
+type ConsensusStatus struct {
+	Committees []*CommitteeConsensusStatus // The active committee goes first.
+}
+
+// CommitteeConsensusStatus describes a committee, this node is a member of.
+type CommitteeConsensusStatus struct {
+	Address   iotago.Address
+	Active    bool                       // True for the latest active committee.
+	CmtLog    *cmt_log.Status            // Nil, if there is no committee log for it.
+	Peers     []*ConsensusPeerStatus     // Ordered as in the DKShare.
+	Instances []*ConsensusInstanceStatus // Ordered by the LogIndex.
+}
+
+// ConsensusPeerStatus describes the liveness of a committee member, as seen by this node.
+type ConsensusPeerStatus struct {
+	Index       uint16
+	PubKey      *cryptolib.PublicKey
+	PeeringURL  string
+	Connected   bool             // The peering connection is alive.
+	LogIndex    cmt_log.LogIndex // The highest LogIndex proposed by the peer, NIL if nothing is received yet.
+	LastMessage time.Time        // The last consensus message received from the peer, zero if none.
+}
+
+// ConsensusInstanceStatus describes a consensus instance, running on this node.
+type ConsensusInstanceStatus struct {
+	LogIndex        cmt_log.LogIndex
+	BaseAliasOutput *isc.AliasOutputWithID // Nil, if the instance has not received its input yet.
+	Status          *consGR.Status         // Nil, if the instance has terminated before responding.
+}
+
+type consensusStatusQuery struct {
+	ctx    context.Context
+	respCh chan *consensusStatusSnapshot
+}
+
+// Taken in the main thread of the chain node, the consensus instances are queried later.
+type consensusStatusSnapshot struct {
+	committees []*committeeSnapshot
+}
+
+type committeeSnapshot struct {
+	status    *CommitteeConsensusStatus
+	keys      []*cryptolib.PublicKey
+	instances []*consensusInstSnapshot
+}
+
+type consensusInstSnapshot struct {
+	logIndex        cmt_log.LogIndex
+	baseAliasOutput *isc.AliasOutputWithID
+	consensus       *consGR.ConsGr
+}
+
+func (cni *chainNodeImpl) GetConsensusStatus(ctx context.Context) *ConsensusStatus {
+	query := &consensusStatusQuery{ctx: ctx, respCh: make(chan *consensusStatusSnapshot, 1)}
+	select {
+	case cni.consensusStatusCh <- query:
+	case <-ctx.Done():
+		return nil
+	}
+	var snapshot *consensusStatusSnapshot
+	select {
+	case snapshot = <-query.respCh:
+	case <-ctx.Done():
+		return nil
+	}
+	//
+	// The consensus instances run in their own threads, thus are queried here.
+	netPeerStatus := cni.net.PeerStatus()
+	committees := make([]*CommitteeConsensusStatus, len(snapshot.committees))
+	for i, cs := range snapshot.committees {
+		lastMessages := map[gpa.NodeID]time.Time{}
+		for _, inst := range cs.instances {
+			status, ok := <-inst.consensus.StatusAsync(ctx)
+			if !ok && ctx.Err() != nil {
+				return nil
+			}
+			if status != nil {
+				for nodeID, t := range status.PeerLastMessage {
+					if t.After(lastMessages[nodeID]) {
+						lastMessages[nodeID] = t
+					}
+				}
+			}
+			cs.status.Instances = append(cs.status.Instances, &ConsensusInstanceStatus{
+				LogIndex:        inst.logIndex,
+				BaseAliasOutput: inst.baseAliasOutput,
+				Status:          status,
+			})
+		}
+		cs.status.Peers = consensusPeerStatus(cs.keys, cs.status.CmtLog, lastMessages, netPeerStatus)
+		committees[i] = cs.status
+	}
+	return &ConsensusStatus{Committees: committees}
+}
+
+func consensusPeerStatus(
+	keys []*cryptolib.PublicKey,
+	cmtLogStatus *cmt_log.Status,
+	lastMessages map[gpa.NodeID]time.Time,
+	netPeerStatus []peering.PeerStatusProvider,
+) []*ConsensusPeerStatus {
+	peers := make([]*ConsensusPeerStatus, len(keys))
+	for i, pubKey := range keys {
+		nodeID := gpa.NodeIDFromPublicKey(pubKey)
+		peer := &ConsensusPeerStatus{
+			Index:       uint16(i),
+			PubKey:      pubKey,
+			LogIndex:    cmt_log.NilLogIndex(),
+			LastMessage: lastMessages[nodeID],
+		}
+		if cmtLogStatus != nil {
+			if li, ok := cmtLogStatus.PeerLogIndexes[nodeID]; ok {
+				peer.LogIndex = li
+			}
+		}
+		index := slices.IndexFunc(netPeerStatus, func(st peering.PeerStatusProvider) bool {
+			return st.PubKey().Equals(pubKey)
+		})
+		if index != -1 {
+			peer.PeeringURL = netPeerStatus[index].PeeringURL()
+			peer.Connected = netPeerStatus[index].IsAlive()
+		}
+		peers[i] = peer
+	}
+	return peers
+}
+
+// Called in the main thread of the chain node.
+func (cni *chainNodeImpl) handleConsensusStatusQuery(query *consensusStatusQuery) {
+	if query.ctx.Err() != nil {
+		return
+	}
+	snapshot := &consensusStatusSnapshot{committees: []*committeeSnapshot{}}
+	byAddr := map[iotago.Ed25519Address]*committeeSnapshot{}
+	for _, cls := range cni.chainMgrInst.CmtLogStatus() {
+		committeeAddr := cls.CommitteeAddr
+		cs := &committeeSnapshot{
+			status: &CommitteeConsensusStatus{
+				Address:   &committeeAddr,
+				Active:    cls.Active,
+				CmtLog:    cls.Status,
+				Instances: []*ConsensusInstanceStatus{},
+			},
+			keys:      cls.CommitteeKeys,
+			instances: []*consensusInstSnapshot{},
+		}
+		byAddr[committeeAddr] = cs
+		snapshot.committees = append(snapshot.committees, cs)
+	}
+	cni.consensusInsts.ForEach(func(committeeAddr iotago.Ed25519Address, consensusInstances *shrinkingmap.ShrinkingMap[cmt_log.LogIndex, *consensusInst]) bool {
+		cs, ok := byAddr[committeeAddr]
+		if !ok {
+			committeeAddrCopy := committeeAddr
+			cs = &committeeSnapshot{
+				status: &CommitteeConsensusStatus{
+					Address:   &committeeAddrCopy,
+					Instances: []*ConsensusInstanceStatus{},
+				},
+				instances: []*consensusInstSnapshot{},
+			}
+			byAddr[committeeAddr] = cs
+			snapshot.committees = append(snapshot.committees, cs)
+		}
+		consensusInstances.ForEach(func(li cmt_log.LogIndex, ci *consensusInst) bool {
+			if cs.keys == nil {
+				cs.keys = ci.committee
+			}
+			inst := &consensusInstSnapshot{logIndex: li, consensus: ci.consensus}
+			if ci.request != nil {
+				inst.baseAliasOutput = ci.request.BaseAliasOutput
+			}
+			cs.instances = append(cs.instances, inst)
+			return true
+		})
+		sort.Slice(cs.instances, func(i, j int) bool {
+			return cs.instances[i].logIndex < cs.instances[j].logIndex
+		})
+		return true
+	})
+	sort.SliceStable(snapshot.committees, func(i, j int) bool {
+		return snapshot.committees[i].status.Active && !snapshot.committees[j].status.Active
+	})
+	query.respCh <- snapshot
+}
@@ -36,10 +36,20 @@
 // to the proposal based on a tangle time. The tangle time is received from the
 // L1 with the milestones.
 //
-// NOTE: A node looses its off-ledger requests on restart. The on-ledger requests
-// will be added back to the mempool by reading them from the L1 node.
+// The off-ledger requests are persisted in a per-chain journal (OffLedgerJournal).
+// On restart they are loaded from it and re-added to the mempool when the first
+// chain head is received, after dropping the ones that cannot be processed anymore
+// (bad nonce, expired TTL or already processed according to the blocklog).
+// The on-ledger requests will be added back to the mempool by reading them from
+// the L1 node.
 //
-// TODO: Propose subset of the requests. That's for the next release.
+// When building a proposal, the requests are ordered according to the
+// OrderingPolicy of the chain, and at most MaxProposalSize of them are proposed.
+// The consensus reorders the decided batch, so the policy only decides which
+// requests get into the proposal, not their execution order.
+// The off-ledger requests of a single account are always proposed in the order
+// of their nonces. The number of off-ledger requests a single account can have
+// in the pool can be limited with MaxRequestsPerAccount.
 package mempool
 
 import (
@@ -65,6 +75,7 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 	"github.com/iotaledger/wasp/packages/vm/core/evm/evmimpl"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/root"
 )
 
 const (
@@ -108,6 +119,9 @@ type Mempool interface {
 	ServerNodesUpdated(committeePubKeys []*cryptolib.PublicKey, serverNodePubKeys []*cryptolib.PublicKey)
 	AccessNodesUpdated(committeePubKeys []*cryptolib.PublicKey, accessNodePubKeys []*cryptolib.PublicKey)
 	ConsensusInstancesUpdated(activeConsensusInstances []consGR.ConsensusID)
+	// Returns the off-ledger requests currently waiting in the mempool,
+	// ordered by nonce for each sender. Used to inspect the pool only.
+	OffLedgerRequestsAsync(ctx context.Context) <-chan []isc.OffLedgerRequest
 
 	GetContents() io.Reader
 }
@@ -136,6 +150,8 @@ type mempoolImpl struct {
 	timePool                       TimePool
 	onLedgerPool                   RequestPool[isc.OnLedgerRequest]
 	offLedgerPool                  *TypedPoolByNonce[isc.OffLedgerRequest]
+	offLedgerJournal               OffLedgerJournal
+	offLedgerToRestore             []*OffLedgerJournalEntry // Loaded from the journal, waiting for the first chain head.
 	distSync                       gpa.GPA
 	chainHeadAO                    *isc.AliasOutputWithID
 	chainHeadState                 state.State
@@ -148,8 +164,10 @@ type mempoolImpl struct {
 	consensusInstances             []consGR.ConsensusID
 	waitReq                        WaitReq
 	waitChainHead                  []*reqConsensusProposal
+	waitScheduledCall              []*reqConsensusProposal
 	reqConsensusProposalPipe       pipe.Pipe[*reqConsensusProposal]
 	reqConsensusRequestsPipe       pipe.Pipe[*reqConsensusRequests]
+	reqOffLedgerRequestsPipe       pipe.Pipe[*reqOffLedgerRequests]
 	reqReceiveOnLedgerRequestPipe  pipe.Pipe[isc.OnLedgerRequest]
 	reqReceiveOffLedgerRequestPipe pipe.Pipe[isc.OffLedgerRequest]
 	reqTangleTimeUpdatedPipe       pipe.Pipe[time.Time]
@@ -161,6 +179,7 @@ type mempoolImpl struct {
 	activeConsensusInstances       []consGR.ConsensusID
 	ttl                            time.Duration // time to live (how much time requests are allowed to sit in the pool without being processed)
 	broadcastInterval              time.Duration // how often requests should be rebroadcasted
+	ordering                       OrderingParameters
 	log                            *logger.Logger
 	metrics                        *metrics.ChainMempoolMetrics
 	listener                       ChainListener
@@ -191,9 +210,11 @@ type reqConsensusProposal struct {
 	aliasOutput *isc.AliasOutputWithID
 	consensusID consGR.ConsensusID
 	responseCh  chan<- []*isc.RequestRef
+	responded   bool
 }
 
 func (r *reqConsensusProposal) Respond(reqRefs []*isc.RequestRef) {
+	r.responded = true
 	r.responseCh <- reqRefs
 	close(r.responseCh)
 }
@@ -204,6 +225,11 @@ type reqConsensusRequests struct {
 	responseCh  chan<- []isc.Request
 }
 
+type reqOffLedgerRequests struct {
+	ctx        context.Context
+	responseCh chan<- []isc.OffLedgerRequest
+}
+
 type reqTrackNewChainHead struct {
 	st         state.State
 	from       *isc.AliasOutputWithID
@@ -222,8 +248,10 @@ func New(
 	metrics *metrics.ChainMempoolMetrics,
 	pipeMetrics *metrics.ChainPipeMetrics,
 	listener ChainListener,
+	offLedgerJournal OffLedgerJournal,
 	ttl time.Duration,
 	broadcastInterval time.Duration,
+	ordering OrderingParameters,
 ) Mempool {
 	netPeeringID := peering.HashPeeringIDFromBytes(chainID.Bytes(), []byte("Mempool")) // ChainID × Mempool
 	waitReq := NewWaitReq(waitRequestCleanupEvery)
@@ -232,7 +260,9 @@ func New(
 		tangleTime:                     time.Time{},
 		timePool:                       NewTimePool(metrics.SetTimePoolSize, log.Named("TIM")),
 		onLedgerPool:                   NewTypedPool[isc.OnLedgerRequest](waitReq, metrics.SetOnLedgerPoolSize, metrics.SetOnLedgerReqTime, log.Named("ONL")),
-		offLedgerPool:                  NewTypedPoolByNonce[isc.OffLedgerRequest](waitReq, metrics.SetOffLedgerPoolSize, metrics.SetOffLedgerReqTime, log.Named("OFF")),
+		offLedgerPool:                  NewTypedPoolByNonce[isc.OffLedgerRequest](waitReq, offLedgerJournal, metrics.SetOffLedgerPoolSize, metrics.SetOffLedgerReqTime, log.Named("OFF")),
+		offLedgerJournal:               offLedgerJournal,
+		offLedgerToRestore:             offLedgerJournal.LoadAll(),
 		chainHeadAO:                    nil,
 		serverNodesUpdatedPipe:         pipe.NewInfinitePipe[*reqServerNodesUpdated](),
 		serverNodes:                    []*cryptolib.PublicKey{},
@@ -241,8 +271,10 @@ func New(
 		committeeNodes:                 []*cryptolib.PublicKey{},
 		waitReq:                        waitReq,
 		waitChainHead:                  []*reqConsensusProposal{},
+		waitScheduledCall:              []*reqConsensusProposal{},
 		reqConsensusProposalPipe:       pipe.NewInfinitePipe[*reqConsensusProposal](),
 		reqConsensusRequestsPipe:       pipe.NewInfinitePipe[*reqConsensusRequests](),
+		reqOffLedgerRequestsPipe:       pipe.NewInfinitePipe[*reqOffLedgerRequests](),
 		reqReceiveOnLedgerRequestPipe:  pipe.NewInfinitePipe[isc.OnLedgerRequest](),
 		reqReceiveOffLedgerRequestPipe: pipe.NewInfinitePipe[isc.OffLedgerRequest](),
 		reqTangleTimeUpdatedPipe:       pipe.NewInfinitePipe[time.Time](),
@@ -255,15 +287,18 @@ func New(
 		activeConsensusInstances:       []consGR.ConsensusID{},
 		ttl:                            ttl,
 		broadcastInterval:              broadcastInterval,
+		ordering:                       ordering,
 		log:                            log,
 		metrics:                        metrics,
 		listener:                       listener,
 	}
 
+	metrics.SetOrderingPolicy(string(ordering.Policy))
 	pipeMetrics.TrackPipeLen("mp-serverNodesUpdatedPipe", mpi.serverNodesUpdatedPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-accessNodesUpdatedPipe", mpi.accessNodesUpdatedPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqConsensusProposalPipe", mpi.reqConsensusProposalPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqConsensusRequestsPipe", mpi.reqConsensusRequestsPipe.Len)
+	pipeMetrics.TrackPipeLen("mp-reqOffLedgerRequestsPipe", mpi.reqOffLedgerRequestsPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqReceiveOnLedgerRequestPipe", mpi.reqReceiveOnLedgerRequestPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqReceiveOffLedgerRequestPipe", mpi.reqReceiveOffLedgerRequestPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqTangleTimeUpdatedPipe", mpi.reqTangleTimeUpdatedPipe.Len)
@@ -355,6 +390,15 @@ func (mpi *mempoolImpl) ConsensusRequestsAsync(ctx context.Context, requestRefs
 	return res
 }
 
+func (mpi *mempoolImpl) OffLedgerRequestsAsync(ctx context.Context) <-chan []isc.OffLedgerRequest {
+	res := make(chan []isc.OffLedgerRequest, 1)
+	mpi.reqOffLedgerRequestsPipe.In() <- &reqOffLedgerRequests{
+		ctx:        ctx,
+		responseCh: res,
+	}
+	return res
+}
+
 func (mpi *mempoolImpl) writeContentAndClose(pw *io.PipeWriter) {
 	defer pw.Close()
 	mpi.onLedgerPool.WriteContent(pw)
@@ -373,6 +417,7 @@ func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc)
 	consensusInstancesUpdatedPipeOutCh := mpi.consensusInstancesUpdatedPipe.Out()
 	reqConsensusProposalPipeOutCh := mpi.reqConsensusProposalPipe.Out()
 	reqConsensusRequestsPipeOutCh := mpi.reqConsensusRequestsPipe.Out()
+	reqOffLedgerRequestsPipeOutCh := mpi.reqOffLedgerRequestsPipe.Out()
 	reqReceiveOnLedgerRequestPipeOutCh := mpi.reqReceiveOnLedgerRequestPipe.Out()
 	reqReceiveOffLedgerRequestPipeOutCh := mpi.reqReceiveOffLedgerRequestPipe.Out()
 	reqTangleTimeUpdatedPipeOutCh := mpi.reqTangleTimeUpdatedPipe.Out()
@@ -409,6 +454,12 @@ func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc)
 				break
 			}
 			mpi.handleConsensusRequests(recv)
+		case recv, ok := <-reqOffLedgerRequestsPipeOutCh:
+			if !ok {
+				reqOffLedgerRequestsPipeOutCh = nil
+				break
+			}
+			mpi.handleOffLedgerRequests(recv)
 		case recv, ok := <-reqReceiveOnLedgerRequestPipeOutCh:
 			if !ok {
 				reqReceiveOnLedgerRequestPipeOutCh = nil
@@ -531,6 +582,15 @@ func (mpi *mempoolImpl) shouldAddOffledgerRequest(req isc.OffLedgerRequest) erro
 		return fmt.Errorf("bad nonce, expected: %d", accountNonce)
 	}
 
+	// a request replacing the one with the same nonce is accepted even if the account is at its limit
+	if mpi.ordering.MaxRequestsPerAccount > 0 {
+		count, replacing := mpi.offLedgerPool.AccountRequestCount(req.SenderAccount(), req.Nonce())
+		if count >= mpi.ordering.MaxRequestsPerAccount && !replacing {
+			mpi.metrics.IncRequestsRejectedAccountLimit()
+			return fmt.Errorf("%w, limit: %d", ErrAccountLimitReached, mpi.ordering.MaxRequestsPerAccount)
+		}
+	}
+
 	// check user has on-chain balance
 	accountsState := accounts.NewStateAccess(mpi.chainHeadState)
 	if !accountsState.AccountExists(req.SenderAccount(), mpi.chainID) {
@@ -583,15 +643,32 @@ func (mpi *mempoolImpl) handleConsensusProposal(recv *reqConsensusProposal) {
 
 func (mpi *mempoolImpl) refsToPropose(consensusID consGR.ConsensusID) []*isc.RequestRef {
 	//
-	// The case for matching ChainHeadAO and request BaseAO
-	reqRefs := []*isc.RequestRef{}
+	// The candidates are collected per sender, then ordered according to the ordering policy.
+	onLedgerQueues := map[string]senderQueue{}
+	offLedgerQueues := map[string]senderQueue{}
+	fee := func(isc.Request) uint64 { return 0 }
+	if mpi.ordering.Policy == OrderingFeePriority && mpi.chainHeadState != nil {
+		chainInfo := governance.NewStateAccess(mpi.chainHeadState).ChainInfo(mpi.chainID)
+		accountsState := accounts.NewStateAccess(mpi.chainHeadState)
+		fee = func(req isc.Request) uint64 {
+			var senderBaseTokens uint64
+			if sender := req.SenderAccount(); sender != nil {
+				senderBaseTokens = accountsState.BaseTokensBalance(sender, mpi.chainID)
+			}
+			return requestFee(req, chainInfo, senderBaseTokens)
+		}
+	}
 	if !mpi.tangleTime.IsZero() { // Wait for tangle-time to process the on ledger requests.
-		mpi.onLedgerPool.Filter(func(request isc.OnLedgerRequest, _ time.Time) bool {
+		mpi.onLedgerPool.Filter(func(request isc.OnLedgerRequest, ts time.Time) bool {
 			if isc.RequestIsExpired(request, mpi.tangleTime) {
 				return false // Drop it from the mempool
 			}
 			if isc.RequestIsUnlockable(request, mpi.chainID.AsAddress(), mpi.tangleTime) {
-				reqRefs = append(reqRefs, isc.RequestRefFromRequest(request))
+				sender := ""
+				if request.SenderAccount() != nil {
+					sender = request.SenderAccount().String()
+				}
+				onLedgerQueues[sender] = append(onLedgerQueues[sender], newProposalCandidate(request, ts, fee(request), nil))
 			}
 			return true // Keep them for now
 		})
@@ -630,10 +707,12 @@ func (mpi *mempoolImpl) refsToPropose(consensusID consGR.ConsensusID) []*isc.Req
 			}
 
 			if reqNonce == accountNonce {
-				// expected nonce, add it to the list to propose
-				mpi.log.Debugf("refsToPropose, account: %s, proposing reqID %s with nonce: %d", account, e.req.ID().String(), e.req.Nonce())
-				reqRefs = append(reqRefs, isc.RequestRefFromRequest(e.req))
-				e.proposedFor = append(e.proposedFor, consensusID)
+				// expected nonce, add it to the candidates to propose
+				entry := e
+				offLedgerQueues[account] = append(offLedgerQueues[account], newProposalCandidate(e.req, e.ts, fee(e.req), func() {
+					mpi.log.Debugf("refsToPropose, account: %s, proposing reqID %s with nonce: %d", account, entry.req.ID().String(), entry.req.Nonce())
+					entry.proposedFor = append(entry.proposedFor, consensusID)
+				}))
 				accountNonce++ // increment the account nonce to match the next valid request
 			}
 			if reqNonce > accountNonce {
@@ -643,18 +722,47 @@ func (mpi *mempoolImpl) refsToPropose(consensusID consGR.ConsensusID) []*isc.Req
 		}
 	})
 
+	queues := make([]senderQueue, 0, len(onLedgerQueues)+len(offLedgerQueues))
+	for sender, q := range onLedgerQueues {
+		queues = append(queues, mergeSenderQueues(q, offLedgerQueues[sender]))
+	}
+	for account, q := range offLedgerQueues {
+		if _, ok := onLedgerQueues[account]; !ok {
+			queues = append(queues, q)
+		}
+	}
+
+	candidates := orderProposal(mpi.ordering.Policy, queues, mpi.ordering.MaxProposalSize)
+	reqRefs := make([]*isc.RequestRef, len(candidates))
+	for i, c := range candidates {
+		if c.proposed != nil {
+			c.proposed()
+		}
+		reqRefs[i] = c.ref
+	}
 	return reqRefs
 }
 
 func (mpi *mempoolImpl) handleConsensusProposalForChainHead(recv *reqConsensusProposal) {
+	if recv.responded {
+		return // Already responded with an empty batch, because of a scheduled call.
+	}
 	refs := mpi.refsToPropose(recv.consensusID)
 	if len(refs) > 0 {
 		recv.Respond(refs)
 		return
 	}
+	if mpi.scheduledCallDue() {
+		// An idle chain has to produce a block to run the calls scheduled by contracts.
+		recv.Respond([]*isc.RequestRef{})
+		return
+	}
 
 	//
-	// Wait for any request.
+	// Wait for any request, or for a scheduled call to become due.
+	if !lo.Contains(mpi.waitScheduledCall, recv) {
+		mpi.waitScheduledCall = append(mpi.waitScheduledCall, recv)
+	}
 	mpi.waitReq.WaitAny(recv.ctx, func(_ isc.Request) {
 		mpi.handleConsensusProposalForChainHead(recv)
 	})
@@ -706,6 +814,24 @@ func (mpi *mempoolImpl) handleConsensusRequests(recv *reqConsensusRequests) {
 	})
 }
 
+func (mpi *mempoolImpl) handleOffLedgerRequests(recv *reqOffLedgerRequests) {
+	if recv.ctx.Err() != nil {
+		close(recv.responseCh)
+		return
+	}
+	reqs := []isc.OffLedgerRequest{}
+	mpi.offLedgerPool.Iterate(func(account string, entries []*OrderedPoolEntry[isc.OffLedgerRequest]) {
+		for _, e := range entries {
+			if e.old {
+				continue // replaced by a newer request with the same nonce
+			}
+			reqs = append(reqs, e.req)
+		}
+	})
+	recv.responseCh <- reqs
+	close(recv.responseCh)
+}
+
 func (mpi *mempoolImpl) handleReceiveOnLedgerRequest(request isc.OnLedgerRequest) {
 	requestID := request.ID()
 	requestRef := isc.RequestRefFromRequest(request)
@@ -785,6 +911,44 @@ func (mpi *mempoolImpl) handleTangleTimeUpdated(tangleTime time.Time) {
 			mpi.waitReq.MarkAvailable(e.req)
 		})
 	}
+	//
+	// Scheduled calls become due with the tangle time.
+	mpi.handleScheduledCallsDue()
+}
+
+// Responds with an empty batch to the proposals waiting for requests,
+// if a call scheduled by a contract has become due at the chain head.
+func (mpi *mempoolImpl) handleScheduledCallsDue() {
+	if len(mpi.waitScheduledCall) == 0 {
+		return
+	}
+	due := mpi.scheduledCallDue()
+	newWaitScheduledCall := []*reqConsensusProposal{}
+	for _, waiting := range mpi.waitScheduledCall {
+		if waiting.responded || waiting.ctx.Err() != nil {
+			continue // Drop it.
+		}
+		if due && waiting.aliasOutput.Equals(mpi.chainHeadAO) {
+			waiting.Respond([]*isc.RequestRef{})
+			continue
+		}
+		newWaitScheduledCall = append(newWaitScheduledCall, waiting)
+	}
+	mpi.waitScheduledCall = newWaitScheduledCall
+}
+
+// Returns true, if a call scheduled by a contract is due at the current tangle time.
+// The tangle time is used, because it is the time proposed by the consensus for the block.
+// The scheduled calls are not executed in the maintenance mode.
+func (mpi *mempoolImpl) scheduledCallDue() bool {
+	if mpi.chainHeadState == nil || mpi.tangleTime.IsZero() {
+		return false
+	}
+	next, ok := root.NewStateAccess(mpi.chainHeadState).NextScheduledCallTime()
+	if !ok || next.After(mpi.tangleTime) {
+		return false
+	}
+	return !governance.NewStateAccess(mpi.chainHeadState).MaintenanceStatus()
 }
 
 // - Re-add all the request from the reverted blocks.
@@ -846,6 +1010,11 @@ func (mpi *mempoolImpl) handleTrackNewChainHead(req *reqTrackNewChainHead) {
 	mpi.chainHeadState = req.st
 	mpi.chainHeadAO = req.till
 	//
+	// Restore the journaled off-ledger requests, now when we have the state to validate them.
+	if len(mpi.offLedgerToRestore) != 0 {
+		mpi.restoreOffLedgerFromJournal()
+	}
+	//
 	// Process the pending consensus proposal requests if any.
 	if len(mpi.waitChainHead) != 0 {
 		newWaitChainHead := []*reqConsensusProposal{}
@@ -863,6 +1032,45 @@ func (mpi *mempoolImpl) handleTrackNewChainHead(req *reqTrackNewChainHead) {
 	}
 }
 
+// Re-adds the off-ledger requests that were in the mempool before the node restart.
+// The requests that cannot be processed anymore are dropped from the journal.
+func (mpi *mempoolImpl) restoreOffLedgerFromJournal() {
+	entries := mpi.offLedgerToRestore
+	mpi.offLedgerToRestore = nil
+	restored := 0
+	for _, entry := range entries {
+		if mpi.offLedgerPool.Has(isc.RequestRefFromRequest(entry.Request)) {
+			continue // Received it again already, e.g. from other nodes.
+		}
+		if err := mpi.shouldRestoreOffLedgerRequest(entry); err != nil {
+			mpi.log.Debugf("dropping journaled off-ledger request %v: %v", entry.Request.ID(), err)
+			mpi.offLedgerJournal.Remove(entry.Request)
+			continue
+		}
+		mpi.offLedgerPool.addWithTS(entry.Request, entry.TS)
+		mpi.metrics.IncRequestsReceived(entry.Request)
+		restored++
+	}
+	mpi.log.Infof("Restored %v of %v off-ledger requests from the journal.", restored, len(entries))
+}
+
+func (mpi *mempoolImpl) shouldRestoreOffLedgerRequest(entry *OffLedgerJournalEntry) error {
+	if time.Since(entry.TS) > mpi.ttl {
+		return fmt.Errorf("TTL expired")
+	}
+	if err := mpi.shouldAddOffledgerRequest(entry.Request); err != nil {
+		return err
+	}
+	processed, err := blocklog.IsRequestProcessed(mpi.chainHeadState, entry.Request.ID())
+	if err != nil {
+		return fmt.Errorf("cannot check if request was processed: %w", err)
+	}
+	if processed {
+		return fmt.Errorf("already processed")
+	}
+	return nil
+}
+
 func (mpi *mempoolImpl) handleNetMessage(recv *peering.PeerMessageIn) {
 	msg, err := mpi.distSync.UnmarshalMessage(recv.MsgData)
 	if err != nil {
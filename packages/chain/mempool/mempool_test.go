@@ -677,8 +677,10 @@ func TestTTL(t *testing.T) {
 		chainMetrics.Mempool,
 		chainMetrics.Pipe,
 		chain.NewEmptyChainListener(),
+		mempool.NewEmptyOffLedgerJournal(),
 		200*time.Millisecond, // 200ms TTL
 		1*time.Second,
+		mempool.NewOrderingParameters(),
 	)
 	defer te.close()
 	start := time.Now()
@@ -727,6 +729,151 @@ func TestTTL(t *testing.T) {
 	require.Len(t, reqs2, 1) // only the last request is returned
 }
 
+// The governor sends 3 requests with a low gas budget, then another account sends
+// 2 requests with a high gas budget. Only 2 requests fit in a proposal. The ordering
+// of the proposal by the other policies is tested in TestOrderProposal.
+func TestProposalFeePriority(t *testing.T) {
+	te := newEnv(t, 1, 0, true)
+	defer te.close()
+	ordering := mempool.NewOrderingParameters()
+	ordering.Policy = mempool.OrderingFeePriority
+	ordering.MaxProposalSize = 2
+	ordering.MaxRequestsPerAccount = 3
+	chainMetrics := metrics.NewChainMetricsProvider().GetChainMetrics(isc.EmptyChainID())
+	te.mempools[0] = mempool.New(
+		te.ctx,
+		te.chainID,
+		te.peerIdentities[0],
+		te.networkProviders[0],
+		te.log.Named("N#0"),
+		chainMetrics.Mempool,
+		chainMetrics.Pipe,
+		chain.NewEmptyChainListener(),
+		mempool.NewEmptyOffLedgerJournal(),
+		24*time.Hour,
+		1*time.Second,
+		ordering,
+	)
+	start := time.Now()
+	mp := te.mempools[0]
+	mp.TangleTimeUpdated(start)
+	<-mp.TrackNewChainHead(te.stateForAO(0, te.originAO), nil, te.originAO, []state.Block{}, []state.Block{})
+
+	// deposit some funds so off-ledger requests can go through
+	other := cryptolib.NewKeyPair()
+	currentAO := te.originAO
+	for i, sender := range []*cryptolib.KeyPair{te.governor, other} {
+		output := transaction.BasicOutputFromPostData(
+			sender.Address(),
+			isc.EmptyContractIdentity(),
+			isc.RequestParameters{
+				TargetAddress: te.chainID.AsAddress(),
+				Assets:        isc.NewAssetsBaseTokens(10 * isc.Million),
+			},
+		)
+		onLedgerReq, err := isc.OnLedgerFromUTXO(output, tpkg.RandOutputID(uint16(i)))
+		require.NoError(t, err)
+		mp.ReceiveOnLedgerRequest(onLedgerReq)
+		currentAO = blockFn(te, []isc.Request{onLedgerReq}, currentAO, start)
+	}
+
+	newRequest := func(sender *cryptolib.KeyPair, nonce uint64, gasBudget uint64) isc.OffLedgerRequest {
+		return isc.NewOffLedgerRequest(te.chainID, isc.Hn("foo"), isc.Hn("bar"), dict.New(), nonce, gasBudget).Sign(sender)
+	}
+	offLedgerReqs := []isc.OffLedgerRequest{
+		newRequest(te.governor, 0, gas.LimitsDefault.MinGasPerRequest),
+		newRequest(te.governor, 1, gas.LimitsDefault.MinGasPerRequest),
+		newRequest(te.governor, 2, gas.LimitsDefault.MinGasPerRequest),
+		newRequest(other, 0, gas.LimitsDefault.MaxGasPerRequest),
+		newRequest(other, 1, gas.LimitsDefault.MaxGasPerRequest),
+	}
+	for _, req := range offLedgerReqs {
+		require.NoError(t, mp.ReceiveOffLedgerRequest(req))
+	}
+	require.Eventually(t, func() bool {
+		return len(<-mp.OffLedgerRequestsAsync(te.ctx)) == len(offLedgerReqs)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the governor is at its limit, only replacing a request is allowed
+	require.ErrorContains(t, mp.ReceiveOffLedgerRequest(newRequest(te.governor, 3, gas.LimitsDefault.MinGasPerRequest)), "too many requests")
+	require.NoError(t, mp.ReceiveOffLedgerRequest(newRequest(te.governor, 2, gas.LimitsDefault.MaxGasPerRequest)))
+
+	// the replaced request pays the highest fee, but waits for the preceding nonces
+	expected := []int{3, 4}
+	reqRefs := <-mp.ConsensusProposalAsync(te.ctx, currentAO, consGR.ConsensusID{})
+	require.Len(t, reqRefs, len(expected))
+	for i, index := range expected {
+		require.True(t, reqRefs[i].IsFor(offLedgerReqs[index]), "request %d of the proposal", i)
+	}
+}
+
+func TestOffLedgerJournal(t *testing.T) {
+	te := newEnv(t, 1, 0, true)
+	defer te.close()
+	start := time.Now()
+	mp := te.mempools[0]
+	mp.TangleTimeUpdated(start)
+	<-mp.TrackNewChainHead(te.stateForAO(0, te.originAO), nil, te.originAO, []state.Block{}, []state.Block{})
+
+	// deposit some funds so off-ledger requests can go through
+	output := transaction.BasicOutputFromPostData(
+		te.governor.Address(),
+		isc.EmptyContractIdentity(),
+		isc.RequestParameters{
+			TargetAddress: te.chainID.AsAddress(),
+			Assets:        isc.NewAssetsBaseTokens(10 * isc.Million),
+		},
+	)
+	onLedgerReq, err := isc.OnLedgerFromUTXO(output, tpkg.RandOutputID(uint16(0)))
+	require.NoError(t, err)
+	mp.ReceiveOnLedgerRequest(onLedgerReq)
+	currentAO := blockFn(te, []isc.Request{onLedgerReq}, te.originAO, start)
+
+	offLedgerReqs := make([]isc.OffLedgerRequest, 2)
+	for i := range offLedgerReqs {
+		offLedgerReqs[i] = isc.NewOffLedgerRequest(
+			te.chainID,
+			isc.Hn("foo"),
+			isc.Hn("bar"),
+			dict.New(),
+			uint64(i),
+			gas.LimitsDefault.MaxGasPerRequest,
+		).Sign(te.governor)
+		require.NoError(t, mp.ReceiveOffLedgerRequest(offLedgerReqs[i]))
+	}
+	time.Sleep(200 * time.Millisecond) // give some time for the requests to reach the pool
+	reqRefs := <-mp.ConsensusProposalAsync(te.ctx, currentAO, consGR.ConsensusID{})
+	require.Len(t, reqRefs, 2)
+	require.Len(t, te.journals[0].LoadAll(), 2)
+
+	// Restart the mempool on the same DB. The first request is processed
+	// while the node is down, so only the second one should be restored.
+	chainMetrics := metrics.NewChainMetricsProvider().GetChainMetrics(isc.EmptyChainID())
+	te.mempools[0] = mempool.New(
+		te.ctx,
+		te.chainID,
+		te.peerIdentities[0],
+		te.networkProviders[0],
+		te.log.Named("N#0-restarted"),
+		chainMetrics.Mempool,
+		chainMetrics.Pipe,
+		chain.NewEmptyChainListener(),
+		te.journals[0],
+		24*time.Hour,
+		1*time.Second,
+		mempool.NewOrderingParameters(),
+	)
+	te.mempools[0].TangleTimeUpdated(start)
+	currentAO = blockFn(te, []isc.Request{offLedgerReqs[0]}, currentAO, start)
+
+	reqRefs = <-te.mempools[0].ConsensusProposalAsync(te.ctx, currentAO, consGR.ConsensusID{})
+	require.Len(t, reqRefs, 1)
+	require.Equal(t, isc.RequestRefFromRequest(offLedgerReqs[1]), reqRefs[0])
+	journaled := te.journals[0].LoadAll()
+	require.Len(t, journaled, 1)
+	require.Equal(t, offLedgerReqs[1].ID(), journaled[0].Request.ID())
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // testEnv
 
@@ -748,6 +895,7 @@ type testEnv struct {
 	chainID          isc.ChainID
 	originAO         *isc.AliasOutputWithID
 	mempools         []mempool.Mempool
+	journals         []mempool.OffLedgerJournal
 	stores           []state.Store
 }
 
@@ -787,11 +935,15 @@ func newEnv(t *testing.T, n, f int, reliable bool) *testEnv {
 	//
 	// Initialize the nodes.
 	te.mempools = make([]mempool.Mempool, len(te.peerIdentities))
+	te.journals = make([]mempool.OffLedgerJournal, len(te.peerIdentities))
 	te.stores = make([]state.Store, len(te.peerIdentities))
 	for i := range te.peerIdentities {
-		te.stores[i] = state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+		chainDB := mapdb.NewMapDB()
+		te.stores[i] = state.NewStoreWithUniqueWriteMutex(chainDB)
 		_, err := origin.InitChainByAliasOutput(te.stores[i], te.originAO)
 		require.NoError(t, err)
+		te.journals[i], err = mempool.NewOffLedgerJournal(chainDB, te.log.Named(fmt.Sprintf("J#%v", i)))
+		require.NoError(t, err)
 		chainMetrics := metrics.NewChainMetricsProvider().GetChainMetrics(isc.EmptyChainID())
 		te.mempools[i] = mempool.New(
 			te.ctx,
@@ -802,8 +954,10 @@ func newEnv(t *testing.T, n, f int, reliable bool) *testEnv {
 			chainMetrics.Mempool,
 			chainMetrics.Pipe,
 			chain.NewEmptyChainListener(),
+			te.journals[i],
 			24*time.Hour,
 			1*time.Second,
+			mempool.NewOrderingParameters(),
 		)
 	}
 	return te
@@ -0,0 +1,121 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package mempool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/wasp/packages/chaindb"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// OffLedgerJournal persists the off-ledger requests held by the mempool,
+// so that they are not lost when the node is restarted. The mempool keeps
+// the journal in sync with its off-ledger pool and reloads it on start-up.
+type OffLedgerJournal interface {
+	// Stores the request along with the time it was received by this node.
+	Add(request isc.OffLedgerRequest, ts time.Time)
+	// Removes the request from the journal, if it is there.
+	Remove(request isc.OffLedgerRequest)
+	// Returns all the requests stored in the journal. Entries that
+	// cannot be decoded are dropped from the journal.
+	LoadAll() []*OffLedgerJournalEntry
+}
+
+type OffLedgerJournalEntry struct {
+	Request isc.OffLedgerRequest
+	TS      time.Time
+}
+
+type offLedgerJournal struct {
+	store kvstore.KVStore
+	log   *logger.Logger
+}
+
+var _ OffLedgerJournal = &offLedgerJournal{}
+
+// NewOffLedgerJournal creates a journal on top of the chain's database.
+// All the entries are kept under the chaindb.PrefixMempoolOffLedger partition.
+func NewOffLedgerJournal(chainStore kvstore.KVStore, log *logger.Logger) (OffLedgerJournal, error) {
+	store, err := chainStore.WithExtendedRealm([]byte{chaindb.PrefixMempoolOffLedger})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create the off-ledger journal store: %w", err)
+	}
+	return &offLedgerJournal{store: store, log: log}, nil
+}
+
+func (j *offLedgerJournal) Add(request isc.OffLedgerRequest, ts time.Time) {
+	ww := rwutil.NewBytesWriter()
+	ww.WriteInt64(ts.UnixNano())
+	ww.WriteBytes(request.Bytes())
+	if err := j.store.Set(request.ID().Bytes(), ww.Bytes()); err != nil {
+		j.log.Warnf("cannot journal off-ledger request %v: %v", request.ID(), err)
+	}
+}
+
+func (j *offLedgerJournal) Remove(request isc.OffLedgerRequest) {
+	if err := j.store.Delete(request.ID().Bytes()); err != nil {
+		j.log.Warnf("cannot remove off-ledger request %v from the journal: %v", request.ID(), err)
+	}
+}
+
+func (j *offLedgerJournal) LoadAll() []*OffLedgerJournalEntry {
+	entries := []*OffLedgerJournalEntry{}
+	invalidKeys := []kvstore.Key{}
+	err := j.store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		entry, err := offLedgerJournalEntryFromBytes(value)
+		if err != nil {
+			j.log.Warnf("dropping invalid off-ledger journal entry %x: %v", key, err)
+			invalidKeys = append(invalidKeys, key)
+			return true
+		}
+		entries = append(entries, entry)
+		return true
+	})
+	if err != nil {
+		j.log.Warnf("cannot read the off-ledger journal: %v", err)
+	}
+	for _, key := range invalidKeys {
+		if err := j.store.Delete(key); err != nil {
+			j.log.Warnf("cannot delete invalid off-ledger journal entry %x: %v", key, err)
+		}
+	}
+	return entries
+}
+
+func offLedgerJournalEntryFromBytes(data []byte) (*OffLedgerJournalEntry, error) {
+	rr := rwutil.NewBytesReader(data)
+	ts := time.Unix(0, rr.ReadInt64())
+	reqBytes := rr.ReadBytes()
+	rr.Close()
+	if rr.Err != nil {
+		return nil, rr.Err
+	}
+	req, err := isc.RequestFromBytes(reqBytes)
+	if err != nil {
+		return nil, err
+	}
+	offLedgerReq, ok := req.(isc.OffLedgerRequest)
+	if !ok {
+		return nil, fmt.Errorf("unexpected request type %T", req)
+	}
+	return &OffLedgerJournalEntry{Request: offLedgerReq, TS: ts}, nil
+}
+
+// NewEmptyOffLedgerJournal returns a journal that persists nothing.
+func NewEmptyOffLedgerJournal() OffLedgerJournal {
+	return &emptyOffLedgerJournal{}
+}
+
+type emptyOffLedgerJournal struct{}
+
+var _ OffLedgerJournal = &emptyOffLedgerJournal{}
+
+func (*emptyOffLedgerJournal) Add(isc.OffLedgerRequest, time.Time) {}
+func (*emptyOffLedgerJournal) Remove(isc.OffLedgerRequest)         {}
+func (*emptyOffLedgerJournal) LoadAll() []*OffLedgerJournalEntry   { return nil }
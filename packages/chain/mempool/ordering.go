@@ -0,0 +1,196 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package mempool
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+)
+
+// OrderingPolicy decides which requests are included in a consensus proposal first,
+// when there are more requests in the mempool than fit in a single proposal.
+// The off-ledger requests of a single account are always proposed in the order of their nonces.
+//
+// The policy only selects the requests of the proposal: the consensus orders the decided
+// batch pseudo-randomly (see bp.AggregatedBatchProposals.OrderedRequests), so the policy
+// has no effect on the execution order within a block. Hence the policy matters only if
+// MaxProposalSize is set.
+type OrderingPolicy string
+
+const (
+	// The requests that arrived first are proposed first
+	OrderingFIFO OrderingPolicy = "fifo"
+	// The requests paying the highest fee are proposed first
+	OrderingFeePriority OrderingPolicy = "feePriority"
+	// The senders take turns, one request of each sender at a time, starting
+	// from the sender with the oldest request
+	OrderingRoundRobin OrderingPolicy = "roundRobin"
+)
+
+func OrderingPolicyFromString(policy string) (OrderingPolicy, error) {
+	switch OrderingPolicy(policy) {
+	case "", OrderingFIFO:
+		return OrderingFIFO, nil
+	case OrderingFeePriority, OrderingRoundRobin:
+		return OrderingPolicy(policy), nil
+	default:
+		return OrderingFIFO, fmt.Errorf("unknown mempool ordering policy %q", policy)
+	}
+}
+
+// ErrAccountLimitReached is returned for an off-ledger request, if its account already has
+// MaxRequestsPerAccount requests in the mempool.
+var ErrAccountLimitReached = errors.New("too many requests of the account in mempool")
+
+// DefaultMaxProposalSize is the default limit of the requests proposed to a consensus instance.
+const DefaultMaxProposalSize = 100
+
+type OrderingParameters struct {
+	// Which requests are proposed first
+	Policy OrderingPolicy
+	// At most this number of requests is proposed to a consensus instance; 0 means unlimited
+	MaxProposalSize int
+	// At most this number of off-ledger requests of a single account can wait in the mempool; 0 means unlimited
+	MaxRequestsPerAccount int
+}
+
+func NewOrderingParameters() OrderingParameters {
+	return OrderingParameters{
+		Policy:                OrderingFIFO,
+		MaxProposalSize:       DefaultMaxProposalSize,
+		MaxRequestsPerAccount: 0,
+	}
+}
+
+// proposalCandidate is a request that can be included in a proposal.
+type proposalCandidate struct {
+	ref      *isc.RequestRef
+	ts       time.Time
+	fee      uint64 // in base tokens, only used by the fee priority policy
+	proposed func() // called if the request is included in the proposal
+}
+
+func newProposalCandidate(req isc.Request, ts time.Time, fee uint64, proposed func()) *proposalCandidate {
+	return &proposalCandidate{
+		ref:      isc.RequestRefFromRequest(req),
+		ts:       ts,
+		fee:      fee,
+		proposed: proposed,
+	}
+}
+
+// requestFee returns the fee the request pays if it burns its whole gas budget. The fee is
+// limited by the base tokens the sender has on the chain, as these are what the fee is charged
+// from: the account of the sender, and the base tokens of an on-ledger request.
+func requestFee(req isc.Request, chainInfo *isc.ChainInfo, senderBaseTokens uint64) uint64 {
+	gasBudget, isEVM := req.GasBudget()
+	if isEVM {
+		gasBudget = gas.EVMGasToISC(gasBudget, &chainInfo.GasFeePolicy.EVMGasRatio)
+	}
+	gasBudget = min(gasBudget, chainInfo.GasLimits.MaxGasPerRequest)
+	available := senderBaseTokens
+	if onLedgerReq, ok := req.(isc.OnLedgerRequest); ok {
+		available += onLedgerReq.Assets().BaseTokens
+	}
+	return min(chainInfo.GasFeePolicy.FeeFromGas(gasBudget), available)
+}
+
+// senderQueue holds the candidates of a single sender. Only the head of the queue can
+// be proposed, so that the nonces of the off-ledger requests are kept in order.
+type senderQueue []*proposalCandidate
+
+// mergeSenderQueues merges two queues of the same sender by arrival time, keeping the order of each queue.
+func mergeSenderQueues(a, b senderQueue) senderQueue {
+	ret := make(senderQueue, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if b[0].ts.Before(a[0].ts) {
+			ret = append(ret, b[0])
+			b = b[1:]
+		} else {
+			ret = append(ret, a[0])
+			a = a[1:]
+		}
+	}
+	ret = append(ret, a...)
+	return append(ret, b...)
+}
+
+// senderQueueHeap orders the sender queues by their heads.
+type senderQueueHeap struct {
+	queues []senderQueue
+	less   func(a, b *proposalCandidate) bool
+}
+
+var _ heap.Interface = &senderQueueHeap{}
+
+func (h *senderQueueHeap) Len() int           { return len(h.queues) }
+func (h *senderQueueHeap) Less(i, j int) bool { return h.less(h.queues[i][0], h.queues[j][0]) }
+func (h *senderQueueHeap) Swap(i, j int)      { h.queues[i], h.queues[j] = h.queues[j], h.queues[i] }
+func (h *senderQueueHeap) Push(x any)         { h.queues = append(h.queues, x.(senderQueue)) }
+
+func (h *senderQueueHeap) Pop() any {
+	last := h.queues[len(h.queues)-1]
+	h.queues = h.queues[:len(h.queues)-1]
+	return last
+}
+
+func olderCandidate(a, b *proposalCandidate) bool {
+	return a.ts.Before(b.ts)
+}
+
+func higherFeeCandidate(a, b *proposalCandidate) bool {
+	if a.fee != b.fee {
+		return a.fee > b.fee
+	}
+	return olderCandidate(a, b)
+}
+
+// orderProposal selects at most maxSize candidates (all of them, if maxSize is 0)
+// from the queues of the senders, in the order defined by the policy.
+func orderProposal(policy OrderingPolicy, queues []senderQueue, maxSize int) []*proposalCandidate {
+	queues = slices.DeleteFunc(queues, func(q senderQueue) bool { return len(q) == 0 })
+	total := 0
+	for _, q := range queues {
+		total += len(q)
+	}
+	if maxSize <= 0 || maxSize > total {
+		maxSize = total
+	}
+	ret := make([]*proposalCandidate, 0, maxSize)
+
+	if policy == OrderingRoundRobin {
+		slices.SortStableFunc(queues, func(a, b senderQueue) int { return a[0].ts.Compare(b[0].ts) })
+		for round := 0; len(ret) < maxSize; round++ {
+			for _, q := range queues {
+				if round < len(q) && len(ret) < maxSize {
+					ret = append(ret, q[round])
+				}
+			}
+		}
+		return ret
+	}
+
+	h := &senderQueueHeap{queues: queues, less: olderCandidate}
+	if policy == OrderingFeePriority {
+		h.less = higherFeeCandidate
+	}
+	heap.Init(h)
+	for len(ret) < maxSize {
+		q := h.queues[0]
+		ret = append(ret, q[0])
+		if len(q) == 1 {
+			heap.Pop(h)
+			continue
+		}
+		h.queues[0] = q[1:]
+		heap.Fix(h, 0)
+	}
+	return ret
+}
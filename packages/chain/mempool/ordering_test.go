@@ -0,0 +1,74 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+)
+
+func TestOrderProposal(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	chainID := isc.RandomChainID()
+	newCandidate := func(sender *cryptolib.KeyPair, nonce uint64, ts time.Duration, fee uint64) *proposalCandidate {
+		req := isc.NewOffLedgerRequest(chainID, isc.Hn("foo"), isc.Hn("bar"), dict.New(), nonce, gas.LimitsDefault.MinGasPerRequest).Sign(sender)
+		return newProposalCandidate(req, start.Add(ts), fee, nil)
+	}
+	// the first sender sends 3 requests paying a low fee, then the second
+	// sender sends 2 requests paying a high fee
+	first := cryptolib.NewKeyPair()
+	second := cryptolib.NewKeyPair()
+	candidates := []*proposalCandidate{
+		newCandidate(first, 0, 0, 10),
+		newCandidate(first, 1, 1*time.Second, 10),
+		newCandidate(first, 2, 4*time.Second, 1000),
+		newCandidate(second, 0, 2*time.Second, 100),
+		newCandidate(second, 1, 3*time.Second, 100),
+	}
+	queues := func() []senderQueue {
+		return []senderQueue{
+			{candidates[3], candidates[4]},
+			{candidates[0], candidates[1], candidates[2]},
+		}
+	}
+
+	for _, test := range []struct {
+		policy   OrderingPolicy
+		maxSize  int
+		expected []int
+	}{
+		{OrderingFIFO, 2, []int{0, 1}},
+		{OrderingFIFO, 0, []int{0, 1, 3, 4, 2}},
+		// the request with the highest fee waits for the preceding nonces of its sender
+		{OrderingFeePriority, 2, []int{3, 4}},
+		{OrderingFeePriority, 4, []int{3, 4, 0, 1}},
+		{OrderingRoundRobin, 2, []int{0, 3}},
+		{OrderingRoundRobin, 0, []int{0, 3, 1, 4, 2}},
+	} {
+		proposal := orderProposal(test.policy, queues(), test.maxSize)
+		require.Len(t, proposal, len(test.expected), "policy %s, max size %d", test.policy, test.maxSize)
+		for i, index := range test.expected {
+			require.Same(t, candidates[index], proposal[i], "policy %s, max size %d, request %d", test.policy, test.maxSize, i)
+		}
+	}
+}
+
+func TestRequestFee(t *testing.T) {
+	chainInfo := &isc.ChainInfo{
+		GasFeePolicy: gas.DefaultFeePolicy(),
+		GasLimits:    gas.LimitsDefault,
+	}
+	req := isc.NewOffLedgerRequest(isc.RandomChainID(), isc.Hn("foo"), isc.Hn("bar"), dict.New(), 0, 1_000_000).Sign(cryptolib.NewKeyPair())
+	require.EqualValues(t, chainInfo.GasFeePolicy.FeeFromGas(1_000_000), requestFee(req, chainInfo, 1*isc.Million))
+	// the sender can't pay more than its balance
+	require.EqualValues(t, 10, requestFee(req, chainInfo, 10))
+
+	// the gas budget is limited by the maximal gas of a request
+	req = isc.NewOffLedgerRequest(isc.RandomChainID(), isc.Hn("foo"), isc.Hn("bar"), dict.New(), 0, 2*gas.LimitsDefault.MaxGasPerRequest).Sign(cryptolib.NewKeyPair())
+	require.EqualValues(t, chainInfo.GasFeePolicy.FeeFromGas(gas.LimitsDefault.MaxGasPerRequest), requestFee(req, chainInfo, 1000*isc.Million))
+}
@@ -19,6 +19,7 @@ import (
 // keeps a map of requests ordered by nonce for each account
 type TypedPoolByNonce[V isc.OffLedgerRequest] struct {
 	waitReq WaitReq
+	journal OffLedgerJournal
 	refLUT  *shrinkingmap.ShrinkingMap[isc.RequestRefKey, *OrderedPoolEntry[V]]
 	// reqsByAcountOrdered keeps an ordered map of reqsByAcountOrdered for each account by nonce
 	reqsByAcountOrdered *shrinkingmap.ShrinkingMap[string, []*OrderedPoolEntry[V]] // string is isc.AgentID.String()
@@ -27,9 +28,10 @@ type TypedPoolByNonce[V isc.OffLedgerRequest] struct {
 	log                 *logger.Logger
 }
 
-func NewTypedPoolByNonce[V isc.OffLedgerRequest](waitReq WaitReq, sizeMetric func(int), timeMetric func(time.Duration), log *logger.Logger) *TypedPoolByNonce[V] {
+func NewTypedPoolByNonce[V isc.OffLedgerRequest](waitReq WaitReq, journal OffLedgerJournal, sizeMetric func(int), timeMetric func(time.Duration), log *logger.Logger) *TypedPoolByNonce[V] {
 	return &TypedPoolByNonce[V]{
 		waitReq:             waitReq,
+		journal:             journal,
 		reqsByAcountOrdered: shrinkingmap.New[string, []*OrderedPoolEntry[V]](),
 		refLUT:              shrinkingmap.New[isc.RequestRefKey, *OrderedPoolEntry[V]](),
 		sizeMetric:          sizeMetric,
@@ -58,8 +60,14 @@ func (p *TypedPoolByNonce[V]) Get(reqRef *isc.RequestRef) V {
 }
 
 func (p *TypedPoolByNonce[V]) Add(request V) {
+	p.addWithTS(request, time.Now())
+}
+
+// Adds the request as if it was received at the specified time.
+// Used to restore the requests from the journal, to keep their TTL.
+func (p *TypedPoolByNonce[V]) addWithTS(request V, ts time.Time) {
 	ref := isc.RequestRefFromRequest(request)
-	entry := &OrderedPoolEntry[V]{req: request, ts: time.Now()}
+	entry := &OrderedPoolEntry[V]{req: request, ts: ts}
 	account := request.SenderAccount().String()
 
 	if !p.refLUT.Set(ref.AsKey(), entry) {
@@ -67,6 +75,8 @@ func (p *TypedPoolByNonce[V]) Add(request V) {
 		return // not added already exists
 	}
 
+	p.journal.Add(request, ts)
+
 	defer func() {
 		p.log.Debugf("ADD %v as key=%v, senderAccount: %s", request.ID(), ref, account)
 		p.sizeMetric(p.refLUT.Size())
@@ -125,6 +135,7 @@ func (p *TypedPoolByNonce[V]) Remove(request V) {
 	if p.refLUT.Delete(refKey) {
 		p.log.Debugf("DEL %v as key=%v", request.ID(), refKey)
 	}
+	p.journal.Remove(entry.req)
 	account := entry.req.SenderAccount().String()
 	reqsByAccount, exists := p.reqsByAcountOrdered.Get(account)
 	if !exists {
@@ -148,6 +159,18 @@ func (p *TypedPoolByNonce[V]) Remove(request V) {
 	p.reqsByAcountOrdered.Set(account, reqsByAccount)
 }
 
+// AccountRequestCount returns the number of requests of the account in the pool,
+// and whether one of them has the specified nonce.
+func (p *TypedPoolByNonce[V]) AccountRequestCount(account isc.AgentID, nonce uint64) (int, bool) {
+	entries, exists := p.reqsByAcountOrdered.Get(account.String())
+	if !exists {
+		return 0, false
+	}
+	return len(entries), slices.ContainsFunc(entries, func(e *OrderedPoolEntry[V]) bool {
+		return e.req.Nonce() == nonce
+	})
+}
+
 func (p *TypedPoolByNonce[V]) Iterate(f func(account string, requests []*OrderedPoolEntry[V])) {
 	p.reqsByAcountOrdered.ForEach(func(acc string, entries []*OrderedPoolEntry[V]) bool {
 		f(acc, slices.Clone(entries))
@@ -86,6 +86,12 @@ type Chain interface {
 	GetConsensusPipeMetrics() ConsensusPipeMetrics // TODO: Review this.
 	GetConsensusWorkflowStatus() ConsensusWorkflowStatus
 	GetMempoolContents() io.Reader
+	// Returns the off-ledger requests waiting in the mempool, ordered by nonce
+	// for each sender. Returns nil, if the context is done before the mempool responds.
+	GetMempoolOffLedgerRequests(ctx context.Context) []isc.OffLedgerRequest
+	// Returns the state of the committee logs and the consensus instances of this node,
+	// and the liveness of the committee peers. Returns nil, if the context is done first.
+	GetConsensusStatus(ctx context.Context) *ConsensusStatus
 }
 
 type CommitteeInfo struct {
@@ -148,6 +154,7 @@ type chainNodeImpl struct {
 	nodeIdentity        *cryptolib.KeyPair
 	chainID             isc.ChainID
 	chainMgr            gpa.AckHandler
+	chainMgrInst        chainmanager.ChainMgr // The same as chainMgr, used to query its status.
 	chainStore          indexedstore.IndexedStore
 	nodeConn            NodeConnection
 	tangleTime          time.Time
@@ -165,6 +172,7 @@ type chainNodeImpl struct {
 	serversUpdatedPipe  pipe.Pipe[*serversUpdate]
 	awaitReceiptActCh   chan *awaitReceiptReq
 	awaitReceiptCnfCh   chan *awaitReceiptReq
+	consensusStatusCh   chan *consensusStatusQuery
 	stateTrackerAct     StateTracker
 	stateTrackerCnf     StateTracker
 	blockWAL            sm_gpa_utils.BlockWAL
@@ -284,6 +292,8 @@ func New(
 	smParameters sm_gpa.StateManagerParameters,
 	mempoolTTL time.Duration,
 	mempoolBroadcastInterval time.Duration,
+	mempoolOrdering mempool.OrderingParameters,
+	mempoolOffLedgerJournal mempool.OffLedgerJournal,
 ) (Chain, error) {
 	log.Debugf("Starting the chain, chainID=%v", chainID)
 	if listener == nil {
@@ -311,6 +321,7 @@ func New(
 		serversUpdatedPipe:     pipe.NewInfinitePipe[*serversUpdate](),
 		awaitReceiptActCh:      make(chan *awaitReceiptReq, 1),
 		awaitReceiptCnfCh:      make(chan *awaitReceiptReq, 1),
+		consensusStatusCh:      make(chan *consensusStatusQuery),
 		stateTrackerAct:        nil, // Set bellow.
 		stateTrackerCnf:        nil, // Set bellow.
 		blockWAL:               blockWAL,
@@ -431,10 +442,13 @@ func New(
 		chainMetrics.Mempool,
 		chainMetrics.Pipe,
 		cni.listener,
+		mempoolOffLedgerJournal,
 		mempoolTTL,
 		mempoolBroadcastInterval,
+		mempoolOrdering,
 	)
 	cni.chainMgr = gpa.NewAckHandler(cni.me, chainMgr.AsGPA(), RedeliveryPeriod)
+	cni.chainMgrInst = chainMgr
 	cni.stateMgr = stateMgr
 	cni.mempool = mempool
 	cni.stateTrackerAct = NewStateTracker(ctx, stateMgr, cni.handleStateTrackerActCB, chainMetrics.StateManager.SetChainActiveStateWant, chainMetrics.StateManager.SetChainActiveStateHave, cni.log.Named("ST.ACT"))
@@ -601,6 +615,12 @@ func (cni *chainNodeImpl) run(ctx context.Context, cleanupFunc context.CancelFun
 				continue
 			}
 			cni.stateTrackerCnf.AwaitRequestReceipt(query)
+		case query, ok := <-cni.consensusStatusCh:
+			if !ok {
+				cni.consensusStatusCh = nil
+				continue
+			}
+			cni.handleConsensusStatusQuery(query)
 		case resp, ok := <-cni.stateTrackerAct.ChainNodeAwaitStateMgrCh():
 			if ok {
 				cni.stateTrackerAct.ChainNodeStateMgrResponse(resp)
@@ -1255,6 +1275,15 @@ func (cni *chainNodeImpl) GetMempoolContents() io.Reader {
 	return cni.mempool.GetContents()
 }
 
+func (cni *chainNodeImpl) GetMempoolOffLedgerRequests(ctx context.Context) []isc.OffLedgerRequest {
+	select {
+	case reqs := <-cni.mempool.OffLedgerRequestsAsync(ctx):
+		return reqs
+	case <-ctx.Done():
+		return nil
+	}
+}
+
 func (cni *chainNodeImpl) recoverStoreFromWAL(chainStore indexedstore.IndexedStore, chainWAL sm_gpa_utils.BlockWAL) {
 	//
 	// Load all the existing blocks from the WAL.
@@ -19,6 +19,7 @@ import (
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/contracts/native/inccounter"
 	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/chain/mempool"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_snapshots"
@@ -223,6 +224,17 @@ func testNodeBasic(t *testing.T, n, f int, reliable bool, timeout time.Duration)
 			return true
 		})
 	}
+	//
+	// Check, if the consensus status is reported by all the nodes.
+	for _, node := range te.nodes {
+		status := node.GetConsensusStatus(ctxTimeout)
+		require.NotNil(t, status)
+		require.NotEmpty(t, status.Committees)
+		require.True(t, status.Committees[0].Active)
+		require.Equal(t, te.cmtAddress, status.Committees[0].Address)
+		require.NotNil(t, status.Committees[0].CmtLog)
+		require.Len(t, status.Committees[0].Peers, n)
+	}
 }
 
 func awaitRequestsProcessed(ctx context.Context, te *testEnv, requests []isc.Request, desc string) {
@@ -470,6 +482,8 @@ func newEnv(t *testing.T, n, f int, reliable bool) *testEnv {
 			sm_gpa.NewStateManagerParameters(),
 			24*time.Hour,
 			1*time.Second,
+			mempool.NewOrderingParameters(),
+			mempool.NewEmptyOffLedgerJournal(),
 		)
 		require.NoError(t, err)
 		te.nodes[i].ServersUpdated(te.peerPubKeys)
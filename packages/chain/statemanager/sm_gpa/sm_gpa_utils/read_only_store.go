@@ -90,3 +90,11 @@ func (ros *readOnlyStore) TakeSnapshot(trieRoot trie.Hash, w io.Writer) error {
 func (ros *readOnlyStore) RestoreSnapshot(trie.Hash, io.Reader) error {
 	return fmt.Errorf("cannot write snapshot into read-only store")
 }
+
+func (ros *readOnlyStore) TakeDeltaSnapshot(baseRoot, trieRoot trie.Hash, w io.Writer) error {
+	return ros.store.TakeDeltaSnapshot(baseRoot, trieRoot, w)
+}
+
+func (ros *readOnlyStore) RestoreDeltaSnapshots(trie.Hash, ...io.Reader) (trie.Hash, error) {
+	return trie.Hash{}, fmt.Errorf("cannot write snapshot into read-only store")
+}
@@ -0,0 +1,16 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package sm_pruning
+
+// PruningService periodically deletes the states of the chain, which are no
+// longer needed according to the configured pruning policy. Pruning is done
+// in the background, at a limited rate, starting from the oldest state, so
+// that the states, which are kept, always form a continuous chain up to the
+// latest state. The exception are the checkpoints of `PolicyKeepCheckpoints`,
+// which are kept in between the pruned states.
+type PruningService interface {
+	// Prune executes a single pruning run synchronously and returns the number
+	// of states pruned.
+	Prune() (int, error)
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package sm_pruning
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
+)
+
+type Policy string
+
+const (
+	// Pruning service is disabled
+	PolicyNone Policy = "none"
+	// States older than the last `KeepLastBlocks` states are pruned
+	PolicyKeepLastBlocks Policy = "keepLastBlocks"
+	// States with timestamp older than `KeepTimeWindow` are pruned
+	PolicyKeepTimeWindow Policy = "keepTimeWindow"
+	// States older than the last `KeepLastBlocks` states are pruned, except
+	// the ones with index divisible by `CheckpointPeriod`
+	PolicyKeepCheckpoints Policy = "keepCheckpoints"
+)
+
+func PolicyFromString(policy string) (Policy, error) {
+	switch Policy(policy) {
+	case "", PolicyNone:
+		return PolicyNone, nil
+	case PolicyKeepLastBlocks, PolicyKeepTimeWindow, PolicyKeepCheckpoints:
+		return Policy(policy), nil
+	default:
+		return PolicyNone, fmt.Errorf("unknown pruning policy %q", policy)
+	}
+}
+
+type Parameters struct {
+	// Which states are pruned
+	Policy Policy
+	// This number of the latest states is kept by `PolicyKeepLastBlocks` and `PolicyKeepCheckpoints`
+	KeepLastBlocks uint32
+	// States not older than this are kept by `PolicyKeepTimeWindow`
+	KeepTimeWindow time.Duration
+	// States with index divisible by this number are kept by `PolicyKeepCheckpoints`
+	CheckpointPeriod uint32
+	// This number of states is always kept regardless of the policy; the
+	// block keep amount of the chain is respected as well if it is larger
+	MinStatesToKeep uint32
+	// How often should the pruning run
+	Period time.Duration
+	// At most this number of states is pruned per second
+	MaxStatesPerSecond int
+	// If at least this number of states is pruned in a single run, the database
+	// is flushed; 0 disables the flushing
+	FlushThreshold int
+
+	TimeProvider sm_gpa_utils.TimeProvider
+}
+
+func NewParameters(tpOpt ...sm_gpa_utils.TimeProvider) Parameters {
+	var tp sm_gpa_utils.TimeProvider
+	if len(tpOpt) > 0 {
+		tp = tpOpt[0]
+	} else {
+		tp = sm_gpa_utils.NewDefaultTimeProvider()
+	}
+	return Parameters{
+		Policy:             PolicyNone,
+		KeepLastBlocks:     10000,
+		KeepTimeWindow:     30 * 24 * time.Hour,
+		CheckpointPeriod:   10000,
+		MinStatesToKeep:    1,
+		Period:             1 * time.Minute,
+		MaxStatesPerSecond: 100,
+		FlushThreshold:     10000,
+		TimeProvider:       tp,
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package sm_pruning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/wasp/packages/metrics"
+	"github.com/iotaledger/wasp/packages/shutdown"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/trie"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+)
+
+type pruningService struct {
+	log                 *logger.Logger
+	ctx                 context.Context
+	shutdownCoordinator *shutdown.Coordinator
+	store               state.Store
+	parameters          Parameters
+	flushFun            func() error
+	limiter             *rate.Limiter
+	metrics             *metrics.ChainPruningMetrics
+	mutex               sync.Mutex
+}
+
+type pruningCandidate struct {
+	trieRoot   trie.Hash
+	stateIndex uint32
+}
+
+var _ PruningService = &pruningService{}
+
+// New creates the pruning service and starts its background loop, which
+// prunes the store every `Period` until the context is closed. `flushFun`
+// is called after a run, which pruned at least `FlushThreshold` states, so that
+// the database compacts the deleted states sooner; it may be nil.
+func New(
+	ctx context.Context,
+	shutdownCoordinator *shutdown.Coordinator,
+	store state.Store,
+	parameters Parameters,
+	flushFun func() error,
+	metrics *metrics.ChainPruningMetrics,
+	log *logger.Logger,
+) PruningService {
+	limit := rate.Inf
+	if parameters.MaxStatesPerSecond > 0 {
+		limit = rate.Limit(parameters.MaxStatesPerSecond)
+	}
+	result := &pruningService{
+		log:                 log.Named("Pruning"),
+		ctx:                 ctx,
+		shutdownCoordinator: shutdownCoordinator,
+		store:               store,
+		parameters:          parameters,
+		flushFun:            flushFun,
+		limiter:             rate.NewLimiter(limit, 1),
+		metrics:             metrics,
+		mutex:               sync.Mutex{},
+	}
+	go result.run()
+	return result
+}
+
+func (psT *pruningService) run() {
+	if psT.shutdownCoordinator != nil {
+		defer psT.shutdownCoordinator.Done()
+	}
+	psT.log.Debugf("Pruning service started, policy %s, period %v", psT.parameters.Policy, psT.parameters.Period)
+	for {
+		select {
+		case <-psT.ctx.Done():
+			psT.log.Debugf("Stopping pruning service, because context was closed")
+			return
+		case <-psT.parameters.TimeProvider.After(psT.parameters.Period):
+			if _, err := psT.Prune(); err != nil {
+				psT.log.Errorf("Pruning failed: %v", err)
+			}
+		}
+	}
+}
+
+// -------------------------------------
+// Implementations of PruningService interface
+// -------------------------------------
+
+func (psT *pruningService) Prune() (int, error) {
+	psT.mutex.Lock()
+	defer psT.mutex.Unlock()
+
+	if psT.parameters.Policy == PolicyNone {
+		return 0, nil
+	}
+	start := time.Now()
+	candidates, err := psT.getStatesToPrune()
+	if err != nil {
+		return 0, err
+	}
+	psT.metrics.SetStatesToPrune(len(candidates))
+	if len(candidates) == 0 {
+		return 0, nil
+	}
+
+	pruned := 0
+	for ; pruned < len(candidates); pruned++ {
+		if err = psT.limiter.Wait(psT.ctx); err != nil {
+			break // Context closed; the rest of the states will be pruned after restart
+		}
+		candidate := candidates[pruned]
+		singleStart := time.Now()
+		stats, err := psT.store.Prune(candidate.trieRoot)
+		if err != nil {
+			// Returning in order not to leave gaps of pruned trie roots in between not pruned ones
+			psT.completeRun(start, pruned)
+			return pruned, fmt.Errorf("failed to prune trie root %s of state %v: %w", candidate.trieRoot, candidate.stateIndex, err)
+		}
+		psT.metrics.StatePruned(time.Since(singleStart), candidate.stateIndex)
+		psT.metrics.SetStatesToPrune(len(candidates) - pruned - 1)
+		psT.log.Debugf("State index %v %s pruned: %v nodes and %v values deleted",
+			candidate.stateIndex, candidate.trieRoot, stats.DeletedNodes, stats.DeletedValues)
+	}
+	psT.completeRun(start, pruned)
+	return pruned, nil
+}
+
+// -------------------------------------
+// Internal functions
+// -------------------------------------
+
+func (psT *pruningService) completeRun(start time.Time, pruned int) {
+	psT.metrics.PruningRunCompleted(time.Since(start), pruned)
+	psT.log.Debugf("Pruning run completed, %v states pruned in %v", pruned, time.Since(start))
+	if psT.flushFun == nil || psT.parameters.FlushThreshold <= 0 || pruned < psT.parameters.FlushThreshold {
+		return
+	}
+	flushStart := time.Now()
+	if err := psT.flushFun(); err != nil {
+		psT.log.Warnf("Failed to flush the database after pruning %v states: %v", pruned, err)
+		return
+	}
+	psT.metrics.FlushCompleted(time.Since(flushStart))
+	psT.log.Infof("Database flushed after pruning %v states", pruned)
+}
+
+// getStatesToPrune walks the chain of blocks back from the latest one until
+// the first already pruned state (or the origin) is reached and returns the
+// states, which must be pruned according to the policy. The states are
+// ordered from the oldest to the newest.
+func (psT *pruningService) getStatesToPrune() ([]pruningCandidate, error) {
+	latestBlock, err := psT.store.LatestBlock()
+	if errors.Is(err, state.ErrUnknownLatestTrieRoot) {
+		return nil, nil // Nothing is committed yet
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot get latest block: %w", err)
+	}
+	latestState, err := psT.store.StateByTrieRoot(latestBlock.TrieRoot())
+	if err != nil {
+		return nil, fmt.Errorf("cannot get latest state: %w", err)
+	}
+	latestIndex := latestBlock.StateIndex()
+	statesToKeep := psT.getStatesToKeep(latestState)
+	if latestIndex < statesToKeep {
+		return nil, nil // Number of states in chain is not larger than `statesToKeep`
+	}
+	firstIndexToKeep := latestIndex - statesToKeep + 1
+
+	candidates := make([]pruningCandidate, 0)
+	commitment := latestBlock.PreviousL1Commitment()
+	for commitment != nil && psT.store.HasTrieRoot(commitment.TrieRoot()) {
+		block, err := psT.store.BlockByTrieRoot(commitment.TrieRoot())
+		if err != nil {
+			return nil, fmt.Errorf("cannot get block %s: %w", commitment, err)
+		}
+		if block.StateIndex() < firstIndexToKeep {
+			candidates = append(candidates, pruningCandidate{
+				trieRoot:   commitment.TrieRoot(),
+				stateIndex: block.StateIndex(),
+			})
+		}
+		commitment = block.PreviousL1Commitment()
+	}
+
+	result := make([]pruningCandidate, 0, len(candidates))
+	for i := len(candidates) - 1; i >= 0; i-- {
+		candidate := candidates[i]
+		switch psT.parameters.Policy {
+		case PolicyKeepCheckpoints:
+			if psT.parameters.CheckpointPeriod > 0 && candidate.stateIndex%psT.parameters.CheckpointPeriod == 0 {
+				continue
+			}
+		case PolicyKeepTimeWindow:
+			candidateState, err := psT.store.StateByTrieRoot(candidate.trieRoot)
+			if err != nil {
+				return nil, fmt.Errorf("cannot get state %v: %w", candidate.stateIndex, err)
+			}
+			if !candidateState.Timestamp().Before(psT.parameters.TimeProvider.GetNow().Add(-psT.parameters.KeepTimeWindow)) {
+				return result, nil // This and all the newer states are in the time window
+			}
+		}
+		result = append(result, candidate)
+	}
+	return result, nil
+}
+
+// getStatesToKeep returns the number of the latest states, which must not be
+// pruned. It is never less than the block keep amount of the chain and
+// `MinStatesToKeep`; the latest state is always kept.
+func (psT *pruningService) getStatesToKeep(latestState state.State) uint32 {
+	result := psT.parameters.MinStatesToKeep
+	if blockKeepAmount := governance.NewStateAccess(latestState).GetBlockKeepAmount(); blockKeepAmount > 0 && uint32(blockKeepAmount) > result {
+		result = uint32(blockKeepAmount)
+	}
+	switch psT.parameters.Policy {
+	case PolicyKeepLastBlocks, PolicyKeepCheckpoints:
+		if psT.parameters.KeepLastBlocks > result {
+			result = psT.parameters.KeepLastBlocks
+		}
+	}
+	if result < 1 {
+		result = 1
+	}
+	return result
+}
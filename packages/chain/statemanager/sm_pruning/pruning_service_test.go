@@ -0,0 +1,159 @@
+package sm_pruning
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/metrics"
+	"github.com/iotaledger/wasp/packages/origin"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+)
+
+const testBlockCount = 20
+
+var testStartTime = time.Unix(1_700_000_000, 0)
+
+// Creates the store with `testBlockCount` blocks after the origin block;
+// block `i` has timestamp `testStartTime + i minutes`.
+func newTestStore(t *testing.T) (state.Store, []state.Block) {
+	store := state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+	initParams := dict.Dict{origin.ParamBlockKeepAmount: codec.EncodeInt32(1)}
+	blocks := []state.Block{origin.InitChain(store, initParams, 0)}
+	for i := 1; i <= testBlockCount; i++ {
+		stateDraft, err := store.NewStateDraft(testStartTime.Add(time.Duration(i)*time.Minute), blocks[i-1].L1Commitment())
+		require.NoError(t, err)
+		stateDraft.Set("counter", codec.EncodeUint32(uint32(i)))
+		blocks = append(blocks, store.Commit(stateDraft))
+	}
+	require.NoError(t, store.SetLatest(blocks[testBlockCount].TrieRoot()))
+	return store, blocks
+}
+
+func newTestPruningService(t *testing.T, store state.Store, parameters Parameters, flushFun func() error) PruningService {
+	log := testlogger.NewLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	parameters.Period = 1000 * time.Hour // Pruning is invoked explicitly in tests
+	parameters.MaxStatesPerSecond = 0
+	pruningMetrics := metrics.NewChainMetricsProvider().GetChainMetrics(isc.EmptyChainID()).Pruning
+	return New(ctx, nil, store, parameters, flushFun, pruningMetrics, log)
+}
+
+func requirePruned(t *testing.T, store state.Store, blocks []state.Block, isPrunedFun func(uint32) bool) {
+	for _, block := range blocks {
+		require.Equal(t, !isPrunedFun(block.StateIndex()), store.HasTrieRoot(block.TrieRoot()), "state %v", block.StateIndex())
+	}
+}
+
+func TestPruningPolicyNone(t *testing.T) {
+	store, blocks := newTestStore(t)
+	pruningService := newTestPruningService(t, store, NewParameters(), nil)
+	pruned, err := pruningService.Prune()
+	require.NoError(t, err)
+	require.Equal(t, 0, pruned)
+	requirePruned(t, store, blocks, func(uint32) bool { return false })
+}
+
+func TestPruningKeepLastBlocks(t *testing.T) {
+	store, blocks := newTestStore(t)
+	parameters := NewParameters()
+	parameters.Policy = PolicyKeepLastBlocks
+	parameters.KeepLastBlocks = 5
+	parameters.FlushThreshold = 10
+	flushes := 0
+	pruningService := newTestPruningService(t, store, parameters, func() error { flushes++; return nil })
+
+	pruned, err := pruningService.Prune()
+	require.NoError(t, err)
+	require.Equal(t, testBlockCount-4, pruned)
+	require.Equal(t, 1, flushes)
+	requirePruned(t, store, blocks, func(i uint32) bool { return i < testBlockCount-4 })
+
+	// Nothing more to prune; flushing is not repeated
+	pruned, err = pruningService.Prune()
+	require.NoError(t, err)
+	require.Equal(t, 0, pruned)
+	require.Equal(t, 1, flushes)
+}
+
+func TestPruningMinStatesToKeep(t *testing.T) {
+	store, blocks := newTestStore(t)
+	parameters := NewParameters()
+	parameters.Policy = PolicyKeepLastBlocks
+	parameters.KeepLastBlocks = 5
+	parameters.MinStatesToKeep = 8
+	pruningService := newTestPruningService(t, store, parameters, nil)
+
+	pruned, err := pruningService.Prune()
+	require.NoError(t, err)
+	require.Equal(t, testBlockCount-7, pruned)
+	requirePruned(t, store, blocks, func(i uint32) bool { return i < testBlockCount-7 })
+}
+
+func TestPruningKeepTimeWindow(t *testing.T) {
+	store, blocks := newTestStore(t)
+	parameters := NewParameters(sm_gpa_utils.NewArtifficialTimeProvider(testStartTime.Add(15 * time.Minute)))
+	parameters.Policy = PolicyKeepTimeWindow
+	parameters.KeepTimeWindow = 10 * time.Minute
+	pruningService := newTestPruningService(t, store, parameters, nil)
+
+	pruned, err := pruningService.Prune()
+	require.NoError(t, err)
+	require.Equal(t, 5, pruned)
+	requirePruned(t, store, blocks, func(i uint32) bool { return i < 5 })
+
+	// Latest state is kept even if it is out of the time window
+	parameters.TimeProvider.SetNow(testStartTime.Add(time.Duration(testBlockCount+100) * time.Minute))
+	pruned, err = pruningService.Prune()
+	require.NoError(t, err)
+	require.Equal(t, testBlockCount-5, pruned)
+	requirePruned(t, store, blocks, func(i uint32) bool { return i < testBlockCount })
+}
+
+func TestPruningKeepCheckpoints(t *testing.T) {
+	store, blocks := newTestStore(t)
+	parameters := NewParameters()
+	parameters.Policy = PolicyKeepCheckpoints
+	parameters.KeepLastBlocks = 3
+	parameters.CheckpointPeriod = 4
+	pruningService := newTestPruningService(t, store, parameters, nil)
+
+	isPrunedFun := func(i uint32) bool { return i < testBlockCount-2 && i%4 != 0 }
+	pruned, err := pruningService.Prune()
+	require.NoError(t, err)
+	require.Equal(t, 13, pruned)
+	requirePruned(t, store, blocks, isPrunedFun)
+
+	// New blocks are pruned on the next run; older checkpoints are not touched
+	for i := testBlockCount + 1; i <= testBlockCount+4; i++ {
+		stateDraft, err := store.NewStateDraft(testStartTime.Add(time.Duration(i)*time.Minute), blocks[i-1].L1Commitment())
+		require.NoError(t, err)
+		blocks = append(blocks, store.Commit(stateDraft))
+	}
+	require.NoError(t, store.SetLatest(blocks[len(blocks)-1].TrieRoot()))
+	isPrunedFun = func(i uint32) bool { return i < testBlockCount+2 && i%4 != 0 }
+	pruned, err = pruningService.Prune()
+	require.NoError(t, err)
+	require.Equal(t, 3, pruned)
+	requirePruned(t, store, blocks, isPrunedFun)
+}
+
+func TestPolicyFromString(t *testing.T) {
+	policy, err := PolicyFromString("")
+	require.NoError(t, err)
+	require.Equal(t, PolicyNone, policy)
+	policy, err = PolicyFromString("keepCheckpoints")
+	require.NoError(t, err)
+	require.Equal(t, PolicyKeepCheckpoints, policy)
+	_, err = PolicyFromString("keepEverything")
+	require.Error(t, err)
+}
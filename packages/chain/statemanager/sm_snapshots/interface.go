@@ -31,6 +31,7 @@ type SnapshotInfo interface {
 
 type snapshotManagerCore interface {
 	createSnapshot(SnapshotInfo)
+	createDeltaSnapshot(snapshotInfo SnapshotInfo, baseStateIndex uint32)
 	loadSnapshot() SnapshotInfo
 }
 
@@ -38,9 +39,15 @@ type snapshotManagerCore interface {
 // sources/destinations. It can:
 // * take required snapshot from the store and write it to some `Writer` (`storeSnapshot` method)
 // * read the snapshot from some `Reader` and put it to the store (`loadSnapshot` method).
+// * take the difference between two snapshots from the store and write it to
+// some `Writer` (`storeDeltaSnapshot` method)
+// * read the chain of differences from `Reader`s and put them to the store on
+// top of the base snapshot (`loadDeltaSnapshots` method).
 type snapshotter interface {
 	storeSnapshot(SnapshotInfo, io.Writer) error
 	loadSnapshot(SnapshotInfo, io.Reader) error
+	storeDeltaSnapshot(baseSnapshotInfo, snapshotInfo SnapshotInfo, w io.Writer) error
+	loadDeltaSnapshots(baseSnapshotInfo SnapshotInfo, snapshotInfos []SnapshotInfo, rs []io.Reader) error
 }
 
 type Downloader interface {
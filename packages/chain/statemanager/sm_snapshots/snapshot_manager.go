@@ -1,7 +1,6 @@
 package sm_snapshots
 
 import (
-	"bufio"
 	"context"
 	"errors"
 	"fmt"
@@ -9,12 +8,15 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/runtime/ioutils"
+	"github.com/iotaledger/wasp/packages/cryptolib"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/metrics"
+	"github.com/iotaledger/wasp/packages/registry"
 	"github.com/iotaledger/wasp/packages/shutdown"
 	"github.com/iotaledger/wasp/packages/state"
 )
@@ -27,10 +29,16 @@ type snapshotManagerImpl struct {
 	chainID isc.ChainID
 	metrics *metrics.ChainSnapshotsMetrics
 
-	snapshotter      snapshotter
-	localPath        string
-	baseNetworkPaths []string
-	snapshotToLoad   *state.BlockHash
+	store                  state.Store
+	snapshotter            snapshotter
+	localPath              string
+	localStore             *fileSnapshotStore
+	networkStores          []SnapshotStore
+	uploadStores           []SnapshotStore
+	snapshotToLoad         *state.BlockHash
+	requireSignedManifests bool
+	nodeIdentity           *cryptolib.KeyPair
+	trustedPeers           registry.TrustedPeersRegistryProvider
 }
 
 var (
@@ -42,12 +50,11 @@ const (
 	constDownloadTimeout                     = 10 * time.Minute
 	constSnapshotIndexHashFileNameSepparator = "-"
 	constSnapshotFileSuffix                  = ".snap"
+	constDeltaSnapshotFileSuffix             = ".delta"
 	constSnapshotTmpFileSuffix               = ".tmp"
 	constSnapshotDownloaded                  = "net"
 	constIndexFileName                       = "INDEX" // Index file contains a new-line separated list of snapshot files
 	constLocalAddress                        = "file://"
-	constSchemeHTTP                          = "http(s)"
-	constSchemeFile                          = "file"
 )
 
 func NewSnapshotManager(
@@ -57,30 +64,41 @@ func NewSnapshotManager(
 	snapshotToLoad *state.BlockHash,
 	createPeriod uint32,
 	delayPeriod uint32,
+	deltaPeriod uint32,
 	baseLocalPath string,
 	baseNetworkPaths []string,
+	baseUploadPaths []string,
+	requireSignedManifests bool,
 	store state.Store,
+	nodeIdentity *cryptolib.KeyPair,
+	trustedPeers registry.TrustedPeersRegistryProvider,
 	metrics *metrics.ChainSnapshotsMetrics,
 	log *logger.Logger,
 ) (SnapshotManager, error) {
 	localPath := filepath.Join(baseLocalPath, chainID.String())
 	snapMLog := log.Named("Snap")
 	result := &snapshotManagerImpl{
-		log:              snapMLog,
-		ctx:              ctx,
-		chainID:          chainID,
-		metrics:          metrics,
-		snapshotter:      newSnapshotter(store),
-		localPath:        localPath,
-		baseNetworkPaths: baseNetworkPaths,
-		snapshotToLoad:   snapshotToLoad,
+		log:                    snapMLog,
+		ctx:                    ctx,
+		chainID:                chainID,
+		metrics:                metrics,
+		store:                  store,
+		snapshotter:            newSnapshotter(store),
+		localPath:              localPath,
+		localStore:             newFileSnapshotStoreWithPath(localPath),
+		snapshotToLoad:         snapshotToLoad,
+		requireSignedManifests: requireSignedManifests,
+		nodeIdentity:           nodeIdentity,
+		trustedPeers:           trustedPeers,
 	}
 	if err := ioutils.CreateDirectory(localPath, 0o777); err != nil {
 		return nil, fmt.Errorf("cannot create folder %s: %v", localPath, err)
 	}
+	result.networkStores = result.createStores(baseNetworkPaths)
+	result.uploadStores = result.createStores(baseUploadPaths)
 	result.cleanTempFiles() // To be able to make snapshots, which were not finished. See comment in `createSnapshot` function
 	snapMLog.Debugf("Snapshot manager created; folder %v is used for snapshots", localPath)
-	result.snapshotManagerRunner = newSnapshotManagerRunner(ctx, store, shutdownCoordinator, createPeriod, delayPeriod, result, snapMLog)
+	result.snapshotManagerRunner = newSnapshotManagerRunner(ctx, store, shutdownCoordinator, createPeriod, delayPeriod, deltaPeriod, result, snapMLog)
 	return result, nil
 }
 
@@ -103,100 +121,91 @@ func NewSnapshotManager(
 // to delete all temporary files on snapshot manager start.
 func (smiT *snapshotManagerImpl) createSnapshot(snapshotInfo SnapshotInfo) {
 	start := time.Now()
-	stateIndex := snapshotInfo.StateIndex()
-	commitment := snapshotInfo.Commitment()
-	smiT.log.Debugf("Creating snapshot %v %s...", stateIndex, commitment)
-	tmpFileName := tempSnapshotFileName(stateIndex, commitment.BlockHash())
-	tmpFilePath := filepath.Join(smiT.localPath, tmpFileName)
-	exists, _, _ := ioutils.PathExists(tmpFilePath)
-	if exists {
-		smiT.log.Debugf("Creating snapshot %v %s: skipped making snapshot as it is already being produced", stateIndex, commitment)
-		return
+	fileName := snapshotFileName(snapshotInfo.StateIndex(), snapshotInfo.BlockHash())
+	writeFun := func(w io.Writer) error {
+		return smiT.snapshotter.storeSnapshot(snapshotInfo, w)
 	}
-	f, err := os.OpenFile(tmpFilePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o666)
-	if err != nil {
-		smiT.log.Errorf("Creating snapshot %v %s: failed to create temporary snapshot file %s: %v", stateIndex, commitment, tmpFilePath, err)
-		f.Close()
-		return
+	createdFun := func() {
+		smiT.snapshotManagerRunner.snapshotCreated(snapshotInfo)
+		smiT.metrics.SnapshotCreated(time.Since(start), snapshotInfo.StateIndex())
 	}
-	go func() {
-		defer f.Close()
-
-		smiT.log.Debugf("Creating snapshot %v %s: storing it to file", stateIndex, commitment)
-		err := smiT.snapshotter.storeSnapshot(snapshotInfo, f)
-		if err != nil {
-			smiT.log.Errorf("Creating snapshot %v %s: failed to write snapshot to temporary file %s: %v", stateIndex, commitment, tmpFilePath, err)
-			return
-		}
+	smiT.createSnapshotFile("snapshot", snapshotInfo, fileName, writeFun, createdFun)
+}
 
-		finalFileName := snapshotFileName(stateIndex, commitment.BlockHash())
-		finalFilePath := filepath.Join(smiT.localPath, finalFileName)
-		err = os.Rename(tmpFilePath, finalFilePath)
+// Delta snapshot contains only the difference between the base snapshot and
+// this one. Delta snapshot file is created in the same way as the full one,
+// see the comment of `createSnapshot`.
+func (smiT *snapshotManagerImpl) createDeltaSnapshot(snapshotInfo SnapshotInfo, baseStateIndex uint32) {
+	fileName := deltaSnapshotFileName(snapshotInfo.StateIndex(), snapshotInfo.BlockHash())
+	writeFun := func(w io.Writer) error {
+		baseSnapshotInfo, err := smiT.ancestorSnapshotInfo(snapshotInfo, baseStateIndex)
 		if err != nil {
-			smiT.log.Errorf("Creating snapshot %v %s: failed to move temporary snapshot file %s to permanent location %s: %v",
-				stateIndex, commitment, tmpFilePath, finalFilePath, err)
-			return
+			return err
 		}
-		smiT.snapshotManagerRunner.snapshotCreated(snapshotInfo)
-		smiT.log.Infof("Creating snapshot %v %s: snapshot created in %s", stateIndex, commitment, finalFilePath)
-		smiT.metrics.SnapshotCreated(time.Since(start), stateIndex)
-	}()
+		return smiT.snapshotter.storeDeltaSnapshot(baseSnapshotInfo, snapshotInfo, w)
+	}
+	smiT.createSnapshotFile("delta snapshot", snapshotInfo, fileName, writeFun, func() {})
 }
 
 func (smiT *snapshotManagerImpl) loadSnapshot() SnapshotInfo {
-	snapshotPaths := make([]string, 0)
+	snapshotLocations := make([]*snapshotLocation, 0)
 	snapshotInfos := make([]SnapshotInfo, 0)
 
-	var considerSnapshotFun func(snapshotInfo SnapshotInfo, path string)
+	var considerSnapshotFun func(snapshotInfo SnapshotInfo, location *snapshotLocation)
 	var searchCondition string
 	if smiT.snapshotToLoad == nil {
 		largestIndex := uint32(0)
-		considerSnapshotFun = func(snapshotInfo SnapshotInfo, path string) {
+		considerSnapshotFun = func(snapshotInfo SnapshotInfo, location *snapshotLocation) {
 			if snapshotInfo.StateIndex() < largestIndex {
 				smiT.log.Debugf("Snapshot %s found in %s; it is ignored, because its index is lower than current largest index %v",
-					path, snapshotInfo, largestIndex)
+					location, snapshotInfo, largestIndex)
 				return
 			}
 			if snapshotInfo.StateIndex() == largestIndex {
-				snapshotPaths = append(snapshotPaths, path)
+				snapshotLocations = append(snapshotLocations, location)
 				snapshotInfos = append(snapshotInfos, snapshotInfo)
 				smiT.log.Debugf("Snapshot %s found in %s; it is added to the list of considered snapshots, because its index mathec current largest index",
-					path, snapshotInfo)
+					location, snapshotInfo)
 				return
 			}
 			// NOTE: snapshotInfo.StateIndex() > largestIndex
-			snapshotPaths = []string{path}
+			snapshotLocations = []*snapshotLocation{location}
 			snapshotInfos = []SnapshotInfo{snapshotInfo}
 			smiT.log.Debugf("Snapshot %s found in %s; it is now the only considered snapshot, because its index is larger than former largest index %v",
-				path, snapshotInfo, largestIndex)
+				location, snapshotInfo, largestIndex)
 			largestIndex = snapshotInfo.StateIndex()
 		}
 		searchCondition = fmt.Sprintf("state index %v", largestIndex)
 	} else {
-		considerSnapshotFun = func(snapshotInfo SnapshotInfo, path string) {
+		considerSnapshotFun = func(snapshotInfo SnapshotInfo, location *snapshotLocation) {
 			if snapshotInfo.BlockHash().Equals(*smiT.snapshotToLoad) {
-				snapshotPaths = append(snapshotPaths, path)
+				snapshotLocations = append(snapshotLocations, location)
 				snapshotInfos = append(snapshotInfos, snapshotInfo)
 				smiT.log.Debugf("Snapshot %s found in %s; it is added to the list of considered snapshots, because its hash matches what was requested",
-					path, snapshotInfo)
+					location, snapshotInfo)
 				return
 			}
-			smiT.log.Debugf("Snapshot %s found in %s; it is ignored, because its hash does not match what was requested", path, snapshotInfo)
+			smiT.log.Debugf("Snapshot %s found in %s; it is ignored, because its hash does not match what was requested", location, snapshotInfo)
 		}
 		searchCondition = fmt.Sprintf("block hash %s", *smiT.snapshotToLoad)
 	}
 
-	smiT.searchLocalSnapshots(considerSnapshotFun)
-	smiT.searchNetworkSnapshots(smiT.baseNetworkPaths, considerSnapshotFun)
-	smiT.log.Debugf("%v snapshots with %s will be considered for loading in this order: %v", len(snapshotPaths), searchCondition, snapshotPaths)
+	smiT.searchSnapshots(smiT.localStore, considerSnapshotFun)
+	for _, networkStore := range smiT.networkStores {
+		smiT.searchSnapshots(networkStore, considerSnapshotFun)
+	}
+	smiT.log.Debugf("%v snapshots with %s will be considered for loading in this order: %v", len(snapshotLocations), searchCondition, snapshotLocations)
 
-	for i := range snapshotPaths {
-		err := smiT.loadSnapshotFromPath(snapshotInfos[i], snapshotPaths[i])
+	for i := range snapshotLocations {
+		err := smiT.loadSnapshotFromLocation(snapshotInfos[i], snapshotLocations[i])
 		if err == nil {
-			smiT.log.Infof("Snapshot %s successfully loaded from %s", snapshotInfos[i], snapshotPaths[i])
+			smiT.log.Infof("Snapshot %s successfully loaded from %s", snapshotInfos[i], snapshotLocations[i])
+			if smiT.snapshotToLoad == nil {
+				return smiT.loadDeltaSnapshots(snapshotInfos[i])
+			}
 			return snapshotInfos[i]
 		}
-		smiT.log.Errorf("Failed to load snapshot %s from %s: %v", snapshotInfos[i], snapshotPaths[i], err)
+		smiT.log.Errorf("Failed to load snapshot %s from %s: %v", snapshotInfos[i], snapshotLocations[i], err)
 	}
 	smiT.log.Warnf("Failed to load any snapshot; will continue with empty store")
 	return nil
@@ -206,209 +215,335 @@ func (smiT *snapshotManagerImpl) loadSnapshot() SnapshotInfo {
 // Internal functions
 // -------------------------------------
 
-// This happens strictly before snapshot manager starts to produce new snapshots.
-// So there is no way that this function will delete temp file, which is needed.
-func (smiT *snapshotManagerImpl) cleanTempFiles() {
-	tempFileRegExp := tempSnapshotFileNameString("*", "*")
-	tempFileRegExpWithPath := filepath.Join(smiT.localPath, tempFileRegExp)
-	tempFiles, err := filepath.Glob(tempFileRegExpWithPath)
+type snapshotLocation struct {
+	store    SnapshotStore
+	fileName string
+}
+
+func (sl *snapshotLocation) String() string {
+	return sl.store.String() + "/" + sl.fileName
+}
+
+func (smiT *snapshotManagerImpl) createSnapshotFile(
+	fileType string,
+	snapshotInfo SnapshotInfo,
+	fileName string,
+	writeFun func(io.Writer) error,
+	createdFun func(),
+) {
+	stateIndex := snapshotInfo.StateIndex()
+	commitment := snapshotInfo.Commitment()
+	logPrefix := fmt.Sprintf("Creating %s %v %s", fileType, stateIndex, commitment)
+	smiT.log.Debugf("%s...", logPrefix)
+	tmpFilePath := filepath.Join(smiT.localPath, tempFileName(fileName))
+	exists, _, _ := ioutils.PathExists(tmpFilePath)
+	if exists {
+		smiT.log.Debugf("%s: skipped making %s as it is already being produced", logPrefix, fileType)
+		return
+	}
+	f, err := os.OpenFile(tmpFilePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o666)
 	if err != nil {
-		smiT.log.Errorf("Failed to obtain temporary snapshot file list: %v", err)
+		smiT.log.Errorf("%s: failed to create temporary %s file %s: %v", logPrefix, fileType, tmpFilePath, err)
+		f.Close()
 		return
 	}
+	go func() {
+		defer f.Close()
 
-	removed := 0
-	for _, tempFile := range tempFiles {
-		err = os.Remove(tempFile)
+		smiT.log.Debugf("%s: storing it to file", logPrefix)
+		err := writeFun(f)
 		if err != nil {
-			smiT.log.Warnf("Failed to remove temporary snapshot file %s: %v", tempFile, err)
-		} else {
-			removed++
+			smiT.log.Errorf("%s: failed to write %s to temporary file %s: %v", logPrefix, fileType, tmpFilePath, err)
+			return
+		}
+
+		finalFilePath := filepath.Join(smiT.localPath, fileName)
+		manifest, err := newSnapshotManifestFromFile(snapshotInfo, tmpFilePath)
+		if err != nil {
+			smiT.log.Errorf("%s: failed to create manifest: %v", logPrefix, err)
+			return
+		}
+		if smiT.nodeIdentity != nil {
+			manifest.Sign(smiT.nodeIdentity)
 		}
+		err = writeFileAtomically(filepath.Join(smiT.localPath, manifestFileName(fileName)), func(w io.Writer) error {
+			return writeManifest(manifest, w)
+		})
+		if err != nil {
+			smiT.log.Errorf("%s: failed to write manifest: %v", logPrefix, err)
+			return
+		}
+		err = os.Rename(tmpFilePath, finalFilePath)
+		if err != nil {
+			smiT.log.Errorf("%s: failed to move temporary %s file %s to permanent location %s: %v",
+				logPrefix, fileType, tmpFilePath, finalFilePath, err)
+			return
+		}
+		createdFun()
+		smiT.log.Infof("%s: %s created in %s", logPrefix, fileType, finalFilePath)
+
+		for _, uploadStore := range smiT.uploadStores {
+			err = uploadStore.Upload(finalFilePath, manifest)
+			if err != nil {
+				smiT.log.Errorf("%s: failed to upload %s to %s: %v", logPrefix, fileType, uploadStore, err)
+				continue
+			}
+			smiT.log.Infof("%s: %s uploaded to %s", logPrefix, fileType, uploadStore)
+		}
+	}()
+}
+
+// The ancestor is found by following the chain of blocks back from the snapshot,
+// thus it is guaranteed that the snapshot is a descendant of the returned one.
+func (smiT *snapshotManagerImpl) ancestorSnapshotInfo(snapshotInfo SnapshotInfo, stateIndex uint32) (SnapshotInfo, error) {
+	commitment := snapshotInfo.Commitment()
+	for index := snapshotInfo.StateIndex(); index > stateIndex; index-- {
+		block, err := smiT.store.BlockByTrieRoot(commitment.TrieRoot())
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain block %v %s: %w", index, commitment, err)
+		}
+		commitment = block.PreviousL1Commitment()
 	}
-	smiT.log.Debugf("Removed %v out of %v temporary snapshot files", removed, len(tempFiles))
+	return NewSnapshotInfo(stateIndex, commitment), nil
 }
 
-func (smiT *snapshotManagerImpl) searchLocalSnapshots(considerSnapshotFun func(SnapshotInfo, string)) {
-	fileRegExp := snapshotFileNameString("*", "*")
-	fileRegExpWithPath := filepath.Join(smiT.localPath, fileRegExp)
-	files, err := filepath.Glob(fileRegExpWithPath)
-	if err != nil {
-		smiT.log.Errorf("Search local snapshots: failed to obtain snapshot file list: %v", err)
-		return
+// Delta snapshots, which form a chain starting at the loaded snapshot, are
+// applied on top of it. The chain is extended as long as a delta snapshot,
+// based on the last snapshot of the chain, can be found and verified. If
+// applying the chain fails, the loaded snapshot is left as it is.
+func (smiT *snapshotManagerImpl) loadDeltaSnapshots(baseSnapshotInfo SnapshotInfo) SnapshotInfo {
+	deltaSnapshotLocations := make(map[string][]*snapshotLocation) // base snapshot -> delta snapshots based on it
+	deltaSnapshotInfos := make(map[*snapshotLocation]SnapshotInfo)
+	considerDeltaSnapshotFun := func(snapshotInfo, baseSnapshotInfo SnapshotInfo, location *snapshotLocation) {
+		key := baseSnapshotInfo.String()
+		deltaSnapshotLocations[key] = append(deltaSnapshotLocations[key], location)
+		deltaSnapshotInfos[location] = snapshotInfo
 	}
-	snapshotCount := 0
-	for _, file := range files {
-		func() { // Function to make the defers sooner
-			f, err := os.Open(file)
+	smiT.searchDeltaSnapshots(smiT.localStore, considerDeltaSnapshotFun)
+	for _, networkStore := range smiT.networkStores {
+		smiT.searchDeltaSnapshots(networkStore, considerDeltaSnapshotFun)
+	}
+
+	snapshotInfos := make([]SnapshotInfo, 0)
+	filePaths := make([]string, 0)
+	lastSnapshotInfo := baseSnapshotInfo
+	for found := true; found; {
+		found = false
+		for _, location := range deltaSnapshotLocations[lastSnapshotInfo.String()] {
+			snapshotInfo := deltaSnapshotInfos[location]
+			filePath, err := smiT.fetchSnapshot(snapshotInfo, location)
 			if err != nil {
-				smiT.log.Errorf("Search local snapshots: failed to open snapshot file %s: %v", file, err)
-				return
+				smiT.log.Errorf("Failed to fetch delta snapshot %s from %s: %v", snapshotInfo, location, err)
+				continue
 			}
-			defer f.Close()
-			snapshotInfo, err := readSnapshotInfo(f)
+			snapshotInfos = append(snapshotInfos, snapshotInfo)
+			filePaths = append(filePaths, filePath)
+			lastSnapshotInfo = snapshotInfo
+			found = true
+			break
+		}
+	}
+	if len(snapshotInfos) == 0 {
+		smiT.log.Debugf("No delta snapshots based on snapshot %s found", baseSnapshotInfo)
+		return baseSnapshotInfo
+	}
+
+	err := func() error { // Function to make the defers sooner
+		rs := make([]io.Reader, len(filePaths))
+		for i, filePath := range filePaths {
+			f, err := os.Open(filePath)
 			if err != nil {
-				smiT.log.Errorf("Search local snapshots: failed to read snapshot info from file %s: %v", file, err)
-				return
+				return fmt.Errorf("failed to open delta snapshot file %s", filePath)
 			}
-			considerSnapshotFun(snapshotInfo, constLocalAddress+file)
-			snapshotCount++
-		}()
+			defer f.Close()
+			rs[i] = f
+		}
+		return smiT.snapshotter.loadDeltaSnapshots(baseSnapshotInfo, snapshotInfos, rs)
+	}()
+	if err != nil {
+		smiT.log.Errorf("Failed to load %v delta snapshots on top of snapshot %s: %v", len(snapshotInfos), baseSnapshotInfo, err)
+		return baseSnapshotInfo
 	}
-	smiT.log.Debugf("Search local snapshots: %v snapshot files found", snapshotCount)
+	smiT.log.Infof("%v delta snapshots successfully loaded on top of snapshot %s; snapshot %s is loaded",
+		len(snapshotInfos), baseSnapshotInfo, lastSnapshotInfo)
+	return lastSnapshotInfo
 }
 
-func (smiT *snapshotManagerImpl) searchNetworkSnapshots(baseNetworkPaths []string, considerSnapshotFun func(SnapshotInfo, string)) {
+func (smiT *snapshotManagerImpl) createStores(basePaths []string) []SnapshotStore {
 	chainIDString := smiT.chainID.String()
-	for _, baseNetworkPath := range baseNetworkPaths {
-		func() { // Function to make the defers sooner
-			baseNetworkPathWithChainID, err := url.JoinPath(baseNetworkPath, chainIDString)
-			if err != nil {
-				smiT.log.Errorf("Search network snapshots: unable to join paths %s and %s: %v", baseNetworkPath, chainIDString, err)
-				return
-			}
-			scheme, basePath, err := smiT.splitURL(baseNetworkPathWithChainID)
-			if err != nil {
-				smiT.log.Errorf("Search network snapshots: unable to parse url %s: %v", baseNetworkPathWithChainID, err)
-				return
-			}
-			reader, err := smiT.getReadCloser(scheme, "index file", basePath, constIndexFileName)
-			if err != nil {
-				smiT.log.Errorf("Search network snapshots: failed to open index file: %v", err)
-				return
-			}
-			defer reader.Close()
-			snapshotCount := 0
-			scanner := bufio.NewScanner(reader) // Defaults to splitting input by newline character
-			for scanner.Scan() {
-				func() {
-					snapshotFileName := scanner.Text()
-					sReader, er := smiT.getReadCloser(scheme, "snapshot header", basePath, snapshotFileName)
-					if er != nil {
-						smiT.log.Errorf("Search network snapshots: failed to open snapshot file: %v", er)
-						return
-					}
-					defer sReader.Close()
-					snapshotInfo, er := readSnapshotInfo(sReader)
-					if er != nil {
-						smiT.log.Errorf("Search network snapshots: failed to read snapshot info from %s in %s: %v", snapshotFileName, basePath, er)
-						return
-					}
-					baseNetworkPathSnapshot, er := url.JoinPath(baseNetworkPathWithChainID, snapshotFileName)
-					if er != nil {
-						smiT.log.Errorf("Search network snapshots: unable to join paths %s and %s: %v", baseNetworkPathWithChainID, snapshotFileName, er)
-						return
-					}
-					considerSnapshotFun(snapshotInfo, baseNetworkPathSnapshot)
-					snapshotCount++
-				}()
-			}
-			err = scanner.Err()
-			if err != nil && !errors.Is(err, io.EOF) {
-				smiT.log.Errorf("Search network snapshots: failed read index file from %s: %v", basePath, err)
-			}
-			smiT.log.Debugf("Search network snapshots: %v snapshot files found on %s", snapshotCount, baseNetworkPath)
-		}()
+	result := make([]SnapshotStore, 0, len(basePaths))
+	for _, basePath := range basePaths {
+		basePathWithChainID, err := url.JoinPath(basePath, chainIDString)
+		if err != nil {
+			smiT.log.Errorf("Unable to join paths %s and %s: %v", basePath, chainIDString, err)
+			continue
+		}
+		snapshotStore, err := NewSnapshotStore(smiT.ctx, basePathWithChainID, smiT.localPath, smiT.log)
+		if err != nil {
+			smiT.log.Errorf("Unable to create snapshot store %s: %v", basePathWithChainID, err)
+			continue
+		}
+		result = append(result, snapshotStore)
 	}
+	return result
 }
 
-func (smiT *snapshotManagerImpl) loadSnapshotFromPath(snapshotInfo SnapshotInfo, url string) error {
-	loadSnapshotFun := func(r io.Reader) error {
-		err := smiT.snapshotter.loadSnapshot(snapshotInfo, r)
+// This happens strictly before snapshot manager starts to produce new snapshots.
+// So there is no way that this function will delete temp file, which is needed.
+func (smiT *snapshotManagerImpl) cleanTempFiles() {
+	tempFiles := make([]string, 0)
+	for _, fileName := range []string{snapshotFileNameString("*", "*"), deltaSnapshotFileNameString("*", "*")} {
+		tempFileRegExpWithPath := filepath.Join(smiT.localPath, tempFileName(fileName))
+		files, err := filepath.Glob(tempFileRegExpWithPath)
 		if err != nil {
-			return fmt.Errorf("loading snapshot failed: %v", err)
+			smiT.log.Errorf("Failed to obtain temporary snapshot file list: %v", err)
+			return
 		}
-		return nil
+		tempFiles = append(tempFiles, files...)
 	}
-	loadLocalFun := func(path string) error {
-		f, err := os.Open(path)
+
+	removed := 0
+	for _, tempFile := range tempFiles {
+		err := os.Remove(tempFile)
 		if err != nil {
-			return fmt.Errorf("failed to open snapshot file %s", path)
+			smiT.log.Warnf("Failed to remove temporary snapshot file %s: %v", tempFile, err)
+		} else {
+			removed++
 		}
-		defer f.Close()
-		return loadSnapshotFun(f)
 	}
-	loadNetworkFun := func(url string) error {
-		fileNameLocal := downloadedSnapshotFileName(snapshotInfo.StateIndex(), snapshotInfo.BlockHash())
-		filePathLocal := filepath.Join(smiT.localPath, fileNameLocal)
-		addProgressReporterFun := func(r io.Reader, f string, s uint64) io.Reader {
-			return smiT.addProgressReporter(r, fmt.Sprintf("snapshot %s", snapshotInfo), f, s)
+	smiT.log.Debugf("Removed %v out of %v temporary snapshot files", removed, len(tempFiles))
+}
+
+func (smiT *snapshotManagerImpl) searchSnapshots(snapshotStore SnapshotStore, considerSnapshotFun func(SnapshotInfo, *snapshotLocation)) {
+	smiT.searchSnapshotFiles(snapshotStore, "snapshot", isSnapshotFileName, func(r io.Reader, location *snapshotLocation) error {
+		snapshotInfo, err := readSnapshotInfo(r)
+		if err != nil {
+			return err
 		}
-		err := DownloadToFile(smiT.ctx, url, filePathLocal, constDownloadTimeout, addProgressReporterFun)
+		considerSnapshotFun(snapshotInfo, location)
+		return nil
+	})
+}
+
+func (smiT *snapshotManagerImpl) searchDeltaSnapshots(
+	snapshotStore SnapshotStore,
+	considerDeltaSnapshotFun func(snapshotInfo, baseSnapshotInfo SnapshotInfo, location *snapshotLocation),
+) {
+	smiT.searchSnapshotFiles(snapshotStore, "delta snapshot", isDeltaSnapshotFileName, func(r io.Reader, location *snapshotLocation) error {
+		snapshotInfo, baseSnapshotInfo, err := readDeltaSnapshotInfo(r)
 		if err != nil {
 			return err
 		}
-		smiT.log.Debugf("Loading snapshot %s from url %s: snapshot successfully downloaded to %s", snapshotInfo, url, filePathLocal)
-		return loadLocalFun(filePathLocal)
-	}
+		considerDeltaSnapshotFun(snapshotInfo, baseSnapshotInfo, location)
+		return nil
+	})
+}
 
-	scheme, path, err := smiT.splitURL(url)
+func (smiT *snapshotManagerImpl) searchSnapshotFiles(
+	snapshotStore SnapshotStore,
+	fileType string,
+	isFileTypeFun func(string) bool,
+	readInfoFun func(io.Reader, *snapshotLocation) error,
+) {
+	fileNames, err := snapshotStore.ListSnapshots()
 	if err != nil {
-		return fmt.Errorf("Loading snapshot %s failed: %v", snapshotInfo, err)
+		smiT.log.Errorf("Search %ss in %s: failed to obtain snapshot file list: %v", fileType, snapshotStore, err)
+		return
 	}
-	switch scheme {
-	case constSchemeHTTP:
-		smiT.log.Debugf("Loading snapshot %s from url %s...", snapshotInfo, path)
-		return loadNetworkFun(path)
-	case constSchemeFile:
-		smiT.log.Debugf("Loading snapshot %s from file %s...", snapshotInfo, path)
-		return loadLocalFun(path)
-	default:
-		return fmt.Errorf("Loading snapshot %s failed: unknown scheme %s in %s", snapshotInfo, scheme, url)
+	snapshotCount := 0
+	for _, fileName := range fileNames {
+		if !isFileTypeFun(fileName) {
+			continue
+		}
+		func() { // Function to make the defers sooner
+			r, err := snapshotStore.Open(fileName)
+			if err != nil {
+				smiT.log.Errorf("Search %ss in %s: failed to open %s file %s: %v", fileType, snapshotStore, fileType, fileName, err)
+				return
+			}
+			defer r.Close()
+			err = readInfoFun(r, &snapshotLocation{store: snapshotStore, fileName: fileName})
+			if err != nil {
+				smiT.log.Errorf("Search %ss in %s: failed to read %s info from file %s: %v", fileType, snapshotStore, fileType, fileName, err)
+				return
+			}
+			snapshotCount++
+		}()
 	}
+	smiT.log.Debugf("Search %ss in %s: %v %s files found", fileType, snapshotStore, snapshotCount, fileType)
 }
 
-func (smiT *snapshotManagerImpl) splitURL(uString string) (scheme string, path string, err error) {
-	uObj, err := url.Parse(uString)
+// Before the snapshot is loaded to the store, it is checked against its manifest
+// (if it has one): the snapshot info, size and content hash of the snapshot file
+// must match the ones in the manifest. If the manifest is signed, the signature
+// must be valid and the signer must be a trusted peer. If signed manifests are
+// required, the snapshots from the network stores without valid signed manifest
+// are not loaded. The snapshots in the local store are made (or put there) by
+// the operator of the node, so they are loaded even if their manifests are not signed.
+func (smiT *snapshotManagerImpl) loadSnapshotFromLocation(snapshotInfo SnapshotInfo, location *snapshotLocation) error {
+	smiT.log.Debugf("Loading snapshot %s from %s...", snapshotInfo, location)
+	filePath, err := smiT.fetchSnapshot(snapshotInfo, location)
 	if err != nil {
-		return "", "", err
+		return err
 	}
-	switch uObj.Scheme {
-	case "http", "https":
-		return constSchemeHTTP, uString, nil
-	case "file":
-		return constSchemeFile, filepath.Join(uObj.Host, uObj.Path), nil
-	default:
-		return "", "", fmt.Errorf("unknown scheme %s", uObj.Scheme)
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file %s", filePath)
 	}
+	defer f.Close()
+	err = smiT.snapshotter.loadSnapshot(snapshotInfo, f)
+	if err != nil {
+		return fmt.Errorf("loading snapshot failed: %v", err)
+	}
+	return nil
 }
 
-func (smiT *snapshotManagerImpl) getReadCloser(scheme string, fileType string, basePath string, file string) (io.ReadCloser, error) {
-	switch scheme {
-	case constSchemeHTTP:
-		fullPath, err := url.JoinPath(basePath, file)
-		if err != nil {
-			return nil, fmt.Errorf("unable to join paths %s and %s: %v", basePath, file, err)
+// Fetches the (full or delta) snapshot file to local disk and verifies it against
+// its manifest, see `loadSnapshotFromLocation`.
+func (smiT *snapshotManagerImpl) fetchSnapshot(snapshotInfo SnapshotInfo, location *snapshotLocation) (string, error) {
+	requireSignedManifest := smiT.requireSignedManifests && location.store != SnapshotStore(smiT.localStore)
+	manifest, err := smiT.readManifest(location)
+	if err != nil {
+		if requireSignedManifest {
+			return "", fmt.Errorf("failed to read manifest: %w", err)
 		}
-		downloader, err := NewDownloaderWithTimeout(smiT.ctx, fullPath, constDownloadTimeout)
-		if err != nil {
-			return nil, fmt.Errorf("failed to start downloading file from url %s: %v", fullPath, err)
+		smiT.log.Warnf("Loading snapshot %s from %s: snapshot will not be verified, because its manifest cannot be read: %v", snapshotInfo, location, err)
+	} else {
+		if !manifest.SnapshotInfo.Equals(snapshotInfo) {
+			return "", fmt.Errorf("manifest of snapshot %s is for different snapshot %s", snapshotInfo, manifest.SnapshotInfo)
 		}
-		r := smiT.addProgressReporter(downloader, fileType, fullPath, downloader.GetLength())
-		return NewReaderWithClose(r, downloader.Close), nil
-	case constSchemeFile:
-		fullPath := filepath.Join(basePath, file)
-		f, err := os.Open(fullPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to open file %s", fullPath)
+		err = manifest.VerifySignature(smiT.trustedPeers)
+		if errors.Is(err, ErrManifestNotSigned) && !requireSignedManifest {
+			smiT.log.Debugf("Loading snapshot %s from %s: manifest is not signed", snapshotInfo, location)
+		} else if err != nil {
+			return "", err
 		}
-		return f, nil
-	default:
-		return nil, fmt.Errorf("unnknown scheme %s", scheme)
 	}
-}
 
-func (smiT *snapshotManagerImpl) addProgressReporter(r io.Reader, fileType string, url string, length uint64) io.Reader {
-	progressReporter := NewProgressReporter(smiT.log, fmt.Sprintf("Downloading %s from url %s", fileType, url), length)
-	return io.TeeReader(r, progressReporter)
+	filePath, err := location.store.Fetch(location.fileName)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch snapshot file: %w", err)
+	}
+	if manifest != nil {
+		if err = manifest.VerifyFile(filePath); err != nil {
+			return "", fmt.Errorf("snapshot file verification failed: %w", err)
+		}
+		smiT.log.Debugf("Loading snapshot %s from %s: snapshot file %s matches the manifest", snapshotInfo, location, filePath)
+	}
+	return filePath, nil
 }
 
-func tempSnapshotFileName(index uint32, blockHash state.BlockHash) string {
-	return tempSnapshotFileNameString(fmt.Sprint(index), blockHash.String())
+func (smiT *snapshotManagerImpl) readManifest(location *snapshotLocation) (*SnapshotManifest, error) {
+	r, err := location.store.Open(manifestFileName(location.fileName))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return readManifest(r)
 }
 
-func tempSnapshotFileNameString(index, blockHash string) string {
-	return snapshotFileNameString(index, blockHash) + constSnapshotTmpFileSuffix
+func tempFileName(fileName string) string {
+	return fileName + constSnapshotTmpFileSuffix
 }
 
 func snapshotFileName(index uint32, blockHash state.BlockHash) string {
@@ -419,11 +554,25 @@ func snapshotFileNameString(index, blockHash string) string {
 	return index + constSnapshotIndexHashFileNameSepparator + blockHash + constSnapshotFileSuffix
 }
 
-func downloadedSnapshotFileName(index uint32, blockHash state.BlockHash) string {
-	return downloadedSnapshotFileNameString(fmt.Sprint(index), blockHash.String())
+func deltaSnapshotFileName(index uint32, blockHash state.BlockHash) string {
+	return deltaSnapshotFileNameString(fmt.Sprint(index), blockHash.String())
+}
+
+func deltaSnapshotFileNameString(index, blockHash string) string {
+	return index + constSnapshotIndexHashFileNameSepparator + blockHash + constDeltaSnapshotFileSuffix
+}
+
+func isSnapshotFileName(fileName string) bool {
+	return strings.HasSuffix(fileName, constSnapshotFileSuffix)
+}
+
+func isDeltaSnapshotFileName(fileName string) bool {
+	return strings.HasSuffix(fileName, constDeltaSnapshotFileSuffix)
 }
 
-func downloadedSnapshotFileNameString(index, blockHash string) string {
-	return index + constSnapshotIndexHashFileNameSepparator + blockHash +
-		constSnapshotIndexHashFileNameSepparator + constSnapshotDownloaded + constSnapshotFileSuffix
+// Works for both full and delta snapshot files.
+func downloadedSnapshotFileName(snapshotFileName string) string {
+	suffix := filepath.Ext(snapshotFileName)
+	return strings.TrimSuffix(snapshotFileName, suffix) +
+		constSnapshotIndexHashFileNameSepparator + constSnapshotDownloaded + suffix
 }
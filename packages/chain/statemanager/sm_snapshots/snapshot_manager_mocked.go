@@ -66,7 +66,7 @@ func NewMockedSnapshotManager(
 		nodeStore:           nodeStore,
 		snapshotToLoad:      snapshotToLoad,
 	}
-	result.snapshotManagerRunner = newSnapshotManagerRunner(context.Background(), nodeStore, nil, createPeriod, delayPeriod, result, result.log)
+	result.snapshotManagerRunner = newSnapshotManagerRunner(context.Background(), nodeStore, nil, createPeriod, delayPeriod, 0, result, result.log)
 	return result
 }
 
@@ -120,6 +120,9 @@ func (msmT *MockedSnapshotManager) createSnapshot(snapshotInfo SnapshotInfo) {
 	}()
 }
 
+// Delta snapshots are not mocked: mocked snapshot manager never makes them.
+func (msmT *MockedSnapshotManager) createDeltaSnapshot(SnapshotInfo, uint32) {}
+
 func (msmT *MockedSnapshotManager) loadSnapshot() SnapshotInfo {
 	if msmT.snapshotToLoad == nil {
 		return nil
@@ -26,6 +26,7 @@ type snapshotManagerRunner struct {
 	loadedSnapshotStateIndex  uint32
 	createPeriod              uint32
 	delayPeriod               uint32
+	deltaPeriod               uint32
 	queue                     []SnapshotInfo
 
 	core snapshotManagerCore
@@ -37,6 +38,7 @@ func newSnapshotManagerRunner(
 	shutdownCoordinator *shutdown.Coordinator,
 	createPeriod uint32,
 	delayPeriod uint32,
+	deltaPeriod uint32,
 	core snapshotManagerCore,
 	log *logger.Logger,
 ) *snapshotManagerRunner {
@@ -50,6 +52,7 @@ func newSnapshotManagerRunner(
 		loadedSnapshotStateIndex:  0,
 		createPeriod:              createPeriod,
 		delayPeriod:               delayPeriod,
+		deltaPeriod:               deltaPeriod,
 		queue:                     make([]SnapshotInfo, 0),
 		core:                      core,
 	}
@@ -132,18 +135,47 @@ func (smrT *snapshotManagerRunner) handleBlockCommitted(snapshotInfo SnapshotInf
 		smrT.lastIndexSnapshottedMutex.Lock()
 		defer smrT.lastIndexSnapshottedMutex.Unlock()
 		lastIndexSnapshotted = smrT.lastIndexSnapshotted
-		if (stateIndex > lastIndexSnapshotted) && (stateIndex%smrT.createPeriod == 0) { // TODO: what if snapshotted state has been reverted?
+		if (stateIndex > lastIndexSnapshotted) && smrT.isSnapshotIndex(stateIndex) { // TODO: what if snapshotted state has been reverted?
 			smrT.queue = append(smrT.queue, snapshotInfo)
 		}
 		stateIndexToCommit := stateIndex - smrT.delayPeriod
-		if (stateIndexToCommit > lastIndexSnapshotted) && (stateIndexToCommit%smrT.createPeriod == 0) {
+		if (stateIndexToCommit > lastIndexSnapshotted) && smrT.isSnapshotIndex(stateIndexToCommit) {
 			return lo.Filter(smrT.queue, func(si SnapshotInfo, index int) bool { return si.StateIndex() == stateIndexToCommit })
 		}
 		return []SnapshotInfo{}
 	}()
 	for i, siToCreate := range sisToCreate {
 		if !(lo.ContainsBy(sisToCreate[:i], func(si SnapshotInfo) bool { return si.Equals(siToCreate) })) {
-			smrT.core.createSnapshot(siToCreate)
+			if smrT.isDeltaSnapshotIndex(siToCreate.StateIndex()) {
+				smrT.core.createDeltaSnapshot(siToCreate, smrT.deltaSnapshotBaseIndex(siToCreate.StateIndex()))
+			} else {
+				smrT.core.createSnapshot(siToCreate)
+			}
 		}
 	}
 }
+
+func (smrT *snapshotManagerRunner) isSnapshotIndex(stateIndex uint32) bool {
+	return stateIndex%smrT.createPeriod == 0 || smrT.isDeltaSnapshotIndex(stateIndex)
+}
+
+// Delta snapshots are made every `deltaPeriod` states between the full snapshots,
+// made every `createPeriod` states. No delta snapshots are made before the first
+// full snapshot.
+func (smrT *snapshotManagerRunner) isDeltaSnapshotIndex(stateIndex uint32) bool {
+	return smrT.deltaPeriod > 0 &&
+		stateIndex > smrT.createPeriod &&
+		stateIndex%smrT.createPeriod != 0 &&
+		stateIndex%smrT.deltaPeriod == 0
+}
+
+// Delta snapshot is based on the previous delta snapshot or on the last full
+// snapshot, if there is no delta snapshot between the full one and this one.
+// Thus the full snapshot and the deltas after it form a chain.
+func (smrT *snapshotManagerRunner) deltaSnapshotBaseIndex(stateIndex uint32) uint32 {
+	fullSnapshotIndex := stateIndex - stateIndex%smrT.createPeriod
+	if stateIndex-fullSnapshotIndex <= smrT.deltaPeriod {
+		return fullSnapshotIndex
+	}
+	return stateIndex - smrT.deltaPeriod
+}
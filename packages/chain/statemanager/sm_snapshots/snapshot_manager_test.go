@@ -77,9 +77,14 @@ func getLocalFuns(t *testing.T) (createNewNodeFun, snapshotsAvailableFun) {
 				snapshotToLoad,
 				0,
 				0,
+				0,
 				localSnapshotsCreatePathConst,
 				[]string{},
+				[]string{},
+				true, // The local snapshots are loaded without signed manifests anyway
 				store,
+				nil,
+				nil,
 				mockSnapshotsMetrics(),
 				log,
 			)
@@ -112,9 +117,14 @@ func getNetworkFuns(t *testing.T, networkPaths []string) (createNewNodeFun, snap
 				snapshotToLoad,
 				0,
 				0,
+				0,
 				localSnapshotsDownloadPathConst,
 				networkPaths,
+				[]string{},
+				false,
 				store,
+				nil,
+				nil,
 				mockSnapshotsMetrics(),
 				log,
 			)
@@ -176,9 +186,14 @@ func testSnapshotManagerAny(
 		nil,
 		uint32(snapshotCreatePeriod),
 		uint32(snapshotDelayPeriod),
+		0,
 		localSnapshotsCreatePathConst,
 		[]string{},
+		[]string{},
+		false,
 		storeOrig,
+		nil,
+		nil,
 		mockSnapshotsMetrics(),
 		log,
 	)
@@ -228,6 +243,86 @@ func testSnapshotManagerAny(
 	}
 }
 
+func TestSnapshotManagerDelta(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	defer cleanupAfterSnapshotManagerTest(t)
+
+	numberOfBlocks := 20
+	snapshotCreatePeriod := 8
+	snapshotDeltaPeriod := 3
+	factory := sm_gpa_utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(numberOfBlocks, 1)
+	storeOrig := factory.GetStore()
+	snapshotManagerOrig, err := NewSnapshotManager(
+		context.Background(),
+		nil,
+		factory.GetChainID(),
+		nil,
+		uint32(snapshotCreatePeriod),
+		0,
+		uint32(snapshotDeltaPeriod),
+		localSnapshotsCreatePathConst,
+		[]string{},
+		[]string{},
+		false,
+		storeOrig,
+		nil,
+		nil,
+		mockSnapshotsMetrics(),
+		log,
+	)
+	require.NoError(t, err)
+
+	// Full snapshots are made in states 8 and 16; delta snapshots in states 9, 12, 15 and 18
+	for _, block := range blocks {
+		snapshotManagerOrig.BlockCommittedAsync(NewSnapshotInfo(block.StateIndex(), block.L1Commitment()))
+	}
+	deltaExistsFun := func(block state.Block) bool {
+		path := filepath.Join(localSnapshotsCreatePathConst, factory.GetChainID().String(), deltaSnapshotFileName(block.StateIndex(), block.Hash()))
+		exists, _, err := ioutils.PathExists(path)
+		require.NoError(t, err)
+		return exists
+	}
+	require.True(t, waitForBlock(t, factory.GetChainID(), blocks[15], 10, 50*time.Millisecond))
+	require.True(t, ensureTrue(t, "delta snapshot 18 to be created", func() bool { return deltaExistsFun(blocks[17]) }, 10, func() { time.Sleep(50 * time.Millisecond) }))
+	for _, block := range blocks {
+		stateIndex := block.StateIndex()
+		isDelta := stateIndex > uint32(snapshotCreatePeriod) && stateIndex%uint32(snapshotCreatePeriod) != 0 && stateIndex%uint32(snapshotDeltaPeriod) == 0
+		require.Equal(t, stateIndex%uint32(snapshotCreatePeriod) == 0, snapshotExists(t, factory.GetChainID(), stateIndex, block.L1Commitment()))
+		require.True(t, ensureTrue(t, fmt.Sprintf("delta snapshot %v", stateIndex), func() bool { return deltaExistsFun(block) == isDelta }, 10, func() { time.Sleep(50 * time.Millisecond) }))
+	}
+
+	// Node is restarted: it loads the full snapshot 16 and the delta snapshot 18 on top of it
+	storeNew := state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+	snapshotManagerNew, err := NewSnapshotManager(
+		context.Background(),
+		nil,
+		factory.GetChainID(),
+		nil,
+		0,
+		0,
+		0,
+		localSnapshotsCreatePathConst,
+		[]string{},
+		[]string{},
+		false,
+		storeNew,
+		nil,
+		nil,
+		mockSnapshotsMetrics(),
+		log,
+	)
+	require.NoError(t, err)
+	require.Equal(t, uint32(18), snapshotManagerNew.GetLoadedSnapshotStateIndex())
+	for _, block := range []state.Block{blocks[15], blocks[17]} {
+		require.True(t, storeNew.HasTrieRoot(block.TrieRoot()))
+		sm_gpa_utils.CheckBlockInStore(t, storeNew, block)
+		sm_gpa_utils.CheckStateInStores(t, storeOrig, storeNew, block.L1Commitment())
+	}
+	require.False(t, storeNew.HasTrieRoot(blocks[16].TrieRoot()))
+}
+
 func snapshotExists(t *testing.T, chainID isc.ChainID, stateIndex uint32, commitment *state.L1Commitment) bool {
 	path := filepath.Join(localSnapshotsCreatePathConst, chainID.String(), snapshotFileName(stateIndex, commitment.BlockHash()))
 	exists, isDir, err := ioutils.PathExists(path)
@@ -0,0 +1,210 @@
+package sm_snapshots
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/crypto/blake2b"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/registry"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// SnapshotManifest describes the snapshot file and allows checking its
+// integrity before the snapshot is loaded to the store. The manifest is stored
+// next to the snapshot file (see `manifestFileName`) in JSON format. The manifest
+// may be signed by the node, which created the snapshot; the signature is
+// accepted only if the signer is a trusted peer.
+type SnapshotManifest struct {
+	SnapshotInfo SnapshotInfo
+	Size         uint64            // Size of the snapshot file in bytes
+	ContentHash  hashing.HashValue // Blake2b-256 hash of the entire snapshot file
+	Signer       *cryptolib.PublicKey
+	Signature    []byte
+}
+
+type snapshotManifestJSON struct {
+	StateIndex  uint32 `json:"stateIndex"`
+	TrieRoot    string `json:"trieRoot"`
+	BlockHash   string `json:"blockHash"`
+	Size        uint64 `json:"size"`
+	ContentHash string `json:"contentHash"`
+	Signer      string `json:"signer,omitempty"`
+	Signature   string `json:"signature,omitempty"`
+}
+
+const constManifestFileSuffix = ".manifest"
+
+var ErrManifestNotSigned = errors.New("snapshot manifest is not signed")
+
+// Computes the manifest of the snapshot file in provided path. The manifest is not signed.
+func newSnapshotManifestFromFile(snapshotInfo SnapshotInfo, filePath string) (*SnapshotManifest, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot file %s: %w", filePath, err)
+	}
+	defer f.Close()
+	size, contentHash, err := hashSnapshot(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash snapshot file %s: %w", filePath, err)
+	}
+	return &SnapshotManifest{
+		SnapshotInfo: snapshotInfo,
+		Size:         size,
+		ContentHash:  contentHash,
+	}, nil
+}
+
+func hashSnapshot(r io.Reader) (uint64, hashing.HashValue, error) {
+	var contentHash hashing.HashValue
+	h, err := blake2b.New256(nil)
+	if err != nil {
+		return 0, contentHash, err
+	}
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return 0, contentHash, err
+	}
+	copy(contentHash[:], h.Sum(nil))
+	return uint64(n), contentHash, nil
+}
+
+func (sm *SnapshotManifest) essenceBytes() []byte {
+	ww := rwutil.NewBytesWriter()
+	ww.WriteUint32(sm.SnapshotInfo.StateIndex())
+	ww.Write(sm.SnapshotInfo.Commitment())
+	ww.WriteUint64(sm.Size)
+	ww.WriteN(sm.ContentHash[:])
+	return ww.Bytes()
+}
+
+func (sm *SnapshotManifest) Sign(keyPair *cryptolib.KeyPair) {
+	sm.Signer = keyPair.GetPublicKey()
+	sm.Signature = keyPair.GetPrivateKey().Sign(sm.essenceBytes())
+}
+
+// Checks that the signature of the manifest is valid and that the signer
+// is trusted by this node.
+func (sm *SnapshotManifest) VerifySignature(trustedPeers registry.TrustedPeersRegistryProvider) error {
+	if sm.Signer == nil {
+		return ErrManifestNotSigned
+	}
+	if !sm.Signer.Verify(sm.essenceBytes(), sm.Signature) {
+		return fmt.Errorf("invalid signature of snapshot manifest %s", sm.SnapshotInfo)
+	}
+	if trustedPeers == nil {
+		return fmt.Errorf("signer %s of snapshot manifest %s cannot be checked: no trusted peers", sm.Signer, sm.SnapshotInfo)
+	}
+	if err := trustedPeers.IsTrustedPeer(sm.Signer); err != nil {
+		return fmt.Errorf("signer %s of snapshot manifest %s is not trusted: %w", sm.Signer, sm.SnapshotInfo, err)
+	}
+	return nil
+}
+
+// Checks that the snapshot file in provided path matches the manifest.
+func (sm *SnapshotManifest) VerifyFile(filePath string) error {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file %s: %w", filePath, err)
+	}
+	defer f.Close()
+	snapshotInfo, err := readSnapshotInfo(f)
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot info from file %s: %w", filePath, err)
+	}
+	if !snapshotInfo.Equals(sm.SnapshotInfo) {
+		return fmt.Errorf("snapshot %s in file %s does not match manifest %s", snapshotInfo, filePath, sm.SnapshotInfo)
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind snapshot file %s: %w", filePath, err)
+	}
+	size, contentHash, err := hashSnapshot(f)
+	if err != nil {
+		return fmt.Errorf("failed to hash snapshot file %s: %w", filePath, err)
+	}
+	if size != sm.Size {
+		return fmt.Errorf("size %v of snapshot file %s does not match manifest size %v", size, filePath, sm.Size)
+	}
+	if contentHash != sm.ContentHash {
+		return fmt.Errorf("hash %s of snapshot file %s does not match manifest hash %s", contentHash, filePath, sm.ContentHash)
+	}
+	return nil
+}
+
+func (sm *SnapshotManifest) MarshalJSON() ([]byte, error) {
+	manifestJSON := &snapshotManifestJSON{
+		StateIndex:  sm.SnapshotInfo.StateIndex(),
+		TrieRoot:    sm.SnapshotInfo.TrieRoot().String(),
+		BlockHash:   sm.SnapshotInfo.BlockHash().String(),
+		Size:        sm.Size,
+		ContentHash: sm.ContentHash.Hex(),
+	}
+	if sm.Signer != nil {
+		manifestJSON.Signer = sm.Signer.String()
+		manifestJSON.Signature = iotago.EncodeHex(sm.Signature)
+	}
+	return json.Marshal(manifestJSON)
+}
+
+func (sm *SnapshotManifest) UnmarshalJSON(data []byte) error {
+	var manifestJSON snapshotManifestJSON
+	if err := json.Unmarshal(data, &manifestJSON); err != nil {
+		return err
+	}
+	trieRoot, err := hex.DecodeString(manifestJSON.TrieRoot)
+	if err != nil {
+		return fmt.Errorf("failed to parse trie root: %w", err)
+	}
+	blockHash, err := state.BlockHashFromString(manifestJSON.BlockHash)
+	if err != nil {
+		return fmt.Errorf("failed to parse block hash: %w", err)
+	}
+	commitment, err := state.L1CommitmentFromBytes(append(trieRoot, blockHash[:]...))
+	if err != nil {
+		return fmt.Errorf("failed to parse L1 commitment: %w", err)
+	}
+	contentHash, err := hashing.HashValueFromHex(manifestJSON.ContentHash)
+	if err != nil {
+		return fmt.Errorf("failed to parse content hash: %w", err)
+	}
+	sm.SnapshotInfo = NewSnapshotInfo(manifestJSON.StateIndex, commitment)
+	sm.Size = manifestJSON.Size
+	sm.ContentHash = contentHash
+	sm.Signer = nil
+	sm.Signature = nil
+	if manifestJSON.Signer != "" {
+		sm.Signer, err = cryptolib.PublicKeyFromString(manifestJSON.Signer)
+		if err != nil {
+			return fmt.Errorf("failed to parse signer: %w", err)
+		}
+		sm.Signature, err = iotago.DecodeHex(manifestJSON.Signature)
+		if err != nil {
+			return fmt.Errorf("failed to parse signature: %w", err)
+		}
+	}
+	return nil
+}
+
+func readManifest(r io.Reader) (*SnapshotManifest, error) {
+	manifest := &SnapshotManifest{}
+	if err := json.NewDecoder(r).Decode(manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func writeManifest(manifest *SnapshotManifest, w io.Writer) error {
+	return json.NewEncoder(w).Encode(manifest)
+}
+
+func manifestFileName(snapshotFileName string) string {
+	return snapshotFileName + constManifestFileSuffix
+}
@@ -0,0 +1,153 @@
+package sm_snapshots
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/testutil"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+)
+
+func TestManifestVerify(t *testing.T) {
+	defer cleanupAfterSnapshotManagerTest(t)
+
+	factory := sm_gpa_utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(5, 1)
+	lastBlock := blocks[len(blocks)-1]
+	snapshotInfo := NewSnapshotInfo(lastBlock.StateIndex(), lastBlock.L1Commitment())
+	require.NoError(t, os.MkdirAll(localSnapshotsPathConst, 0o777))
+	filePath := filepath.Join(localSnapshotsPathConst, snapshotFileName(snapshotInfo.StateIndex(), snapshotInfo.BlockHash()))
+	f, err := os.Create(filePath)
+	require.NoError(t, err)
+	require.NoError(t, newSnapshotter(factory.GetStore()).storeSnapshot(snapshotInfo, f))
+	require.NoError(t, f.Close())
+
+	manifest, err := newSnapshotManifestFromFile(snapshotInfo, filePath)
+	require.NoError(t, err)
+	require.NoError(t, manifest.VerifyFile(filePath))
+	trustedPeers := testutil.NewTrustedNetworkManager()
+	require.ErrorIs(t, manifest.VerifySignature(trustedPeers), ErrManifestNotSigned)
+
+	// Signature is valid only if the signer is trusted
+	keyPair := cryptolib.NewKeyPair()
+	manifest.Sign(keyPair)
+	require.Error(t, manifest.VerifySignature(trustedPeers))
+	_, err = trustedPeers.TrustPeer("signer", keyPair.GetPublicKey(), "localhost:1234")
+	require.NoError(t, err)
+	require.NoError(t, manifest.VerifySignature(trustedPeers))
+
+	// Manifest survives the JSON round trip
+	manifestBytes, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	manifestRead, err := readManifest(bytes.NewReader(manifestBytes))
+	require.NoError(t, err)
+	require.True(t, manifestRead.SnapshotInfo.Equals(snapshotInfo))
+	require.Equal(t, manifest.Size, manifestRead.Size)
+	require.Equal(t, manifest.ContentHash, manifestRead.ContentHash)
+	require.NoError(t, manifestRead.VerifySignature(trustedPeers))
+	require.NoError(t, manifestRead.VerifyFile(filePath))
+
+	// Tampered manifest or file is rejected
+	manifestRead.Size++
+	require.Error(t, manifestRead.VerifySignature(trustedPeers))
+	require.Error(t, manifestRead.VerifyFile(filePath))
+	snapshotBytes, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	snapshotBytes[len(snapshotBytes)-1] ^= 0xFF
+	require.NoError(t, os.WriteFile(filePath, snapshotBytes, 0o666))
+	require.Error(t, manifest.VerifyFile(filePath))
+}
+
+func TestSnapshotManagerUploadSigned(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	defer cleanupAfterSnapshotManagerTest(t)
+
+	uploadPath := filepath.Join(localSnapshotsPathConst, "upload")
+	absUploadPath, err := filepath.Abs(uploadPath)
+	require.NoError(t, err)
+	uploadURL := "file://" + absUploadPath
+	nodeIdentity := cryptolib.NewKeyPair()
+	factory := sm_gpa_utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(4, 1)
+	snapshotManagerOrig, err := NewSnapshotManager(
+		context.Background(),
+		nil,
+		factory.GetChainID(),
+		nil,
+		2,
+		0,
+		0,
+		localSnapshotsCreatePathConst,
+		[]string{},
+		[]string{uploadURL},
+		false,
+		factory.GetStore(),
+		nodeIdentity,
+		nil,
+		mockSnapshotsMetrics(),
+		log,
+	)
+	require.NoError(t, err)
+	for _, block := range blocks {
+		snapshotManagerOrig.BlockCommittedAsync(NewSnapshotInfo(block.StateIndex(), block.L1Commitment()))
+	}
+	lastBlock := blocks[len(blocks)-1]
+	uploadedFilePath := filepath.Join(uploadPath, factory.GetChainID().String(), snapshotFileName(lastBlock.StateIndex(), lastBlock.L1Commitment().BlockHash()))
+	uploadedFun := func() bool {
+		_, err := os.Stat(manifestFileName(uploadedFilePath))
+		return err == nil
+	}
+	require.True(t, ensureTrue(t, "snapshot to be uploaded", uploadedFun, 10, func() { time.Sleep(50 * time.Millisecond) }))
+
+	createNewFun := func(trustedSigner *cryptolib.PublicKey) (SnapshotManager, state.Store) {
+		tnm := testutil.NewTrustedNetworkManager()
+		if trustedSigner != nil {
+			_, err := tnm.TrustPeer("creator", trustedSigner, "localhost:1234")
+			require.NoError(t, err)
+		}
+		storeNew := state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+		snapshotManagerNew, err := NewSnapshotManager(
+			context.Background(),
+			nil,
+			factory.GetChainID(),
+			nil,
+			0,
+			0,
+			0,
+			localSnapshotsDownloadPathConst,
+			[]string{uploadURL},
+			[]string{},
+			true,
+			storeNew,
+			nil,
+			tnm,
+			mockSnapshotsMetrics(),
+			log,
+		)
+		require.NoError(t, err)
+		return snapshotManagerNew, storeNew
+	}
+
+	// Signer of the snapshot is not trusted: snapshot is not loaded
+	snapshotManagerNew, storeNew := createNewFun(nil)
+	require.Equal(t, uint32(0), snapshotManagerNew.GetLoadedSnapshotStateIndex())
+	require.False(t, storeNew.HasTrieRoot(lastBlock.TrieRoot()))
+
+	// Signer of the snapshot is trusted: snapshot is loaded
+	snapshotManagerNew, storeNew = createNewFun(nodeIdentity.GetPublicKey())
+	require.Equal(t, lastBlock.StateIndex(), snapshotManagerNew.GetLoadedSnapshotStateIndex())
+	sm_gpa_utils.CheckBlockInStore(t, storeNew, lastBlock)
+	sm_gpa_utils.CheckStateInStores(t, factory.GetStore(), storeNew, lastBlock.L1Commitment())
+}
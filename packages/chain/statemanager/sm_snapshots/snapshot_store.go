@@ -0,0 +1,401 @@
+package sm_snapshots
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/runtime/ioutils"
+)
+
+// SnapshotStore is a location, where snapshot files (and their manifests) are
+// kept. Snapshot manager searches the stores for the snapshots to load and
+// uploads the snapshots it creates to them. Stores are identified by URL; the
+// store implementation is selected by the scheme of the URL. Implementations
+// for `file://` and `http(s)://` schemes are provided by default; other
+// implementations may be added by `RegisterSnapshotStore`.
+type SnapshotStore interface {
+	// ListSnapshots returns the names of the (full and delta) snapshot files in the store.
+	ListSnapshots() ([]string, error)
+	// Open opens the file of the store for reading.
+	Open(fileName string) (io.ReadCloser, error)
+	// Fetch makes the file of the store available on local disk and returns the path to it.
+	Fetch(fileName string) (string, error)
+	// Upload puts the snapshot file from the local disk and its manifest to the store.
+	Upload(filePath string, manifest *SnapshotManifest) error
+	String() string
+}
+
+// SnapshotStoreFactory creates the store for the provided URL. Files, that have
+// to be fetched from the store, may be put to `localPath` folder.
+type SnapshotStoreFactory func(ctx context.Context, storeURL *url.URL, localPath string, log *logger.Logger) (SnapshotStore, error)
+
+var (
+	snapshotStoreFactories      = map[string]SnapshotStoreFactory{}
+	snapshotStoreFactoriesMutex = &sync.RWMutex{}
+)
+
+func init() {
+	RegisterSnapshotStore("file", newFileSnapshotStore)
+	RegisterSnapshotStore("http", newHTTPSnapshotStore)
+	RegisterSnapshotStore("https", newHTTPSnapshotStore)
+}
+
+func RegisterSnapshotStore(scheme string, factory SnapshotStoreFactory) {
+	snapshotStoreFactoriesMutex.Lock()
+	defer snapshotStoreFactoriesMutex.Unlock()
+	snapshotStoreFactories[scheme] = factory
+}
+
+func NewSnapshotStore(ctx context.Context, storeURL string, localPath string, log *logger.Logger) (SnapshotStore, error) {
+	uObj, err := url.Parse(storeURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse url %s: %w", storeURL, err)
+	}
+	snapshotStoreFactoriesMutex.RLock()
+	factory, ok := snapshotStoreFactories[uObj.Scheme]
+	snapshotStoreFactoriesMutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown scheme %s", uObj.Scheme)
+	}
+	return factory(ctx, uObj, localPath, log)
+}
+
+// -------------------------------------
+// Snapshot store in local file system
+// -------------------------------------
+
+// If the folder of the store contains `INDEX` file, only the snapshots listed
+// in it are considered to be in the store. Otherwise, all the files named as
+// snapshots or delta snapshots are in the store.
+type fileSnapshotStore struct {
+	path string
+}
+
+var _ SnapshotStore = &fileSnapshotStore{}
+
+func newFileSnapshotStore(_ context.Context, storeURL *url.URL, _ string, _ *logger.Logger) (SnapshotStore, error) {
+	return newFileSnapshotStoreWithPath(filepath.Join(storeURL.Host, storeURL.Path)), nil
+}
+
+func newFileSnapshotStoreWithPath(path string) *fileSnapshotStore {
+	return &fileSnapshotStore{path: path}
+}
+
+func (fss *fileSnapshotStore) ListSnapshots() ([]string, error) {
+	f, err := os.Open(fss.indexFilePath())
+	if err == nil {
+		defer f.Close()
+		return readIndex(f)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to open index file: %w", err)
+	}
+	result := make([]string, 0)
+	for _, pattern := range []string{snapshotFileNameString("*", "*"), deltaSnapshotFileNameString("*", "*")} {
+		files, err := filepath.Glob(filepath.Join(fss.path, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain snapshot file list: %w", err)
+		}
+		for i := range files {
+			result = append(result, filepath.Base(files[i]))
+		}
+	}
+	return result, nil
+}
+
+func (fss *fileSnapshotStore) Open(fileName string) (io.ReadCloser, error) {
+	if err := checkFileName(fileName); err != nil {
+		return nil, err
+	}
+	filePath := filepath.Join(fss.path, fileName)
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	return f, nil
+}
+
+func (fss *fileSnapshotStore) Fetch(fileName string) (string, error) {
+	if err := checkFileName(fileName); err != nil {
+		return "", err
+	}
+	filePath := filepath.Join(fss.path, fileName)
+	exists, isDir, err := ioutils.PathExists(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to check file %s: %w", filePath, err)
+	}
+	if !exists || isDir {
+		return "", fmt.Errorf("file %s does not exist", filePath)
+	}
+	return filePath, nil
+}
+
+func (fss *fileSnapshotStore) Upload(filePath string, manifest *SnapshotManifest) error {
+	if err := ioutils.CreateDirectory(fss.path, 0o777); err != nil {
+		return fmt.Errorf("cannot create folder %s: %w", fss.path, err)
+	}
+	fileName := filepath.Base(filePath)
+	storeFilePath := filepath.Join(fss.path, fileName)
+	if filepath.Clean(filePath) != filepath.Clean(storeFilePath) {
+		if err := copyFile(filePath, storeFilePath); err != nil {
+			return err
+		}
+	}
+	err := writeFileAtomically(filepath.Join(fss.path, manifestFileName(fileName)), func(w io.Writer) error {
+		return writeManifest(manifest, w)
+	})
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(fss.indexFilePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil // Snapshots are listed by their names, no need to update index
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open index file: %w", err)
+	}
+	fileNames, err := readIndex(f)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(fss.indexFilePath(), func(w io.Writer) error {
+		return writeIndex(addToIndex(fileNames, fileName), w)
+	})
+}
+
+func (fss *fileSnapshotStore) String() string {
+	return constLocalAddress + fss.path
+}
+
+func (fss *fileSnapshotStore) indexFilePath() string {
+	return filepath.Join(fss.path, constIndexFileName)
+}
+
+// -------------------------------------
+// Snapshot store on http(s) server
+// -------------------------------------
+
+// Snapshots in the store must be listed in `INDEX` file. Uploading is done
+// with PUT requests, thus it requires the server to support them.
+type httpSnapshotStore struct {
+	ctx       context.Context
+	baseURL   string
+	localPath string
+	log       *logger.Logger
+}
+
+var _ SnapshotStore = &httpSnapshotStore{}
+
+func newHTTPSnapshotStore(ctx context.Context, storeURL *url.URL, localPath string, log *logger.Logger) (SnapshotStore, error) {
+	return &httpSnapshotStore{
+		ctx:       ctx,
+		baseURL:   storeURL.String(),
+		localPath: localPath,
+		log:       log,
+	}, nil
+}
+
+func (hss *httpSnapshotStore) ListSnapshots() ([]string, error) {
+	r, err := hss.openWithProgress("index file", constIndexFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index file: %w", err)
+	}
+	defer r.Close()
+	return readIndex(r)
+}
+
+func (hss *httpSnapshotStore) Open(fileName string) (io.ReadCloser, error) {
+	return hss.openWithProgress("file", fileName)
+}
+
+func (hss *httpSnapshotStore) Fetch(fileName string) (string, error) {
+	if err := checkFileName(fileName); err != nil {
+		return "", err
+	}
+	fileURL, err := url.JoinPath(hss.baseURL, fileName)
+	if err != nil {
+		return "", fmt.Errorf("unable to join paths %s and %s: %w", hss.baseURL, fileName, err)
+	}
+	filePathLocal := filepath.Join(hss.localPath, downloadedSnapshotFileName(fileName))
+	addProgressReporterFun := func(r io.Reader, f string, s uint64) io.Reader {
+		return addProgressReporter(hss.log, r, "snapshot", f, s)
+	}
+	err = DownloadToFile(hss.ctx, fileURL, filePathLocal, constDownloadTimeout, addProgressReporterFun)
+	if err != nil {
+		return "", err
+	}
+	return filePathLocal, nil
+}
+
+func (hss *httpSnapshotStore) Upload(filePath string, manifest *SnapshotManifest) error {
+	fileName := filepath.Base(filePath)
+	f, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file %s: %w", filePath, err)
+	}
+	defer f.Close()
+	if err = hss.put(fileName, f); err != nil {
+		return err
+	}
+	manifestBuffer := new(bytes.Buffer)
+	if err = writeManifest(manifest, manifestBuffer); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err = hss.put(manifestFileName(fileName), manifestBuffer); err != nil {
+		return err
+	}
+	fileNames, err := hss.ListSnapshots()
+	if err != nil {
+		hss.log.Debugf("Failed to read index file from %s, a new one will be created: %v", hss.baseURL, err)
+		fileNames = []string{}
+	}
+	indexBuffer := new(bytes.Buffer)
+	if err = writeIndex(addToIndex(fileNames, fileName), indexBuffer); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+	return hss.put(constIndexFileName, indexBuffer)
+}
+
+func (hss *httpSnapshotStore) String() string {
+	return hss.baseURL
+}
+
+func (hss *httpSnapshotStore) openWithProgress(fileType, fileName string) (io.ReadCloser, error) {
+	if err := checkFileName(fileName); err != nil {
+		return nil, err
+	}
+	fileURL, err := url.JoinPath(hss.baseURL, fileName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to join paths %s and %s: %w", hss.baseURL, fileName, err)
+	}
+	downloader, err := NewDownloaderWithTimeout(hss.ctx, fileURL, constDownloadTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start downloading file from url %s: %w", fileURL, err)
+	}
+	r := addProgressReporter(hss.log, downloader, fileType, fileURL, downloader.GetLength())
+	return NewReaderWithClose(r, downloader.Close), nil
+}
+
+func (hss *httpSnapshotStore) put(fileName string, r io.Reader) error {
+	fileURL, err := url.JoinPath(hss.baseURL, fileName)
+	if err != nil {
+		return fmt.Errorf("unable to join paths %s and %s: %w", hss.baseURL, fileName, err)
+	}
+	ctx, cancel := context.WithTimeout(hss.ctx, constDownloadTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, fileURL, r)
+	if err != nil {
+		return fmt.Errorf("failed to make put request to %s: %w", fileURL, err)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to upload file to %s: %w", fileURL, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("put request to %s got status code %v", fileURL, response.StatusCode)
+	}
+	return nil
+}
+
+// -------------------------------------
+// Helper functions
+// -------------------------------------
+
+func addProgressReporter(log *logger.Logger, r io.Reader, fileType string, url string, length uint64) io.Reader {
+	progressReporter := NewProgressReporter(log, fmt.Sprintf("Downloading %s from url %s", fileType, url), length)
+	return io.TeeReader(r, progressReporter)
+}
+
+func readIndex(r io.Reader) ([]string, error) {
+	result := make([]string, 0)
+	scanner := bufio.NewScanner(r) // Defaults to splitting input by newline character
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			if err := checkFileName(line); err != nil {
+				return nil, fmt.Errorf("failed to read index file: %w", err)
+			}
+			result = append(result, line)
+		}
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read index file: %w", err)
+	}
+	return result, nil
+}
+
+// The names listed in the index file come from the store, which may be untrusted,
+// so only the plain file names are accepted, which can't point outside of the store
+// and outside of the folder, to which the files are fetched.
+func checkFileName(fileName string) error {
+	if fileName == "." || fileName != filepath.Base(fileName) || strings.ContainsAny(fileName, `/\`) || strings.Contains(fileName, "..") {
+		return fmt.Errorf("invalid file name %q", fileName)
+	}
+	return nil
+}
+
+func writeIndex(fileNames []string, w io.Writer) error {
+	for _, fileName := range fileNames {
+		if _, err := io.WriteString(w, fileName+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addToIndex(fileNames []string, fileName string) []string {
+	for _, name := range fileNames {
+		if name == fileName {
+			return fileNames
+		}
+	}
+	return append(fileNames, fileName)
+}
+
+func copyFile(srcPath, dstPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to open file %s: %w", srcPath, err)
+	}
+	defer src.Close()
+	return writeFileAtomically(dstPath, func(w io.Writer) error {
+		_, err := io.Copy(w, src)
+		return err
+	})
+}
+
+// Writes the file to the temporary location first and moves it to the
+// permanent location only after it is written completely.
+func writeFileAtomically(filePath string, writeFun func(io.Writer) error) error {
+	filePathTemp := filePath + constSnapshotTmpFileSuffix
+	err := func() error {
+		f, err := os.OpenFile(filePathTemp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o666)
+		if err != nil {
+			return fmt.Errorf("failed to create temporary file %s: %w", filePathTemp, err)
+		}
+		defer f.Close()
+		if err = writeFun(f); err != nil {
+			return fmt.Errorf("failed to write temporary file %s: %w", filePathTemp, err)
+		}
+		return nil
+	}()
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(filePathTemp, filePath); err != nil {
+		return fmt.Errorf("failed to move temporary file %s to permanent location %s: %w", filePathTemp, filePath, err)
+	}
+	return nil
+}
@@ -2,6 +2,12 @@ package sm_snapshots
 
 import (
 	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -9,6 +15,7 @@ import (
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
 	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
 )
 
 func TestNewerSnapshotKeepsOlderSnapshot(t *testing.T) {
@@ -96,3 +103,30 @@ func twoSnapshotsCheckEnds(t *testing.T, performTestFun func(t *testing.T, store
 	sm_gpa_utils.CheckBlockInStore(t, storeNew, lastBlock)
 	sm_gpa_utils.CheckStateInStores(t, storeOrig, storeNew, lastCommitment)
 }
+
+func TestHTTPSnapshotStoreFileNames(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+
+	// The store lists a file outside of its folder
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("1-abc.snap\n../../evil.snap\n"))
+	}))
+	defer server.Close()
+	storeURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	localPath := filepath.Join(t.TempDir(), "download")
+	store, err := newHTTPSnapshotStore(context.Background(), storeURL, localPath, log)
+	require.NoError(t, err)
+
+	_, err = store.ListSnapshots()
+	require.ErrorContains(t, err, "invalid file name")
+	for _, fileName := range []string{"../../evil.snap", "/tmp/evil.snap", "dir/evil.snap", `dir\evil.snap`, "..", ".", ""} {
+		_, err = store.Fetch(fileName)
+		require.Error(t, err, fileName)
+		_, err = store.Open(fileName)
+		require.Error(t, err, fileName)
+	}
+	_, err = os.Stat(filepath.Join(localPath, downloadedSnapshotFileName("../../evil.snap")))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -22,17 +22,9 @@ func newSnapshotter(store state.Store) snapshotter {
 }
 
 func (sn *snapshotterImpl) storeSnapshot(snapshotInfo SnapshotInfo, w io.Writer) error {
-	indexArray := make([]byte, 4) // Size of block index, which is of type uint32: 4 bytes
-	binary.LittleEndian.PutUint32(indexArray, snapshotInfo.StateIndex())
-	err := writeBytes(indexArray, w)
-	if err != nil {
-		return fmt.Errorf("failed writing block index %v: %w", snapshotInfo.StateIndex(), err)
-	}
-
-	trieRootBytes := snapshotInfo.Commitment().Bytes()
-	err = writeBytes(trieRootBytes, w)
+	err := writeSnapshotInfo(snapshotInfo, w)
 	if err != nil {
-		return fmt.Errorf("failed writing L1 commitment %s: %w", snapshotInfo.Commitment(), err)
+		return err
 	}
 
 	err = sn.store.TakeSnapshot(snapshotInfo.TrieRoot(), w)
@@ -57,6 +49,84 @@ func (sn *snapshotterImpl) loadSnapshot(snapshotInfo SnapshotInfo, r io.Reader)
 	return nil
 }
 
+// Delta snapshot starts with the info of the snapshot it produces followed by
+// the info of the snapshot it is based on. Thus `readSnapshotInfo` works for
+// delta snapshots as well.
+func (sn *snapshotterImpl) storeDeltaSnapshot(baseSnapshotInfo, snapshotInfo SnapshotInfo, w io.Writer) error {
+	err := writeSnapshotInfo(snapshotInfo, w)
+	if err != nil {
+		return err
+	}
+	err = writeSnapshotInfo(baseSnapshotInfo, w)
+	if err != nil {
+		return fmt.Errorf("failed writing base snapshot info: %w", err)
+	}
+	err = sn.store.TakeDeltaSnapshot(baseSnapshotInfo.TrieRoot(), snapshotInfo.TrieRoot(), w)
+	if err != nil {
+		return fmt.Errorf("failed to store delta snapshot: %w", err)
+	}
+	return nil
+}
+
+func (sn *snapshotterImpl) loadDeltaSnapshots(baseSnapshotInfo SnapshotInfo, snapshotInfos []SnapshotInfo, rs []io.Reader) error {
+	if len(snapshotInfos) != len(rs) {
+		return fmt.Errorf("%v delta snapshots expected, %v provided", len(snapshotInfos), len(rs))
+	}
+	expectedBaseSnapshotInfo := baseSnapshotInfo
+	for i := range rs {
+		readSnapshotInfo, readBaseSnapshotInfo, err := readDeltaSnapshotInfo(rs[i])
+		if err != nil {
+			return fmt.Errorf("failed reading delta snapshot info: %w", err)
+		}
+		if !readSnapshotInfo.Equals(snapshotInfos[i]) {
+			return fmt.Errorf("delta snapshot read %s is different than expected %s", readSnapshotInfo, snapshotInfos[i])
+		}
+		if !readBaseSnapshotInfo.Equals(expectedBaseSnapshotInfo) {
+			return fmt.Errorf("delta snapshot %s is based on %s instead of %s", readSnapshotInfo, readBaseSnapshotInfo, expectedBaseSnapshotInfo)
+		}
+		expectedBaseSnapshotInfo = readSnapshotInfo
+	}
+	trieRoot, err := sn.store.RestoreDeltaSnapshots(baseSnapshotInfo.TrieRoot(), rs...)
+	if err != nil {
+		return fmt.Errorf("failed restoring delta snapshots: %w", err)
+	}
+	if !trieRoot.Equals(expectedBaseSnapshotInfo.TrieRoot()) {
+		return fmt.Errorf("delta snapshots restored trie root %s instead of %s", trieRoot, expectedBaseSnapshotInfo.TrieRoot())
+	}
+	return nil
+}
+
+func writeSnapshotInfo(snapshotInfo SnapshotInfo, w io.Writer) error {
+	indexArray := make([]byte, 4) // Size of block index, which is of type uint32: 4 bytes
+	binary.LittleEndian.PutUint32(indexArray, snapshotInfo.StateIndex())
+	err := writeBytes(indexArray, w)
+	if err != nil {
+		return fmt.Errorf("failed writing block index %v: %w", snapshotInfo.StateIndex(), err)
+	}
+
+	trieRootBytes := snapshotInfo.Commitment().Bytes()
+	err = writeBytes(trieRootBytes, w)
+	if err != nil {
+		return fmt.Errorf("failed writing L1 commitment %s: %w", snapshotInfo.Commitment(), err)
+	}
+	return nil
+}
+
+func readDeltaSnapshotInfo(r io.Reader) (SnapshotInfo, SnapshotInfo, error) {
+	snapshotInfo, err := readSnapshotInfo(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	baseSnapshotInfo, err := readSnapshotInfo(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read base snapshot info: %w", err)
+	}
+	if baseSnapshotInfo.StateIndex() >= snapshotInfo.StateIndex() {
+		return nil, nil, fmt.Errorf("base snapshot %s of delta snapshot %s is not older than it", baseSnapshotInfo, snapshotInfo)
+	}
+	return snapshotInfo, baseSnapshotInfo, nil
+}
+
 func readSnapshotInfo(r io.Reader) (SnapshotInfo, error) {
 	indexArray, err := readBytes(r)
 	if err != nil {
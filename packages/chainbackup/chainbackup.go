@@ -0,0 +1,217 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+// Package chainbackup implements the format of the online backups of a chain.
+// The backup consists of a manifest, the registry entries of the chain (the
+// chain record and the DK shares of the committee, if the node is part of it)
+// and the trie snapshot of a single state of the chain. The snapshot is the
+// last part of the backup, so that it can be streamed without buffering.
+//
+// The DK shares hold the private key shares of the node, thus they are sealed
+// with the key encryption of the node (see registry.KeyEncryption) and can only
+// be restored by a node using the same passphrase or key file. They are never
+// written in plain text.
+package chainbackup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/registry"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/tcrypto"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// increment when changing the backup format
+const backupVersion = 1
+
+var ErrDKSharesNotEncrypted = errors.New("the DK shares can only be backed up, if the key encryption is enabled")
+
+type Manifest struct {
+	ChainID      isc.ChainID
+	StateIndex   uint32
+	L1Commitment *state.L1Commitment
+	CreatedAt    time.Time
+}
+
+// FileName returns the name of the file, the backup is meant to be stored in.
+func (m *Manifest) FileName() string {
+	return fmt.Sprintf("%s_%d_%s.backup", m.ChainID, m.StateIndex, m.L1Commitment.BlockHash())
+}
+
+type Backup struct {
+	Manifest    *Manifest
+	ChainRecord *registry.ChainRecord
+	DKShares    []tcrypto.DKShare
+}
+
+func NewBackup(chainID isc.ChainID, block state.Block, chainRecord *registry.ChainRecord, dkShares []tcrypto.DKShare) *Backup {
+	return &Backup{
+		Manifest: &Manifest{
+			ChainID:      chainID,
+			StateIndex:   block.StateIndex(),
+			L1Commitment: block.L1Commitment(),
+			CreatedAt:    time.Now(),
+		},
+		ChainRecord: chainRecord,
+		DKShares:    dkShares,
+	}
+}
+
+// Write writes the backup to `w` taking the snapshot of the state from the
+// store. The state must be present in the store. The DK shares are sealed with
+// `keyEncryption`, which is required, if there are any.
+func Write(w io.Writer, backup *Backup, store state.Store, keyEncryption *registry.KeyEncryption) error {
+	chainRecordBytes, err := json.Marshal(backup.ChainRecord)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chain record: %w", err)
+	}
+	if len(backup.DKShares) > 0 && keyEncryption == nil {
+		return ErrDKSharesNotEncrypted
+	}
+	sealedDKShares := make([][]byte, len(backup.DKShares))
+	for i, dkShare := range backup.DKShares {
+		if sealedDKShares[i], err = keyEncryption.Seal(dkShare.Bytes()); err != nil {
+			return fmt.Errorf("failed to encrypt DK share: %w", err)
+		}
+	}
+	ww := rwutil.NewWriter(w)
+	ww.WriteUint8(backupVersion)
+	ww.WriteN(backup.Manifest.ChainID.Bytes())
+	ww.WriteUint32(backup.Manifest.StateIndex)
+	ww.WriteBytes(backup.Manifest.L1Commitment.Bytes())
+	ww.WriteInt64(backup.Manifest.CreatedAt.UnixNano())
+	ww.WriteBytes(chainRecordBytes)
+	ww.WriteSize32(len(sealedDKShares))
+	for _, sealedDKShare := range sealedDKShares {
+		ww.WriteBytes(sealedDKShare)
+	}
+	if ww.Err != nil {
+		return ww.Err
+	}
+	return store.TakeSnapshot(backup.Manifest.L1Commitment.TrieRoot(), w)
+}
+
+// Read reads the manifest and the registry entries of the backup from `r`.
+// The DK shares are decrypted with `keyEncryption` and bound to the private key
+// of the node. Afterwards `restoreStateFun` is called to consume the rest of the
+// stream, which is the snapshot of the state; it is meant to be passed to
+// `RestoreState`.
+func Read(r io.Reader, nodePrivKey *cryptolib.PrivateKey, keyEncryption *registry.KeyEncryption, restoreStateFun func(backup *Backup, r io.Reader) error) (*Backup, error) {
+	rr := rwutil.NewReader(r)
+	version := rr.ReadUint8()
+	if rr.Err == nil && version != backupVersion {
+		return nil, fmt.Errorf("backup version mismatch: expected %v, got %v", backupVersion, version)
+	}
+	chainIDBytes := make([]byte, isc.ChainIDLength)
+	rr.ReadN(chainIDBytes)
+	stateIndex := rr.ReadUint32()
+	l1CommitmentBytes := rr.ReadBytes()
+	createdAt := rr.ReadInt64()
+	chainRecordBytes := rr.ReadBytes()
+	dkSharesBytes := make([][]byte, rr.ReadSize32())
+	for i := range dkSharesBytes {
+		dkSharesBytes[i] = rr.ReadBytes()
+	}
+	if rr.Err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", rr.Err)
+	}
+
+	chainID, err := isc.ChainIDFromBytes(chainIDBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse chain ID: %w", err)
+	}
+	l1Commitment, err := state.L1CommitmentFromBytes(l1CommitmentBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse L1 commitment: %w", err)
+	}
+	chainRecord := &registry.ChainRecord{}
+	if err = json.Unmarshal(chainRecordBytes, chainRecord); err != nil {
+		return nil, fmt.Errorf("failed to parse chain record: %w", err)
+	}
+	if !chainRecord.ChainID().Equals(chainID) {
+		return nil, errors.New("chain record does not belong to the chain of the backup")
+	}
+	dkShares := make([]tcrypto.DKShare, len(dkSharesBytes))
+	for i := range dkSharesBytes {
+		if !registry.IsEncrypted(dkSharesBytes[i]) {
+			return nil, errors.New("the DK shares of the backup are not encrypted")
+		}
+		dkShareBytes, err := keyEncryption.Open(dkSharesBytes[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt DK share: %w", err)
+		}
+		dkShares[i], err = tcrypto.DKShareFromBytes(dkShareBytes, tcrypto.DefaultEd25519Suite(), tcrypto.DefaultBLSSuite(), nodePrivKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse DK share: %w", err)
+		}
+	}
+	backup := &Backup{
+		Manifest: &Manifest{
+			ChainID:      chainID,
+			StateIndex:   stateIndex,
+			L1Commitment: l1Commitment,
+			CreatedAt:    time.Unix(0, createdAt),
+		},
+		ChainRecord: chainRecord,
+		DKShares:    dkShares,
+	}
+	if err = restoreStateFun(backup, r); err != nil {
+		return nil, err
+	}
+	return backup, nil
+}
+
+// VerifyManifest checks, that the state of the backup is the state anchored on
+// L1 or one of its predecessors. An older state is verified by following the
+// blocks available in the store back from the anchored state, a backup, which
+// cannot be linked to the anchored state that way, is refused.
+func VerifyManifest(store state.Store, manifest *Manifest, anchorStateIndex uint32, anchor *state.L1Commitment) error {
+	if manifest.StateIndex > anchorStateIndex {
+		return fmt.Errorf("backup state %v is newer than the state %v anchored on L1", manifest.StateIndex, anchorStateIndex)
+	}
+	commitment := anchor
+	for stateIndex := anchorStateIndex; stateIndex > manifest.StateIndex; stateIndex-- {
+		block, err := store.BlockByTrieRoot(commitment.TrieRoot())
+		if err != nil {
+			return fmt.Errorf("backup state %v cannot be linked to the state %v anchored on L1, block %v is not available: %w",
+				manifest.StateIndex, anchorStateIndex, stateIndex, err)
+		}
+		commitment = block.PreviousL1Commitment()
+		if commitment == nil {
+			return fmt.Errorf("block %v has no predecessor", stateIndex)
+		}
+	}
+	if !manifest.L1Commitment.Equals(commitment) {
+		return fmt.Errorf("backup commitment %s of state %v does not match the commitment %s linked to L1",
+			manifest.L1Commitment, manifest.StateIndex, commitment)
+	}
+	return nil
+}
+
+// RestoreState restores the snapshot of the state from `r` to the store and
+// checks, that the restored block matches the manifest of the backup.
+func RestoreState(store state.Store, backup *Backup, r io.Reader) error {
+	trieRoot := backup.Manifest.L1Commitment.TrieRoot()
+	if err := store.RestoreSnapshot(trieRoot, r); err != nil {
+		return fmt.Errorf("failed to restore state snapshot: %w", err)
+	}
+	block, err := store.BlockByTrieRoot(trieRoot)
+	if err != nil {
+		return fmt.Errorf("restored block not found: %w", err)
+	}
+	if block.StateIndex() != backup.Manifest.StateIndex || !block.L1Commitment().Equals(backup.Manifest.L1Commitment) {
+		return fmt.Errorf("restored block %v %s does not match the manifest: state index %v, commitment %s",
+			block.StateIndex(), block.L1Commitment(), backup.Manifest.StateIndex, backup.Manifest.L1Commitment)
+	}
+	if !store.HasTrieRoot(trieRoot) {
+		return fmt.Errorf("restored trie root %s not found", trieRoot)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package chainbackup
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/registry"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/tcrypto"
+	"github.com/iotaledger/wasp/packages/testutil/testpeers"
+)
+
+func TestBackupRestore(t *testing.T) {
+	factory := sm_gpa_utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(5, 1)
+	lastBlock := blocks[len(blocks)-1]
+	accessNode := cryptolib.NewKeyPair().GetPublicKey()
+	chainRecord := registry.NewChainRecord(factory.GetChainID(), true, []*cryptolib.PublicKey{accessNode})
+	backup := NewBackup(factory.GetChainID(), lastBlock, chainRecord, nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, backup, factory.GetStore(), nil))
+	backupBytes := buf.Bytes()
+
+	storeNew := state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+	backupRead, err := Read(bytes.NewReader(backupBytes), cryptolib.NewKeyPair().GetPrivateKey(), nil, func(backup *Backup, r io.Reader) error {
+		return RestoreState(storeNew, backup, r)
+	})
+	require.NoError(t, err)
+	require.True(t, backupRead.Manifest.ChainID.Equals(factory.GetChainID()))
+	require.Equal(t, lastBlock.StateIndex(), backupRead.Manifest.StateIndex)
+	require.True(t, backupRead.Manifest.L1Commitment.Equals(lastBlock.L1Commitment()))
+	require.Equal(t, backup.Manifest.CreatedAt.UnixNano(), backupRead.Manifest.CreatedAt.UnixNano())
+	require.True(t, backupRead.ChainRecord.ChainID().Equals(factory.GetChainID()))
+	require.Len(t, backupRead.ChainRecord.AccessNodes, 1)
+	require.True(t, backupRead.ChainRecord.AccessNodes[0].Equals(accessNode))
+	require.Empty(t, backupRead.DKShares)
+	sm_gpa_utils.CheckBlockInStore(t, storeNew, lastBlock)
+	sm_gpa_utils.CheckStateInStores(t, factory.GetStore(), storeNew, lastBlock.L1Commitment())
+
+	// Corrupted backup is rejected
+	backupBytes[len(backupBytes)-1] ^= 0xFF
+	_, err = Read(bytes.NewReader(backupBytes), cryptolib.NewKeyPair().GetPrivateKey(), nil, func(backup *Backup, r io.Reader) error {
+		return RestoreState(state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB()), backup, r)
+	})
+	require.Error(t, err)
+}
+
+func TestBackupDKSharesEncrypted(t *testing.T) {
+	factory := sm_gpa_utils.NewBlockFactory(t)
+	lastBlock := factory.GetBlocks(1, 1)[0]
+	_, peerIdentities := testpeers.SetupKeys(1)
+	cmtAddress, dkShareProviders := testpeers.SetupDkgTrivial(t, 1, 0, peerIdentities, nil)
+	dkShare, err := dkShareProviders[0].LoadDKShare(cmtAddress)
+	require.NoError(t, err)
+	chainRecord := registry.NewChainRecord(factory.GetChainID(), true, nil)
+	backup := NewBackup(factory.GetChainID(), lastBlock, chainRecord, []tcrypto.DKShare{dkShare})
+	nodePrivKey := peerIdentities[0].GetPrivateKey()
+
+	// The DK shares are never written in plain text
+	require.ErrorIs(t, Write(io.Discard, backup, factory.GetStore(), nil), ErrDKSharesNotEncrypted)
+
+	keyEncryption, err := registry.NewKeyEncryption([]byte("secret"))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, backup, factory.GetStore(), keyEncryption))
+	require.False(t, bytes.Contains(buf.Bytes(), dkShare.Bytes()))
+
+	restore := func(backup *Backup, r io.Reader) error {
+		return RestoreState(state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB()), backup, r)
+	}
+	backupRead, err := Read(bytes.NewReader(buf.Bytes()), nodePrivKey, keyEncryption, restore)
+	require.NoError(t, err)
+	require.Len(t, backupRead.DKShares, 1)
+	require.Equal(t, dkShare.Bytes(), backupRead.DKShares[0].Bytes())
+
+	otherKeyEncryption, err := registry.NewKeyEncryption([]byte("other"))
+	require.NoError(t, err)
+	_, err = Read(bytes.NewReader(buf.Bytes()), nodePrivKey, otherKeyEncryption, restore)
+	require.ErrorIs(t, err, registry.ErrKeysWrongSecret)
+	_, err = Read(bytes.NewReader(buf.Bytes()), nodePrivKey, nil, restore)
+	require.ErrorIs(t, err, registry.ErrKeysLocked)
+}
+
+func TestVerifyManifest(t *testing.T) {
+	factory := sm_gpa_utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(5, 1)
+	anchor := blocks[len(blocks)-1]
+	chainRecord := registry.NewChainRecord(factory.GetChainID(), true, nil)
+	manifestOf := func(block state.Block) *Manifest {
+		return NewBackup(factory.GetChainID(), block, chainRecord, nil).Manifest
+	}
+
+	// The anchored state and its predecessors are accepted
+	for _, block := range blocks {
+		require.NoError(t, VerifyManifest(factory.GetStore(), manifestOf(block), anchor.StateIndex(), anchor.L1Commitment()))
+	}
+	// The anchored state is accepted even if the store has no blocks
+	emptyStore := state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+	require.NoError(t, VerifyManifest(emptyStore, manifestOf(anchor), anchor.StateIndex(), anchor.L1Commitment()))
+	// An older state cannot be verified without the blocks linking it to the anchored state
+	require.Error(t, VerifyManifest(emptyStore, manifestOf(blocks[1]), anchor.StateIndex(), anchor.L1Commitment()))
+	// A newer state is refused
+	require.Error(t, VerifyManifest(factory.GetStore(), manifestOf(anchor), blocks[2].StateIndex(), blocks[2].L1Commitment()))
+	// An older state with a forged commitment is refused
+	forged := manifestOf(blocks[1])
+	forged.L1Commitment = blocks[2].L1Commitment()
+	require.Error(t, VerifyManifest(factory.GetStore(), forged, anchor.StateIndex(), anchor.L1Commitment()))
+}
@@ -17,8 +17,10 @@ import (
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/chain/cmt_log"
+	"github.com/iotaledger/wasp/packages/chain/mempool"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
+	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_pruning"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_snapshots"
 	"github.com/iotaledger/wasp/packages/chains/access_mgr"
 	"github.com/iotaledger/wasp/packages/cryptolib"
@@ -54,6 +56,7 @@ type Chains struct {
 	trustedNetworkManager        peering.TrustedNetworkManager
 	trustedNetworkListenerCancel context.CancelFunc
 	chainStateStoreProvider      database.ChainStateKVStoreProvider
+	chainStateFlushProvider      database.ChainStateFlushProvider
 
 	walLoadToStore                      bool
 	walEnabled                          bool
@@ -70,8 +73,19 @@ type Chains struct {
 	snapshotsToLoad                     map[isc.ChainIDKey]state.BlockHash
 	snapshotPeriod                      uint32
 	snapshotDelay                       uint32
+	snapshotDeltaPeriod                 uint32
 	snapshotFolderPath                  string
 	snapshotNetworkPaths                []string
+	snapshotUploadPaths                 []string
+	snapshotRequireSignedManifests      bool
+	pruningPolicy                       sm_pruning.Policy
+	pruningKeepLastBlocks               uint32
+	pruningKeepTimeWindow               time.Duration
+	pruningCheckpointPeriod             uint32
+	pruningPeriod                       time.Duration
+	pruningMaxStatesPerSecond           int
+	pruningFlushThreshold               int
+	archiveMode                         bool
 
 	chainRecordRegistryProvider registry.ChainRecordRegistryProvider
 	dkShareRegistryProvider     registry.DKShareRegistryProvider
@@ -92,6 +106,12 @@ type Chains struct {
 
 	mempoolTTL               time.Duration
 	mempoolBroadcastInterval time.Duration
+	mempoolJournalEnabled    bool
+
+	mempoolDefaultOrderingPolicy mempool.OrderingPolicy
+	mempoolOrderingPolicies      map[isc.ChainIDKey]mempool.OrderingPolicy
+	mempoolMaxProposalSize       int
+	mempoolMaxRequestsPerAccount int
 }
 
 type activeChain struct {
@@ -111,6 +131,7 @@ func New(
 	networkProvider peering.NetworkProvider,
 	trustedNetworkManager peering.TrustedNetworkManager,
 	chainStateStoreProvider database.ChainStateKVStoreProvider,
+	chainStateFlushProvider database.ChainStateFlushProvider,
 	walLoadToStore bool,
 	walEnabled bool,
 	walFolderPath string,
@@ -125,8 +146,19 @@ func New(
 	snapshotsToLoad []string,
 	snapshotPeriod uint32,
 	snapshotDelay uint32,
+	snapshotDeltaPeriod uint32,
 	snapshotFolderPath string,
 	snapshotNetworkPaths []string,
+	snapshotUploadPaths []string,
+	snapshotRequireSignedManifests bool,
+	pruningPolicy string,
+	pruningKeepLastBlocks uint32,
+	pruningKeepTimeWindow time.Duration,
+	pruningCheckpointPeriod uint32,
+	pruningPeriod time.Duration,
+	pruningMaxStatesPerSecond int,
+	pruningFlushThreshold int,
+	archiveMode bool,
 	chainRecordRegistryProvider registry.ChainRecordRegistryProvider,
 	dkShareRegistryProvider registry.DKShareRegistryProvider,
 	nodeIdentityProvider registry.NodeIdentityProvider,
@@ -134,6 +166,10 @@ func New(
 	chainListener chain.ChainListener,
 	mempoolTTL time.Duration,
 	mempoolBroadcastInterval time.Duration,
+	mempoolJournalEnabled bool,
+	mempoolOrderingPolicies []string,
+	mempoolMaxProposalSize int,
+	mempoolMaxRequestsPerAccount int,
 	shutdownCoordinator *shutdown.Coordinator,
 	chainMetricsProvider *metrics.ChainMetricsProvider,
 ) *Chains {
@@ -148,6 +184,15 @@ func New(
 		}
 		validatorFeeAddr = addr
 	}
+	pruningPolicyParsed, err := sm_pruning.PolicyFromString(pruningPolicy)
+	if err != nil {
+		panic(fmt.Errorf("error parsing pruning.policy: %w", err))
+	}
+	if archiveMode {
+		log.Infof("archive mode is enabled, the states of the chains will not be pruned")
+		pruningPolicyParsed = sm_pruning.PolicyNone
+		smPruningMinStatesToKeep = 0
+	}
 	ret := &Chains{
 		log:                                 log,
 		mutex:                               &sync.RWMutex{},
@@ -161,6 +206,7 @@ func New(
 		networkProvider:                     networkProvider,
 		trustedNetworkManager:               trustedNetworkManager,
 		chainStateStoreProvider:             chainStateStoreProvider,
+		chainStateFlushProvider:             chainStateFlushProvider,
 		walLoadToStore:                      walLoadToStore,
 		walEnabled:                          walEnabled,
 		walFolderPath:                       walFolderPath,
@@ -174,20 +220,37 @@ func New(
 		smPruningMaxStatesToDelete:          smPruningMaxStatesToDelete,
 		snapshotPeriod:                      snapshotPeriod,
 		snapshotDelay:                       snapshotDelay,
+		snapshotDeltaPeriod:                 snapshotDeltaPeriod,
 		snapshotFolderPath:                  snapshotFolderPath,
 		snapshotNetworkPaths:                snapshotNetworkPaths,
+		snapshotUploadPaths:                 snapshotUploadPaths,
+		snapshotRequireSignedManifests:      snapshotRequireSignedManifests,
+		pruningPolicy:                       pruningPolicyParsed,
+		pruningKeepLastBlocks:               pruningKeepLastBlocks,
+		pruningKeepTimeWindow:               pruningKeepTimeWindow,
+		pruningCheckpointPeriod:             pruningCheckpointPeriod,
+		pruningPeriod:                       pruningPeriod,
+		pruningMaxStatesPerSecond:           pruningMaxStatesPerSecond,
+		pruningFlushThreshold:               pruningFlushThreshold,
+		archiveMode:                         archiveMode,
 		chainRecordRegistryProvider:         chainRecordRegistryProvider,
 		dkShareRegistryProvider:             dkShareRegistryProvider,
 		nodeIdentityProvider:                nodeIdentityProvider,
 		chainListener:                       nil, // See bellow.
 		mempoolTTL:                          mempoolTTL,
 		mempoolBroadcastInterval:            mempoolBroadcastInterval,
+		mempoolJournalEnabled:               mempoolJournalEnabled,
+		mempoolMaxProposalSize:              mempoolMaxProposalSize,
+		mempoolMaxRequestsPerAccount:        mempoolMaxRequestsPerAccount,
 		consensusStateRegistry:              consensusStateRegistry,
 		shutdownCoordinator:                 shutdownCoordinator,
 		chainMetricsProvider:                chainMetricsProvider,
 		validatorFeeAddr:                    validatorFeeAddr,
 	}
 	ret.initSnapshotsToLoad(snapshotsToLoad)
+	if err := ret.initMempoolOrderingPolicies(mempoolOrderingPolicies); err != nil {
+		panic(fmt.Errorf("error parsing mempool ordering policy: %w", err))
+	}
 	ret.chainListener = NewChainsListener(chainListener, ret.chainAccessUpdatedCB)
 	return ret
 }
@@ -221,6 +284,43 @@ func (c *Chains) initSnapshotsToLoad(configs []string) {
 	}
 }
 
+func (c *Chains) initMempoolOrderingPolicies(configs []string) error {
+	c.mempoolDefaultOrderingPolicy = mempool.OrderingFIFO
+	c.mempoolOrderingPolicies = make(map[isc.ChainIDKey]mempool.OrderingPolicy)
+	for _, config := range configs {
+		chainIDStr, policyStr, found := strings.Cut(config, ":")
+		if !found {
+			policy, err := mempool.OrderingPolicyFromString(config)
+			if err != nil {
+				return err
+			}
+			c.mempoolDefaultOrderingPolicy = policy
+			continue
+		}
+		chainID, err := isc.ChainIDFromString(chainIDStr)
+		if err != nil {
+			return fmt.Errorf("%s in %s is not a chain ID: %w", chainIDStr, config, err)
+		}
+		policy, err := mempool.OrderingPolicyFromString(policyStr)
+		if err != nil {
+			return err
+		}
+		c.mempoolOrderingPolicies[chainID.Key()] = policy
+	}
+	return nil
+}
+
+func (c *Chains) mempoolOrdering(chainID isc.ChainID) mempool.OrderingParameters {
+	ordering := mempool.NewOrderingParameters()
+	ordering.Policy = c.mempoolDefaultOrderingPolicy
+	if policy, ok := c.mempoolOrderingPolicies[chainID.Key()]; ok {
+		ordering.Policy = policy
+	}
+	ordering.MaxProposalSize = c.mempoolMaxProposalSize
+	ordering.MaxRequestsPerAccount = c.mempoolMaxRequestsPerAccount
+	return ordering
+}
+
 func (c *Chains) Run(ctx context.Context) error {
 	if err := c.nodeConnection.WaitUntilInitiallySynced(ctx); err != nil {
 		return fmt.Errorf("waiting for L1 node to become sync failed, error: %w", err)
@@ -353,6 +453,9 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID) error { //nolint:fu
 	stateManagerParameters.StateManagerRequestCleaningPeriod = c.smStateManagerRequestCleaningPeriod
 	stateManagerParameters.StateManagerTimerTickPeriod = c.smStateManagerTimerTickPeriod
 	stateManagerParameters.PruningMinStatesToKeep = c.smPruningMinStatesToKeep
+	if c.pruningPolicy != sm_pruning.PolicyNone {
+		stateManagerParameters.PruningMinStatesToKeep = 0 // Store is pruned by the pruning service
+	}
 	stateManagerParameters.PruningMaxStatesToDelete = c.smPruningMaxStatesToDelete
 
 	// Initialize Snapshotter
@@ -377,9 +480,14 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID) error { //nolint:fu
 		snapshotToLoad,
 		c.snapshotPeriod,
 		c.snapshotDelay,
+		c.snapshotDeltaPeriod,
 		c.snapshotFolderPath,
 		c.snapshotNetworkPaths,
+		c.snapshotUploadPaths,
+		c.snapshotRequireSignedManifests,
 		chainStore,
+		c.nodeIdentityProvider.NodeIdentity(),
+		c.trustedNetworkManager,
 		chainMetrics.Snapshots,
 		chainLog,
 	)
@@ -387,6 +495,29 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID) error { //nolint:fu
 		panic(fmt.Errorf("cannot create Snapshotter: %w", err))
 	}
 
+	if c.pruningPolicy != sm_pruning.PolicyNone {
+		sm_pruning.New(
+			chainCtx,
+			chainShutdownCoordinator.Nested("Pruning"),
+			chainStore,
+			c.pruningParameters(),
+			func() error { return c.chainStateFlushProvider(chainID) },
+			chainMetrics.Pruning,
+			chainLog,
+		)
+	}
+
+	var mempoolJournal mempool.OffLedgerJournal
+	if c.mempoolJournalEnabled {
+		mempoolJournal, err = mempool.NewOffLedgerJournal(chainKVStore, chainLog.Named("MPJ"))
+		if err != nil {
+			chainCancel()
+			return fmt.Errorf("cannot create mempool journal: %w", err)
+		}
+	} else {
+		mempoolJournal = mempool.NewEmptyOffLedgerJournal()
+	}
+
 	newChain, err := chain.New(
 		chainCtx,
 		chainLog,
@@ -415,6 +546,8 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID) error { //nolint:fu
 		stateManagerParameters,
 		c.mempoolTTL,
 		c.mempoolBroadcastInterval,
+		c.mempoolOrdering(chainID),
+		mempoolJournal,
 	)
 	if err != nil {
 		chainCancel()
@@ -471,10 +604,25 @@ func (c *Chains) Get(chainID isc.ChainID) (chain.Chain, error) {
 	return ret.chain, nil
 }
 
+// pruningParameters returns the parameters of the pruning service. The state manager's
+// `PruningMinStatesToKeep` is not applied here, the service keeps the states according
+// to its own policy, the block keep amount of the chain and the latest state.
+func (c *Chains) pruningParameters() sm_pruning.Parameters {
+	pruningParameters := sm_pruning.NewParameters()
+	pruningParameters.Policy = c.pruningPolicy
+	pruningParameters.KeepLastBlocks = c.pruningKeepLastBlocks
+	pruningParameters.KeepTimeWindow = c.pruningKeepTimeWindow
+	pruningParameters.CheckpointPeriod = c.pruningCheckpointPeriod
+	pruningParameters.Period = c.pruningPeriod
+	pruningParameters.MaxStatesPerSecond = c.pruningMaxStatesPerSecond
+	pruningParameters.FlushThreshold = c.pruningFlushThreshold
+	return pruningParameters
+}
+
 func (c *Chains) ValidatorAddress() iotago.Address {
 	return c.validatorFeeAddr
 }
 
 func (c *Chains) IsArchiveNode() bool {
-	return c.smPruningMinStatesToKeep < 1
+	return c.smPruningMinStatesToKeep < 1 && c.pruningPolicy == sm_pruning.PolicyNone
 }
@@ -0,0 +1,40 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package chains
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_pruning"
+)
+
+// The state manager's `PruningMinStatesToKeep` (10000 by default) must not
+// keep more states than the pruning service policy asks for.
+func TestPruningParameters(t *testing.T) {
+	c := &Chains{
+		smPruningMinStatesToKeep:  10000,
+		pruningPolicy:             sm_pruning.PolicyKeepLastBlocks,
+		pruningKeepLastBlocks:     100,
+		pruningKeepTimeWindow:     time.Hour,
+		pruningCheckpointPeriod:   1000,
+		pruningPeriod:             time.Minute,
+		pruningMaxStatesPerSecond: 10,
+		pruningFlushThreshold:     500,
+	}
+	parameters := c.pruningParameters()
+	require.Equal(t, sm_pruning.PolicyKeepLastBlocks, parameters.Policy)
+	require.EqualValues(t, 100, parameters.KeepLastBlocks)
+	require.EqualValues(t, 1, parameters.MinStatesToKeep)
+	require.Equal(t, time.Hour, parameters.KeepTimeWindow)
+	require.EqualValues(t, 1000, parameters.CheckpointPeriod)
+	require.Equal(t, time.Minute, parameters.Period)
+	require.Equal(t, 10, parameters.MaxStatesPerSecond)
+	require.Equal(t, 500, parameters.FlushThreshold)
+
+	c.pruningPolicy = sm_pruning.PolicyKeepTimeWindow
+	require.EqualValues(t, 1, c.pruningParameters().MinStatesToKeep)
+}
@@ -1,12 +1,17 @@
 package chainutil
 
 import (
+	"errors"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
 func EVMTraceTransaction(
@@ -25,8 +30,68 @@ func EVMTraceTransaction(
 		false,
 		&isc.EVMTracer{
 			Tracer:  tracer,
-			TxIndex: txIndex,
+			TxIndex: &txIndex,
 		},
 	)
 	return err
 }
+
+// EVMTraceBlock re-runs all requests of a block, executing all EVM txs
+// with the given tracer. onTxStart is called with the hash of each EVM tx
+// before it is traced. EVM calls made by non-EVM requests are not traced.
+func EVMTraceBlock(
+	ch chain.ChainCore,
+	aliasOutput *isc.AliasOutputWithID,
+	blockTime time.Time,
+	iscRequestsInBlock []isc.Request,
+	tracer tracers.Tracer,
+	onTxStart func(txHash common.Hash),
+) error {
+	_, err := runISCTask(
+		ch,
+		aliasOutput,
+		blockTime,
+		iscRequestsInBlock,
+		false,
+		&isc.EVMTracer{Tracer: tracer, OnTxStart: onTxStart},
+	)
+	return err
+}
+
+// EVMTraceCall executes an EVM contract call with the given tracer,
+// discarding any state changes
+func EVMTraceCall(
+	ch chain.ChainCore,
+	aliasOutput *isc.AliasOutputWithID,
+	call ethereum.CallMsg,
+	tracer tracers.Tracer,
+) error {
+	info := getChainInfo(ch)
+
+	gasLimit := gas.EVMCallGasLimit(info.GasLimits, &info.GasFeePolicy.EVMGasRatio)
+	if call.Gas != 0 && call.Gas > gasLimit {
+		call.Gas = gasLimit
+	}
+
+	if call.GasPrice == nil {
+		call.GasPrice = info.GasFeePolicy.GasPriceWei(parameters.L1().BaseToken.Decimals)
+	}
+
+	iscReq := isc.NewEVMOffLedgerCallRequest(ch.ID(), call)
+	results, err := runISCTask(
+		ch,
+		aliasOutput,
+		time.Now(),
+		[]isc.Request{iscReq},
+		true,
+		&isc.EVMTracer{Tracer: tracer},
+	)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return errors.New("request was skipped")
+	}
+	// a reverted call is still a valid trace, so the receipt error is not returned
+	return nil
+}
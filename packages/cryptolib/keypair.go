@@ -44,6 +44,10 @@ func (k *KeyPair) AsAddressSigner() iotago.AddressSigner {
 	return iotago.NewInMemoryAddressSigner(addrKeys)
 }
 
+func (k *KeyPair) SignBytes(data []byte) []byte {
+	return k.privateKey.Sign(data)
+}
+
 func (k *KeyPair) GetPrivateKey() *PrivateKey {
 	return k.privateKey
 }
@@ -0,0 +1,17 @@
+package cryptolib
+
+import (
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// VariantKeyPair is a key pair which is able to sign, but does not necessarily expose its private key,
+// e.g. because the key is kept in an encrypted keystore or by an external signer.
+// KeyPair is the in-memory implementation.
+type VariantKeyPair interface {
+	GetPublicKey() *PublicKey
+	Address() *iotago.Ed25519Address
+	AsAddressSigner() iotago.AddressSigner
+	SignBytes(data []byte) []byte
+}
+
+var _ VariantKeyPair = &KeyPair{}
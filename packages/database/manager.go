@@ -22,6 +22,8 @@ const (
 
 type ChainStateKVStoreProvider func(chainID isc.ChainID) (kvstore.KVStore, *sync.Mutex, error)
 
+type ChainStateFlushProvider func(chainID isc.ChainID) error
+
 type ChainStateDatabaseManager struct {
 	mutex sync.RWMutex
 
@@ -143,6 +145,20 @@ func (m *ChainStateDatabaseManager) ChainStateKVStore(chainID isc.ChainID) (kvst
 	return databaseChainState.database.KVStore(), &databaseChainState.database.writeMutex, nil
 }
 
+// FlushChainState flushes the chain state database. With RocksDB the deleted
+// entries are then removed from the disk by the background compactions.
+func (m *ChainStateDatabaseManager) FlushChainState(chainID isc.ChainID) error {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	databaseChainState, exists := m.databases[chainID]
+	if !exists {
+		return fmt.Errorf("chain state database of chain %s does not exist", chainID)
+	}
+
+	return databaseChainState.database.KVStore().Flush()
+}
+
 func (m *ChainStateDatabaseManager) FlushAndCloseStores() error {
 	var err error
 
@@ -8,6 +8,12 @@
 //
 // Implementation is based on <https://github.com/dedis/kyber/blob/master/share/dkg/rabin/dkg.go>
 // which is based on <https://link.springer.com/article/10.1007/s00145-006-0347-3>.
+//
+// The DKG is started by a user authorized to do so on the initiator node. The peering
+// layer authenticates the nodes, so a node knows which peer has sent the init message,
+// and takes part in the procedure only if that peer is the initiator, and if the initiator
+// as well as all the participants are in its trusted peers list. Every procedure is recorded
+// in the DKG audit log of each node, see registry.DKGAuditLogProvider. The peers can only
+// attribute it to the initiator node, the name of the user, that started the procedure,
+// is not sent to them and is recorded by the initiator node only.
 package dkg
-
-// TODO: Only authenticated nodes can initiate (and participate in?) the DKG.
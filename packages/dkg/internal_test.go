@@ -0,0 +1,52 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package dkg
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/registry"
+	"github.com/iotaledger/wasp/packages/testutil"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+)
+
+// TestAuditedFailures checks, that the repeated failures are recorded once per procedure,
+// and that the procedures are forgotten, when they succeed or are not retried anymore.
+func TestAuditedFailures(t *testing.T) {
+	auditLog := testutil.NewDKGAuditLog()
+	n := &Node{
+		auditLog:        auditLog,
+		auditedFailures: map[string]*procedureFailures{},
+		auditLock:       &sync.Mutex{},
+		log:             testlogger.NewLogger(t),
+	}
+	failure := func(procedureID, err string, ts time.Time) {
+		n.auditFirstFailure(&registry.DKGAuditEntry{Time: ts, Procedure: registry.DKGProcedureReshare, ProcedureID: procedureID, Error: err})
+	}
+	audit := func(procedureID string, err error) {
+		n.audit(&registry.DKGAuditEntry{Procedure: registry.DKGProcedureReshare, ProcedureID: procedureID}, nil, err)
+	}
+
+	errFailed := errors.New("failed")
+	audit("a", errFailed)
+	audit("a", errFailed)
+	audit("b", errFailed)
+	require.Len(t, auditLog.DKGAuditEntries(), 2)
+	require.Len(t, n.auditedFailures, 2)
+
+	// The failures are forgotten, when the procedure succeeds.
+	audit("a", nil)
+	require.Len(t, auditLog.DKGAuditEntries(), 3)
+	require.Len(t, n.auditedFailures, 1)
+
+	// And when the procedure is not retried for a while.
+	failure("c", errFailed.Error(), time.Now().Add(auditedFailuresRetention+time.Minute))
+	require.Len(t, n.auditedFailures, 1)
+	require.Contains(t, n.auditedFailures, registry.DKGProcedureReshare+"//c")
+}
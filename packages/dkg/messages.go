@@ -34,6 +34,12 @@ const (
 	// because it is used to start new chain, thus peeringID is not used for message recognition.
 	initiatorInitMsgType = peering.FirstUserMsgCode + 184 // Initiator -> Peer: init new DKG, reply with initiatorStatusMsgType.
 	//
+	// Initiator <-> Peer node communication for resharing of an existing key.
+	// As the initiatorInitMsgType, these are not bound to a peeringID.
+	reshareDealMsgType   = peering.FirstUserMsgCode + 185 // Initiator -> Peer of the current committee: deal the key share, reply with reshareDealtMsgType.
+	reshareCommitMsgType = peering.FirstUserMsgCode + 186 // Initiator -> Peer of the next committee: combine the deals, reply with initiatorPubShareMsgType.
+	reshareDealtMsgType  = peering.FirstUserMsgCode + 187 // Peer -> Initiator: the deals for the next committee.
+	//
 	// Initiator <-> Peer proc communication.
 	initiatorMsgBase         = peering.FirstUserMsgCode + 4 // 4 to align with round numbers.
 	initiatorStepMsgType     = initiatorMsgBase + 1         // Initiator -> Peer: start new step, reply with initiatorStatusMsgType.
@@ -154,6 +160,12 @@ func readInitiatorMsg(peerMessage *peering.PeerMessageData, edSuite, blsSuite ky
 		msg = &initiatorPubShareMsg{edSuite: edSuite, blsSuite: blsSuite}
 	case initiatorStatusMsgType:
 		msg = new(initiatorStatusMsg)
+	case reshareDealMsgType:
+		msg = new(reshareDealMsg)
+	case reshareCommitMsgType:
+		msg = &reshareCommitMsg{edSuite: edSuite, blsSuite: blsSuite}
+	case reshareDealtMsgType:
+		msg = &reshareDealtMsg{edSuite: edSuite, blsSuite: blsSuite}
 	default:
 		return nil, nil
 	}
@@ -168,7 +180,8 @@ type initiatorInitMsgIn struct {
 // initiatorInitMsg
 //
 // This is a message sent by the initiator to all the peers to
-// initiate the DKG process.
+// initiate the DKG process. The message is not signed, the peering
+// layer authenticates its sender, which must be the initiator.
 type initiatorInitMsg struct {
 	step         byte
 	dkgRef       string // Some unique string to identify duplicate initialization.
@@ -469,6 +482,257 @@ func (msg *initiatorStatusMsg) IsResponse() bool {
 	return true
 }
 
+// reshareDealMsg
+//
+// This is a message sent by the initiator to the members of the
+// current committee to deal their key shares to the next committee.
+type reshareDealMsg struct {
+	step          byte
+	reshareID     peering.PeeringID
+	sharedAddress iotago.Address
+	peerPubs      []*cryptolib.PublicKey // The next committee.
+	threshold     uint16                 // Threshold of the next committee.
+}
+
+var _ initiatorMsg = new(reshareDealMsg)
+
+func (msg *reshareDealMsg) MsgType() byte {
+	return reshareDealMsgType
+}
+
+func (msg *reshareDealMsg) Step() byte {
+	return msg.step
+}
+
+func (msg *reshareDealMsg) SetStep(step byte) {
+	msg.step = step
+}
+
+func (msg *reshareDealMsg) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	msg.step = rr.ReadByte()
+	rr.ReadN(msg.reshareID[:])
+	msg.sharedAddress = isc.AddressFromReader(rr)
+	msg.peerPubs = readPublicKeys(rr)
+	msg.threshold = rr.ReadUint16()
+	return rr.Err
+}
+
+func (msg *reshareDealMsg) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteByte(msg.step)
+	ww.WriteN(msg.reshareID[:])
+	isc.AddressToWriter(ww, msg.sharedAddress)
+	writePublicKeys(ww, msg.peerPubs)
+	ww.WriteUint16(msg.threshold)
+	return ww.Err
+}
+
+func (msg *reshareDealMsg) Error() error {
+	return nil
+}
+
+func (msg *reshareDealMsg) IsResponse() bool {
+	return false
+}
+
+// reshareDealtMsg
+//
+// This is a response of a member of the current committee to the reshareDealMsg.
+// The shares are encrypted with the public keys of the next committee members,
+// thus the initiator cannot read them.
+type reshareDealtMsg struct {
+	step            byte
+	edCommits       []kyber.Point
+	blsCommits      []kyber.Point
+	encryptedShares [][]byte    // For each member of the next committee.
+	edSuite         kyber.Group // Transient, for un-marshaling only.
+	blsSuite        kyber.Group // Transient, for un-marshaling only.
+}
+
+var _ initiatorMsg = new(reshareDealtMsg)
+
+func (msg *reshareDealtMsg) MsgType() byte {
+	return reshareDealtMsgType
+}
+
+func (msg *reshareDealtMsg) Step() byte {
+	return msg.step
+}
+
+func (msg *reshareDealtMsg) SetStep(step byte) {
+	msg.step = step
+}
+
+func (msg *reshareDealtMsg) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	msg.step = rr.ReadByte()
+	msg.edCommits = readPoints(rr, msg.edSuite)
+	msg.blsCommits = readPoints(rr, msg.blsSuite)
+	size := rr.ReadSize16()
+	msg.encryptedShares = make([][]byte, size)
+	for i := range msg.encryptedShares {
+		msg.encryptedShares[i] = rr.ReadBytes()
+	}
+	return rr.Err
+}
+
+func (msg *reshareDealtMsg) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteByte(msg.step)
+	writePoints(ww, msg.edCommits)
+	writePoints(ww, msg.blsCommits)
+	ww.WriteSize16(len(msg.encryptedShares))
+	for i := range msg.encryptedShares {
+		ww.WriteBytes(msg.encryptedShares[i])
+	}
+	return ww.Err
+}
+
+func (msg *reshareDealtMsg) Error() error {
+	return nil
+}
+
+func (msg *reshareDealtMsg) IsResponse() bool {
+	return true
+}
+
+// reshareCommitMsg
+//
+// This is a message sent by the initiator to the members of the next committee.
+// It contains the public part of the current DKShare and the deals of all the
+// members of the current committee for the receiver.
+type reshareCommitMsg struct {
+	step            byte
+	reshareID       peering.PeeringID
+	sharedAddress   iotago.Address
+	peerPubs        []*cryptolib.PublicKey // The next committee.
+	threshold       uint16                 // Threshold of the next committee.
+	currentPubs     []*cryptolib.PublicKey // The current committee.
+	currentT        uint16
+	currentBLST     uint16
+	edSharedPublic  kyber.Point
+	edPublicShares  []kyber.Point
+	blsSharedPublic kyber.Point
+	blsPublicShares []kyber.Point
+	deals           []*reshareEncryptedDeal
+	edSuite         kyber.Group // Transient, for un-marshaling only.
+	blsSuite        kyber.Group // Transient, for un-marshaling only.
+}
+
+type reshareEncryptedDeal struct {
+	dealer          uint16
+	edCommits       []kyber.Point
+	blsCommits      []kyber.Point
+	encryptedShares []byte
+}
+
+var _ initiatorMsg = new(reshareCommitMsg)
+
+func (msg *reshareCommitMsg) MsgType() byte {
+	return reshareCommitMsgType
+}
+
+func (msg *reshareCommitMsg) Step() byte {
+	return msg.step
+}
+
+func (msg *reshareCommitMsg) SetStep(step byte) {
+	msg.step = step
+}
+
+func (msg *reshareCommitMsg) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	msg.step = rr.ReadByte()
+	rr.ReadN(msg.reshareID[:])
+	msg.sharedAddress = isc.AddressFromReader(rr)
+	msg.peerPubs = readPublicKeys(rr)
+	msg.threshold = rr.ReadUint16()
+	msg.currentPubs = readPublicKeys(rr)
+	msg.currentT = rr.ReadUint16()
+	msg.currentBLST = rr.ReadUint16()
+	msg.edSharedPublic = cryptolib.PointFromReader(rr, msg.edSuite)
+	msg.edPublicShares = readPoints(rr, msg.edSuite)
+	msg.blsSharedPublic = cryptolib.PointFromReader(rr, msg.blsSuite)
+	msg.blsPublicShares = readPoints(rr, msg.blsSuite)
+	size := rr.ReadSize16()
+	msg.deals = make([]*reshareEncryptedDeal, size)
+	for i := range msg.deals {
+		msg.deals[i] = &reshareEncryptedDeal{
+			dealer:          rr.ReadUint16(),
+			edCommits:       readPoints(rr, msg.edSuite),
+			blsCommits:      readPoints(rr, msg.blsSuite),
+			encryptedShares: rr.ReadBytes(),
+		}
+	}
+	return rr.Err
+}
+
+func (msg *reshareCommitMsg) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteByte(msg.step)
+	ww.WriteN(msg.reshareID[:])
+	isc.AddressToWriter(ww, msg.sharedAddress)
+	writePublicKeys(ww, msg.peerPubs)
+	ww.WriteUint16(msg.threshold)
+	writePublicKeys(ww, msg.currentPubs)
+	ww.WriteUint16(msg.currentT)
+	ww.WriteUint16(msg.currentBLST)
+	cryptolib.PointToWriter(ww, msg.edSharedPublic)
+	writePoints(ww, msg.edPublicShares)
+	cryptolib.PointToWriter(ww, msg.blsSharedPublic)
+	writePoints(ww, msg.blsPublicShares)
+	ww.WriteSize16(len(msg.deals))
+	for _, deal := range msg.deals {
+		ww.WriteUint16(deal.dealer)
+		writePoints(ww, deal.edCommits)
+		writePoints(ww, deal.blsCommits)
+		ww.WriteBytes(deal.encryptedShares)
+	}
+	return ww.Err
+}
+
+func (msg *reshareCommitMsg) Error() error {
+	return nil
+}
+
+func (msg *reshareCommitMsg) IsResponse() bool {
+	return false
+}
+
+func readPublicKeys(rr *rwutil.Reader) []*cryptolib.PublicKey {
+	size := rr.ReadSize16()
+	ret := make([]*cryptolib.PublicKey, size)
+	for i := range ret {
+		ret[i] = cryptolib.NewEmptyPublicKey()
+		rr.Read(ret[i])
+	}
+	return ret
+}
+
+func writePublicKeys(ww *rwutil.Writer, pubKeys []*cryptolib.PublicKey) {
+	ww.WriteSize16(len(pubKeys))
+	for i := range pubKeys {
+		ww.Write(pubKeys[i])
+	}
+}
+
+func readPoints(rr *rwutil.Reader, suite kyber.Group) []kyber.Point {
+	size := rr.ReadSize16()
+	ret := make([]kyber.Point, size)
+	for i := range ret {
+		ret[i] = cryptolib.PointFromReader(rr, suite)
+	}
+	return ret
+}
+
+func writePoints(ww *rwutil.Writer, points []kyber.Point) {
+	ww.WriteSize16(len(points))
+	for i := range points {
+		cryptolib.PointToWriter(ww, points[i])
+	}
+}
+
 // rabin_dkg.Deal
 type rabinDealMsg struct {
 	step byte
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/iotaledger/hive.go/ds/shrinkingmap"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/peering"
 	"github.com/iotaledger/wasp/packages/registry"
 	"github.com/iotaledger/wasp/packages/tcrypto"
@@ -26,6 +28,9 @@ import (
 
 type NodeProvider func() *Node
 
+// ErrUntrustedPeer is returned, if a DKG procedure involves a node, that is not a trusted peer.
+var ErrUntrustedPeer = errors.New("the peer is not trusted")
+
 // Node represents a node, that can participate in a DKG procedure.
 // It receives commands from the initiator as a dkg.NodeProvider,
 // and communicates with other DKG nodes via the peering network.
@@ -36,7 +41,13 @@ type Node struct {
 	blsSuite                Suite                                     // Cryptography to use for the Pairing based operations.
 	edSuite                 suites.Suite                              // Cryptography to use for the Ed25519 based operations.
 	netProvider             peering.NetworkProvider                   // Network to communicate through.
+	trustedNetworkManager   peering.TrustedNetworkManager             // Only the trusted peers can take part in the DKG.
 	dkShareRegistryProvider registry.DKShareRegistryProvider          // Where to store the generated keys.
+	auditLog                registry.DKGAuditLogProvider              // Where to record the DKG procedures.
+	auditedFailures         map[string]*procedureFailures             // Failures recorded already, the initiators retry them.
+	auditLock               *sync.Mutex                               // To guard access to the audited failures.
+	reshareApprovals        map[hashing.HashValue]time.Time           // Resharings approved by the operator, until the expiry.
+	reshareLock             *sync.Mutex                               // To guard access to the reshare approvals.
 	processes               *shrinkingmap.ShrinkingMap[string, *proc] // Only for introspection.
 	procLock                *sync.RWMutex                             // To guard access to the process pool.
 	initMsgQueue            chan *initiatorInitMsgIn                  // Incoming events processed async.
@@ -49,7 +60,9 @@ type Node struct {
 func NewNode(
 	identity *cryptolib.KeyPair,
 	netProvider peering.NetworkProvider,
+	trustedNetworkManager peering.TrustedNetworkManager,
 	dkShareRegistryProvider registry.DKShareRegistryProvider,
+	auditLog registry.DKGAuditLogProvider,
 	log *logger.Logger,
 ) (*Node, error) {
 	kyberKeyPair, err := identity.GetPrivateKey().AsKyberKeyPair()
@@ -63,7 +76,13 @@ func NewNode(
 		blsSuite:                tcrypto.DefaultBLSSuite(),
 		edSuite:                 edwards25519.NewBlakeSHA256Ed25519(),
 		netProvider:             netProvider,
+		trustedNetworkManager:   trustedNetworkManager,
 		dkShareRegistryProvider: dkShareRegistryProvider,
+		auditLog:                auditLog,
+		auditedFailures:         map[string]*procedureFailures{},
+		auditLock:               &sync.Mutex{},
+		reshareApprovals:        map[hashing.HashValue]time.Time{},
+		reshareLock:             &sync.Mutex{},
 		processes:               shrinkingmap.New[string, *proc](),
 		procLock:                &sync.RWMutex{},
 		initMsgQueue:            make(chan *initiatorInitMsgIn),
@@ -80,6 +99,15 @@ func (n *Node) receiveInitMessage(peerMsg *peering.PeerMessageIn) {
 		panic(fmt.Errorf("DKG init handler does not accept peer messages of other receiver type %v, message type=%v",
 			peerMsg.MsgReceiver, peerMsg.MsgType))
 	}
+	if peerMsg.MsgType == reshareDealMsgType || peerMsg.MsgType == reshareCommitMsgType {
+		msg, err := readInitiatorMsg(peerMsg.PeerMessageData, n.edSuite, n.blsSuite)
+		if err != nil {
+			n.log.Warnf("Dropping unknown message: %v", peerMsg)
+			return
+		}
+		go n.onReshareMsg(peerMsg.SenderPubKey, msg)
+		return
+	}
 	if peerMsg.MsgType != initiatorInitMsgType {
 		panic(fmt.Errorf("wrong type of DKG init message: %v", peerMsg.MsgType))
 	}
@@ -101,30 +129,53 @@ func (n *Node) Close() {
 
 // GenerateDistributedKey takes all the required parameters from the node and initiated the DKG procedure.
 // This function is executed on the DKG initiator node (a chosen leader for this DKG instance).
-//
-//nolint:funlen,gocyclo
+// The initiatedBy identifies the user requesting the procedure, it is recorded in the DKG audit
+// log of this node only, the peers record this node as the initiator.
 func (n *Node) GenerateDistributedKey(
+	initiatedBy string,
 	peerPubs []*cryptolib.PublicKey,
 	threshold uint16,
 	roundRetry time.Duration, // Retry for Peer <-> Peer communication.
 	stepRetry time.Duration, // Retry for Initiator -> Peer communication.
 	timeout time.Duration, // Timeout for the entire procedure.
 ) (tcrypto.DKShare, error) {
-	n.log.Infof("Starting new DKG procedure, initiator=%v, peers=%+v", n.netProvider.Self().PeeringURL(), peerPubs)
+	dkgID := peering.RandomPeeringID()
+	dkShare, err := n.generateDistributedKey(dkgID, initiatedBy, peerPubs, threshold, roundRetry, stepRetry, timeout)
+	n.audit(&registry.DKGAuditEntry{
+		Procedure:   registry.DKGProcedureGenerate,
+		Role:        registry.DKGRoleInitiator,
+		ProcedureID: dkgID.String(),
+		Initiator:   n.identity.GetPublicKey(),
+		InitiatedBy: initiatedBy,
+		Peers:       peerPubs,
+		Threshold:   threshold,
+	}, dkShare, err)
+	return dkShare, err
+}
+
+//nolint:funlen,gocyclo
+func (n *Node) generateDistributedKey(
+	dkgID peering.PeeringID,
+	initiatedBy string,
+	peerPubs []*cryptolib.PublicKey,
+	threshold uint16,
+	roundRetry time.Duration,
+	stepRetry time.Duration,
+	timeout time.Duration,
+) (tcrypto.DKShare, error) {
+	n.log.Infof("Starting new DKG procedure, initiator=%v, initiatedBy=%v, peers=%+v", n.netProvider.Self().PeeringURL(), initiatedBy, peerPubs)
 	var err error
 	peerCount := uint16(len(peerPubs))
 	//
 	// Some validation for the parameters.
-	if peerCount < 1 || threshold < 1 || threshold > peerCount {
-		return nil, invalidParams(fmt.Errorf("wrong DKG parameters: N = %d, T = %d", peerCount, threshold))
+	if err = checkThreshold(peerCount, threshold); err != nil {
+		return nil, err
 	}
-
-	if threshold < uint16(byz_quorum.MinQuorum(int(peerCount))) {
-		return nil, invalidParams(fmt.Errorf("wrong DKG parameters: for N = %d value T must be at least %d", peerCount, peerCount/2+1))
+	if err = n.checkTrusted(peerPubs...); err != nil {
+		return nil, invalidParams(err)
 	}
 	//
 	// Setup network connections.
-	dkgID := peering.RandomPeeringID()
 	var netGroup peering.GroupProvider
 	if netGroup, err = n.netProvider.PeerGroup(dkgID, peerPubs); err != nil {
 		return nil, err
@@ -156,7 +207,7 @@ func (n *Node) GenerateDistributedKey(
 	if err = n.exchangeInitiatorAcks(netGroup, netGroup.AllNodes(), recvCh, rTimeout, gTimeout, rabinStep0Initialize,
 		func(peerIdx uint16, peer peering.PeerSender) {
 			n.log.Debugf("Initiator sends step=%v command to %v", rabinStep0Initialize, peer.PeeringURL())
-			peer.SendMsg(makePeerMessage(initPeeringID, peering.ReceiverDkgInit, rabinStep0Initialize, &initiatorInitMsg{
+			initMsg := &initiatorInitMsg{
 				dkgRef:       dkgID.String(), // It could be some other identifier.
 				peeringID:    dkgID,
 				peerPubs:     peerPubs,
@@ -164,7 +215,8 @@ func (n *Node) GenerateDistributedKey(
 				threshold:    threshold,
 				timeout:      timeout,
 				roundRetry:   roundRetry,
-			}))
+			}
+			peer.SendMsg(makePeerMessage(initPeeringID, peering.ReceiverDkgInit, rabinStep0Initialize, initMsg))
 		},
 	); err != nil {
 		return nil, err
@@ -292,6 +344,104 @@ func (n *Node) GenerateDistributedKey(
 	return dkShare, nil
 }
 
+func checkThreshold(peerCount, threshold uint16) error {
+	if peerCount < 1 || threshold < 1 || threshold > peerCount {
+		return invalidParams(fmt.Errorf("wrong DKG parameters: N = %d, T = %d", peerCount, threshold))
+	}
+	if threshold < uint16(byz_quorum.MinQuorum(int(peerCount))) {
+		return invalidParams(fmt.Errorf("wrong DKG parameters: for N = %d value T must be at least %d", peerCount, peerCount/2+1))
+	}
+	return nil
+}
+
+// checkTrusted refuses the DKG procedures involving nodes, that are not trusted peers.
+// The node itself is trusted implicitly.
+func (n *Node) checkTrusted(pubKeys ...*cryptolib.PublicKey) error {
+	for _, pubKey := range pubKeys {
+		if pubKey.Equals(n.identity.GetPublicKey()) {
+			continue
+		}
+		if err := n.trustedNetworkManager.IsTrustedPeer(pubKey); err != nil {
+			return fmt.Errorf("%w: %v", ErrUntrustedPeer, pubKey)
+		}
+	}
+	return nil
+}
+
+// checkInitAuthorized checks, that the init message was produced by the sender and
+// that all the nodes taking part in the procedure are trusted by this node.
+// The sender is authenticated by the peering layer, thus the message is not signed.
+func (n *Node) checkInitAuthorized(msg *initiatorInitMsgIn) error {
+	if !msg.initiatorPub.Equals(msg.SenderPubKey) {
+		return fmt.Errorf("the DKG init message was sent by %v on behalf of %v", msg.SenderPubKey, msg.initiatorPub)
+	}
+	if err := n.checkTrusted(msg.initiatorPub); err != nil {
+		return fmt.Errorf("the DKG initiator is refused: %w", err)
+	}
+	if err := n.checkTrusted(msg.peerPubs...); err != nil {
+		return fmt.Errorf("the DKG participant is refused: %w", err)
+	}
+	return nil
+}
+
+// audit records the outcome of a DKG procedure. A failure to write the
+// audit log does not affect the procedure, it was completed already.
+// The initiator retries the requests, that have failed, until it gives up,
+// thus the same failure is only recorded once per procedure.
+func (n *Node) audit(entry *registry.DKGAuditEntry, dkShare tcrypto.DKShare, err error) {
+	entry.Time = time.Now()
+	if dkShare != nil {
+		entry.SharedAddress = dkShare.GetAddress()
+		entry.Peers = dkShare.GetNodePubKeys()
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if !n.auditFirstFailure(entry) {
+		return
+	}
+	if auditErr := n.auditLog.AddDKGAuditEntry(entry); auditErr != nil {
+		n.log.Errorf("Failed to record DKG procedure %v in the audit log: %v", entry.ProcedureID, auditErr)
+	}
+}
+
+// procedureFailures are the failures of a single procedure, that were recorded in the audit log.
+type procedureFailures struct {
+	errors   map[string]bool
+	lastSeen time.Time
+}
+
+// auditedFailuresRetention is how long the failures of a procedure are remembered after the last retry.
+const auditedFailuresRetention = 1 * time.Hour
+
+// auditFirstFailure returns false, if the same failure of the procedure was recorded already.
+// The failures are forgotten, when the procedure succeeds or is not retried for a while.
+func (n *Node) auditFirstFailure(entry *registry.DKGAuditEntry) bool {
+	n.auditLock.Lock()
+	defer n.auditLock.Unlock()
+	for procedureKey, failures := range n.auditedFailures {
+		if entry.Time.Sub(failures.lastSeen) > auditedFailuresRetention {
+			delete(n.auditedFailures, procedureKey)
+		}
+	}
+	procedureKey := strings.Join([]string{entry.Procedure, entry.Role, entry.ProcedureID}, "/")
+	if entry.Error == "" {
+		delete(n.auditedFailures, procedureKey)
+		return true
+	}
+	failures, ok := n.auditedFailures[procedureKey]
+	if !ok {
+		failures = &procedureFailures{errors: map[string]bool{}}
+		n.auditedFailures[procedureKey] = failures
+	}
+	failures.lastSeen = entry.Time
+	if failures.errors[entry.Error] {
+		return false
+	}
+	failures.errors[entry.Error] = true
+	return true
+}
+
 // Async recv is needed to avoid locking on the even publisher (Recv vs Attach in proc).
 func (n *Node) recvLoop() {
 	for recv := range n.initMsgQueue {
@@ -303,6 +453,14 @@ func (n *Node) recvLoop() {
 func (n *Node) onInitMsg(msg *initiatorInitMsgIn) {
 	var err error
 	var p *proc
+	if err = n.checkInitAuthorized(msg); err != nil {
+		n.log.Warnf("Refusing DKG procedure %v: %v", msg.dkgRef, err)
+		n.audit(n.participantAuditEntry(msg), nil, err)
+		n.netProvider.SendMsgByPubKey(msg.SenderPubKey, makePeerMessage(msg.peeringID, peering.ReceiverDkg, msg.step, &initiatorStatusMsg{
+			error: err,
+		}))
+		return
+	}
 	n.procLock.RLock()
 	if n.processes.Has(msg.dkgRef) {
 		// To have idempotence for retries, we need to consider duplicate
@@ -322,12 +480,26 @@ func (n *Node) onInitMsg(msg *initiatorInitMsgIn) {
 			n.processes.Set(p.dkgRef, p)
 		}
 		n.procLock.Unlock()
+		if err != nil {
+			n.audit(n.participantAuditEntry(msg), nil, err)
+		}
 		n.netProvider.SendMsgByPubKey(msg.SenderPubKey, makePeerMessage(msg.peeringID, peering.ReceiverDkg, msg.step, &initiatorStatusMsg{
 			error: err,
 		}))
 	}()
 }
 
+func (n *Node) participantAuditEntry(msg *initiatorInitMsgIn) *registry.DKGAuditEntry {
+	return &registry.DKGAuditEntry{
+		Procedure:   registry.DKGProcedureGenerate,
+		Role:        registry.DKGRoleParticipant,
+		ProcedureID: msg.dkgRef,
+		Initiator:   msg.initiatorPub,
+		Peers:       msg.peerPubs,
+		Threshold:   msg.threshold,
+	}
+}
+
 // Called by the DKG process on termination.
 func (n *Node) dropProcess(p *proc) bool {
 	n.procLock.Lock()
@@ -11,7 +11,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/share"
 
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/wasp/packages/dkg"
@@ -20,6 +22,7 @@ import (
 	"github.com/iotaledger/wasp/packages/testutil"
 	"github.com/iotaledger/wasp/packages/testutil/testlogger"
 	"github.com/iotaledger/wasp/packages/testutil/testpeers"
+	"github.com/iotaledger/wasp/packages/util"
 )
 
 // TestBasic checks if DKG procedure is executed successfully in a common case.
@@ -41,11 +44,12 @@ func TestBasic(t *testing.T) {
 	//
 	// Initialize the DKG subsystem in each node.
 	dkgNodes := make([]*dkg.Node, len(peeringURLs))
+	trustedNetworkManager := testpeers.TrustAll(peeringURLs, peerIdentities)
 	dkShareRegistryProviders := make([]registry.DKShareRegistryProvider, len(peeringURLs))
 	for i := range peeringURLs {
 		dkShareRegistryProviders[i] = testutil.NewDkgRegistryProvider(peerIdentities[i].GetPrivateKey())
 		dkgNode, err := dkg.NewNode(
-			peerIdentities[i], networkProviders[i], dkShareRegistryProviders[i],
+			peerIdentities[i], networkProviders[i], trustedNetworkManager, dkShareRegistryProviders[i], testutil.NewDKGAuditLog(),
 			testlogger.WithLevel(log.With("PeeringURL", peeringURLs[i]), logger.LevelDebug, false),
 		)
 		require.NoError(t, err)
@@ -54,6 +58,7 @@ func TestBasic(t *testing.T) {
 	//
 	// Initiate the key generation from some client node.
 	dkShare, err := dkgNodes[0].GenerateDistributedKey(
+		"test",
 		testpeers.PublicKeys(peerIdentities),
 		threshold,
 		1*time.Second,
@@ -123,10 +128,11 @@ func TestUnreliableNet(t *testing.T) {
 	//
 	// Initialize the DKG subsystem in each node.
 	dkgNodes := make([]*dkg.Node, len(peerPeeringURLs))
+	trustedNetworkManager := testpeers.TrustAll(peerPeeringURLs, peerIdentities)
 	for i := range peerPeeringURLs {
 		dksReg := testutil.NewDkgRegistryProvider(peerIdentities[i].GetPrivateKey())
 		dkgNode, err := dkg.NewNode(
-			peerIdentities[i], networkProviders[i], dksReg,
+			peerIdentities[i], networkProviders[i], trustedNetworkManager, dksReg, testutil.NewDKGAuditLog(),
 			testlogger.WithLevel(log.With("PeeringURL", peerPeeringURLs[i]), logger.LevelDebug, false),
 		)
 		require.NoError(t, err)
@@ -135,6 +141,7 @@ func TestUnreliableNet(t *testing.T) {
 	//
 	// Initiate the key generation from some client node.
 	dkShare, err := dkgNodes[0].GenerateDistributedKey(
+		"test",
 		testpeers.PublicKeys(peerIdentities),
 		threshold,
 		100*time.Millisecond, // Round retry.
@@ -167,10 +174,11 @@ func TestLowN(t *testing.T) {
 		//
 		// Initialize the DKG subsystem in each node.
 		dkgNodes := make([]*dkg.Node, len(peerPeeringURLs))
+		trustedNetworkManager := testpeers.TrustAll(peerPeeringURLs, peerIdentities)
 		for i := range peerPeeringURLs {
 			dksReg := testutil.NewDkgRegistryProvider(peerIdentities[i].GetPrivateKey())
 			dkgNode, err := dkg.NewNode(
-				peerIdentities[i], networkProviders[i], dksReg,
+				peerIdentities[i], networkProviders[i], trustedNetworkManager, dksReg, testutil.NewDKGAuditLog(),
 				testlogger.WithLevel(log.With("PeeringURL", peerPeeringURLs[i]), logger.LevelDebug, false),
 			)
 			require.NoError(t, err)
@@ -179,6 +187,7 @@ func TestLowN(t *testing.T) {
 		//
 		// Initiate the key generation from some client node.
 		dkShare, err := dkgNodes[0].GenerateDistributedKey(
+			"test",
 			testpeers.PublicKeys(peerIdentities),
 			threshold,
 			1*time.Second,
@@ -190,3 +199,179 @@ func TestLowN(t *testing.T) {
 		require.NotNil(t, dkShare.GetSharedPublic())
 	}
 }
+
+// TestReshare checks, if the key can be moved to another committee without changing the address.
+func TestReshare(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	//
+	// Create a fake network and keys for the tests.
+	// The current committee is nodes 0..3, the next one is nodes 2..6.
+	timeout := 100 * time.Second
+	var peerCount uint16 = 7
+	peeringURLs, peerIdentities := testpeers.SetupKeys(peerCount)
+	peeringNetwork := testutil.NewPeeringNetwork(
+		peeringURLs, peerIdentities, 10000,
+		testutil.NewPeeringNetReliable(log),
+		testlogger.WithLevel(log, logger.LevelWarn, false),
+	)
+	networkProviders := peeringNetwork.NetworkProviders()
+	dkgNodes := make([]*dkg.Node, len(peeringURLs))
+	trustedNetworkManager := testpeers.TrustAll(peeringURLs, peerIdentities)
+	dkShareRegistryProviders := make([]registry.DKShareRegistryProvider, len(peeringURLs))
+	for i := range peeringURLs {
+		dkShareRegistryProviders[i] = testutil.NewDkgRegistryProvider(peerIdentities[i].GetPrivateKey())
+		dkgNode, err := dkg.NewNode(
+			peerIdentities[i], networkProviders[i], trustedNetworkManager, dkShareRegistryProviders[i], testutil.NewDKGAuditLog(),
+			testlogger.WithLevel(log.With("PeeringURL", peeringURLs[i]), logger.LevelDebug, false),
+		)
+		require.NoError(t, err)
+		dkgNodes[i] = dkgNode
+	}
+	pubKeys := testpeers.PublicKeys(peerIdentities)
+	currentShare, err := dkgNodes[0].GenerateDistributedKey("test", pubKeys[:4], 3, 1*time.Second, 2*time.Second, timeout)
+	require.NoError(t, err)
+	//
+	// Reshare the key, the initiator must be a member of the current committee.
+	_, err = dkgNodes[5].ReshareDistributedKey("test", currentShare.GetAddress(), pubKeys[2:], 4, 2*time.Second, timeout)
+	require.Error(t, err)
+	// The next committee can't list a node twice.
+	duplicatePubKeys := append(util.CloneSlice(pubKeys[2:6]), pubKeys[2])
+	_, err = dkgNodes[1].ReshareDistributedKey("test", currentShare.GetAddress(), duplicatePubKeys, 4, 2*time.Second, timeout)
+	require.ErrorContains(t, err, "listed more than once")
+	require.Error(t, dkgNodes[0].ApproveReshare("test", currentShare.GetAddress(), duplicatePubKeys, 4, timeout))
+	// The initiator alone can't move the key, T members of the current committee have to approve it.
+	_, err = dkgNodes[1].ReshareDistributedKey("test", currentShare.GetAddress(), pubKeys[2:], 4, 1*time.Second, 3*time.Second)
+	require.ErrorContains(t, err, "not approved")
+	require.Error(t, dkgNodes[5].ApproveReshare("test", currentShare.GetAddress(), pubKeys[2:], 4, timeout))
+	require.NoError(t, dkgNodes[0].ApproveReshare("test", currentShare.GetAddress(), pubKeys[2:], 4, timeout))
+	require.NoError(t, dkgNodes[2].ApproveReshare("test", currentShare.GetAddress(), pubKeys[2:], 4, timeout))
+	// Node 3 has not approved the resharing, the deals of the other T members are enough.
+	nextShare, err := dkgNodes[1].ReshareDistributedKey("test", currentShare.GetAddress(), pubKeys[2:], 4, 2*time.Second, timeout)
+	require.NoError(t, err)
+	require.True(t, currentShare.GetAddress().Equal(nextShare.GetAddress()))
+	require.True(t, currentShare.GetSharedPublic().Equals(nextShare.GetSharedPublic()))
+	require.EqualValues(t, 5, nextShare.GetN())
+	require.EqualValues(t, 4, nextShare.GetT())
+	//
+	// The current DKShares are kept until the next ones are activated.
+	currentPriShares := make([]*share.PriShare, 0)
+	for i := 0; i < 4; i++ {
+		dks, err2 := dkShareRegistryProviders[i].LoadDKShare(currentShare.GetAddress())
+		require.NoError(t, err2)
+		require.EqualValues(t, 4, dks.GetN())
+		currentPriShares = append(currentPriShares, dks.DSS().PriShare())
+	}
+	nextPriShares := make([]*share.PriShare, 0)
+	dataToSign := []byte{112, 117, 116, 105, 110, 32, 99, 104, 117, 105, 108, 111, 33}
+	blsPartSigs := make([][]byte, 0)
+	for i := range dkShareRegistryProviders {
+		if i < 2 {
+			_, err2 := dkShareRegistryProviders[i].LoadNextDKShare(currentShare.GetAddress())
+			require.Error(t, err2)
+			continue
+		}
+		dks, err2 := dkShareRegistryProviders[i].ActivateNextDKShare(currentShare.GetAddress())
+		require.NoError(t, err2)
+		require.EqualValues(t, i-2, *dks.GetIndex())
+		nextPriShares = append(nextPriShares, dks.DSS().PriShare())
+		blsPartSig, err2 := dks.BLSSignShare(dataToSign)
+		require.NoError(t, err2)
+		blsPartSigs = append(blsPartSigs, blsPartSig)
+	}
+	//
+	// Both committees share the same secret.
+	edSuite := tcrypto.DefaultEd25519Suite()
+	currentSecret, err := share.RecoverSecret(edSuite, currentPriShares, 3, 4)
+	require.NoError(t, err)
+	nextSecret, err := share.RecoverSecret(edSuite, nextPriShares, 4, 5)
+	require.NoError(t, err)
+	require.True(t, currentSecret.Equal(nextSecret))
+	_, err = share.RecoverSecret(edSuite, nextPriShares[:3], 4, 5)
+	require.Error(t, err)
+	//
+	// The BLS signatures of the next committee are verified with the same public key.
+	aggrDks, err := dkShareRegistryProviders[2].LoadDKShare(currentShare.GetAddress())
+	require.NoError(t, err)
+	blsAggrSig, err := aggrDks.BLSRecoverMasterSignature(blsPartSigs, dataToSign)
+	require.NoError(t, err)
+	require.NoError(t, currentShare.BLSVerifyMasterSignature(dataToSign, blsAggrSig.Signature[:]))
+}
+
+// TestUntrustedPeer checks, that the nodes refuse the DKG with the peers they don't trust,
+// and that all the procedures are recorded in the audit logs.
+func TestUntrustedPeer(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	//
+	// Node 3 does not trust node 2.
+	timeout := 100 * time.Second
+	var peerCount uint16 = 4
+	peeringURLs, peerIdentities := testpeers.SetupKeys(peerCount)
+	peeringNetwork := testutil.NewPeeringNetwork(
+		peeringURLs, peerIdentities, 10000,
+		testutil.NewPeeringNetReliable(log),
+		testlogger.WithLevel(log, logger.LevelWarn, false),
+	)
+	networkProviders := peeringNetwork.NetworkProviders()
+	dkgNodes := make([]*dkg.Node, len(peeringURLs))
+	auditLogs := make([]registry.DKGAuditLogProvider, len(peeringURLs))
+	for i := range peeringURLs {
+		trustedNetworkManager := testpeers.TrustAll(peeringURLs, peerIdentities)
+		if i == 3 {
+			_, err := trustedNetworkManager.DistrustPeer(peerIdentities[2].GetPublicKey())
+			require.NoError(t, err)
+		}
+		auditLogs[i] = testutil.NewDKGAuditLog()
+		dkgNode, err := dkg.NewNode(
+			peerIdentities[i], networkProviders[i], trustedNetworkManager,
+			testutil.NewDkgRegistryProvider(peerIdentities[i].GetPrivateKey()), auditLogs[i],
+			testlogger.WithLevel(log.With("PeeringURL", peeringURLs[i]), logger.LevelDebug, false),
+		)
+		require.NoError(t, err)
+		dkgNodes[i] = dkgNode
+	}
+	pubKeys := testpeers.PublicKeys(peerIdentities)
+	//
+	// The initiator itself refuses to start the DKG with an untrusted peer.
+	_, err := dkgNodes[3].GenerateDistributedKey("alice", pubKeys, 3, 1*time.Second, 2*time.Second, timeout)
+	require.ErrorIs(t, err, dkg.ErrUntrustedPeer)
+	//
+	// The participant refuses the DKG initiated by another node.
+	// The initiator retries the refused request until it gives up, but the refusal is recorded once.
+	_, err = dkgNodes[0].GenerateDistributedKey("bob", pubKeys, 3, 1*time.Second, 500*time.Millisecond, 5*time.Second)
+	require.ErrorContains(t, err, dkg.ErrUntrustedPeer.Error())
+	refused := auditLogs[3].DKGAuditEntries()
+	require.Len(t, refused, 2)
+	require.Equal(t, registry.DKGRoleParticipant, refused[1].Role)
+	require.Empty(t, refused[1].InitiatedBy, "the user is only known to the initiator node")
+	require.True(t, pubKeys[0].Equals(refused[1].Initiator))
+	require.Nil(t, refused[1].SharedAddress)
+	require.NotEmpty(t, refused[1].Error)
+	//
+	// The DKG among the trusted peers succeeds and is recorded by all the nodes.
+	dkShare, err := dkgNodes[0].GenerateDistributedKey("carol", pubKeys[:3], 3, 1*time.Second, 2*time.Second, timeout)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		entries := auditLogs[i].DKGAuditEntries()
+		last := entries[len(entries)-1]
+		require.Equal(t, registry.DKGProcedureGenerate, last.Procedure)
+		if i == 0 {
+			require.Equal(t, registry.DKGRoleInitiator, last.Role)
+			require.Equal(t, "carol", last.InitiatedBy)
+		} else {
+			require.Equal(t, registry.DKGRoleParticipant, last.Role)
+			require.Empty(t, last.InitiatedBy)
+		}
+		require.True(t, dkShare.GetAddress().Equal(last.SharedAddress))
+		require.Empty(t, last.Error)
+	}
+	initiatorEntries := lo.Filter(auditLogs[0].DKGAuditEntries(), func(entry *registry.DKGAuditEntry, _ int) bool {
+		return entry.Role == registry.DKGRoleInitiator
+	})
+	require.Len(t, initiatorEntries, 2)
+	require.NotEmpty(t, initiatorEntries[0].Error)
+	require.Equal(t, "bob", initiatorEntries[0].InitiatedBy)
+	require.Empty(t, initiatorEntries[1].Error)
+	require.Equal(t, "carol", initiatorEntries[1].InitiatedBy)
+}
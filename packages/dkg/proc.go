@@ -24,6 +24,7 @@ import (
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/cryptolib"
 	"github.com/iotaledger/wasp/packages/peering"
+	"github.com/iotaledger/wasp/packages/registry"
 	"github.com/iotaledger/wasp/packages/tcrypto"
 	"github.com/iotaledger/wasp/packages/util"
 )
@@ -646,7 +647,7 @@ func (p *proc) rabinStep6R6SendReconstructCommitsMakeResp(
 		p.dkShare.GetSharedPublic(),
 	)
 	var pubShareMsg *initiatorPubShareMsg
-	if pubShareMsg, err = p.makeInitiatorPubShareMsg(step); err != nil {
+	if pubShareMsg, err = makeInitiatorPubShareMsg(p.dkShare, step); err != nil {
 		return nil, err
 	}
 	return makePeerMessage(p.dkgID, peering.ReceiverDkg, step, pubShareMsg), nil
@@ -668,7 +669,15 @@ func (p *proc) rabinStep7CommitAndTerminateMakeResp(step byte, initRecv *peering
 		return nil, errors.New("there is no dkShare to commit")
 	}
 	p.dkShare.SetPublicShares(doneMsg.edPubShares, doneMsg.blsPubShares) // Store public shares of all the other peers.
-	if err := p.node.dkShareRegistryProvider.SaveDKShare(p.dkShare); err != nil {
+	err = p.node.dkShareRegistryProvider.SaveDKShare(p.dkShare)
+	p.node.audit(&registry.DKGAuditEntry{
+		Procedure:   registry.DKGProcedureGenerate,
+		Role:        registry.DKGRoleParticipant,
+		ProcedureID: p.dkgRef,
+		Initiator:   p.initiatorPub,
+		Threshold:   p.threshold,
+	}, p.dkShare, err)
+	if err != nil {
 		return nil, err
 	}
 	return makePeerMessage(p.dkgID, peering.ReceiverDkg, step, &initiatorStatusMsg{error: nil}), nil
@@ -689,32 +698,32 @@ func (p *proc) nodeInQUAL(kst keySetType, nodeIdx uint16) bool {
 	return false
 }
 
-func (p *proc) makeInitiatorPubShareMsg(step byte) (*initiatorPubShareMsg, error) {
+func makeInitiatorPubShareMsg(dkShare tcrypto.DKShare, step byte) (*initiatorPubShareMsg, error) {
 	var err error
 	// var dssPublicShareBytes []byte
-	// if dssPublicShareBytes, err = p.dkShare.DSSPublicShares()[*p.dkShare.GetIndex()].MarshalBinary(); err != nil {
+	// if dssPublicShareBytes, err = dkShare.DSSPublicShares()[*dkShare.GetIndex()].MarshalBinary(); err != nil {
 	// 	return nil, err
 	// }
 	var blsPublicShareBytes []byte
-	if blsPublicShareBytes, err = p.dkShare.BLSPublicShares()[*p.dkShare.GetIndex()].MarshalBinary(); err != nil {
+	if blsPublicShareBytes, err = dkShare.BLSPublicShares()[*dkShare.GetIndex()].MarshalBinary(); err != nil {
 		return nil, err
 	}
 	// var dssSignature *dss.PartialSig // TODO: we have to add another DKG here to produce a nonce.
-	// if dssSignature, err = p.dkShare.DSSSignShare(dssPublicShareBytes); err != nil {
+	// if dssSignature, err = dkShare.DSSSignShare(dssPublicShareBytes); err != nil {
 	// 	return nil, err
 	// }
 	var blsSignature []byte
-	if blsSignature, err = p.dkShare.BLSSign(blsPublicShareBytes); err != nil {
+	if blsSignature, err = dkShare.BLSSign(blsPublicShareBytes); err != nil {
 		return nil, err
 	}
 	return &initiatorPubShareMsg{
 		step:            step,
-		sharedAddress:   p.dkShare.GetAddress(),
-		edSharedPublic:  p.dkShare.DSSSharedPublic(),
-		edPublicShare:   p.dkShare.DSSPublicShares()[*p.dkShare.GetIndex()],
+		sharedAddress:   dkShare.GetAddress(),
+		edSharedPublic:  dkShare.DSSSharedPublic(),
+		edPublicShare:   dkShare.DSSPublicShares()[*dkShare.GetIndex()],
 		edSignature:     []byte{}, // dssSignature.Signature, // TODO: Restore this.
-		blsSharedPublic: p.dkShare.BLSSharedPublic(),
-		blsPublicShare:  p.dkShare.BLSPublicShares()[*p.dkShare.GetIndex()],
+		blsSharedPublic: dkShare.BLSSharedPublic(),
+		blsPublicShare:  dkShare.BLSPublicShares()[*dkShare.GetIndex()],
 		blsSignature:    blsSignature,
 	}, nil
 }
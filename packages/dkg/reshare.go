@@ -0,0 +1,614 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package dkg
+
+//
+// Resharing moves an existing distributed key to another committee (with different N and T),
+// without changing the key, thus the chain address stays the same. The procedure is driven
+// by the initiator, which must be a member of the current committee:
+//
+//   - The members of the current committee share their key shares (Ed25519 and BLS) with the
+//     next committee using random polynomials (see tcrypto.ReshareDeals). The shares are
+//     encrypted for the receivers, and returned to the initiator with the public commitments.
+//     The initiator collects the deals from T of the members, the other ones are not needed.
+//   - The initiator passes the deals and the public part of the current DKShare to the members
+//     of the next committee. Each of them verifies the deals, combines them to its new DKShare
+//     (see tcrypto.NewResharedDKShare) and stores it as the next DKShare in the registry.
+//
+// A member of the current committee deals its share only if the resharing (the shared address,
+// the next committee and its threshold) was approved by the operator of that node, see
+// Node.ApproveReshare. The resharing is approved implicitly on the initiator node. Thus the
+// key can be moved only if the operators of at least T members of the current committee
+// agree to that, the same quorum, that can sign with the key.
+//
+// The current DKShares are kept until the next ones are activated in the registry, thus the
+// current committee can continue operating during the handover.
+//
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/encrypt/ecies"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/peering"
+	"github.com/iotaledger/wasp/packages/registry"
+	"github.com/iotaledger/wasp/packages/tcrypto"
+	"github.com/iotaledger/wasp/packages/util"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+const (
+	reshareStep1Deal   = byte(1)
+	reshareStep2Commit = byte(2)
+)
+
+// ReshareDistributedKey moves the key of the sharedAddress to the committee of peerPubs with
+// the specified threshold. It returns the public part of the next DKShare. The next DKShares
+// are stored in the registries of the next committee members, but they have to be activated
+// there before they are used instead of the current ones.
+// This function is executed on the initiator node, which must be a member of the current committee.
+// The resharing has to be approved on other T-1 members of the current committee, before the timeout.
+func (n *Node) ReshareDistributedKey(
+	initiatedBy string,
+	sharedAddress iotago.Address,
+	peerPubs []*cryptolib.PublicKey,
+	threshold uint16,
+	stepRetry time.Duration, // Retry for Initiator -> Peer communication.
+	timeout time.Duration, // Timeout for the entire procedure.
+) (tcrypto.DKShare, error) {
+	reshareID := peering.RandomPeeringID()
+	dkShare, err := n.reshareDistributedKey(reshareID, initiatedBy, sharedAddress, peerPubs, threshold, stepRetry, timeout)
+	n.audit(&registry.DKGAuditEntry{
+		Procedure:     registry.DKGProcedureReshare,
+		Role:          registry.DKGRoleInitiator,
+		ProcedureID:   reshareID.String(),
+		Initiator:     n.identity.GetPublicKey(),
+		InitiatedBy:   initiatedBy,
+		Peers:         peerPubs,
+		Threshold:     threshold,
+		SharedAddress: sharedAddress,
+	}, dkShare, err)
+	return dkShare, err
+}
+
+//nolint:funlen,gocyclo
+func (n *Node) reshareDistributedKey(
+	reshareID peering.PeeringID,
+	initiatedBy string,
+	sharedAddress iotago.Address,
+	peerPubs []*cryptolib.PublicKey,
+	threshold uint16,
+	stepRetry time.Duration,
+	timeout time.Duration,
+) (tcrypto.DKShare, error) {
+	n.log.Infof("Starting DKG resharing procedure, initiator=%v, initiatedBy=%v, sharedAddress=%v, peers=%+v", n.netProvider.Self().PeeringURL(), initiatedBy, sharedAddress, peerPubs)
+	peerCount := uint16(len(peerPubs))
+	if err := checkThreshold(peerCount, threshold); err != nil {
+		return nil, err
+	}
+	if err := checkDistinctPubKeys(peerPubs); err != nil {
+		return nil, err
+	}
+	if err := n.checkTrusted(peerPubs...); err != nil {
+		return nil, invalidParams(err)
+	}
+	current, err := n.dkShareRegistryProvider.LoadDKShare(sharedAddress)
+	if err != nil {
+		return nil, invalidParams(fmt.Errorf("the initiator must be a member of the current committee: %w", err))
+	}
+	if err := n.checkTrusted(current.GetNodePubKeys()...); err != nil {
+		return nil, invalidParams(err)
+	}
+	blsThreshold := uint16(deriveBlsThreshold(&initiatorInitMsg{peerPubs: peerPubs, threshold: threshold}))
+	n.setReshareApproval(sharedAddress, peerPubs, threshold, time.Now().Add(timeout))
+	//
+	// The network group consists of the current committee followed by the new members of the next one.
+	currentPubs := current.GetNodePubKeys()
+	groupPubs := util.CloneSlice(currentPubs)
+	for _, peerPub := range peerPubs {
+		if !containsPubKey(groupPubs, peerPub) {
+			groupPubs = append(groupPubs, peerPub)
+		}
+	}
+	var netGroup peering.GroupProvider
+	if netGroup, err = n.netProvider.PeerGroup(reshareID, groupPubs); err != nil {
+		return nil, err
+	}
+	defer netGroup.Close()
+	recvCh := make(chan *peering.PeerMessageIn, len(groupPubs)*2)
+	unhook := n.netProvider.Attach(&reshareID, peering.ReceiverDkg, func(recv *peering.PeerMessageIn) {
+		recvCh <- recv
+	})
+	defer util.ExecuteIfNotNil(unhook)
+	allNodes := netGroup.AllNodes()
+	currentPeers := make(map[uint16]peering.PeerSender, len(currentPubs))
+	for i := range currentPubs {
+		currentPeers[uint16(i)] = allNodes[uint16(i)]
+	}
+	nextPeers := make(map[uint16]peering.PeerSender, len(peerPubs))
+	nextIndexes := make(map[uint16]uint16, len(peerPubs)) // Group index -> index in the next committee.
+	for i := range peerPubs {
+		groupIndex, err := netGroup.PeerIndexByPubKey(peerPubs[i])
+		if err != nil {
+			return nil, err
+		}
+		nextPeers[groupIndex] = allNodes[groupIndex]
+		nextIndexes[groupIndex] = uint16(i)
+	}
+	//
+	// Collect the deals from the current committee, T of them are enough.
+	dealtMsgs, err := n.collectReshareDeals(netGroup, currentPeers, recvCh, max(current.GetT(), current.BLSThreshold()), stepRetry, timeout,
+		func(peerIdx uint16, peer peering.PeerSender) {
+			n.log.Debugf("Initiator sends step=%v command to %v", reshareStep1Deal, peer.PeeringURL())
+			peer.SendMsg(makePeerMessage(initPeeringID, peering.ReceiverDkgInit, reshareStep1Deal, &reshareDealMsg{
+				reshareID:     reshareID,
+				sharedAddress: sharedAddress,
+				peerPubs:      peerPubs,
+				threshold:     threshold,
+			}))
+		},
+		func(msg *reshareDealtMsg) error {
+			if len(msg.encryptedShares) != len(peerPubs) {
+				return fmt.Errorf("expected %v deals, received %v", len(peerPubs), len(msg.encryptedShares))
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	//
+	// Pass the deals to the next committee and get the public keys.
+	edPublicShares := current.DSSPublicShares()
+	blsPublicShares := current.BLSPublicShares()
+	pubShareResponses := make(map[uint16]*initiatorPubShareMsg, len(peerPubs))
+	if err = n.exchangeInitiatorMsgs(netGroup, nextPeers, recvCh, stepRetry, timeout, reshareStep2Commit,
+		func(peerIdx uint16, peer peering.PeerSender) {
+			n.log.Debugf("Initiator sends step=%v command to %v", reshareStep2Commit, peer.PeeringURL())
+			deals := make([]*reshareEncryptedDeal, 0, len(dealtMsgs))
+			for dealer := range currentPubs {
+				dealtMsg, ok := dealtMsgs[uint16(dealer)]
+				if !ok {
+					continue
+				}
+				deals = append(deals, &reshareEncryptedDeal{
+					dealer:          uint16(dealer),
+					edCommits:       dealtMsg.edCommits,
+					blsCommits:      dealtMsg.blsCommits,
+					encryptedShares: dealtMsg.encryptedShares[nextIndexes[peerIdx]],
+				})
+			}
+			peer.SendMsg(makePeerMessage(initPeeringID, peering.ReceiverDkgInit, reshareStep2Commit, &reshareCommitMsg{
+				reshareID:       reshareID,
+				sharedAddress:   sharedAddress,
+				peerPubs:        peerPubs,
+				threshold:       threshold,
+				currentPubs:     currentPubs,
+				currentT:        current.GetT(),
+				currentBLST:     current.BLSThreshold(),
+				edSharedPublic:  current.DSSSharedPublic(),
+				edPublicShares:  edPublicShares,
+				blsSharedPublic: current.BLSSharedPublic(),
+				blsPublicShares: blsPublicShares,
+				deals:           deals,
+			}))
+		},
+		func(recv *peering.PeerMessageGroupIn, initMsg initiatorMsg) (bool, error) {
+			msg, ok := initMsg.(*initiatorPubShareMsg)
+			if !ok {
+				return false, errors.New("msgType != initiatorPubShareMsg")
+			}
+			pubShareResponses[nextIndexes[recv.SenderIndex]] = msg
+			return true, nil
+		},
+	); err != nil {
+		return nil, err
+	}
+	nextEdPublicShares := make([]kyber.Point, peerCount)
+	nextBLSPublicShares := make([]kyber.Point, peerCount)
+	for i := range pubShareResponses {
+		if !sharedAddress.Equal(pubShareResponses[i].sharedAddress) {
+			return nil, errors.New("nodes reshared to a different address")
+		}
+		if !current.DSSSharedPublic().Equal(pubShareResponses[i].edSharedPublic) {
+			return nil, errors.New("nodes reshared to a different Ed25519 shared public key")
+		}
+		if !current.BLSSharedPublic().Equal(pubShareResponses[i].blsSharedPublic) {
+			return nil, errors.New("nodes reshared to a different BLS shared public key")
+		}
+		nextEdPublicShares[i] = pubShareResponses[i].edPublicShare
+		nextBLSPublicShares[i] = pubShareResponses[i].blsPublicShare
+	}
+	dkShare := tcrypto.NewDKSharePublic(
+		sharedAddress,
+		peerCount,
+		threshold,
+		n.identity.GetPrivateKey(),
+		peerPubs,
+		n.edSuite,
+		current.DSSSharedPublic(),
+		nextEdPublicShares,
+		n.blsSuite,
+		blsThreshold,
+		current.BLSSharedPublic(),
+		nextBLSPublicShares,
+	)
+	for i := range pubShareResponses {
+		var blsPubShareBytes []byte
+		if blsPubShareBytes, err = pubShareResponses[i].blsPublicShare.MarshalBinary(); err != nil {
+			return nil, err
+		}
+		if err = dkShare.BLSVerify(pubShareResponses[i].blsPublicShare, blsPubShareBytes, pubShareResponses[i].blsSignature); err != nil {
+			return nil, fmt.Errorf("failed to verify BLS signature: %w", err)
+		}
+	}
+	n.log.Infof("Reshared SharedAddress=%v to %v nodes, T=%v", sharedAddress, peerCount, threshold)
+	return dkShare, nil
+}
+
+// collectReshareDeals requests the deals from the members of the current committee, until the
+// needed number of them is received. The requests are resent to the members, that haven't
+// responded yet, thus the members refusing the resharing only delay the procedure.
+func (n *Node) collectReshareDeals(
+	netGroup peering.GroupProvider,
+	peers map[uint16]peering.PeerSender,
+	recvCh chan *peering.PeerMessageIn,
+	needed uint16,
+	retryTimeout time.Duration,
+	giveUpTimeout time.Duration,
+	sendCB func(peerIdx uint16, peer peering.PeerSender),
+	checkCB func(msg *reshareDealtMsg) error,
+) (map[uint16]*reshareDealtMsg, error) {
+	dealtMsgs := make(map[uint16]*reshareDealtMsg, len(peers))
+	errs := make(map[uint16]error)
+	sendAll := func() {
+		for i := range peers {
+			if _, ok := dealtMsgs[i]; !ok {
+				sendCB(i, peers[i])
+			}
+		}
+	}
+	retryCh := time.After(retryTimeout)
+	giveUpCh := time.After(giveUpTimeout)
+	sendAll()
+	for len(dealtMsgs) < int(needed) {
+		select {
+		case recv, ok := <-recvCh:
+			if !ok {
+				return nil, errors.New("recv_channel_closed")
+			}
+			senderIndex, err := netGroup.PeerIndexByPubKey(recv.SenderPubKey)
+			if err != nil {
+				continue
+			}
+			if _, ok := peers[senderIndex]; !ok {
+				continue
+			}
+			if _, ok := dealtMsgs[senderIndex]; ok {
+				continue // Only consider the first deal.
+			}
+			initMsg, err := readInitiatorMsg(recv.PeerMessageData, n.edSuite, n.blsSuite)
+			if err != nil {
+				n.log.Warnf("Failed to read message from %v: %v", recv.SenderPubKey.String(), err)
+				errs[senderIndex] = err
+				continue
+			}
+			if initMsg == nil || !initMsg.IsResponse() || initMsg.Step() != reshareStep1Deal {
+				continue
+			}
+			if initMsg.Error() != nil {
+				errs[senderIndex] = initMsg.Error()
+				continue
+			}
+			msg, ok := initMsg.(*reshareDealtMsg)
+			if !ok {
+				errs[senderIndex] = errors.New("msgType != reshareDealtMsg")
+				continue
+			}
+			if err := checkCB(msg); err != nil {
+				errs[senderIndex] = err
+				continue
+			}
+			dealtMsgs[senderIndex] = msg
+			delete(errs, senderIndex)
+		case <-retryCh:
+			sendAll()
+			retryCh = time.After(retryTimeout)
+		case <-giveUpCh:
+			var errMsg string
+			for i := range peers {
+				if _, ok := dealtMsgs[i]; ok {
+					continue
+				}
+				if errs[i] != nil {
+					errMsg += fmt.Sprintf("[%v:%v]", i, errs[i].Error())
+				} else {
+					errMsg += fmt.Sprintf("[%v:%v]", i, "round_timeout")
+				}
+			}
+			return nil, fmt.Errorf("received %v of %v needed deals: %v", len(dealtMsgs), needed, errMsg)
+		}
+	}
+	return dealtMsgs, nil
+}
+
+// ApproveReshare allows this node to deal its share of the key of the sharedAddress, if the key is
+// reshared to the committee of peerPubs with the specified threshold, during the validity period.
+// It is executed on the members of the current committee, except the initiator.
+func (n *Node) ApproveReshare(
+	approvedBy string,
+	sharedAddress iotago.Address,
+	peerPubs []*cryptolib.PublicKey,
+	threshold uint16,
+	validity time.Duration,
+) error {
+	err := n.approveReshare(sharedAddress, peerPubs, threshold, validity)
+	n.audit(&registry.DKGAuditEntry{
+		Procedure:     registry.DKGProcedureReshareApproval,
+		Role:          registry.DKGRoleParticipant,
+		ProcedureID:   reshareApprovalKey(sharedAddress, peerPubs, threshold).Hex(),
+		Initiator:     n.identity.GetPublicKey(),
+		InitiatedBy:   approvedBy,
+		Peers:         peerPubs,
+		Threshold:     threshold,
+		SharedAddress: sharedAddress,
+	}, nil, err)
+	return err
+}
+
+func (n *Node) approveReshare(sharedAddress iotago.Address, peerPubs []*cryptolib.PublicKey, threshold uint16, validity time.Duration) error {
+	if err := checkThreshold(uint16(len(peerPubs)), threshold); err != nil {
+		return err
+	}
+	if err := checkDistinctPubKeys(peerPubs); err != nil {
+		return err
+	}
+	if err := n.checkTrusted(peerPubs...); err != nil {
+		return invalidParams(err)
+	}
+	if validity <= 0 {
+		return invalidParams(errors.New("the validity of the approval must be positive"))
+	}
+	if _, err := n.dkShareRegistryProvider.LoadDKShare(sharedAddress); err != nil {
+		return invalidParams(fmt.Errorf("the node must be a member of the current committee: %w", err))
+	}
+	n.setReshareApproval(sharedAddress, peerPubs, threshold, time.Now().Add(validity))
+	return nil
+}
+
+// setReshareApproval records the approval, and forgets the expired ones.
+func (n *Node) setReshareApproval(sharedAddress iotago.Address, peerPubs []*cryptolib.PublicKey, threshold uint16, expiry time.Time) {
+	n.reshareLock.Lock()
+	defer n.reshareLock.Unlock()
+	now := time.Now()
+	for key, keyExpiry := range n.reshareApprovals {
+		if !keyExpiry.After(now) {
+			delete(n.reshareApprovals, key)
+		}
+	}
+	key := reshareApprovalKey(sharedAddress, peerPubs, threshold)
+	if keyExpiry, ok := n.reshareApprovals[key]; ok && keyExpiry.After(expiry) {
+		return
+	}
+	n.reshareApprovals[key] = expiry
+}
+
+func (n *Node) isReshareApproved(sharedAddress iotago.Address, peerPubs []*cryptolib.PublicKey, threshold uint16) bool {
+	n.reshareLock.Lock()
+	defer n.reshareLock.Unlock()
+	expiry, ok := n.reshareApprovals[reshareApprovalKey(sharedAddress, peerPubs, threshold)]
+	return ok && expiry.After(time.Now())
+}
+
+func reshareApprovalKey(sharedAddress iotago.Address, peerPubs []*cryptolib.PublicKey, threshold uint16) hashing.HashValue {
+	ww := rwutil.NewBytesWriter()
+	ww.WriteString(sharedAddress.Key())
+	ww.WriteSize16(len(peerPubs))
+	for _, peerPub := range peerPubs {
+		ww.WriteBytes(peerPub.AsBytes())
+	}
+	ww.WriteUint16(threshold)
+	return hashing.HashData(ww.Bytes())
+}
+
+// onReshareMsg handles the resharing requests from the initiator. The requests are stateless,
+// so the repeated requests are just processed again. Each of them is recorded in the audit log,
+// as the node deals its key share or stores a new one, the repeated failures are recorded once.
+func (n *Node) onReshareMsg(senderPubKey *cryptolib.PublicKey, msg initiatorMsg) {
+	var resp msgByteCoder
+	var reshareID peering.PeeringID
+	var err error
+	auditEntry := &registry.DKGAuditEntry{
+		Procedure: registry.DKGProcedureReshare,
+		Role:      registry.DKGRoleParticipant,
+		Initiator: senderPubKey,
+	}
+	switch msg := msg.(type) {
+	case *reshareDealMsg:
+		reshareID = msg.reshareID
+		resp, err = n.reshareDeal(senderPubKey, msg)
+		auditEntry.Peers = msg.peerPubs
+		auditEntry.Threshold = msg.threshold
+		auditEntry.SharedAddress = msg.sharedAddress
+	case *reshareCommitMsg:
+		reshareID = msg.reshareID
+		resp, err = n.reshareCommit(senderPubKey, msg)
+		auditEntry.Peers = msg.peerPubs
+		auditEntry.Threshold = msg.threshold
+		auditEntry.SharedAddress = msg.sharedAddress
+	default:
+		return
+	}
+	auditEntry.ProcedureID = reshareID.String()
+	n.audit(auditEntry, nil, err)
+	if err != nil {
+		n.log.Warnf("Resharing step=%v failed: %v", msg.Step(), err)
+		resp = &initiatorStatusMsg{error: err}
+	}
+	n.netProvider.SendMsgByPubKey(senderPubKey, makePeerMessage(reshareID, peering.ReceiverDkg, msg.Step(), resp))
+}
+
+// reshareDeal is executed on the members of the current committee.
+func (n *Node) reshareDeal(initiatorPub *cryptolib.PublicKey, msg *reshareDealMsg) (*reshareDealtMsg, error) {
+	if err := checkThreshold(uint16(len(msg.peerPubs)), msg.threshold); err != nil {
+		return nil, err
+	}
+	if err := checkDistinctPubKeys(msg.peerPubs); err != nil {
+		return nil, err
+	}
+	if err := n.checkTrusted(initiatorPub); err != nil {
+		return nil, fmt.Errorf("the resharing initiator is refused: %w", err)
+	}
+	if err := n.checkTrusted(msg.peerPubs...); err != nil {
+		return nil, fmt.Errorf("the member of the next committee is refused: %w", err)
+	}
+	dkShare, err := n.dkShareRegistryProvider.LoadDKShare(msg.sharedAddress)
+	if err != nil {
+		return nil, err
+	}
+	if !containsPubKey(dkShare.GetNodePubKeys(), initiatorPub) {
+		return nil, errors.New("the resharing initiator is not a member of the current committee")
+	}
+	if !n.isReshareApproved(msg.sharedAddress, msg.peerPubs, msg.threshold) {
+		return nil, errors.New("the resharing is not approved on this node")
+	}
+	blsThreshold := deriveBlsThreshold(&initiatorInitMsg{peerPubs: msg.peerPubs, threshold: msg.threshold})
+	deals, err := dkShare.ReshareDeals(uint16(len(msg.peerPubs)), msg.threshold, uint16(blsThreshold))
+	if err != nil {
+		return nil, err
+	}
+	encryptedShares := make([][]byte, len(deals))
+	for i, deal := range deals {
+		ww := rwutil.NewBytesWriter()
+		cryptolib.ScalarToWriter(ww, deal.EdShare)
+		cryptolib.ScalarToWriter(ww, deal.BLSShare)
+		if ww.Err != nil {
+			return nil, ww.Err
+		}
+		peerPub, err := msg.peerPubs[i].AsKyberPoint()
+		if err != nil {
+			return nil, err
+		}
+		if encryptedShares[i], err = ecies.Encrypt(n.edSuite, peerPub, ww.Bytes(), nil); err != nil {
+			return nil, err
+		}
+	}
+	return &reshareDealtMsg{
+		edCommits:       deals[0].EdCommits,
+		blsCommits:      deals[0].BLSCommits,
+		encryptedShares: encryptedShares,
+	}, nil
+}
+
+// reshareCommit is executed on the members of the next committee.
+func (n *Node) reshareCommit(initiatorPub *cryptolib.PublicKey, msg *reshareCommitMsg) (*initiatorPubShareMsg, error) {
+	peerCount := uint16(len(msg.peerPubs))
+	if err := checkThreshold(peerCount, msg.threshold); err != nil {
+		return nil, err
+	}
+	if err := checkDistinctPubKeys(msg.peerPubs); err != nil {
+		return nil, err
+	}
+	if err := checkDistinctPubKeys(msg.currentPubs); err != nil {
+		return nil, err
+	}
+	if !containsPubKey(msg.currentPubs, initiatorPub) {
+		return nil, errors.New("the resharing initiator is not a member of the current committee")
+	}
+	if err := n.checkTrusted(initiatorPub); err != nil {
+		return nil, fmt.Errorf("the resharing initiator is refused: %w", err)
+	}
+	if err := n.checkTrusted(msg.peerPubs...); err != nil {
+		return nil, fmt.Errorf("the member of the next committee is refused: %w", err)
+	}
+	if err := n.checkTrusted(msg.currentPubs...); err != nil {
+		return nil, fmt.Errorf("the member of the current committee is refused: %w", err)
+	}
+	index := -1
+	for i := range msg.peerPubs {
+		if msg.peerPubs[i].Equals(n.identity.GetPublicKey()) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, errors.New("the node is not a member of the next committee")
+	}
+	deals := make([]*tcrypto.ReshareDeal, len(msg.deals))
+	for i, deal := range msg.deals {
+		plain, err := ecies.Decrypt(n.edSuite, n.secKey, deal.encryptedShares, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decrypt the deal of dealer %v: %w", deal.dealer, err)
+		}
+		rr := rwutil.NewBytesReader(plain)
+		deals[i] = &tcrypto.ReshareDeal{
+			Dealer:     deal.dealer,
+			EdCommits:  deal.edCommits,
+			EdShare:    cryptolib.ScalarFromReader(rr, n.edSuite),
+			BLSCommits: deal.blsCommits,
+			BLSShare:   cryptolib.ScalarFromReader(rr, n.blsSuite),
+		}
+		if rr.Err != nil {
+			return nil, fmt.Errorf("cannot read the deal of dealer %v: %w", deal.dealer, rr.Err)
+		}
+	}
+	current := tcrypto.NewDKSharePublic(
+		msg.sharedAddress,
+		uint16(len(msg.currentPubs)),
+		msg.currentT,
+		n.identity.GetPrivateKey(),
+		msg.currentPubs,
+		n.edSuite,
+		msg.edSharedPublic,
+		msg.edPublicShares,
+		n.blsSuite,
+		msg.currentBLST,
+		msg.blsSharedPublic,
+		msg.blsPublicShares,
+	)
+	dkShare, err := tcrypto.NewResharedDKShare(
+		current,
+		deals,
+		uint16(index),
+		peerCount,
+		msg.threshold,
+		uint16(deriveBlsThreshold(&initiatorInitMsg{peerPubs: msg.peerPubs, threshold: msg.threshold})),
+		n.identity.GetPrivateKey(),
+		msg.peerPubs,
+		n.edSuite,
+		n.blsSuite,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.dkShareRegistryProvider.SaveNextDKShare(dkShare); err != nil {
+		return nil, err
+	}
+	return makeInitiatorPubShareMsg(dkShare, msg.step)
+}
+
+// checkDistinctPubKeys refuses the committees listing the same node several times.
+func checkDistinctPubKeys(pubKeys []*cryptolib.PublicKey) error {
+	for i := range pubKeys {
+		if containsPubKey(pubKeys[:i], pubKeys[i]) {
+			return invalidParams(fmt.Errorf("the peer %v is listed more than once", pubKeys[i]))
+		}
+	}
+	return nil
+}
+
+func containsPubKey(pubKeys []*cryptolib.PublicKey, pubKey *cryptolib.PublicKey) bool {
+	for i := range pubKeys {
+		if pubKeys[i].Equals(pubKey) {
+			return true
+		}
+	}
+	return false
+}
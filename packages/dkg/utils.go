@@ -11,6 +11,10 @@ func (e InvalidParamsError) Error() string {
 	return e.error.Error()
 }
 
+func (e InvalidParamsError) Unwrap() error {
+	return e.error
+}
+
 func invalidParams(err error) error {
 	if err == nil {
 		return nil
@@ -0,0 +1,81 @@
+package eventindex
+
+import (
+	"io"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// Event is an event issued by a contract, together with the position of the event in the chain
+// and the sender of the request, which issued it.
+type Event struct {
+	BlockIndex   uint32
+	RequestIndex uint16
+	EventIndex   uint16
+	Sender       isc.AgentID
+	Event        *isc.Event
+}
+
+func EventFromBytes(data []byte) (*Event, error) {
+	return rwutil.ReadFromBytes(data, new(Event))
+}
+
+func (e *Event) Bytes() []byte {
+	return rwutil.WriteToBytes(e)
+}
+
+func (e *Event) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	e.BlockIndex = rr.ReadUint32()
+	e.RequestIndex = rr.ReadUint16()
+	e.EventIndex = rr.ReadUint16()
+	e.Sender = isc.AgentIDFromReader(rr)
+	e.Event = new(isc.Event)
+	rr.Read(e.Event)
+	return rr.Err
+}
+
+func (e *Event) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteUint32(e.BlockIndex)
+	ww.WriteUint16(e.RequestIndex)
+	ww.WriteUint16(e.EventIndex)
+	isc.AgentIDToWriter(ww, e.Sender)
+	ww.Write(e.Event)
+	return ww.Err
+}
+
+// Query selects the indexed events. The zero values of the filters match any event.
+type Query struct {
+	Contract  isc.Hname
+	Topic     string
+	Sender    isc.AgentID
+	FromBlock uint32
+	ToBlock   uint32 // inclusive
+	// Cursor is the NextCursor of the previous page, the events up to it are skipped.
+	Cursor []byte
+	Limit  int
+}
+
+func (q *Query) matches(e *Event) bool {
+	if q.Contract != 0 && e.Event.ContractID != q.Contract {
+		return false
+	}
+	if q.Topic != "" && e.Event.Topic != q.Topic {
+		return false
+	}
+	if q.Sender != nil && (e.Sender == nil || !e.Sender.Equals(q.Sender)) {
+		return false
+	}
+	return true
+}
+
+// Page is the result of a query.
+type Page struct {
+	Events []*Event
+	// NextCursor is set, if the query was stopped by its limit. There may be more events after it.
+	NextCursor []byte
+	// LastIndexedBlock is the last block, which events were indexed. The events of later blocks are not returned yet.
+	LastIndexedBlock *uint32
+}
@@ -0,0 +1,404 @@
+// Package eventindex keeps the events issued by the contracts of a chain in a node-side store.
+// Unlike the events in the blocklog, the indexed events are not pruned together with the blocks,
+// and they can be queried by contract, topic, sender and block range.
+package eventindex
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	hivedb "github.com/iotaledger/hive.go/kvstore/database"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/wasp/packages/database"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/subrealm"
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type Index struct {
+	store      kvstore.KVStore
+	chainStore indexedstore.IndexedStore
+	log        *logger.Logger
+
+	mu sync.RWMutex // guards the store
+
+	pendingMu    sync.Mutex
+	pendingFrom  *uint32
+	pendingUntil uint32
+	pendingCh    chan struct{}
+}
+
+func New(chainStore indexedstore.IndexedStore, dbEngine hivedb.Engine, dbPath string, log *logger.Logger) (*Index, error) {
+	db, err := database.DatabaseWithDefaultSettings(dbPath, true, dbEngine, false)
+	if err != nil {
+		return nil, err
+	}
+	return &Index{
+		store:      db.KVStore(),
+		chainStore: chainStore,
+		log:        log,
+		pendingCh:  make(chan struct{}, 1),
+	}, nil
+}
+
+// Close flushes and closes the store of the index. Run must have returned before.
+func (idx *Index) Close() error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if err := idx.store.Flush(); err != nil {
+		return err
+	}
+	return idx.store.Close()
+}
+
+// BlockApplied schedules the indexing of the events of the block. It does not block the caller.
+// If the block was indexed already (e.g. it was applied again after a reorg), it is indexed again.
+func (idx *Index) BlockApplied(blockIndex uint32) {
+	idx.pendingMu.Lock()
+	if idx.pendingFrom == nil || blockIndex < *idx.pendingFrom {
+		idx.pendingFrom = &blockIndex
+	}
+	idx.pendingUntil = blockIndex
+	idx.pendingMu.Unlock()
+
+	select {
+	case idx.pendingCh <- struct{}{}:
+	default:
+	}
+}
+
+// Run indexes the blocks scheduled with BlockApplied until the context is done.
+// Before that, it indexes the blocks in the chain state, which are missing from the index, e.g.
+// because the index was just created, or the node was down. This way a lost index is rebuilt
+// from the blocks, which are still in the state.
+func (idx *Index) Run(ctx context.Context) {
+	if latest, err := idx.chainStore.LatestBlockIndex(); err == nil {
+		idx.indexBlocks(nil, latest)
+	}
+	for {
+		select {
+		case <-idx.pendingCh:
+			idx.pendingMu.Lock()
+			from, until := idx.pendingFrom, idx.pendingUntil
+			idx.pendingFrom = nil
+			idx.pendingMu.Unlock()
+			if from != nil {
+				idx.indexBlocks(from, until)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// indexBlocks indexes the blocks up to until. The blocks from reindexFrom on are indexed
+// again, if they were indexed already.
+func (idx *Index) indexBlocks(reindexFrom *uint32, until uint32) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	next := uint32(0)
+	if last := idx.lastBlockIndexed(); last != nil {
+		next = *last + 1
+	}
+	if reindexFrom != nil && *reindexFrom < next {
+		idx.deleteEventsFrom(*reindexFrom)
+		next = *reindexFrom
+	}
+
+	for blockIndex := next; blockIndex <= until; blockIndex++ {
+		err := idx.indexBlock(blockIndex)
+		if errors.Is(err, indexedstore.ErrStateNotAvailable) {
+			first, _, historyErr := indexedstore.AvailableHistory(idx.chainStore)
+			if historyErr == nil && first > blockIndex && first <= until {
+				idx.log.Warnf("the events of blocks %d..%d can't be indexed, their states are not available", blockIndex, first-1)
+				blockIndex = first - 1
+				continue
+			}
+		}
+		if err != nil {
+			idx.log.Errorf("failed to index the events of block %d: %v", blockIndex, err)
+			break
+		}
+		idx.setLastBlockIndexed(blockIndex)
+	}
+	if err := idx.store.Flush(); err != nil {
+		idx.log.Errorf("failed to flush the event index: %v", err)
+	}
+}
+
+func (idx *Index) indexBlock(blockIndex uint32) error {
+	if blockIndex == 0 {
+		// the origin block has no requests
+		return nil
+	}
+	chainState, err := idx.chainStore.StateByIndex(blockIndex)
+	if err != nil {
+		return err
+	}
+	partition := subrealm.NewReadOnly(chainState, kv.Key(blocklog.Contract.Hname().Bytes()))
+	_, requests, err := blocklog.GetRequestsInBlock(partition, blockIndex)
+	if err != nil {
+		return err
+	}
+	for requestIndex, req := range requests {
+		for eventIndex, eventData := range blocklog.GetEventsByRequestIndex(partition, blockIndex, uint16(requestIndex)) {
+			event, err := isc.EventFromBytes(eventData)
+			if err != nil {
+				return err
+			}
+			idx.addEvent(&Event{
+				BlockIndex:   blockIndex,
+				RequestIndex: uint16(requestIndex),
+				EventIndex:   uint16(eventIndex),
+				Sender:       req.SenderAccount(),
+				Event:        event,
+			})
+		}
+	}
+	return nil
+}
+
+// LastBlockIndexed returns the last block, which events were indexed, or nil if none was indexed yet.
+func (idx *Index) LastBlockIndexed() *uint32 {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.lastBlockIndexed()
+}
+
+// maxScannedKeys limits the keys scanned by a single query, so that a query selecting few events
+// of a wide block range doesn't hold the index for long. Each block of the range counts as a key.
+var maxScannedKeys = 10_000
+
+// Events returns the indexed events selected by the query, in the order they were issued.
+// The page may have less events than the limit of the query, if too many keys had to be scanned;
+// the query continues from its NextCursor then.
+func (idx *Index) Events(q *Query) (*Page, error) {
+	if q.Cursor != nil && len(q.Cursor) != positionLength {
+		return nil, ErrInvalidCursor
+	}
+	fromBlock := q.FromBlock
+	if q.Cursor != nil {
+		fromBlock = max(fromBlock, binary.BigEndian.Uint32(q.Cursor))
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	page := &Page{Events: []*Event{}, LastIndexedBlock: idx.lastBlockIndexed()}
+	if page.LastIndexedBlock == nil {
+		return page, nil
+	}
+	toBlock := *page.LastIndexedBlock
+	if q.ToBlock != 0 {
+		toBlock = min(toBlock, q.ToBlock)
+	}
+
+	// the keys are iterated block by block, to seek to the first block of the range
+	prefix := idx.queryPrefix(q)
+	scanned := 0
+	var err error
+	for blockIndex := uint64(fromBlock); blockIndex <= uint64(toBlock) && page.NextCursor == nil; blockIndex++ {
+		// the position of the last key scanned, the query continues after it
+		scannedUntil := lastPositionOfBlock(uint32(blockIndex) - 1)
+		if blockIndex == 0 {
+			scannedUntil = nil
+		}
+		iterErr := idx.store.IterateKeys(append(prefix, position(uint32(blockIndex), 0, 0)[:4]...), func(key kvstore.Key) bool {
+			pos := key[len(key)-positionLength:]
+			if q.Cursor != nil && bytes.Compare(pos, q.Cursor) <= 0 {
+				return true
+			}
+			if q.Limit > 0 && len(page.Events) == q.Limit {
+				page.NextCursor = eventPosition(page.Events[len(page.Events)-1])
+				return false
+			}
+			if scanned++; scanned > maxScannedKeys && scannedUntil != nil {
+				page.NextCursor = scannedUntil
+				return false
+			}
+			var event *Event
+			event, err = EventFromBytes(idx.get(append([]byte{prefixEvent}, pos...)))
+			if err != nil {
+				return false
+			}
+			if q.matches(event) {
+				page.Events = append(page.Events, event)
+			}
+			scannedUntil = slices.Clone(pos)
+			return true
+		})
+		if iterErr != nil {
+			return nil, iterErr
+		}
+		if err != nil {
+			return nil, fmt.Errorf("corrupted event index: %w", err)
+		}
+		if scanned++; scanned > maxScannedKeys && page.NextCursor == nil && blockIndex < uint64(toBlock) {
+			page.NextCursor = lastPositionOfBlock(uint32(blockIndex))
+		}
+	}
+	return page, nil
+}
+
+// queryPrefix selects the most specific index for the query, the events are filtered further by Query.matches.
+func (idx *Index) queryPrefix(q *Query) kvstore.KeyPrefix {
+	switch {
+	case q.Sender != nil:
+		return keyPrefixBySender(q.Sender)
+	case q.Topic != "":
+		return keyPrefixByTopic(q.Topic)
+	case q.Contract != 0:
+		return keyPrefixByContract(q.Contract)
+	default:
+		return kvstore.KeyPrefix{prefixEvent}
+	}
+}
+
+// internals
+
+const (
+	prefixLastBlockIndexed = iota
+	prefixEvent
+	prefixEventByContract
+	prefixEventByTopic
+	prefixEventBySender
+)
+
+// positionLength is the length of the position of an event in the keys. The position is encoded in big endian,
+// so the keys are iterated in the order, in which the events were issued.
+const positionLength = 8
+
+func position(blockIndex uint32, requestIndex, eventIndex uint16) []byte {
+	ret := make([]byte, positionLength)
+	binary.BigEndian.PutUint32(ret[:4], blockIndex)
+	binary.BigEndian.PutUint16(ret[4:6], requestIndex)
+	binary.BigEndian.PutUint16(ret[6:], eventIndex)
+	return ret
+}
+
+func lastPositionOfBlock(blockIndex uint32) []byte {
+	return position(blockIndex, math.MaxUint16, math.MaxUint16)
+}
+
+func eventPosition(e *Event) []byte {
+	return position(e.BlockIndex, e.RequestIndex, e.EventIndex)
+}
+
+func keyLastBlockIndexed() kvstore.Key {
+	return []byte{prefixLastBlockIndexed}
+}
+
+func keyEvent(e *Event) kvstore.Key {
+	return append([]byte{prefixEvent}, eventPosition(e)...)
+}
+
+func keyPrefixByContract(contract isc.Hname) kvstore.KeyPrefix {
+	return append([]byte{prefixEventByContract}, contract.Bytes()...)
+}
+
+func keyPrefixByTopic(topic string) kvstore.KeyPrefix {
+	hash := hashing.HashDataBlake2b([]byte(topic))
+	return append([]byte{prefixEventByTopic}, hash[:]...)
+}
+
+func keyPrefixBySender(sender isc.AgentID) kvstore.KeyPrefix {
+	hash := hashing.HashDataBlake2b(sender.Bytes())
+	return append([]byte{prefixEventBySender}, hash[:]...)
+}
+
+// keysOfEvent returns the keys of the event in the secondary indexes.
+func keysOfEvent(e *Event) []kvstore.Key {
+	pos := eventPosition(e)
+	ret := []kvstore.Key{
+		append(keyPrefixByContract(e.Event.ContractID), pos...),
+		append(keyPrefixByTopic(e.Event.Topic), pos...),
+	}
+	if e.Sender != nil {
+		ret = append(ret, append(keyPrefixBySender(e.Sender), pos...))
+	}
+	return ret
+}
+
+func (idx *Index) addEvent(e *Event) {
+	idx.set(keyEvent(e), e.Bytes())
+	for _, key := range keysOfEvent(e) {
+		idx.set(key, []byte{})
+	}
+}
+
+func (idx *Index) deleteEventsFrom(blockIndex uint32) {
+	start := position(blockIndex, 0, 0)
+	var keys []kvstore.Key
+	err := idx.store.Iterate(kvstore.KeyPrefix{prefixEvent}, func(key kvstore.Key, value kvstore.Value) bool {
+		if bytes.Compare(key[1:], start) < 0 {
+			return true
+		}
+		e, err := EventFromBytes(value)
+		if err != nil {
+			panic(fmt.Errorf("corrupted event index: %w", err))
+		}
+		keys = append(keys, keyEvent(e))
+		keys = append(keys, keysOfEvent(e)...)
+		return true
+	})
+	if err != nil {
+		panic(err)
+	}
+	for _, key := range keys {
+		if err := idx.store.Delete(key); err != nil {
+			panic(err)
+		}
+	}
+	if blockIndex == 0 {
+		if err := idx.store.Delete(keyLastBlockIndexed()); err != nil {
+			panic(err)
+		}
+		return
+	}
+	idx.setLastBlockIndexed(blockIndex - 1)
+}
+
+func (idx *Index) get(key kvstore.Key) []byte {
+	ret, err := idx.store.Get(key)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil
+		}
+		panic(err)
+	}
+	return ret
+}
+
+func (idx *Index) set(key kvstore.Key, value []byte) {
+	err := idx.store.Set(key, value)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (idx *Index) setLastBlockIndexed(n uint32) {
+	idx.set(keyLastBlockIndexed(), codec.EncodeUint32(n))
+}
+
+func (idx *Index) lastBlockIndexed() *uint32 {
+	data := idx.get(keyLastBlockIndexed())
+	if data == nil {
+		return nil
+	}
+	ret := codec.MustDecodeUint32(data)
+	return &ret
+}
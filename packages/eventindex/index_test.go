@@ -0,0 +1,64 @@
+package eventindex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/wasp/packages/isc"
+)
+
+func TestEventsScanLimit(t *testing.T) {
+	idx := &Index{store: mapdb.NewMapDB()}
+	// blocks 1..20 have an event each, of alternating contracts
+	contracts := []isc.Hname{isc.Hn("a"), isc.Hn("b")}
+	for blockIndex := uint32(1); blockIndex <= 20; blockIndex++ {
+		idx.addEvent(&Event{
+			BlockIndex: blockIndex,
+			Event:      &isc.Event{ContractID: contracts[blockIndex%2], Topic: "test"},
+		})
+	}
+	idx.setLastBlockIndexed(30)
+
+	defer func(n int) { maxScannedKeys = n }(maxScannedKeys)
+	maxScannedKeys = 10
+
+	// the query starts at its first block, and stops when too many keys were scanned
+	var events []*Event
+	q := &Query{Topic: "test", FromBlock: 5}
+	pages := 0
+	for {
+		page, err := idx.Events(q)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page.Events), maxScannedKeys/2)
+		events = append(events, page.Events...)
+		pages++
+		if page.NextCursor == nil {
+			break
+		}
+		q.Cursor = page.NextCursor
+	}
+	require.Greater(t, pages, 1)
+	require.Len(t, events, 16)
+	for i, e := range events {
+		require.EqualValues(t, 5+i, e.BlockIndex)
+	}
+
+	// the blocks without matching keys count as scanned too
+	events = nil
+	q = &Query{Contract: contracts[0], ToBlock: 25}
+	for {
+		page, err := idx.Events(q)
+		require.NoError(t, err)
+		events = append(events, page.Events...)
+		if page.NextCursor == nil {
+			break
+		}
+		q.Cursor = page.NextCursor
+	}
+	require.Len(t, events, 10)
+	for i, e := range events {
+		require.EqualValues(t, 2+2*i, e.BlockIndex)
+	}
+}
@@ -4,14 +4,17 @@
 package jsonrpc
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"path"
+	"slices"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -45,11 +48,12 @@ import (
 
 // EVMChain provides common functionality to interact with the EVM state.
 type EVMChain struct {
-	backend  ChainBackend
-	chainID  uint16 // cache
-	newBlock *event.Event1[*NewBlockEvent]
-	log      *logger.Logger
-	index    *jsonrpcindex.Index // only indexes blocks that will be pruned from the active state
+	backend      ChainBackend
+	chainID      uint16 // cache
+	newBlock     *event.Event1[*NewBlockEvent]
+	newPendingTx *event.Event1[*types.Transaction] // transactions sent through this node
+	log          *logger.Logger
+	index        *jsonrpcindex.Index // only indexes blocks that will be pruned from the active state
 }
 
 type NewBlockEvent struct {
@@ -71,10 +75,11 @@ func NewEVMChain(
 	log *logger.Logger,
 ) *EVMChain {
 	e := &EVMChain{
-		backend:  backend,
-		newBlock: event.New1[*NewBlockEvent](),
-		log:      log,
-		index:    jsonrpcindex.New(blockchainDB, backend.ISCStateByTrieRoot, indexDbEngine, path.Join(indexDbPath, backend.ISCChainID().String())),
+		backend:      backend,
+		newBlock:     event.New1[*NewBlockEvent](),
+		newPendingTx: event.New1[*types.Transaction](),
+		log:          log,
+		index:        jsonrpcindex.New(blockchainDB, backend.ISCStateByTrieRoot, indexDbEngine, path.Join(indexDbPath, backend.ISCChainID().String())),
 	}
 
 	blocksFromPublisher := pipe.NewInfinitePipe[*publisher.BlockWithTrieRoot]()
@@ -197,7 +202,11 @@ func (e *EVMChain) SendTransaction(tx *types.Transaction) error {
 	if err := evmutil.CheckGasPrice(tx, gasFeePolicy); err != nil {
 		return err
 	}
-	return e.backend.EVMSendTransaction(tx)
+	if err := e.backend.EVMSendTransaction(tx); err != nil {
+		return err
+	}
+	e.newPendingTx.Trigger(tx)
+	return nil
 }
 
 func (e *EVMChain) checkEnoughL2FundsForGasBudget(sender common.Address, evmGas uint64, gasFeePolicy *gas.FeePolicy) error {
@@ -433,6 +442,49 @@ func (e *EVMChain) TransactionCount(address common.Address, blockNumberOrHash *r
 	return emulator.GetNonce(stateDBSubrealmR(chainState), address), nil
 }
 
+// TxPoolContent returns the EVM transactions waiting in the mempool, grouped by
+// sender and ordered by nonce. Following the geth semantics, the transactions
+// that can be executed given the current account nonce are "pending", and the
+// ones after a nonce gap are "queued".
+func (e *EVMChain) TxPoolContent() (pending, queued map[common.Address][]*types.Transaction, err error) {
+	e.log.Debugf("TxPoolContent()")
+	txsBySender := map[common.Address][]*types.Transaction{}
+	for _, req := range e.backend.ISCMempoolOffLedgerRequests() {
+		tx := req.EVMTransaction()
+		if tx == nil {
+			continue // not an EVM transaction
+		}
+		sender, ok := req.SenderAccount().(*isc.EthereumAddressAgentID)
+		if !ok {
+			continue
+		}
+		txsBySender[sender.EthAddress()] = append(txsBySender[sender.EthAddress()], tx)
+	}
+	pending = map[common.Address][]*types.Transaction{}
+	queued = map[common.Address][]*types.Transaction{}
+	for sender, txs := range txsBySender {
+		slices.SortFunc(txs, func(a, b *types.Transaction) int {
+			return cmp.Compare(a.Nonce(), b.Nonce())
+		})
+		nextNonce, err := e.TransactionCount(sender, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i, tx := range txs {
+			if tx.Nonce() < nextNonce {
+				continue // already processed, will be removed from the mempool soon
+			}
+			if tx.Nonce() > nextNonce {
+				queued[sender] = txs[i:]
+				break
+			}
+			pending[sender] = append(pending[sender], tx)
+			nextNonce++
+		}
+	}
+	return pending, queued, nil
+}
+
 func (e *EVMChain) CallContract(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.BlockNumberOrHash) ([]byte, error) {
 	e.log.Debugf("CallContract(callMsg=..., blockNumberOrHash=%v)", blockNumberOrHash)
 	aliasOutput, err := e.iscAliasOutputFromEVMBlockNumberOrHash(blockNumberOrHash)
@@ -456,6 +508,82 @@ func (e *EVMChain) GasPrice() *big.Int {
 	return e.GasFeePolicy().GasPriceWei(parameters.L1().BaseToken.Decimals)
 }
 
+// maxFeeHistoryBlocks is the maximum amount of blocks that can be requested via eth_feeHistory
+const maxFeeHistoryBlocks = 1024
+
+func gasPriceInState(chainState state.State) *big.Int {
+	govPartition := subrealm.NewReadOnly(chainState, kv.Key(governance.Contract.Hname().Bytes()))
+	return governance.MustGetGasFeePolicy(govPartition).GasPriceWei(parameters.L1().BaseToken.Decimals)
+}
+
+// FeeHistory returns the gas price and the gas utilization of blockCount blocks, up to newestBlock.
+// The gas price of a block is set by the fee policy in the state of the previous block;
+// it changes from block to block only if the dynamic fee policy is enabled.
+// As every transaction in a block pays the same gas price, the rewards (tips) are always 0.
+func (e *EVMChain) FeeHistory(blockCount uint64, newestBlock *big.Int, rewardPercentiles []float64) (*RPCFeeHistoryResult, error) {
+	e.log.Debugf("FeeHistory(blockCount=%v, newestBlock=%v, rewardPercentiles=%v)", blockCount, newestBlock, rewardPercentiles)
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 || (i > 0 && p < rewardPercentiles[i-1]) {
+			return nil, fmt.Errorf("invalid reward percentiles: %v", rewardPercentiles)
+		}
+	}
+
+	latestState := e.backend.ISCLatestState()
+	newest := evmBlockNumberByISCBlockIndex(latestState.BlockIndex())
+	if newestBlock != nil {
+		if !newestBlock.IsUint64() || newestBlock.Uint64() > newest {
+			return nil, fmt.Errorf("no EVM block with number %s", newestBlock)
+		}
+		newest = newestBlock.Uint64()
+	}
+	blockCount = min(blockCount, maxFeeHistoryBlocks, newest+1)
+	oldest := newest + 1 - blockCount
+
+	ret := &RPCFeeHistoryResult{
+		OldestBlock:  (*hexutil.Big)(new(big.Int).SetUint64(oldest)),
+		BaseFee:      make([]*hexutil.Big, 0, blockCount+1),
+		GasUsedRatio: make([]float64, 0, blockCount),
+	}
+	if blockCount == 0 {
+		return ret, nil
+	}
+	if len(rewardPercentiles) > 0 {
+		ret.Reward = make([][]*hexutil.Big, 0, blockCount)
+	}
+
+	prevState, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(max(oldest, 1) - 1))
+	if err != nil {
+		return nil, err
+	}
+	for n := oldest; n <= newest; n++ {
+		chainState, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(n))
+		if err != nil {
+			return nil, err
+		}
+		ret.BaseFee = append(ret.BaseFee, (*hexutil.Big)(gasPriceInState(prevState)))
+
+		gasUsedRatio := 0.0
+		blocklogStatePartition := subrealm.NewReadOnly(chainState, kv.Key(blocklog.Contract.Hname().Bytes()))
+		if blockInfo, ok := blocklog.GetBlockInfo(blocklogStatePartition, chainState.BlockIndex()); ok {
+			govPartition := subrealm.NewReadOnly(chainState, kv.Key(governance.Contract.Hname().Bytes()))
+			gasUsedRatio = float64(blockInfo.GasBurned) / float64(governance.MustGetGasLimits(govPartition).MaxGasPerBlock)
+		}
+		ret.GasUsedRatio = append(ret.GasUsedRatio, gasUsedRatio)
+
+		if ret.Reward != nil {
+			rewards := make([]*hexutil.Big, len(rewardPercentiles))
+			for i := range rewards {
+				rewards[i] = (*hexutil.Big)(big.NewInt(0))
+			}
+			ret.Reward = append(ret.Reward, rewards)
+		}
+		prevState = chainState
+	}
+	// the gas price of the block after newestBlock
+	ret.BaseFee = append(ret.BaseFee, (*hexutil.Big)(gasPriceInState(prevState)))
+	return ret, nil
+}
+
 func (e *EVMChain) StorageAt(address common.Address, key common.Hash, blockNumberOrHash *rpc.BlockNumberOrHash) (common.Hash, error) {
 	e.log.Debugf("StorageAt(address=%v, key=%v, blockNumberOrHash=%v)", address, key, blockNumberOrHash)
 	chainState, err := e.iscStateFromEVMBlockNumberOrHash(blockNumberOrHash)
@@ -586,28 +714,33 @@ func (e *EVMChain) SubscribeNewHeads(ch chan<- *types.Header) (unsubscribe func(
 func (e *EVMChain) SubscribeLogs(q *ethereum.FilterQuery, ch chan<- []*types.Log) (unsubscribe func()) {
 	e.log.Debugf("SubscribeLogs(q=%v, ch=?)", q)
 	return e.newBlock.Hook(func(ev *NewBlockEvent) {
-		if q.BlockHash != nil && *q.BlockHash != ev.block.Hash() {
-			return
-		}
-		if q.FromBlock != nil && q.FromBlock.IsUint64() && q.FromBlock.Cmp(ev.block.Number()) > 0 {
-			return
-		}
-		if q.ToBlock != nil && q.ToBlock.IsUint64() && q.ToBlock.Cmp(ev.block.Number()) < 0 {
-			return
-		}
-
-		var matchedLogs []*types.Log
-		for _, log := range ev.logs {
-			if evmtypes.LogMatches(log, q.Addresses, q.Topics) {
-				matchedLogs = append(matchedLogs, log)
-			}
-		}
+		matchedLogs := ev.matchingLogs(q)
 		if len(matchedLogs) > 0 {
 			ch <- matchedLogs
 		}
 	}).Unhook
 }
 
+func (ev *NewBlockEvent) matchingLogs(q *ethereum.FilterQuery) []*types.Log {
+	if q.BlockHash != nil && *q.BlockHash != ev.block.Hash() {
+		return nil
+	}
+	if q.FromBlock != nil && q.FromBlock.IsUint64() && q.FromBlock.Cmp(ev.block.Number()) > 0 {
+		return nil
+	}
+	if q.ToBlock != nil && q.ToBlock.IsUint64() && q.ToBlock.Cmp(ev.block.Number()) < 0 {
+		return nil
+	}
+
+	var matchedLogs []*types.Log
+	for _, log := range ev.logs {
+		if evmtypes.LogMatches(log, q.Addresses, q.Topics) {
+			matchedLogs = append(matchedLogs, log)
+		}
+	}
+	return matchedLogs
+}
+
 func (e *EVMChain) iscRequestsInBlock(evmBlockNumber uint64) (*blocklog.BlockInfo, []isc.Request, error) {
 	iscState, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(evmBlockNumber))
 	if err != nil {
@@ -620,11 +753,7 @@ func (e *EVMChain) iscRequestsInBlock(evmBlockNumber uint64) (*blocklog.BlockInf
 
 func (e *EVMChain) TraceTransaction(txHash common.Hash, config *tracers.TraceConfig) (any, error) {
 	e.log.Debugf("TraceTransaction(txHash=%v, config=?)", txHash)
-	tracerType := "callTracer"
-	if config.Tracer != nil {
-		tracerType = *config.Tracer
-	}
-	tracer, err := newTracer(tracerType, config.TracerConfig)
+	tracer, err := newTracerFromConfig(config)
 	if err != nil {
 		return nil, err
 	}
@@ -656,6 +785,95 @@ func (e *EVMChain) TraceTransaction(txHash common.Hash, config *tracers.TraceCon
 	return tracer.GetResult()
 }
 
+func (e *EVMChain) TraceBlockByNumber(blockNumber *big.Int, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	e.log.Debugf("TraceBlockByNumber(blockNumber=%v, config=?)", blockNumber)
+	block, err := e.BlockByNumber(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return e.traceBlock(block, config)
+}
+
+func (e *EVMChain) TraceBlockByHash(blockHash common.Hash, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	e.log.Debugf("TraceBlockByHash(blockHash=%v, config=?)", blockHash)
+	block := e.BlockByHash(blockHash)
+	if block == nil {
+		return nil, fmt.Errorf("block with hash %s not found", blockHash)
+	}
+	return e.traceBlock(block, config)
+}
+
+// traceBlock re-runs all ISC requests of the block, tracing all its EVM txs at once.
+func (e *EVMChain) traceBlock(block *types.Block, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis block is not traceable")
+	}
+	tracer, err := newBlockTracer(config)
+	if err != nil {
+		return nil, err
+	}
+
+	iscBlock, iscRequestsInBlock, err := e.iscRequestsInBlock(block.NumberU64())
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.backend.EVMTraceBlock(
+		iscBlock.PreviousAliasOutput,
+		iscBlock.Timestamp,
+		iscRequestsInBlock,
+		tracer,
+		tracer.onTxStart,
+	)
+	if err != nil {
+		return nil, err
+	}
+	txResults, err := tracer.results()
+	if err != nil {
+		return nil, err
+	}
+
+	// L1 deposits are included in the block as unsigned "fake" txs, but they
+	// are not executed by the EVM, so there is no trace for them.
+	ret := make([]*TxTraceResult, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		if !isSignedTx(tx) {
+			ret = append(ret, &TxTraceResult{TxHash: tx.Hash(), Error: "L1 deposit is not traceable"})
+			continue
+		}
+		result, ok := txResults[tx.Hash()]
+		if !ok {
+			return nil, fmt.Errorf("missing trace for tx %s", tx.Hash())
+		}
+		ret = append(ret, &TxTraceResult{TxHash: tx.Hash(), Result: result})
+	}
+	return ret, nil
+}
+
+func isSignedTx(tx *types.Transaction) bool {
+	v, r, s := tx.RawSignatureValues()
+	return !util.IsZeroBigInt(v) || !util.IsZeroBigInt(r) || !util.IsZeroBigInt(s)
+}
+
+// TraceCall executes an EVM call on top of the state of the given block,
+// discarding any state changes, and returns the trace.
+func (e *EVMChain) TraceCall(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.BlockNumberOrHash, config *tracers.TraceConfig) (any, error) {
+	e.log.Debugf("TraceCall(callMsg=..., blockNumberOrHash=%v, config=?)", blockNumberOrHash)
+	tracer, err := newTracerFromConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	aliasOutput, err := e.iscAliasOutputFromEVMBlockNumberOrHash(blockNumberOrHash)
+	if err != nil {
+		return nil, err
+	}
+	err = e.backend.EVMTraceCall(aliasOutput, callMsg, tracer)
+	if err != nil {
+		return nil, err
+	}
+	return tracer.GetResult()
+}
+
 var maxUint32 = big.NewInt(math.MaxUint32)
 
 // the first EVM block (number 0) is "minted" at ISC block index 0 (init chain)
@@ -0,0 +1,144 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var errFilterNotFound = errors.New("filter not found")
+
+type filterKind int
+
+const (
+	filterKindLogs filterKind = iota
+	filterKindBlocks
+	filterKindPendingTxs
+)
+
+// pollingFilter holds the changes collected for a filter installed with
+// eth_newFilter, eth_newBlockFilter or eth_newPendingTransactionFilter
+// since the last call to eth_getFilterChanges.
+type pollingFilter struct {
+	kind     filterKind
+	query    *ethereum.FilterQuery // only for filterKindLogs
+	hashes   []common.Hash         // block or tx hashes
+	logs     []*types.Log
+	lastPoll time.Time
+}
+
+// filterManager keeps the server-side state of the polling filters.
+// Filters that are not polled for longer than the timeout are uninstalled.
+type filterManager struct {
+	mutex   sync.Mutex
+	filters map[rpc.ID]*pollingFilter
+	timeout time.Duration
+}
+
+func newFilterManager(evmChain *EVMChain, timeout time.Duration) *filterManager {
+	fm := &filterManager{
+		filters: map[rpc.ID]*pollingFilter{},
+		timeout: timeout,
+	}
+	evmChain.newBlock.Hook(fm.onNewBlock)
+	evmChain.newPendingTx.Hook(fm.onNewPendingTx)
+	return fm
+}
+
+func (fm *filterManager) install(f *pollingFilter) rpc.ID {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	fm.removeExpired()
+	id := rpc.NewID()
+	f.lastPoll = time.Now()
+	fm.filters[id] = f
+	return id
+}
+
+func (fm *filterManager) uninstall(id rpc.ID) bool {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	_, ok := fm.filters[id]
+	delete(fm.filters, id)
+	return ok
+}
+
+// changes returns the hashes or logs collected since the last poll and resets them.
+func (fm *filterManager) changes(id rpc.ID) (any, error) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	fm.removeExpired()
+	f, ok := fm.filters[id]
+	if !ok {
+		return nil, errFilterNotFound
+	}
+	f.lastPoll = time.Now()
+	if f.kind == filterKindLogs {
+		logs := f.logs
+		f.logs = nil
+		if logs == nil {
+			return []*types.Log{}, nil
+		}
+		return logs, nil
+	}
+	hashes := f.hashes
+	f.hashes = nil
+	if hashes == nil {
+		return []common.Hash{}, nil
+	}
+	return hashes, nil
+}
+
+// logsQuery returns the query of a log filter, refreshing its expiration.
+func (fm *filterManager) logsQuery(id rpc.ID) (*ethereum.FilterQuery, error) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	fm.removeExpired()
+	f, ok := fm.filters[id]
+	if !ok || f.kind != filterKindLogs {
+		return nil, errFilterNotFound
+	}
+	f.lastPoll = time.Now()
+	return f.query, nil
+}
+
+func (fm *filterManager) onNewBlock(ev *NewBlockEvent) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	fm.removeExpired()
+	for _, f := range fm.filters {
+		switch f.kind {
+		case filterKindBlocks:
+			f.hashes = append(f.hashes, ev.block.Hash())
+		case filterKindLogs:
+			f.logs = append(f.logs, ev.matchingLogs(f.query)...)
+		}
+	}
+}
+
+func (fm *filterManager) onNewPendingTx(tx *types.Transaction) {
+	fm.mutex.Lock()
+	defer fm.mutex.Unlock()
+	for _, f := range fm.filters {
+		if f.kind == filterKindPendingTxs {
+			f.hashes = append(f.hashes, tx.Hash())
+		}
+	}
+}
+
+// Must be called with the mutex locked.
+func (fm *filterManager) removeExpired() {
+	for id, f := range fm.filters {
+		if time.Since(f.lastPoll) > fm.timeout {
+			delete(fm.filters, id)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpctest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/evm/evmtest"
+)
+
+func TestFilterBlocks(t *testing.T) {
+	env := newSoloTestEnv(t)
+
+	var filterID rpc.ID
+	err := env.RawClient.Call(&filterID, "eth_newBlockFilter")
+	require.NoError(t, err)
+
+	// this will create a new block
+	_, _ = env.soloChain.NewEthereumAccountWithL2Funds()
+
+	var hashes []common.Hash
+	require.Eventually(t, func() bool {
+		var changes []common.Hash
+		require.NoError(t, env.RawClient.Call(&changes, "eth_getFilterChanges", filterID))
+		hashes = append(hashes, changes...)
+		return len(hashes) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Len(t, hashes, 1)
+	require.Equal(t, env.BlockByNumber(nil).Hash(), hashes[0])
+
+	// changes are reset after each poll
+	var changes []common.Hash
+	require.NoError(t, env.RawClient.Call(&changes, "eth_getFilterChanges", filterID))
+	require.Empty(t, changes)
+
+	var uninstalled bool
+	require.NoError(t, env.RawClient.Call(&uninstalled, "eth_uninstallFilter", filterID))
+	require.True(t, uninstalled)
+	err = env.RawClient.Call(&changes, "eth_getFilterChanges", filterID)
+	require.ErrorContains(t, err, "filter not found")
+}
+
+func TestFilterLogs(t *testing.T) {
+	env := newSoloTestEnv(t)
+
+	creator, creatorAddress := env.NewAccountWithL2Funds()
+	contractABI, err := abi.JSON(strings.NewReader(evmtest.ERC20ContractABI))
+	require.NoError(t, err)
+	contractAddress := crypto.CreateAddress(creatorAddress, env.NonceAt(creatorAddress))
+
+	var filterID rpc.ID
+	err = env.RawClient.Call(&filterID, "eth_newFilter", map[string]any{
+		"address": contractAddress,
+	})
+	require.NoError(t, err)
+
+	_, receipt, _ := env.DeployEVMContract(creator, contractABI, evmtest.ERC20ContractBytecode, "TestCoin", "TEST")
+	require.Len(t, receipt.Logs, 1)
+
+	var logs []types.Log
+	require.Eventually(t, func() bool {
+		var changes []types.Log
+		require.NoError(t, env.RawClient.Call(&changes, "eth_getFilterChanges", filterID))
+		logs = append(logs, changes...)
+		return len(logs) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Len(t, logs, 1)
+	require.Equal(t, receipt.TxHash, logs[0].TxHash)
+
+	var allLogs []types.Log
+	require.NoError(t, env.RawClient.CallContext(context.Background(), &allLogs, "eth_getFilterLogs", filterID))
+	require.Len(t, allLogs, 1)
+	require.Equal(t, logs[0].TxHash, allLogs[0].TxHash)
+}
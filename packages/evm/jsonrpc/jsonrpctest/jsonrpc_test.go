@@ -28,7 +28,9 @@ import (
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/solo"
 	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	"github.com/iotaledger/wasp/packages/util"
 	"github.com/iotaledger/wasp/packages/vm/core/evm"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
 type soloTestEnv struct {
@@ -502,3 +504,69 @@ func BenchmarkRPCEstimateGas(b *testing.B) {
 		require.NotZero(b, n)
 	}
 }
+
+func TestRPCTxPool(t *testing.T) {
+	env := newSoloTestEnv(t)
+	sender, senderAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, receiverAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+
+	// nonces 0 and 1 can be executed, 3 has to wait for 2
+	reqs := []isc.Request{}
+	for _, nonce := range []uint64{0, 1, 3} {
+		tx, err := types.SignTx(
+			types.NewTransaction(nonce, receiverAddress, big.NewInt(1), params.TxGas, env.MustGetGasPrice(), nil),
+			env.Signer(),
+			sender,
+		)
+		require.NoError(t, err)
+		req, err := isc.NewEVMOffLedgerTxRequest(env.soloChain.ChainID, tx)
+		require.NoError(t, err)
+		reqs = append(reqs, req)
+	}
+	env.solo.AddRequestsToMempool(env.soloChain, reqs)
+
+	var status map[string]hexutil.Uint
+	require.NoError(t, env.RawClient.Call(&status, "txpool_status"))
+	require.EqualValues(t, 2, status["pending"])
+	require.EqualValues(t, 1, status["queued"])
+
+	var content map[string]map[string]map[string]*jsonrpc.RPCTransaction
+	require.NoError(t, env.RawClient.Call(&content, "txpool_content"))
+	require.Len(t, content["pending"][senderAddress.Hex()], 2)
+	require.Contains(t, content["pending"][senderAddress.Hex()], "1")
+	require.Len(t, content["queued"][senderAddress.Hex()], 1)
+	require.EqualValues(t, 3, content["queued"][senderAddress.Hex()]["3"].Nonce)
+
+	var inspect map[string]map[string]map[string]string
+	require.NoError(t, env.RawClient.Call(&inspect, "txpool_inspect"))
+	require.Contains(t, inspect["pending"][senderAddress.Hex()]["0"], receiverAddress.Hex())
+}
+
+func TestRPCFeeHistory(t *testing.T) {
+	env := newSoloTestEnv(t)
+	initialGasPrice := env.MustGetGasPrice()
+
+	// mostly empty blocks make the gas price go down
+	dfp := gas.DefaultDynamicFeePolicy()
+	dfp.MaxGasPerToken = util.Ratio32{A: 200, B: 1}
+	env.soloChain.SetDynamicFeePolicy(nil, dfp)
+	for i := 0; i < 3; i++ {
+		env.soloChain.NewEthereumAccountWithL2Funds()
+	}
+	gasPrice := env.MustGetGasPrice()
+	require.Less(t, gasPrice.Uint64(), initialGasPrice.Uint64())
+
+	feeHistory, err := env.Client.FeeHistory(context.Background(), 3, nil, []float64{50})
+	require.NoError(t, err)
+	require.EqualValues(t, env.BlockNumber()-2, feeHistory.OldestBlock.Uint64())
+	require.Len(t, feeHistory.BaseFee, 4)
+	require.Len(t, feeHistory.GasUsedRatio, 3)
+	require.Len(t, feeHistory.Reward, 3)
+	for i := range feeHistory.GasUsedRatio {
+		require.Greater(t, feeHistory.GasUsedRatio[i], 0.0)
+		require.Less(t, feeHistory.GasUsedRatio[i], 0.5)
+		require.Less(t, feeHistory.BaseFee[i+1].Uint64(), feeHistory.BaseFee[i].Uint64())
+		require.Zero(t, feeHistory.Reward[i][0].Uint64())
+	}
+	require.Equal(t, gasPrice, feeHistory.BaseFee[3])
+}
@@ -17,6 +17,7 @@ type Parameters struct {
 	WebsocketRateLimitBurst             int
 	WebsocketConnectionCleanupDuration  time.Duration
 	WebsocketClientBlockDuration        time.Duration
+	FilterTimeout                       time.Duration
 }
 
 func NewParameters(
@@ -26,6 +27,7 @@ func NewParameters(
 	websocketRateLimitBurst int,
 	websocketConnectionCleanupDuration time.Duration,
 	websocketClientBlockDuration time.Duration,
+	filterTimeout time.Duration,
 ) *Parameters {
 	return &Parameters{
 		Logs: LogsLimits{
@@ -36,6 +38,7 @@ func NewParameters(
 		WebsocketRateLimitBurst:             websocketRateLimitBurst,
 		WebsocketConnectionCleanupDuration:  websocketConnectionCleanupDuration,
 		WebsocketClientBlockDuration:        websocketClientBlockDuration,
+		FilterTimeout:                       filterTimeout,
 	}
 }
 
@@ -49,6 +52,7 @@ func ParametersDefault() *Parameters {
 		WebsocketRateLimitBurst:             5,
 		WebsocketConnectionCleanupDuration:  5 * time.Minute,
 		WebsocketClientBlockDuration:        5 * time.Minute,
+		FilterTimeout:                       5 * time.Minute,
 	}
 }
 
@@ -69,7 +73,7 @@ func NewServer(
 		{"net", NewNetService(int(chainID))},
 		{"eth", NewEthService(evmChain, accountManager, metrics, params)},
 		{"debug", NewDebugService(evmChain, metrics)},
-		{"txpool", NewTxPoolService()},
+		{"txpool", NewTxPoolService(evmChain, metrics)},
 		{"evm", NewEVMService(evmChain)},
 	} {
 		err := rpcsrv.RegisterName(srv.namespace, srv.service)
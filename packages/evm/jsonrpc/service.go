@@ -38,6 +38,7 @@ type EthService struct {
 	accounts *AccountManager
 	metrics  *metrics.ChainWebAPIMetrics
 	params   *Parameters
+	filters  *filterManager
 }
 
 func NewEthService(
@@ -51,6 +52,7 @@ func NewEthService(
 		accounts: accounts,
 		metrics:  metrics,
 		params:   params,
+		filters:  newFilterManager(evmChain, params.FilterTimeout),
 	}
 }
 
@@ -280,6 +282,13 @@ func (e *EthService) GasPrice() (*hexutil.Big, error) {
 	})
 }
 
+func (e *EthService) FeeHistory(blockCount hexutil.Uint64, newestBlock rpc.BlockNumber, rewardPercentiles []float64) (*RPCFeeHistoryResult, error) {
+	return withMetrics(e.metrics, "eth_feeHistory", func() (*RPCFeeHistoryResult, error) {
+		ret, err := e.evmChain.FeeHistory(uint64(blockCount), parseBlockNumber(newestBlock), rewardPercentiles)
+		return ret, e.resolveError(err)
+	})
+}
+
 func (e *EthService) Mining() bool {
 	return false
 }
@@ -443,14 +452,62 @@ func (e *EthService) Logs(ctx context.Context, q *RPCFilterQuery) (*rpc.Subscrip
 	return rpcSub, nil
 }
 
+func (e *EthService) NewFilter(q *RPCFilterQuery) (rpc.ID, error) {
+	return withMetrics(e.metrics, "eth_newFilter", func() (rpc.ID, error) {
+		if q == nil {
+			q = &RPCFilterQuery{}
+		}
+		return e.filters.install(&pollingFilter{
+			kind:  filterKindLogs,
+			query: (*ethereum.FilterQuery)(q),
+		}), nil
+	})
+}
+
+func (e *EthService) NewBlockFilter() (rpc.ID, error) {
+	return withMetrics(e.metrics, "eth_newBlockFilter", func() (rpc.ID, error) {
+		return e.filters.install(&pollingFilter{kind: filterKindBlocks}), nil
+	})
+}
+
+// NewPendingTransactionFilter creates a filter for the EVM transactions
+// received by this node, i.e. sent via eth_sendRawTransaction.
+func (e *EthService) NewPendingTransactionFilter() (rpc.ID, error) {
+	return withMetrics(e.metrics, "eth_newPendingTransactionFilter", func() (rpc.ID, error) {
+		return e.filters.install(&pollingFilter{kind: filterKindPendingTxs}), nil
+	})
+}
+
+func (e *EthService) UninstallFilter(id rpc.ID) (bool, error) {
+	return withMetrics(e.metrics, "eth_uninstallFilter", func() (bool, error) {
+		return e.filters.uninstall(id), nil
+	})
+}
+
+// GetFilterChanges returns the logs (for log filters) or the hashes (for block
+// and pending transaction filters) produced since the last poll.
+func (e *EthService) GetFilterChanges(id rpc.ID) (interface{}, error) {
+	return withMetrics(e.metrics, "eth_getFilterChanges", func() (interface{}, error) {
+		return e.filters.changes(id)
+	})
+}
+
+func (e *EthService) GetFilterLogs(id rpc.ID) ([]*types.Log, error) {
+	return withMetrics(e.metrics, "eth_getFilterLogs", func() ([]*types.Log, error) {
+		q, err := e.filters.logsQuery(id)
+		if err != nil {
+			return nil, err
+		}
+		logs, err := e.evmChain.Logs(q, &e.params.Logs)
+		if err != nil {
+			return nil, e.resolveError(err)
+		}
+		return logs, nil
+	})
+}
+
 /*
 Not implemented:
-func (e *EthService) NewFilter()
-func (e *EthService) NewBlockFilter()
-func (e *EthService) NewPendingTransactionFilter()
-func (e *EthService) UninstallFilter()
-func (e *EthService) GetFilterChanges()
-func (e *EthService) GetFilterLogs()
 func (e *EthService) SubmitWork()
 func (e *EthService) GetWork()
 func (e *EthService) SubmitHashrate()
@@ -490,31 +547,108 @@ func (s *Web3Service) Sha3(input hexutil.Bytes) hexutil.Bytes {
 	return crypto.Keccak256(input)
 }
 
-type TxPoolService struct{}
-
-func NewTxPoolService() *TxPoolService {
-	return &TxPoolService{}
+// TxPoolService contains the implementations for the `txpool_*` JSONRPC
+// endpoints. The contents are the EVM transactions waiting in the chain's
+// mempool.
+type TxPoolService struct {
+	evmChain *EVMChain
+	metrics  *metrics.ChainWebAPIMetrics
 }
 
-func (s *TxPoolService) Content() map[string]map[string]map[string]*RPCTransaction {
-	return map[string]map[string]map[string]*RPCTransaction{
-		"pending": make(map[string]map[string]*RPCTransaction),
-		"queued":  make(map[string]map[string]*RPCTransaction),
+func NewTxPoolService(evmChain *EVMChain, metrics *metrics.ChainWebAPIMetrics) *TxPoolService {
+	return &TxPoolService{
+		evmChain: evmChain,
+		metrics:  metrics,
 	}
 }
 
-func (s *TxPoolService) Inspect() map[string]map[string]map[string]string {
-	return map[string]map[string]map[string]string{
-		"pending": make(map[string]map[string]string),
-		"queued":  make(map[string]map[string]string),
+func (s *TxPoolService) Content() (map[string]map[string]map[string]*RPCTransaction, error) {
+	return withMetrics(s.metrics, "txpool_content", func() (map[string]map[string]map[string]*RPCTransaction, error) {
+		pending, queued, err := s.evmChain.TxPoolContent()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]map[string]map[string]*RPCTransaction{
+			"pending": txPoolMap(pending, newPendingRPCTransaction),
+			"queued":  txPoolMap(queued, newPendingRPCTransaction),
+		}, nil
+	})
+}
+
+func (s *TxPoolService) ContentFrom(addr common.Address) (map[string]map[string]*RPCTransaction, error) {
+	return withMetrics(s.metrics, "txpool_contentFrom", func() (map[string]map[string]*RPCTransaction, error) {
+		pending, queued, err := s.evmChain.TxPoolContent()
+		if err != nil {
+			return nil, err
+		}
+		ret := map[string]map[string]*RPCTransaction{
+			"pending": {},
+			"queued":  {},
+		}
+		for _, tx := range pending[addr] {
+			ret["pending"][strconv.FormatUint(tx.Nonce(), 10)] = newPendingRPCTransaction(tx)
+		}
+		for _, tx := range queued[addr] {
+			ret["queued"][strconv.FormatUint(tx.Nonce(), 10)] = newPendingRPCTransaction(tx)
+		}
+		return ret, nil
+	})
+}
+
+func (s *TxPoolService) Inspect() (map[string]map[string]map[string]string, error) {
+	return withMetrics(s.metrics, "txpool_inspect", func() (map[string]map[string]map[string]string, error) {
+		pending, queued, err := s.evmChain.TxPoolContent()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]map[string]map[string]string{
+			"pending": txPoolMap(pending, txPoolSummary),
+			"queued":  txPoolMap(queued, txPoolSummary),
+		}, nil
+	})
+}
+
+func (s *TxPoolService) Status() (map[string]hexutil.Uint, error) {
+	return withMetrics(s.metrics, "txpool_status", func() (map[string]hexutil.Uint, error) {
+		pending, queued, err := s.evmChain.TxPoolContent()
+		if err != nil {
+			return nil, err
+		}
+		count := func(txs map[common.Address][]*types.Transaction) (n int) {
+			for _, list := range txs {
+				n += len(list)
+			}
+			return n
+		}
+		return map[string]hexutil.Uint{
+			"pending": hexutil.Uint(count(pending)),
+			"queued":  hexutil.Uint(count(queued)),
+		}, nil
+	})
+}
+
+// txPoolMap converts the transactions to the sender → nonce → value format used by geth.
+func txPoolMap[T any](txs map[common.Address][]*types.Transaction, conv func(*types.Transaction) T) map[string]map[string]T {
+	ret := make(map[string]map[string]T, len(txs))
+	for sender, list := range txs {
+		byNonce := make(map[string]T, len(list))
+		for _, tx := range list {
+			byNonce[strconv.FormatUint(tx.Nonce(), 10)] = conv(tx)
+		}
+		ret[sender.Hex()] = byNonce
 	}
+	return ret
+}
+
+func newPendingRPCTransaction(tx *types.Transaction) *RPCTransaction {
+	return newRPCTransaction(tx, common.Hash{}, 0, 0)
 }
 
-func (s *TxPoolService) Status() map[string]hexutil.Uint {
-	return map[string]hexutil.Uint{
-		"pending": hexutil.Uint(0),
-		"queued":  hexutil.Uint(0),
+func txPoolSummary(tx *types.Transaction) string {
+	if to := tx.To(); to != nil {
+		return fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
 	}
+	return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
 }
 
 type DebugService struct {
@@ -535,6 +669,24 @@ func (d *DebugService) TraceTransaction(txHash common.Hash, config *tracers.Trac
 	})
 }
 
+func (d *DebugService) TraceBlockByNumber(blockNumber rpc.BlockNumber, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	return withMetrics(d.metrics, "debug_traceBlockByNumber", func() ([]*TxTraceResult, error) {
+		return d.evmChain.TraceBlockByNumber(parseBlockNumber(blockNumber), config)
+	})
+}
+
+func (d *DebugService) TraceBlockByHash(blockHash common.Hash, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	return withMetrics(d.metrics, "debug_traceBlockByHash", func() ([]*TxTraceResult, error) {
+		return d.evmChain.TraceBlockByHash(blockHash, config)
+	})
+}
+
+func (d *DebugService) TraceCall(args *RPCCallArgs, blockNumberOrHash *rpc.BlockNumberOrHash, config *tracers.TraceConfig) (interface{}, error) {
+	return withMetrics(d.metrics, "debug_traceCall", func() (interface{}, error) {
+		return d.evmChain.TraceCall(args.parse(), blockNumberOrHash, config)
+	})
+}
+
 type EVMService struct {
 	evmChain *EVMChain
 }
@@ -3,7 +3,10 @@ package jsonrpc
 import (
 	"encoding/json"
 	"fmt"
+	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 )
 
@@ -22,3 +25,126 @@ func newTracer(tracerType string, cfg json.RawMessage) (tracers.Tracer, error) {
 	}
 	return fn(cfg)
 }
+
+// newTracerFromConfig returns a new tracer of the type given in the config,
+// callTracer being the default.
+func newTracerFromConfig(config *tracers.TraceConfig) (tracers.Tracer, error) {
+	tracerType := "callTracer"
+	var cfg json.RawMessage
+	if config != nil {
+		if config.Tracer != nil {
+			tracerType = *config.Tracer
+		}
+		cfg = config.TracerConfig
+	}
+	return newTracer(tracerType, cfg)
+}
+
+// blockTracer is used to trace all EVM txs of a block in a single run.
+// A new tracer is created at the start of each tx, and all events are
+// forwarded to it.
+type blockTracer struct {
+	config    *tracers.TraceConfig
+	txTracers []tracers.Tracer
+	txHashes  []common.Hash
+	nextHash  common.Hash
+	current   tracers.Tracer
+	err       error
+}
+
+var _ tracers.Tracer = &blockTracer{}
+
+func newBlockTracer(config *tracers.TraceConfig) (*blockTracer, error) {
+	// fail early if the tracer type or config is invalid
+	if _, err := newTracerFromConfig(config); err != nil {
+		return nil, err
+	}
+	return &blockTracer{config: config}, nil
+}
+
+// onTxStart is called with the hash of the tx to be traced next.
+func (t *blockTracer) onTxStart(txHash common.Hash) {
+	t.nextHash = txHash
+}
+
+func (t *blockTracer) CaptureTxStart(gasLimit uint64) {
+	txTracer, err := newTracerFromConfig(t.config)
+	if err != nil {
+		// cannot happen, the config is validated in newBlockTracer
+		panic(err)
+	}
+	t.current = txTracer
+	t.txTracers = append(t.txTracers, txTracer)
+	t.txHashes = append(t.txHashes, t.nextHash)
+	t.nextHash = common.Hash{}
+	txTracer.CaptureTxStart(gasLimit)
+}
+
+func (t *blockTracer) CaptureTxEnd(restGas uint64) {
+	t.current.CaptureTxEnd(restGas)
+	t.current = nil
+}
+
+func (t *blockTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	t.current.CaptureStart(env, from, to, create, input, gas, value)
+}
+
+func (t *blockTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.current.CaptureEnd(output, gasUsed, err)
+}
+
+func (t *blockTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.current.CaptureEnter(typ, from, to, input, gas, value)
+}
+
+func (t *blockTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	t.current.CaptureExit(output, gasUsed, err)
+}
+
+func (t *blockTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	t.current.CaptureState(pc, op, gas, cost, scope, rData, depth, err)
+}
+
+func (t *blockTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+	t.current.CaptureFault(pc, op, gas, cost, scope, depth, err)
+}
+
+// GetResult returns the json-encoded results of each traced tx, by tx hash.
+func (t *blockTracer) GetResult() (json.RawMessage, error) {
+	results, err := t.results()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(results)
+}
+
+func (t *blockTracer) results() (map[common.Hash]json.RawMessage, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	results := make(map[common.Hash]json.RawMessage, len(t.txTracers))
+	for i, txTracer := range t.txTracers {
+		res, err := txTracer.GetResult()
+		if err != nil {
+			return nil, err
+		}
+		results[t.txHashes[i]] = res
+	}
+	return results, nil
+}
+
+func (t *blockTracer) Stop(err error) {
+	if t.current != nil {
+		t.current.Stop(err)
+	}
+	if t.err == nil {
+		t.err = err
+	}
+}
+
+// TxTraceResult is the result of tracing a single tx of a block.
+type TxTraceResult struct {
+	TxHash common.Hash     `json:"txHash"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
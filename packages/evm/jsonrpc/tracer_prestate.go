@@ -0,0 +1,19 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native" // registers the native tracers
+)
+
+func init() {
+	registerTracer("prestateTracer", newPrestateTracer)
+}
+
+// newPrestateTracer returns the native go-ethereum prestateTracer, which
+// collects the accounts (balance, code, nonce and storage) touched by a tx
+// before its execution, or the state diff when configured with diffMode.
+func newPrestateTracer(cfg json.RawMessage) (tracers.Tracer, error) {
+	return tracers.DefaultDirectory.New("prestateTracer", &tracers.Context{}, cfg)
+}
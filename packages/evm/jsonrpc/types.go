@@ -198,6 +198,13 @@ func rpcMarshalLogs(r *types.Receipt) []interface{} {
 	return ret
 }
 
+type RPCFeeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
 type RPCCallArgs struct {
 	From     common.Address  `json:"from"`
 	To       *common.Address `json:"to"`
@@ -4,11 +4,13 @@
 package jsonrpc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/tracers"
@@ -21,11 +23,15 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/parameters"
 	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
 	"github.com/iotaledger/wasp/packages/trie"
 	"github.com/iotaledger/wasp/packages/util"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 )
 
+// mempoolQueryTimeout limits the time to wait for the mempool to list its requests.
+const mempoolQueryTimeout = 5 * time.Second
+
 // WaspEVMBackend is the implementation of [ChainBackend] for the production environment.
 type WaspEVMBackend struct {
 	chain      chain.Chain
@@ -99,6 +105,27 @@ func (b *WaspEVMBackend) EVMTraceTransaction(
 	)
 }
 
+func (b *WaspEVMBackend) EVMTraceBlock(
+	aliasOutput *isc.AliasOutputWithID,
+	blockTime time.Time,
+	iscRequestsInBlock []isc.Request,
+	tracer tracers.Tracer,
+	onTxStart func(txHash common.Hash),
+) error {
+	return chainutil.EVMTraceBlock(
+		b.chain,
+		aliasOutput,
+		blockTime,
+		iscRequestsInBlock,
+		tracer,
+		onTxStart,
+	)
+}
+
+func (b *WaspEVMBackend) EVMTraceCall(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg, tracer tracers.Tracer) error {
+	return chainutil.EVMTraceCall(b.chain, aliasOutput, callMsg, tracer)
+}
+
 func (b *WaspEVMBackend) ISCCallView(chainState state.State, scName, funName string, args dict.Dict) (dict.Dict, error) {
 	return chainutil.CallView(chainState, b.chain, isc.Hn(scName), isc.Hn(funName), args)
 }
@@ -131,13 +158,23 @@ func (b *WaspEVMBackend) ISCStateByBlockIndex(blockIndex uint32) (state.State, e
 	if latestState.BlockIndex() == blockIndex {
 		return latestState, nil
 	}
-	return b.chain.Store().StateByIndex(blockIndex)
+	chainState, err := b.chain.Store().StateByIndex(blockIndex)
+	if err != nil {
+		return nil, indexedstore.WithAvailableHistory(b.chain.Store(), err)
+	}
+	return chainState, nil
 }
 
 func (b *WaspEVMBackend) ISCStateByTrieRoot(trieRoot trie.Hash) (state.State, error) {
 	return b.chain.Store().StateByTrieRoot(trieRoot)
 }
 
+func (b *WaspEVMBackend) ISCMempoolOffLedgerRequests() []isc.OffLedgerRequest {
+	ctx, cancel := context.WithTimeout(context.Background(), mempoolQueryTimeout)
+	defer cancel()
+	return b.chain.GetMempoolOffLedgerRequests(ctx)
+}
+
 func (b *WaspEVMBackend) ISCChainID() *isc.ChainID {
 	chID := b.chain.ID()
 	return &chID
@@ -78,7 +78,7 @@ type UnsignedOffLedgerRequest interface {
 	WithGasBudget(gasBudget uint64) UnsignedOffLedgerRequest
 	WithAllowance(allowance *Assets) UnsignedOffLedgerRequest
 	WithSender(sender *cryptolib.PublicKey) UnsignedOffLedgerRequest
-	Sign(key *cryptolib.KeyPair) OffLedgerRequest
+	Sign(key cryptolib.VariantKeyPair) OffLedgerRequest
 }
 
 type OffLedgerRequest interface {
@@ -96,6 +96,10 @@ type OnLedgerRequest interface {
 	IsInternalUTXO(ChainID) bool
 	OutputID() iotago.OutputID
 	Features() Features
+	// ReturnPath of a refundable cross-chain request, nil if the request is not refundable
+	ReturnPath() *ReturnPath
+	// IsRefund is true if the request sends back the assets of a refundable cross-chain request
+	IsRefund() bool
 }
 
 type ReturnAmountOptions interface {
@@ -228,6 +228,17 @@ func (req *onLedgerRequestData) ReturnAmount() (uint64, bool) {
 	return storageDepositReturn.Amount, true
 }
 
+func (req *onLedgerRequestData) ReturnPath() *ReturnPath {
+	if req.requestMetadata == nil {
+		return nil
+	}
+	return req.requestMetadata.ReturnPath
+}
+
+func (req *onLedgerRequestData) IsRefund() bool {
+	return req.requestMetadata != nil && req.requestMetadata.IsRefund
+}
+
 func (req *onLedgerRequestData) SenderAccount() AgentID {
 	sender := req.senderAddress()
 	if sender == nil {
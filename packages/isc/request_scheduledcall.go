@@ -0,0 +1,187 @@
+package isc
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// ScheduledCall is a call registered by a contract, to be executed by the chain itself
+// at the start of the first block with a timestamp not before Time.
+// The gas of the call is paid from the account of the contract that registered it.
+type ScheduledCall struct {
+	ID uint32
+	// Contract is the contract that registered the call. It is the sender of the call.
+	Contract Hname
+	Target   CallTarget
+	Params   dict.Dict
+	// GasBudget for each execution of the call
+	GasBudget uint64
+	// Time when the call is due next
+	Time time.Time
+	// Interval between the executions of a recurring call, 0 for a one-time call
+	Interval time.Duration
+}
+
+func ScheduledCallFromBytes(data []byte) (*ScheduledCall, error) {
+	return rwutil.ReadFromBytes(data, new(ScheduledCall))
+}
+
+func (c *ScheduledCall) Bytes() []byte {
+	return rwutil.WriteToBytes(c)
+}
+
+func (c *ScheduledCall) IsRecurring() bool {
+	return c.Interval > 0
+}
+
+// NextTime returns the first time the call is due after the given time,
+// or false if the call is not recurring
+func (c *ScheduledCall) NextTime(after time.Time) (time.Time, bool) {
+	if !c.IsRecurring() {
+		return time.Time{}, false
+	}
+	if c.Time.After(after) {
+		return c.Time, true
+	}
+	periods := after.Sub(c.Time)/c.Interval + 1
+	return c.Time.Add(periods * c.Interval), true
+}
+
+func (c *ScheduledCall) String() string {
+	return fmt.Sprintf("ScheduledCall(id: %d, contract: %s, target: %s.%s, time: %v, interval: %v)",
+		c.ID, c.Contract, c.Target.Contract, c.Target.EntryPoint, c.Time, c.Interval)
+}
+
+func (c *ScheduledCall) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	c.ID = rr.ReadUint32()
+	rr.Read(&c.Contract)
+	rr.Read(&c.Target.Contract)
+	rr.Read(&c.Target.EntryPoint)
+	c.Params = dict.New()
+	rr.Read(&c.Params)
+	c.GasBudget = rr.ReadGas64()
+	c.Time = time.Unix(0, rr.ReadInt64())
+	c.Interval = rr.ReadDuration()
+	return rr.Err
+}
+
+func (c *ScheduledCall) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteUint32(c.ID)
+	ww.Write(&c.Contract)
+	ww.Write(&c.Target.Contract)
+	ww.Write(&c.Target.EntryPoint)
+	ww.Write(&c.Params)
+	ww.WriteGas64(c.GasBudget)
+	ww.WriteInt64(c.Time.UnixNano())
+	ww.WriteDuration(c.Interval)
+	return ww.Err
+}
+
+// ----------------------------------------------------------------
+
+// ScheduledCallRequest is a synthetic request issued by the VM at the start of a block
+// to execute a due ScheduledCall
+type ScheduledCallRequest interface {
+	Request
+	ScheduledCall() *ScheduledCall
+}
+
+type scheduledCallRequest struct {
+	chainID ChainID
+	call    ScheduledCall
+}
+
+var _ ScheduledCallRequest = &scheduledCallRequest{}
+
+func NewScheduledCallRequest(chainID ChainID, call *ScheduledCall) ScheduledCallRequest {
+	return &scheduledCallRequest{
+		chainID: chainID,
+		call:    *call,
+	}
+}
+
+func (req *scheduledCallRequest) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	rr.ReadKindAndVerify(rwutil.Kind(requestKindScheduledCall))
+	rr.Read(&req.chainID)
+	rr.Read(&req.call)
+	return rr.Err
+}
+
+func (req *scheduledCallRequest) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteKind(rwutil.Kind(requestKindScheduledCall))
+	ww.Write(&req.chainID)
+	ww.Write(&req.call)
+	return ww.Err
+}
+
+func (req *scheduledCallRequest) Allowance() *Assets {
+	return NewEmptyAssets()
+}
+
+func (req *scheduledCallRequest) Assets() *Assets {
+	return NewEmptyAssets()
+}
+
+func (req *scheduledCallRequest) Bytes() []byte {
+	return rwutil.WriteToBytes(req)
+}
+
+func (req *scheduledCallRequest) CallTarget() CallTarget {
+	return req.call.Target
+}
+
+func (req *scheduledCallRequest) EVMCallData() *EVMCallData {
+	return nil
+}
+
+func (req *scheduledCallRequest) GasBudget() (gas uint64, isEVM bool) {
+	return req.call.GasBudget, false
+}
+
+// ID is unique for each execution of the call, because the due time is part of the request
+func (req *scheduledCallRequest) ID() RequestID {
+	return NewRequestID(iotago.TransactionID(hashing.HashData(req.Bytes())), 0)
+}
+
+func (req *scheduledCallRequest) IsOffLedger() bool {
+	return false
+}
+
+func (req *scheduledCallRequest) NFT() *NFT {
+	return nil
+}
+
+func (req *scheduledCallRequest) Params() dict.Dict {
+	return req.call.Params
+}
+
+func (req *scheduledCallRequest) ScheduledCall() *ScheduledCall {
+	return &req.call
+}
+
+func (req *scheduledCallRequest) SenderAccount() AgentID {
+	return NewContractAgentID(req.chainID, req.call.Contract)
+}
+
+func (req *scheduledCallRequest) String() string {
+	return fmt.Sprintf("%T::{ ID: %s, %s }", req, req.ID(), req.call.String())
+}
+
+func (req *scheduledCallRequest) TargetAddress() iotago.Address {
+	return req.chainID.AsAddress()
+}
+
+func (req *scheduledCallRequest) TxValue() *big.Int {
+	return big.NewInt(0)
+}
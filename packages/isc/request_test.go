@@ -3,6 +3,7 @@ package isc
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -51,9 +52,46 @@ func TestRequestDataSerialization(t *testing.T) {
 		req, err = OnLedgerFromUTXO(basicOutput, iotago.OutputID{})
 		require.NoError(t, err)
 		rwutil.ReadWriteTest(t, req.(*onLedgerRequestData), new(onLedgerRequestData))
+		require.Nil(t, req.(OnLedgerRequest).ReturnPath())
 	})
 }
 
+func TestRequestMetadataReturnPath(t *testing.T) {
+	requestMetadata := &RequestMetadata{
+		SenderContract: ContractIdentityFromHname(Hn("sender_contract")),
+		TargetContract: Hn("target_contract"),
+		EntryPoint:     Hn("entrypoint"),
+		Params:         dict.New(),
+		Allowance:      NewAssetsBaseTokens(1),
+		GasBudget:      1000,
+	}
+	rwutil.BytesTest(t, requestMetadata, RequestMetadataFromBytes)
+
+	requestMetadata.ReturnPath = &ReturnPath{
+		Callback:  Hn("callback"),
+		Deadline:  time.Unix(1_700_000_000, 0),
+		GasBudget: 500,
+	}
+	rwutil.BytesTest(t, requestMetadata, RequestMetadataFromBytes)
+	require.True(t, requestMetadata.ReturnPath.IsExpired(time.Unix(1_700_000_001, 0)))
+	require.False(t, requestMetadata.ReturnPath.IsExpired(time.Unix(1_700_000_000, 0)))
+	require.False(t, (&ReturnPath{}).IsExpired(time.Now()))
+
+	requestMetadata.IsRefund = true
+	rwutil.BytesTest(t, requestMetadata, RequestMetadataFromBytes)
+	requestMetadata.ReturnPath = nil
+	rwutil.BytesTest(t, requestMetadata, RequestMetadataFromBytes)
+
+	// trailing bytes must carry valid flags
+	data := requestMetadata.Bytes()
+	data[len(data)-1] = 0
+	_, err := RequestMetadataFromBytes(data)
+	require.Error(t, err)
+	data[len(data)-1] = 0x80
+	_, err = RequestMetadataFromBytes(data)
+	require.Error(t, err)
+}
+
 func TestRequestIDSerialization(t *testing.T) {
 	req := NewOffLedgerRequest(RandomChainID(), 3, 14, dict.New(), 1337, 200).Sign(cryptolib.NewKeyPair())
 	requestID := req.ID()
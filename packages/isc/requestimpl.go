@@ -20,6 +20,7 @@ const (
 	requestKindOffLedgerISC
 	requestKindOffLedgerEVMTx
 	requestKindOffLedgerEVMCall
+	requestKindScheduledCall
 )
 
 func IsOffledgerKind(b byte) bool {
@@ -46,6 +47,8 @@ func RequestFromReader(rr *rwutil.Reader) (ret Request) {
 		ret = new(evmOffLedgerTxRequest)
 	case requestKindOffLedgerEVMCall:
 		ret = new(evmOffLedgerCallRequest)
+	case requestKindScheduledCall:
+		ret = new(scheduledCallRequest)
 	default:
 		if rr.Err == nil {
 			rr.Err = errors.New("invalid Request kind")
@@ -213,8 +216,21 @@ type RequestMetadata struct {
 	Allowance *Assets `json:"allowance"`
 	// gas budget
 	GasBudget uint64 `json:"gasBudget"`
+	// ReturnPath of a refundable cross-chain request, nil if the request is not refundable
+	ReturnPath *ReturnPath `json:"returnPath,omitempty"`
+	// IsRefund marks the request sending back the assets of a refundable cross-chain request.
+	// If the call fails, the assets are credited to the target contract instead of the sender.
+	IsRefund bool `json:"isRefund,omitempty"`
 }
 
+// flags of the optional fields of the request metadata
+const (
+	requestMetadataFlagReturnPath byte = 1 << iota
+	requestMetadataFlagRefund
+
+	requestMetadataFlagsAll = requestMetadataFlagReturnPath | requestMetadataFlagRefund
+)
+
 func requestMetadataFromFeatureSet(set iotago.FeatureSet) (*RequestMetadata, error) {
 	metadataFeatBlock := set.MetadataFeature()
 	if metadataFeatBlock == nil {
@@ -241,6 +257,8 @@ func (meta *RequestMetadata) Clone() *RequestMetadata {
 		Params:         meta.Params.Clone(),
 		Allowance:      meta.Allowance.Clone(),
 		GasBudget:      meta.GasBudget,
+		ReturnPath:     meta.ReturnPath.Clone(),
+		IsRefund:       meta.IsRefund,
 	}
 }
 
@@ -258,6 +276,22 @@ func (meta *RequestMetadata) Read(r io.Reader) error {
 	rr.Read(&meta.Params)
 	meta.Allowance = NewEmptyAssets()
 	rr.Read(meta.Allowance)
+	// the flags of the optional fields are only present if any is set,
+	// to keep the metadata of regular requests unchanged
+	meta.ReturnPath = nil
+	meta.IsRefund = false
+	if rr.Err != nil || len(rr.Bytes()) == 0 {
+		return rr.Err
+	}
+	flags := rr.ReadByte()
+	if rr.Err == nil && (flags == 0 || flags&^requestMetadataFlagsAll != 0) {
+		rr.Err = fmt.Errorf("invalid request metadata flags: %#x", flags)
+	}
+	if flags&requestMetadataFlagReturnPath != 0 {
+		meta.ReturnPath = new(ReturnPath)
+		rr.Read(meta.ReturnPath)
+	}
+	meta.IsRefund = flags&requestMetadataFlagRefund != 0
 	return rr.Err
 }
 
@@ -269,5 +303,19 @@ func (meta *RequestMetadata) Write(w io.Writer) error {
 	ww.WriteGas64(meta.GasBudget)
 	ww.Write(&meta.Params)
 	ww.Write(meta.Allowance)
+	var flags byte
+	if meta.ReturnPath != nil {
+		flags |= requestMetadataFlagReturnPath
+	}
+	if meta.IsRefund {
+		flags |= requestMetadataFlagRefund
+	}
+	if flags == 0 {
+		return ww.Err
+	}
+	ww.WriteByte(flags)
+	if meta.ReturnPath != nil {
+		ww.Write(meta.ReturnPath)
+	}
 	return ww.Err
 }
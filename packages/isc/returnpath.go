@@ -0,0 +1,71 @@
+package isc
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// parameters of the callback of a refunded cross-chain request
+const (
+	// ParamReturnRequestID is the ID of the refunded request on the target chain
+	ParamReturnRequestID = "rr"
+	// ParamReturnError is the reason of the refund, either the error of the request or ErrCrossChainRequestExpired
+	ParamReturnError = "re"
+)
+
+// ReturnPath makes a cross-chain request refundable.
+// When the request fails on the target chain, or it is not processed before the deadline,
+// the target chain sends the assets of the request back to the sender contract on the origin chain,
+// calling its Callback entry point.
+type ReturnPath struct {
+	// Callback is the entry point of the sender contract that is called with the refund.
+	// The nil hname just credits the refunded assets to the sender contract.
+	Callback Hname
+	// Deadline is the time after which the request is refunded without being executed.
+	// Zero means the request has no deadline.
+	Deadline time.Time
+	// GasBudget of the callback
+	GasBudget uint64
+}
+
+func (p *ReturnPath) IsExpired(now time.Time) bool {
+	return !p.Deadline.IsZero() && now.After(p.Deadline)
+}
+
+func (p *ReturnPath) Clone() *ReturnPath {
+	if p == nil {
+		return nil
+	}
+	ret := *p
+	return &ret
+}
+
+func (p *ReturnPath) String() string {
+	return fmt.Sprintf("ReturnPath(callback: %s, deadline: %v, gas budget: %d)", p.Callback, p.Deadline, p.GasBudget)
+}
+
+func (p *ReturnPath) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	rr.Read(&p.Callback)
+	p.Deadline = time.Time{}
+	if deadline := rr.ReadInt64(); deadline != 0 {
+		p.Deadline = time.Unix(0, deadline)
+	}
+	p.GasBudget = rr.ReadGas64()
+	return rr.Err
+}
+
+func (p *ReturnPath) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.Write(&p.Callback)
+	if p.Deadline.IsZero() {
+		ww.WriteInt64(0)
+	} else {
+		ww.WriteInt64(p.Deadline.UnixNano())
+	}
+	ww.WriteGas64(p.GasBudget)
+	return ww.Err
+}
@@ -7,6 +7,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 
 	iotago "github.com/iotaledger/iota.go/v3"
@@ -116,11 +117,17 @@ type Sandbox interface {
 	EstimateRequiredStorageDeposit(r RequestParameters) uint64
 	// StateAnchor properties of the anchor output
 	StateAnchor() *StateAnchor
+	// ScheduleCall registers a call to be executed by the chain at the start of the first block with
+	// a timestamp not before 'at', and then every 'interval' if it is not zero.
+	// The gas of the call is paid from the account of the current contract. Returns the ID of the call
+	ScheduleCall(target, entryPoint Hname, params dict.Dict, gasBudget uint64, at time.Time, interval time.Duration) uint32
+	// CancelScheduledCall cancels a call registered by the current contract
+	CancelScheduledCall(id uint32)
 
 	RequestIndex() uint16
 
 	// EVMTracer returns a non-nil tracer if an EVM tx is being traced
-	// (e.g. with the debug_traceTransaction JSONRPC method).
+	// (e.g. with the debug_trace* JSONRPC methods).
 	EVMTracer() *EVMTracer
 
 	// TakeStateSnapshot takes a snapshot of the state. This is useful to implement the try/catch
@@ -206,6 +213,8 @@ type SendMetadata struct {
 	Params         dict.Dict
 	Allowance      *Assets
 	GasBudget      uint64
+	// ReturnPath makes a cross-chain request refundable, nil if the request is fire-and-forget
+	ReturnPath *ReturnPath
 }
 
 // Utils implement various utilities which are faster on host side than on wasm VM
@@ -235,6 +244,12 @@ type BLS interface {
 }
 
 type EVMTracer struct {
-	Tracer  tracers.Tracer
-	TxIndex uint64
+	Tracer tracers.Tracer
+	// TxIndex is the index in the block of the EVM tx to be traced.
+	// If nil, all EVM txs (and calls) are traced with the same tracer.
+	TxIndex *uint64
+	// OnTxStart, if set, restricts tracing to EVM txs (EVM calls made by
+	// other requests are not traced), and is called with the hash of each
+	// tx before it is traced.
+	OnTxStart func(txHash common.Hash)
 }
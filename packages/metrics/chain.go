@@ -19,6 +19,7 @@ type ChainMetrics struct {
 	Message      *ChainMessageMetrics
 	StateManager *ChainStateManagerMetrics
 	Snapshots    *ChainSnapshotsMetrics
+	Pruning      *ChainPruningMetrics
 	NodeConn     *ChainNodeConnMetrics
 	WebAPI       *ChainWebAPIMetrics
 	State        *ChainStateMetrics
@@ -37,6 +38,7 @@ type ChainMetricsProvider struct {
 	Message      *ChainMessageMetricsProvider
 	StateManager *ChainStateManagerMetricsProvider
 	Snapshots    *ChainSnapshotsMetricsProvider
+	Pruning      *ChainPruningMetricsProvider
 	NodeConn     *ChainNodeConnMetricsProvider
 	WebAPI       *ChainWebAPIMetricsProvider
 	State        *ChainStateMetricsProvider
@@ -54,6 +56,7 @@ func NewChainMetricsProvider() *ChainMetricsProvider {
 		Message:      newChainMessageMetricsProvider(),
 		StateManager: newChainStateManagerMetricsProvider(),
 		Snapshots:    newChainSnapshotsMetricsProvider(),
+		Pruning:      newChainPruningMetricsProvider(),
 		NodeConn:     newChainNodeConnMetricsProvider(),
 		WebAPI:       NewChainWebAPIMetricsProvider(),
 		State:        newChainStateMetricsProvider(),
@@ -69,6 +72,7 @@ func (m *ChainMetricsProvider) Register(reg prometheus.Registerer) {
 	m.Message.register(reg)
 	m.StateManager.register(reg)
 	m.Snapshots.register(reg)
+	m.Pruning.register(reg)
 	m.NodeConn.register(reg)
 	m.WebAPI.register(reg)
 	m.State.register(reg)
@@ -90,6 +94,7 @@ func (m *ChainMetricsProvider) GetChainMetrics(chainID isc.ChainID) *ChainMetric
 		Message:      m.Message.createForChain(chainID),
 		StateManager: m.StateManager.createForChain(chainID),
 		Snapshots:    m.Snapshots.createForChain(chainID),
+		Pruning:      m.Pruning.createForChain(chainID),
 		NodeConn:     m.NodeConn.createForChain(chainID),
 		WebAPI:       m.WebAPI.CreateForChain(chainID),
 		State:        m.State.createForChain(chainID),
@@ -25,6 +25,8 @@ const (
 	labelNameWebapiRequestOperation                 = "api_req_type"
 	labelNameWebapiRequestStatusCode                = "api_req_status_code"
 	labelNameWebapiEvmRPCSuccess                    = "success"
+	labelNameWebapiRateLimit                        = "limit"
+	labelNameMempoolOrderingPolicy                  = "policy"
 )
 
 func getChainLabels(chainID isc.ChainID) prometheus.Labels {
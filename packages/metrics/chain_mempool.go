@@ -23,6 +23,9 @@ type ChainMempoolMetricsProvider struct {
 	offLedgerReqTime  *prometheus.HistogramVec
 	totalSize         *prometheus.GaugeVec
 	missingReqs       *prometheus.GaugeVec
+
+	orderingPolicy               *prometheus.GaugeVec
+	requestsRejectedAccountLimit *prometheus.CounterVec
 }
 
 func newChainMempoolMetricsProvider() *ChainMempoolMetricsProvider {
@@ -107,6 +110,18 @@ func newChainMempoolMetricsProvider() *ChainMempoolMetricsProvider {
 			Name:      "missing_reqs",
 			Help:      "Number of requests missing at this node (asking others to send them).",
 		}, []string{labelNameChain}),
+		orderingPolicy: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "mempool",
+			Name:      "ordering_policy",
+			Help:      "Ordering policy used to build the consensus proposals (1 for the policy in use).",
+		}, []string{labelNameChain, labelNameMempoolOrderingPolicy}),
+		requestsRejectedAccountLimit: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "mempool",
+			Name:      "rejected_account_limit_total",
+			Help:      "Number of off-ledger requests rejected because their account has too many requests in mempool.",
+		}, []string{labelNameChain}),
 	}
 }
 
@@ -125,6 +140,8 @@ func (p *ChainMempoolMetricsProvider) register(reg prometheus.Registerer) {
 		p.offLedgerReqTime,
 		p.totalSize,
 		p.missingReqs,
+		p.orderingPolicy,
+		p.requestsRejectedAccountLimit,
 	)
 }
 
@@ -159,6 +176,7 @@ func newChainMempoolMetrics(collectors *ChainMempoolMetricsProvider, chainID isc
 	collectors.offLedgerReqTime.With(labels)
 	collectors.totalSize.With(labels)
 	collectors.missingReqs.With(labels)
+	collectors.requestsRejectedAccountLimit.With(labels)
 
 	return &ChainMempoolMetrics{
 		collectors: collectors,
@@ -223,3 +241,12 @@ func (m *ChainMempoolMetrics) SetOnLedgerReqTime(d time.Duration) {
 func (m *ChainMempoolMetrics) SetOffLedgerReqTime(d time.Duration) {
 	m.collectors.offLedgerReqTime.With(m.labels).Observe(d.Seconds())
 }
+
+func (m *ChainMempoolMetrics) SetOrderingPolicy(policy string) {
+	m.collectors.orderingPolicy.DeletePartialMatch(m.labels)
+	m.collectors.orderingPolicy.MustCurryWith(m.labels).With(prometheus.Labels{labelNameMempoolOrderingPolicy: policy}).Set(1)
+}
+
+func (m *ChainMempoolMetrics) IncRequestsRejectedAccountLimit() {
+	m.collectors.requestsRejectedAccountLimit.With(m.labels).Inc()
+}
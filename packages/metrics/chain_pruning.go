@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/wasp/packages/isc"
+)
+
+type ChainPruningMetricsProvider struct {
+	statesPruned    *countAndMaxMetrics
+	pruneDuration   *prometheus.HistogramVec
+	runDuration     *prometheus.HistogramVec
+	runStatesPruned *prometheus.HistogramVec
+	statesToPrune   *prometheus.GaugeVec
+	flushes         *prometheus.CounterVec
+	flushDuration   *prometheus.HistogramVec
+}
+
+func newChainPruningMetricsProvider() *ChainPruningMetricsProvider {
+	return &ChainPruningMetricsProvider{
+		statesPruned: newCountAndMaxMetrics(
+			prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: "iota_wasp",
+				Subsystem: "pruning",
+				Name:      "states_pruned",
+				Help:      "Total number of states pruned by the pruning service",
+			}, []string{labelNameChain}),
+			prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: "iota_wasp",
+				Subsystem: "pruning",
+				Name:      "max_pruned_index",
+				Help:      "Largest index of pruned state",
+			}, []string{labelNameChain}),
+		),
+		pruneDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "pruning",
+			Name:      "prune_duration",
+			Help:      "The duration (s) of pruning a single state",
+			Buckets:   execTimeBuckets,
+		}, []string{labelNameChain}),
+		runDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "pruning",
+			Name:      "run_duration",
+			Help:      "The duration (s) of a single pruning run",
+			Buckets:   execTimeBuckets,
+		}, []string{labelNameChain}),
+		runStatesPruned: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "pruning",
+			Name:      "run_states_pruned",
+			Help:      "Number of states pruned in a single pruning run",
+			Buckets:   recCountBuckets,
+		}, []string{labelNameChain}),
+		statesToPrune: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "pruning",
+			Name:      "states_to_prune",
+			Help:      "Number of states waiting to be pruned according to the pruning policy",
+		}, []string{labelNameChain}),
+		flushes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "pruning",
+			Name:      "flushes",
+			Help:      "Total number of database flushes made by the pruning service",
+		}, []string{labelNameChain}),
+		flushDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "pruning",
+			Name:      "flush_duration",
+			Help:      "The duration (s) of flushing the database after pruning",
+			Buckets:   execTimeBuckets,
+		}, []string{labelNameChain}),
+	}
+}
+
+func (p *ChainPruningMetricsProvider) register(reg prometheus.Registerer) {
+	reg.MustRegister(
+		p.pruneDuration,
+		p.runDuration,
+		p.runStatesPruned,
+		p.statesToPrune,
+		p.flushes,
+		p.flushDuration,
+	)
+	reg.MustRegister(p.statesPruned.collectors()...)
+}
+
+func (p *ChainPruningMetricsProvider) createForChain(chainID isc.ChainID) *ChainPruningMetrics {
+	return newChainPruningMetrics(p, chainID)
+}
+
+type ChainPruningMetrics struct {
+	labels     prometheus.Labels
+	collectors *ChainPruningMetricsProvider
+}
+
+func newChainPruningMetrics(collectors *ChainPruningMetricsProvider, chainID isc.ChainID) *ChainPruningMetrics {
+	labels := getChainLabels(chainID)
+
+	// init values so they appear in prometheus
+	collectors.statesPruned.with(labels)
+	collectors.pruneDuration.With(labels)
+	collectors.runDuration.With(labels)
+	collectors.runStatesPruned.With(labels)
+	collectors.statesToPrune.With(labels)
+	collectors.flushes.With(labels)
+	collectors.flushDuration.With(labels)
+
+	return &ChainPruningMetrics{
+		collectors: collectors,
+		labels:     labels,
+	}
+}
+
+func (m *ChainPruningMetrics) StatePruned(duration time.Duration, stateIndex uint32) {
+	m.collectors.pruneDuration.With(m.labels).Observe(duration.Seconds())
+	m.collectors.statesPruned.countValue(m.labels, float64(stateIndex))
+}
+
+func (m *ChainPruningMetrics) PruningRunCompleted(duration time.Duration, statesPruned int) {
+	m.collectors.runDuration.With(m.labels).Observe(duration.Seconds())
+	m.collectors.runStatesPruned.With(m.labels).Observe(float64(statesPruned))
+}
+
+func (m *ChainPruningMetrics) SetStatesToPrune(count int) {
+	m.collectors.statesToPrune.With(m.labels).Set(float64(count))
+}
+
+func (m *ChainPruningMetrics) FlushCompleted(duration time.Duration) {
+	m.collectors.flushes.With(m.labels).Inc()
+	m.collectors.flushDuration.With(m.labels).Observe(duration.Seconds())
+}
@@ -10,8 +10,10 @@ import (
 )
 
 type ChainWebAPIMetricsProvider struct {
-	requests    *prometheus.HistogramVec
-	evmRPCCalls *prometheus.HistogramVec
+	requests             *prometheus.HistogramVec
+	evmRPCCalls          *prometheus.HistogramVec
+	offLedgerRateLimits  *prometheus.GaugeVec
+	offLedgerRateLimited *prometheus.CounterVec
 }
 
 func NewChainWebAPIMetricsProvider() *ChainWebAPIMetricsProvider {
@@ -30,6 +32,18 @@ func NewChainWebAPIMetricsProvider() *ChainWebAPIMetricsProvider {
 			Help:      "Time elapsed (s) processing evm rpc requests",
 			Buckets:   execTimeBuckets,
 		}, []string{labelNameChain, labelNameWebapiRequestOperation, labelNameWebapiEvmRPCSuccess}),
+		offLedgerRateLimits: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "webapi",
+			Name:      "offledger_rate_limit",
+			Help:      "Number of off-ledger requests accepted per second by each rate limit (0 if disabled)",
+		}, []string{labelNameChain, labelNameWebapiRateLimit}),
+		offLedgerRateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "webapi",
+			Name:      "offledger_rate_limited_total",
+			Help:      "Number of off-ledger requests rejected by each rate limit",
+		}, []string{labelNameChain, labelNameWebapiRateLimit}),
 	}
 }
 
@@ -37,6 +51,8 @@ func (p *ChainWebAPIMetricsProvider) register(reg prometheus.Registerer) {
 	reg.MustRegister(
 		p.requests,
 		p.evmRPCCalls,
+		p.offLedgerRateLimits,
+		p.offLedgerRateLimited,
 	)
 }
 
@@ -69,3 +85,15 @@ func (m *ChainWebAPIMetrics) EVMRPCCall(operation string, success bool, duration
 	labels[labelNameWebapiEvmRPCSuccess] = fmt.Sprintf("%v", success)
 	m.collectors.evmRPCCalls.With(labels).Observe(duration.Seconds())
 }
+
+func (m *ChainWebAPIMetrics) SetOffLedgerRateLimit(limit string, requestsPerSecond int) {
+	labels := getChainLabels(m.chainID)
+	labels[labelNameWebapiRateLimit] = limit
+	m.collectors.offLedgerRateLimits.With(labels).Set(float64(requestsPerSecond))
+}
+
+func (m *ChainWebAPIMetrics) IncOffLedgerRateLimited(limit string) {
+	labels := getChainLabels(m.chainID)
+	labels[labelNameWebapiRateLimit] = limit
+	m.collectors.offLedgerRateLimited.With(labels).Inc()
+}
@@ -126,7 +126,7 @@ func calcStateMetadata(initParams dict.Dict, commonAccountAmount uint64, schemaV
 // NewChainOriginTransaction creates new origin transaction for the self-governed chain
 // returns the transaction and newly minted chain ID
 func NewChainOriginTransaction(
-	keyPair *cryptolib.KeyPair,
+	keyPair cryptolib.VariantKeyPair,
 	stateControllerAddress iotago.Address,
 	governanceControllerAddress iotago.Address,
 	deposit uint64,
@@ -189,16 +189,13 @@ func NewChainOriginTransaction(
 		Inputs:    txInputs.UTXOInputs(),
 		Outputs:   outputs,
 	}
-	sigs, err := essence.Sign(
-		txInputs.OrderedSet(unspentOutputs).MustCommitment(),
-		keyPair.GetPrivateKey().AddressKeysForEd25519Address(walletAddr),
-	)
+	sig, err := transaction.SignEssence(essence, txInputs.OrderedSet(unspentOutputs).MustCommitment(), keyPair)
 	if err != nil {
 		return nil, aliasOutput, isc.ChainID{}, err
 	}
 	tx := &iotago.Transaction{
 		Essence: essence,
-		Unlocks: transaction.MakeSignatureAndReferenceUnlocks(len(txInputs), sigs[0]),
+		Unlocks: transaction.MakeSignatureAndReferenceUnlocks(len(txInputs), sig),
 	}
 	txid, err := tx.ID()
 	if err != nil {
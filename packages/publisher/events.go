@@ -23,11 +23,12 @@ const (
 )
 
 type ISCEvent[T any] struct {
-	Kind      ISCEventType  `json:"kind"`
-	Issuer    isc.AgentID   `json:"issuer"`    // (AgentID) nil means issued by the VM
-	RequestID isc.RequestID `json:"requestID"` // (isc.RequestID)
-	ChainID   isc.ChainID   `json:"chainID"`   // (isc.ChainID)
-	Payload   T             `json:"payload"`
+	Kind       ISCEventType  `json:"kind"`
+	Issuer     isc.AgentID   `json:"issuer"`     // (AgentID) nil means issued by the VM
+	RequestID  isc.RequestID `json:"requestID"`  // (isc.RequestID)
+	ChainID    isc.ChainID   `json:"chainID"`    // (isc.ChainID)
+	BlockIndex uint32        `json:"blockIndex"` // index of the block, which caused the event
+	Payload    T             `json:"payload"`
 }
 
 // kind is not printed right now, because it is added when calling p.publish
@@ -58,11 +59,12 @@ func triggerEvent[T any](events *Events, event *event.Event1[*ISCEvent[T]], obj
 	// Otherwise Solo and other consumers would have to subscribe to each event manually,
 	// and we would have to make sure that each new event gets added there too.
 	events.Published.Trigger(&ISCEvent[any]{
-		Kind:      obj.Kind,
-		Issuer:    obj.Issuer,
-		RequestID: obj.RequestID,
-		ChainID:   obj.ChainID,
-		Payload:   obj.Payload,
+		Kind:       obj.Kind,
+		Issuer:     obj.Issuer,
+		RequestID:  obj.RequestID,
+		ChainID:    obj.ChainID,
+		BlockIndex: obj.BlockIndex,
+		Payload:    obj.Payload,
 	})
 }
 
@@ -87,7 +89,8 @@ func PublishBlockEvents(blockApplied *blockApplied, events *Events, log *logger.
 			BlockInfo: blockInfo,
 			TrieRoot:  block.TrieRoot(),
 		},
-		ChainID: chainID,
+		ChainID:    chainID,
+		BlockIndex: blockIndex,
 	})
 
 	//
@@ -110,11 +113,12 @@ func PublishBlockEvents(blockApplied *blockApplied, events *Events, log *logger.
 			parsedReceipt := receipt.ToISCReceipt(vmError)
 
 			triggerEvent(events, events.RequestReceipt, &ISCEvent[*ReceiptWithError]{
-				Kind:      ISCEventKindReceipt,
-				Issuer:    receipt.Request.SenderAccount(),
-				Payload:   &ReceiptWithError{RequestReceipt: parsedReceipt, Error: vmError},
-				RequestID: receipt.Request.ID(),
-				ChainID:   chainID,
+				Kind:       ISCEventKindReceipt,
+				Issuer:     receipt.Request.SenderAccount(),
+				Payload:    &ReceiptWithError{RequestReceipt: parsedReceipt, Error: vmError},
+				RequestID:  receipt.Request.ID(),
+				ChainID:    chainID,
+				BlockIndex: blockIndex,
 			})
 		}
 	}
@@ -134,7 +138,8 @@ func PublishBlockEvents(blockApplied *blockApplied, events *Events, log *logger.
 		Issuer: &isc.NilAgentID{},
 		// TODO should be possible to filter by request ID (not possible with current events impl)
 		// RequestID: event.RequestID,
-		Payload: payload,
-		ChainID: chainID,
+		Payload:    payload,
+		ChainID:    chainID,
+		BlockIndex: blockIndex,
 	})
 }
@@ -0,0 +1,224 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/util"
+)
+
+const (
+	DKGProcedureGenerate        = "generate"
+	DKGProcedureReshare         = "reshare"
+	DKGProcedureReshareApproval = "reshare-approval"
+
+	DKGRoleInitiator   = "initiator"
+	DKGRoleParticipant = "participant"
+)
+
+// DKGAuditEntry records a single DKG procedure (a key generation or a resharing), as seen by this node.
+type DKGAuditEntry struct {
+	Time          time.Time
+	Procedure     string // DKGProcedureGenerate, DKGProcedureReshare or DKGProcedureReshareApproval.
+	Role          string // DKGRoleInitiator or DKGRoleParticipant.
+	ProcedureID   string
+	Initiator     *cryptolib.PublicKey
+	InitiatedBy   string // The authenticated user, that requested the procedure on this node; empty for the participants.
+	Peers         []*cryptolib.PublicKey
+	Threshold     uint16
+	SharedAddress iotago.Address // Nil, if the procedure failed before producing the address.
+	Error         string         // Empty, if the procedure succeeded.
+}
+
+type jsonDKGAuditEntry struct {
+	Time          time.Time `json:"time"`
+	Procedure     string    `json:"procedure"`
+	Role          string    `json:"role"`
+	ProcedureID   string    `json:"procedureId"`
+	Initiator     string    `json:"initiator"`
+	InitiatedBy   string    `json:"initiatedBy,omitempty"`
+	Peers         []string  `json:"peers"`
+	Threshold     uint16    `json:"threshold"`
+	SharedAddress string    `json:"sharedAddress,omitempty"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// DKGAuditLog is an append-only log of the DKG procedures. It is stored as a file
+// with a JSON object per line, so the existing entries are never rewritten.
+type DKGAuditLog struct {
+	mutex   sync.RWMutex
+	entries []*DKGAuditEntry
+
+	filePath      string
+	networkPrefix iotago.NetworkPrefix
+}
+
+var _ DKGAuditLogProvider = &DKGAuditLog{}
+
+// NewDKGAuditLog creates new instance of the DKG audit log. The entries are kept
+// in memory only, if the filePath is empty.
+func NewDKGAuditLog(filePath string, networkPrefix iotago.NetworkPrefix) (*DKGAuditLog, error) {
+	auditLog := &DKGAuditLog{
+		entries:       []*DKGAuditEntry{},
+		filePath:      filePath,
+		networkPrefix: networkPrefix,
+	}
+
+	if filePath == "" {
+		return auditLog, nil
+	}
+
+	// create the target directory during initialization
+	if err := util.CreateDirectoryForFilePath(filePath, 0o770); err != nil {
+		return nil, err
+	}
+
+	if err := auditLog.loadDKGAuditLog(); err != nil {
+		return nil, fmt.Errorf("unable to read DKG audit log (%s): %w", filePath, err)
+	}
+
+	return auditLog, nil
+}
+
+func (p *DKGAuditLog) loadDKGAuditLog() error {
+	f, err := os.Open(p.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// if the file doesn't exist, there are no entries yet.
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		jsonEntry := &jsonDKGAuditEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), jsonEntry); err != nil {
+			return fmt.Errorf("unable to unmarshal line %d: %w", line, err)
+		}
+		entry, err := p.entryFromJSON(jsonEntry)
+		if err != nil {
+			return fmt.Errorf("unable to parse line %d: %w", line, err)
+		}
+		p.entries = append(p.entries, entry)
+	}
+
+	return scanner.Err()
+}
+
+func (p *DKGAuditLog) entryToJSON(entry *DKGAuditEntry) *jsonDKGAuditEntry {
+	jsonEntry := &jsonDKGAuditEntry{
+		Time:        entry.Time,
+		Procedure:   entry.Procedure,
+		Role:        entry.Role,
+		ProcedureID: entry.ProcedureID,
+		InitiatedBy: entry.InitiatedBy,
+		Peers:       make([]string, len(entry.Peers)),
+		Threshold:   entry.Threshold,
+		Error:       entry.Error,
+	}
+	if entry.Initiator != nil {
+		jsonEntry.Initiator = entry.Initiator.String()
+	}
+	for i := range entry.Peers {
+		jsonEntry.Peers[i] = entry.Peers[i].String()
+	}
+	if entry.SharedAddress != nil {
+		jsonEntry.SharedAddress = entry.SharedAddress.Bech32(p.networkPrefix)
+	}
+	return jsonEntry
+}
+
+func (p *DKGAuditLog) entryFromJSON(jsonEntry *jsonDKGAuditEntry) (*DKGAuditEntry, error) {
+	entry := &DKGAuditEntry{
+		Time:        jsonEntry.Time,
+		Procedure:   jsonEntry.Procedure,
+		Role:        jsonEntry.Role,
+		ProcedureID: jsonEntry.ProcedureID,
+		InitiatedBy: jsonEntry.InitiatedBy,
+		Peers:       make([]*cryptolib.PublicKey, len(jsonEntry.Peers)),
+		Threshold:   jsonEntry.Threshold,
+		Error:       jsonEntry.Error,
+	}
+	var err error
+	if jsonEntry.Initiator != "" {
+		if entry.Initiator, err = cryptolib.PublicKeyFromString(jsonEntry.Initiator); err != nil {
+			return nil, err
+		}
+	}
+	for i := range jsonEntry.Peers {
+		if entry.Peers[i], err = cryptolib.PublicKeyFromString(jsonEntry.Peers[i]); err != nil {
+			return nil, err
+		}
+	}
+	if jsonEntry.SharedAddress != "" {
+		if _, entry.SharedAddress, err = iotago.ParseBech32(jsonEntry.SharedAddress); err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
+
+// AddDKGAuditEntry appends the entry to the log. The entry is synced to the file before returning.
+func (p *DKGAuditLog) AddDKGAuditEntry(entry *DKGAuditEntry) error {
+	if entry == nil {
+		return errors.New("DKG audit entry is nil")
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.filePath != "" {
+		line, err := json.Marshal(p.entryToJSON(entry))
+		if err != nil {
+			return fmt.Errorf("unable to marshal DKG audit entry: %w", err)
+		}
+		if err := p.appendLine(line); err != nil {
+			return fmt.Errorf("unable to write DKG audit log (%s): %w", p.filePath, err)
+		}
+	}
+
+	p.entries = append(p.entries, entry)
+	return nil
+}
+
+func (p *DKGAuditLog) appendLine(line []byte) (err error) {
+	f, err := os.OpenFile(p.filePath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// DKGAuditEntries returns all the entries, the oldest first.
+func (p *DKGAuditLog) DKGAuditEntries() []*DKGAuditEntry {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	entries := make([]*DKGAuditEntry, len(p.entries))
+	copy(entries, p.entries)
+	return entries
+}
@@ -4,6 +4,7 @@
 package registry
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -20,15 +21,19 @@ import (
 
 type DKSharesRegistry struct {
 	onChangeMap *onchangemap.OnChangeMap[string, *util.ComparableAddress, tcrypto.DKShare]
+	// DKShares produced by resharing, they are kept aside until activated.
+	nextOnChangeMap *onchangemap.OnChangeMap[string, *util.ComparableAddress, tcrypto.DKShare]
 
 	folderPath    string
 	networkPrefix iotago.NetworkPrefix
+	keyEncryption *KeyEncryption
 }
 
 var _ DKShareRegistryProvider = &DKSharesRegistry{}
 
 // NewDKSharesRegistry creates new instance of the DKShare registry implementation.
-func NewDKSharesRegistry(folderPath string, nodePrivKey *cryptolib.PrivateKey, networkPrefix iotago.NetworkPrefix) (*DKSharesRegistry, error) {
+// The DKShares are stored encrypted, if the keyEncryption is not nil.
+func NewDKSharesRegistry(folderPath string, nodePrivKey *cryptolib.PrivateKey, networkPrefix iotago.NetworkPrefix, keyEncryption *KeyEncryption) (*DKSharesRegistry, error) {
 	// create the target directory during initialization
 	if err := ioutils.CreateDirectory(folderPath, 0o770); err != nil {
 		return nil, err
@@ -37,6 +42,7 @@ func NewDKSharesRegistry(folderPath string, nodePrivKey *cryptolib.PrivateKey, n
 	registry := &DKSharesRegistry{
 		folderPath:    folderPath,
 		networkPrefix: networkPrefix,
+		keyEncryption: keyEncryption,
 	}
 
 	registry.onChangeMap = onchangemap.NewOnChangeMap(
@@ -44,6 +50,11 @@ func NewDKSharesRegistry(folderPath string, nodePrivKey *cryptolib.PrivateKey, n
 		onchangemap.WithItemModifiedCallback[string, *util.ComparableAddress](registry.writeDKShareJSONToFolder),
 		onchangemap.WithItemDeletedCallback[string, *util.ComparableAddress](registry.deleteDKShareJSON),
 	)
+	registry.nextOnChangeMap = onchangemap.NewOnChangeMap(
+		onchangemap.WithItemAddedCallback[string, *util.ComparableAddress](registry.writeNextDKShareJSONToFolder),
+		onchangemap.WithItemModifiedCallback[string, *util.ComparableAddress](registry.writeNextDKShareJSONToFolder),
+		onchangemap.WithItemDeletedCallback[string, *util.ComparableAddress](registry.deleteNextDKShareJSON),
+	)
 
 	// load DKShares on startup
 	if err := registry.loadDKSharesJSONFromFolder(nodePrivKey); err != nil {
@@ -51,6 +62,7 @@ func NewDKSharesRegistry(folderPath string, nodePrivKey *cryptolib.PrivateKey, n
 	}
 
 	registry.onChangeMap.CallbacksEnabled(true)
+	registry.nextOnChangeMap.CallbacksEnabled(true)
 
 	return registry, nil
 }
@@ -62,7 +74,8 @@ func (p *DKSharesRegistry) loadDKSharesJSONFromFolder(nodePrivKey *cryptolib.Pri
 	}
 
 	// regex example: atoi1qqqrqtn44e0563utwau9aaygt824qznjkhvr6836eratglg3cp2n6ydplqx.json
-	filesRegex := regexp.MustCompile(`([a-z]{1,4}1[a-z0-9]{59}).json`)
+	// or atoi1qqqrqtn44e0563utwau9aaygt824qznjkhvr6836eratglg3cp2n6ydplqx.next.json for the reshared keys.
+	filesRegex := regexp.MustCompile(`^([a-z]{1,4}1[a-z0-9]{59})(\.next)?\.json$`)
 
 	files, err := os.ReadDir(p.folderPath)
 	if err != nil {
@@ -77,15 +90,16 @@ func (p *DKSharesRegistry) loadDKSharesJSONFromFolder(nodePrivKey *cryptolib.Pri
 	for _, file := range files {
 		if file.IsDir() {
 			// ignore folders
-			return nil
+			continue
 		}
 
 		if !filesRegex.MatchString(file.Name()) {
 			// ignore unknown files
-			return nil
+			continue
 		}
 
-		sharedAddressBech32 := filesRegex.FindStringSubmatch(file.Name())[1]
+		match := filesRegex.FindStringSubmatch(file.Name())
+		sharedAddressBech32 := match[1]
 		_, sharedAddress, err := iotago.ParseBech32(sharedAddressBech32)
 		if err != nil {
 			return fmt.Errorf("unable to parse shared bech32 address (%s), error: %w", sharedAddressBech32, err)
@@ -93,7 +107,11 @@ func (p *DKSharesRegistry) loadDKSharesJSONFromFolder(nodePrivKey *cryptolib.Pri
 
 		dkShareFilePath := path.Join(p.folderPath, file.Name())
 		dkShare := tcrypto.NewEmptyDKShare(nodePrivKey, tcrypto.DefaultEd25519Suite(), tcrypto.DefaultBLSSuite())
-		if err := ioutils.ReadJSONFromFile(dkShareFilePath, dkShare); err != nil && !os.IsNotExist(err) {
+		dkShareJSON, err := p.keyEncryption.ReadFile(dkShareFilePath)
+		if err != nil {
+			return fmt.Errorf("unable to read DKShare file (%s): %w", dkShareFilePath, err)
+		}
+		if err := json.Unmarshal(dkShareJSON, dkShare); err != nil {
 			return fmt.Errorf("unable to unmarshal json file (%s): %w", dkShareFilePath, err)
 		}
 
@@ -101,6 +119,13 @@ func (p *DKSharesRegistry) loadDKSharesJSONFromFolder(nodePrivKey *cryptolib.Pri
 			return errors.New("unable to add DKShare to registry: sharedAddress in the file not equal to sharedAddress in folder name")
 		}
 
+		if match[2] != "" {
+			if err := p.SaveNextDKShare(dkShare); err != nil {
+				return fmt.Errorf("unable to add reshared DKShare to registry: %w", err)
+			}
+			continue
+		}
+
 		if err := p.SaveDKShare(dkShare); err != nil {
 			return fmt.Errorf("unable to add DKShare to registry: %w", err)
 		}
@@ -115,32 +140,48 @@ func (p *DKSharesRegistry) getDKShareFilePath(dkShare tcrypto.DKShare) string {
 	return path.Join(p.folderPath, fmt.Sprintf("%s.json", sharedAddressBech32))
 }
 
+func (p *DKSharesRegistry) getNextDKShareFilePath(dkShare tcrypto.DKShare) string {
+	sharedAddressBech32 := dkShare.GetAddress().Bech32(p.networkPrefix)
+
+	return path.Join(p.folderPath, fmt.Sprintf("%s.next.json", sharedAddressBech32))
+}
+
 func (p *DKSharesRegistry) writeDKShareJSONToFolder(dkShare tcrypto.DKShare) error {
+	return p.writeDKShareJSONToFile(p.getDKShareFilePath(dkShare), dkShare)
+}
+
+func (p *DKSharesRegistry) writeNextDKShareJSONToFolder(dkShare tcrypto.DKShare) error {
+	return p.writeDKShareJSONToFile(p.getNextDKShareFilePath(dkShare), dkShare)
+}
+
+func (p *DKSharesRegistry) writeDKShareJSONToFile(filePath string, dkShare tcrypto.DKShare) error {
 	if p.folderPath == "" {
 		// do not store entries if no path is given
 		return nil
 	}
 
-	filePath := p.getDKShareFilePath(dkShare)
-	if err := util.CreateDirectoryForFilePath(filePath, 0o770); err != nil {
-		return err
-	}
-
-	if err := ioutils.WriteJSONToFile(filePath, dkShare, 0o600); err != nil {
+	dkShareJSON, err := json.MarshalIndent(dkShare, "", "  ")
+	if err != nil {
 		return fmt.Errorf("unable to marshal json file: %w", err)
 	}
 
-	return nil
+	return p.keyEncryption.WriteFile(filePath, dkShareJSON, 0o600)
 }
 
 func (p *DKSharesRegistry) deleteDKShareJSON(dkShare tcrypto.DKShare) error {
+	return p.deleteDKShareJSONFile(p.getDKShareFilePath(dkShare))
+}
+
+func (p *DKSharesRegistry) deleteNextDKShareJSON(dkShare tcrypto.DKShare) error {
+	return p.deleteDKShareJSONFile(p.getNextDKShareFilePath(dkShare))
+}
+
+func (p *DKSharesRegistry) deleteDKShareJSONFile(filePath string) error {
 	if p.folderPath == "" {
 		// do not delete entries if no path is given
 		return nil
 	}
 
-	filePath := p.getDKShareFilePath(dkShare)
-
 	exists, isDir, err := ioutils.PathExists(filePath)
 	if err != nil {
 		return fmt.Errorf("delete consensus state file failed (%s): %w", filePath, err)
@@ -171,3 +212,45 @@ func (p *DKSharesRegistry) LoadDKShare(sharedAddress iotago.Address) (tcrypto.DK
 	}
 	return dkShare, nil
 }
+
+// SaveNextDKShare stores a DKShare produced by resharing the key of the same address.
+// It is kept besides the current DKShare until activated with ActivateNextDKShare.
+// A previously stored next DKShare of the address is replaced.
+func (p *DKSharesRegistry) SaveNextDKShare(dkShare tcrypto.DKShare) error {
+	if _, err := p.nextOnChangeMap.Get(dkShare.ID()); err == nil {
+		if err := p.nextOnChangeMap.Delete(dkShare.ID()); err != nil {
+			return err
+		}
+	}
+	return p.nextOnChangeMap.Add(dkShare)
+}
+
+func (p *DKSharesRegistry) LoadNextDKShare(sharedAddress iotago.Address) (tcrypto.DKShare, error) {
+	dkShare, err := p.nextOnChangeMap.Get(util.NewComparableAddress(sharedAddress))
+	if err != nil {
+		return dkShare, tcrypto.ErrDKShareNotFound
+	}
+	return dkShare, nil
+}
+
+// ActivateNextDKShare replaces the current DKShare of the address with the next one.
+// The next DKShare is removed only after it is stored as the current one.
+func (p *DKSharesRegistry) ActivateNextDKShare(sharedAddress iotago.Address) (tcrypto.DKShare, error) {
+	id := util.NewComparableAddress(sharedAddress)
+	next, err := p.nextOnChangeMap.Get(id)
+	if err != nil {
+		return nil, tcrypto.ErrDKShareNotFound
+	}
+	if _, err := p.onChangeMap.Get(id); err == nil {
+		if err := p.onChangeMap.Delete(id); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.onChangeMap.Add(next); err != nil {
+		return nil, err
+	}
+	if err := p.nextOnChangeMap.Delete(id); err != nil {
+		return nil, err
+	}
+	return next, nil
+}
@@ -0,0 +1,72 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/util/key"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/tcrypto"
+)
+
+func newTestDKShare(t *testing.T, nodeKeyPair *cryptolib.KeyPair, keyPairE, keyPairB *key.Pair) tcrypto.DKShare {
+	dkShare, err := tcrypto.NewDKShare(
+		0, 1, 1,
+		nodeKeyPair.GetPrivateKey(),
+		[]*cryptolib.PublicKey{nodeKeyPair.GetPublicKey()},
+		tcrypto.DefaultEd25519Suite(),
+		keyPairE.Public,
+		[]kyber.Point{keyPairE.Public},
+		[]kyber.Point{keyPairE.Public},
+		keyPairE.Private,
+		tcrypto.DefaultBLSSuite(),
+		1,
+		keyPairB.Public,
+		[]kyber.Point{keyPairB.Public},
+		[]kyber.Point{keyPairB.Public},
+		keyPairB.Private,
+	)
+	require.NoError(t, err)
+	return dkShare
+}
+
+func TestDKSharesNext(t *testing.T) {
+	folderPath := t.TempDir()
+	nodeKeyPair := cryptolib.NewKeyPair()
+	keyPairE := key.NewKeyPair(tcrypto.DefaultEd25519Suite())
+	keyPairB := key.NewKeyPair(tcrypto.DefaultBLSSuite())
+	current := newTestDKShare(t, nodeKeyPair, keyPairE, keyPairB)
+	next := newTestDKShare(t, nodeKeyPair, keyPairE, keyPairB)
+	next.AssignNodePubKeys([]*cryptolib.PublicKey{nodeKeyPair.GetPublicKey(), cryptolib.NewKeyPair().GetPublicKey()})
+
+	dkSharesRegistry, err := NewDKSharesRegistry(folderPath, nodeKeyPair.GetPrivateKey(), iotago.PrefixTestnet, nil)
+	require.NoError(t, err)
+	require.NoError(t, dkSharesRegistry.SaveDKShare(current))
+	_, err = dkSharesRegistry.ActivateNextDKShare(current.GetAddress())
+	require.ErrorIs(t, err, tcrypto.ErrDKShareNotFound)
+	require.NoError(t, dkSharesRegistry.SaveNextDKShare(next))
+
+	// Both the current and the next DKShares are loaded from the folder.
+	dkSharesRegistry, err = NewDKSharesRegistry(folderPath, nodeKeyPair.GetPrivateKey(), iotago.PrefixTestnet, nil)
+	require.NoError(t, err)
+	loaded, err := dkSharesRegistry.LoadDKShare(current.GetAddress())
+	require.NoError(t, err)
+	require.Len(t, loaded.GetNodePubKeys(), 1)
+	loaded, err = dkSharesRegistry.LoadNextDKShare(current.GetAddress())
+	require.NoError(t, err)
+	require.Len(t, loaded.GetNodePubKeys(), 2)
+
+	// The next DKShare replaces the current one on activation.
+	_, err = dkSharesRegistry.ActivateNextDKShare(current.GetAddress())
+	require.NoError(t, err)
+	dkSharesRegistry, err = NewDKSharesRegistry(folderPath, nodeKeyPair.GetPrivateKey(), iotago.PrefixTestnet, nil)
+	require.NoError(t, err)
+	loaded, err = dkSharesRegistry.LoadDKShare(current.GetAddress())
+	require.NoError(t, err)
+	require.Len(t, loaded.GetNodePubKeys(), 2)
+	_, err = dkSharesRegistry.LoadNextDKShare(current.GetAddress())
+	require.ErrorIs(t, err, tcrypto.ErrDKShareNotFound)
+}
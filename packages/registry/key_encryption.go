@@ -0,0 +1,219 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"golang.org/x/crypto/argon2"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/util"
+)
+
+var (
+	ErrKeysLocked      = errors.New("the keys of the node are encrypted, but neither a passphrase nor a key file is configured to unlock them")
+	ErrKeysWrongSecret = errors.New("unable to decrypt the keys of the node, the passphrase or the key file is wrong")
+)
+
+const (
+	keyEncryptionScheme  = "argon2id-aes256gcm"
+	keyEncryptionKeySize = 32
+	keyEncryptionSalt    = 16
+)
+
+// encryptedFileJSON is the content of an encrypted file, the plain text is e.g. a DKShare JSON or an identity PEM.
+type encryptedFileJSON struct {
+	Encryption string `json:"encryption"`
+	Salt       string `json:"salt"`
+	Nonce      string `json:"nonce"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// KeyEncryption encrypts the files holding the key material of the node, i.e. the node identity and the DKShares.
+// The encryption key is derived from a secret (a passphrase or the content of a key file).
+// A nil KeyEncryption stands for the files stored in plain text.
+type KeyEncryption struct {
+	secret []byte
+	salt   []byte // The salt used to encrypt new files.
+
+	keysMutex sync.Mutex
+	keys      map[string][]byte // Keys derived from the secret by salt.
+}
+
+func NewKeyEncryption(secret []byte) (*KeyEncryption, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("the secret to encrypt the keys must not be empty")
+	}
+	salt := make([]byte, keyEncryptionSalt)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return &KeyEncryption{
+		secret: secret,
+		salt:   salt,
+		keys:   map[string][]byte{},
+	}, nil
+}
+
+// NewKeyEncryptionFromParams takes the secret either from the passphrase or from the key file.
+// It returns nil, if none of them is specified, thus the keys are stored in plain text.
+func NewKeyEncryptionFromParams(passphrase, keyFilePath string) (*KeyEncryption, error) {
+	switch {
+	case passphrase != "" && keyFilePath != "":
+		return nil, errors.New("either a passphrase or a key file can be used to encrypt the keys, not both")
+	case passphrase != "":
+		return NewKeyEncryption([]byte(passphrase))
+	case keyFilePath != "":
+		secret, err := os.ReadFile(keyFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the key file (%s): %w", keyFilePath, err)
+		}
+		return NewKeyEncryption(bytes.TrimSpace(secret))
+	default:
+		return nil, nil
+	}
+}
+
+// IsEncrypted checks, if the file content was produced by KeyEncryption.Seal.
+func IsEncrypted(data []byte) bool {
+	var encrypted encryptedFileJSON
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return false
+	}
+	return encrypted.Encryption != ""
+}
+
+func (e *KeyEncryption) deriveKey(salt []byte) []byte {
+	e.keysMutex.Lock()
+	defer e.keysMutex.Unlock()
+	if key, ok := e.keys[string(salt)]; ok {
+		return key
+	}
+	key := argon2.IDKey(e.secret, salt, 3, 64*1024, 4, keyEncryptionKeySize)
+	e.keys[string(salt)] = key
+	return key
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Seal encrypts the plain text. The data is returned as is, if the encryption is not enabled.
+func (e *KeyEncryption) Seal(plain []byte) ([]byte, error) {
+	if e == nil {
+		return plain, nil
+	}
+	gcm, err := newGCM(e.deriveKey(e.salt))
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(&encryptedFileJSON{
+		Encryption: keyEncryptionScheme,
+		Salt:       iotago.EncodeHex(e.salt),
+		Nonce:      iotago.EncodeHex(nonce),
+		Ciphertext: iotago.EncodeHex(gcm.Seal(nil, nonce, plain, nil)),
+	}, "", "  ")
+}
+
+// Open decrypts the data produced by Seal. The data not encrypted is returned as is.
+func (e *KeyEncryption) Open(data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+	if e == nil {
+		return nil, ErrKeysLocked
+	}
+	var encrypted encryptedFileJSON
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return nil, err
+	}
+	if encrypted.Encryption != keyEncryptionScheme {
+		return nil, fmt.Errorf("unsupported encryption of the keys: %s", encrypted.Encryption)
+	}
+	salt, err := iotago.DecodeHex(encrypted.Salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := iotago.DecodeHex(encrypted.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := iotago.DecodeHex(encrypted.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(e.deriveKey(salt))
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, errors.New("invalid nonce of the encrypted keys")
+	}
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrKeysWrongSecret
+	}
+	return plain, nil
+}
+
+// WriteFile stores the data to the file, encrypted if the encryption is enabled.
+func (e *KeyEncryption) WriteFile(filePath string, plain []byte, perm os.FileMode) error {
+	data, err := e.Seal(plain)
+	if err != nil {
+		return err
+	}
+	if err := util.CreateDirectoryForFilePath(filePath, 0o770); err != nil {
+		return err
+	}
+	// write to a temporary file first, so a failure does not destroy the existing keys
+	tmpFilePath := filePath + ".tmp"
+	if err := os.WriteFile(tmpFilePath, data, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmpFilePath, filePath)
+}
+
+// ReadFile reads the file, and decrypts it, if it is encrypted.
+func (e *KeyEncryption) ReadFile(filePath string) ([]byte, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return e.Open(data)
+}
+
+// EncryptFile encrypts the plain text file in place. It returns false, if the file was encrypted already.
+func (e *KeyEncryption) EncryptFile(filePath string) (bool, error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return false, err
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return false, err
+	}
+	if IsEncrypted(data) {
+		// check the file can be unlocked with the same secret
+		_, err := e.Open(data)
+		return false, err
+	}
+	return true, e.WriteFile(filePath, data, info.Mode().Perm())
+}
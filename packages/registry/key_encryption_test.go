@@ -0,0 +1,119 @@
+package registry
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/util/key"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/tcrypto"
+)
+
+func TestKeyEncryptionSealOpen(t *testing.T) {
+	keyEncryption, err := NewKeyEncryption([]byte("some passphrase"))
+	require.NoError(t, err)
+	plain := []byte("some secret key")
+
+	sealed, err := keyEncryption.Seal(plain)
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(sealed))
+	require.NotContains(t, string(sealed), string(plain))
+
+	opened, err := keyEncryption.Open(sealed)
+	require.NoError(t, err)
+	require.Equal(t, plain, opened)
+
+	// The key is derived from the salt stored in the file, not from the salt of the instance.
+	otherInstance, err := NewKeyEncryption([]byte("some passphrase"))
+	require.NoError(t, err)
+	opened, err = otherInstance.Open(sealed)
+	require.NoError(t, err)
+	require.Equal(t, plain, opened)
+
+	wrongSecret, err := NewKeyEncryption([]byte("other passphrase"))
+	require.NoError(t, err)
+	_, err = wrongSecret.Open(sealed)
+	require.ErrorIs(t, err, ErrKeysWrongSecret)
+
+	var locked *KeyEncryption
+	_, err = locked.Open(sealed)
+	require.ErrorIs(t, err, ErrKeysLocked)
+	opened, err = locked.Open(plain)
+	require.NoError(t, err)
+	require.Equal(t, plain, opened)
+}
+
+func TestKeyEncryptionFromParams(t *testing.T) {
+	keyEncryption, err := NewKeyEncryptionFromParams("", "")
+	require.NoError(t, err)
+	require.Nil(t, keyEncryption)
+
+	keyFilePath := path.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(keyFilePath, []byte("some passphrase\n"), 0o600))
+	_, err = NewKeyEncryptionFromParams("some passphrase", keyFilePath)
+	require.Error(t, err)
+
+	fromPassphrase, err := NewKeyEncryptionFromParams("some passphrase", "")
+	require.NoError(t, err)
+	fromKeyFile, err := NewKeyEncryptionFromParams("", keyFilePath)
+	require.NoError(t, err)
+	sealed, err := fromPassphrase.Seal([]byte("data"))
+	require.NoError(t, err)
+	opened, err := fromKeyFile.Open(sealed)
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), opened)
+}
+
+func TestNodeIdentityEncrypted(t *testing.T) {
+	filePath := path.Join(t.TempDir(), "identity", "identity.key")
+
+	// A plain text identity is created first, then encrypted in place.
+	privKey, created, err := LoadOrCreateNodeIdentityPrivateKey(filePath, "", nil)
+	require.NoError(t, err)
+	require.True(t, created)
+
+	keyEncryption, err := NewKeyEncryption([]byte("some passphrase"))
+	require.NoError(t, err)
+	encrypted, err := keyEncryption.EncryptFile(filePath)
+	require.NoError(t, err)
+	require.True(t, encrypted)
+	encrypted, err = keyEncryption.EncryptFile(filePath)
+	require.NoError(t, err)
+	require.False(t, encrypted)
+
+	loaded, created, err := LoadOrCreateNodeIdentityPrivateKey(filePath, "", keyEncryption)
+	require.NoError(t, err)
+	require.False(t, created)
+	require.Equal(t, privKey.AsBytes(), loaded.AsBytes())
+
+	_, _, err = LoadOrCreateNodeIdentityPrivateKey(filePath, "", nil)
+	require.ErrorIs(t, err, ErrKeysLocked)
+}
+
+func TestDKSharesEncrypted(t *testing.T) {
+	folderPath := t.TempDir()
+	nodeKeyPair := cryptolib.NewKeyPair()
+	dkShare := newTestDKShare(t, nodeKeyPair, key.NewKeyPair(tcrypto.DefaultEd25519Suite()), key.NewKeyPair(tcrypto.DefaultBLSSuite()))
+	keyEncryption, err := NewKeyEncryption([]byte("some passphrase"))
+	require.NoError(t, err)
+
+	dkSharesRegistry, err := NewDKSharesRegistry(folderPath, nodeKeyPair.GetPrivateKey(), iotago.PrefixTestnet, keyEncryption)
+	require.NoError(t, err)
+	require.NoError(t, dkSharesRegistry.SaveDKShare(dkShare))
+	data, err := os.ReadFile(dkSharesRegistry.getDKShareFilePath(dkShare))
+	require.NoError(t, err)
+	require.True(t, IsEncrypted(data))
+
+	dkSharesRegistry, err = NewDKSharesRegistry(folderPath, nodeKeyPair.GetPrivateKey(), iotago.PrefixTestnet, keyEncryption)
+	require.NoError(t, err)
+	loaded, err := dkSharesRegistry.LoadDKShare(dkShare.GetAddress())
+	require.NoError(t, err)
+	require.Equal(t, dkShare.Bytes(), loaded.Bytes())
+
+	_, err = NewDKSharesRegistry(folderPath, nodeKeyPair.GetPrivateKey(), iotago.PrefixTestnet, nil)
+	require.ErrorIs(t, err, ErrKeysLocked)
+}
@@ -1,6 +1,17 @@
 package registry
 
-import "github.com/iotaledger/wasp/packages/cryptolib"
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	hivecrypto "github.com/iotaledger/hive.go/crypto"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+)
 
 type NodeIdentity struct {
 	nodeIdentity *cryptolib.KeyPair
@@ -19,3 +30,90 @@ func (p *NodeIdentity) NodeIdentity() *cryptolib.KeyPair {
 func (p *NodeIdentity) NodePublicKey() *cryptolib.PublicKey {
 	return p.nodeIdentity.GetPublicKey()
 }
+
+// LoadOrCreateNodeIdentityPrivateKey loads the private key of the node from the PEM file,
+// or creates a new one (or takes the one from the config), if the file does not exist.
+// The file is stored encrypted, if the keyEncryption is not nil. An existing plain text
+// file is loaded as is, it can be encrypted in place by the KeyEncryption.EncryptFile.
+// It returns true, if the key was newly created.
+func LoadOrCreateNodeIdentityPrivateKey(filePath, privateKeyFromConfig string, keyEncryption *KeyEncryption) (*cryptolib.PrivateKey, bool, error) {
+	var configPrivKey ed25519.PrivateKey
+	if privateKeyFromConfig != "" {
+		var err error
+		configPrivKey, err = hivecrypto.ParseEd25519PrivateKeyFromString(privateKeyFromConfig)
+		if err != nil {
+			return nil, false, errors.New("configuration contains an invalid private key")
+		}
+	}
+
+	_, err := os.Stat(filePath)
+	switch {
+	case err == nil:
+		pemBytes, err := keyEncryption.ReadFile(filePath)
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to load private key for node identity: %w", err)
+		}
+		privKey, err := decodeNodeIdentityPEM(pemBytes)
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to load private key for node identity: %w", err)
+		}
+		if configPrivKey != nil && !configPrivKey.Equal(privKey) {
+			return nil, false, errors.New("stored private key for node identity doesn't match private key in config")
+		}
+		waspPrivKey, err := cryptolib.PrivateKeyFromBytes(privKey)
+		if err != nil {
+			return nil, false, err
+		}
+		return waspPrivKey, false, nil
+
+	case os.IsNotExist(err):
+		privKey := configPrivKey
+		if privKey == nil {
+			privKey = cryptolib.NewPrivateKey().AsStdKey()
+		}
+		pemBytes, err := encodeNodeIdentityPEM(privKey)
+		if err != nil {
+			return nil, false, err
+		}
+		if err := keyEncryption.WriteFile(filePath, pemBytes, 0o600); err != nil {
+			return nil, false, fmt.Errorf("unable to store private key file for node identity: %w", err)
+		}
+		waspPrivKey, err := cryptolib.PrivateKeyFromBytes(privKey)
+		if err != nil {
+			return nil, false, err
+		}
+		return waspPrivKey, true, nil
+
+	default:
+		return nil, false, fmt.Errorf("unable to check private key file for node identity (%s): %w", filePath, err)
+	}
+}
+
+// encodeNodeIdentityPEM uses the same format as the hive.go PEM files, i.e. PKCS8 in a "PRIVATE KEY" block.
+func encodeNodeIdentityPEM(privKey ed25519.PrivateKey) ([]byte, error) {
+	pkcs8Bytes, err := x509.MarshalPKCS8PrivateKey(privKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal private key: %w", err)
+	}
+	var pemBuffer bytes.Buffer
+	if err := pem.Encode(&pemBuffer, &pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Bytes}); err != nil {
+		return nil, fmt.Errorf("unable to encode private key: %w", err)
+	}
+	return pemBuffer.Bytes(), nil
+}
+
+func decodeNodeIdentityPEM(pemBytes []byte) (ed25519.PrivateKey, error) {
+	pemBlock, _ := pem.Decode(pemBytes)
+	if pemBlock == nil {
+		return nil, errors.New("unable to decode private key")
+	}
+	cryptoPrivKey, err := x509.ParsePKCS8PrivateKey(pemBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key: %w", err)
+	}
+	privKey, ok := cryptoPrivKey.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("unable to type assert private key")
+	}
+	return privKey, nil
+}
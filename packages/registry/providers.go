@@ -21,6 +21,14 @@ type NodeIdentityProvider interface {
 type DKShareRegistryProvider interface {
 	SaveDKShare(dkShare tcrypto.DKShare) error
 	LoadDKShare(sharedAddress iotago.Address) (tcrypto.DKShare, error)
+	SaveNextDKShare(dkShare tcrypto.DKShare) error
+	LoadNextDKShare(sharedAddress iotago.Address) (tcrypto.DKShare, error)
+	ActivateNextDKShare(sharedAddress iotago.Address) (tcrypto.DKShare, error)
+}
+
+type DKGAuditLogProvider interface {
+	AddDKGAuditEntry(entry *DKGAuditEntry) error
+	DKGAuditEntries() []*DKGAuditEntry
 }
 
 type ChainRecordRegistryProvider interface {
@@ -128,6 +128,33 @@ func (ch *Chain) SetGasFeePolicy(user *cryptolib.KeyPair, fp *gas.FeePolicy) {
 	require.NoError(ch.Env.T, err)
 }
 
+// GetDynamicFeePolicy returns the dynamic fee policy of the chain, or nil if the gas price is static
+func (ch *Chain) GetDynamicFeePolicy() *gas.DynamicFeePolicy {
+	res, err := ch.CallView(governance.Contract.Name, governance.ViewGetDynamicFeePolicy.Name)
+	require.NoError(ch.Env.T, err)
+	data := res.Get(governance.ParamDynamicFeePolicyBytes)
+	if data == nil {
+		return nil
+	}
+	p, err := gas.DynamicFeePolicyFromBytes(data)
+	require.NoError(ch.Env.T, err)
+	return p
+}
+
+// SetDynamicFeePolicy enables the dynamic gas price, or disables it if p is nil
+func (ch *Chain) SetDynamicFeePolicy(user *cryptolib.KeyPair, p *gas.DynamicFeePolicy) {
+	params := dict.Dict{}
+	if p != nil {
+		params[governance.ParamDynamicFeePolicyBytes] = p.Bytes()
+	}
+	_, err := ch.PostRequestOffLedger(NewCallParams(
+		governance.Contract.Name,
+		governance.FuncSetDynamicFeePolicy.Name,
+		params,
+	), user)
+	require.NoError(ch.Env.T, err)
+}
+
 func (ch *Chain) GetGasLimits() *gas.Limits {
 	res, err := ch.CallView(governance.Contract.Name, governance.ViewGetGasLimits.Name)
 	require.NoError(ch.Env.T, err)
@@ -265,6 +292,53 @@ func (ch *Chain) DeployContract(user *cryptolib.KeyPair, name string, programHas
 	return err
 }
 
+// UpgradeContract replaces the program of the contract with the given name by 'programHash'.
+// If 'migrationEntryPoint' is not empty, it is called on the new program with the given params.
+// 'user' must be the upgrade authority of the contract or the chain owner (nil defaults to chain originator).
+func (ch *Chain) UpgradeContract(user *cryptolib.KeyPair, name string, programHash hashing.HashValue, migrationEntryPoint string, params ...interface{}) error {
+	par := codec.MakeDict(map[string]interface{}{
+		root.ParamHname:       isc.Hn(name),
+		root.ParamProgramHash: programHash,
+	})
+	if migrationEntryPoint != "" {
+		par[root.ParamMigrationEntryPoint] = codec.EncodeString(migrationEntryPoint)
+	}
+	for k, v := range parseParams(params) {
+		par[k] = v
+	}
+	_, err := ch.PostRequestSync(
+		NewCallParams(root.Contract.Name, root.FuncUpgradeContract.Name, par).
+			WithGasBudget(math.MaxUint64),
+		user,
+	)
+	return err
+}
+
+// RetireContract removes the contract with the given name from the chain, the assets of the contract are moved to 'user'.
+// 'user' must be the upgrade authority of the contract or the chain owner (nil defaults to chain originator).
+func (ch *Chain) RetireContract(user *cryptolib.KeyPair, name string) error {
+	_, err := ch.PostRequestSync(
+		NewCallParams(root.Contract.Name, root.FuncRetireContract.Name, root.ParamHname, isc.Hn(name)).
+			WithGasBudget(math.MaxUint64),
+		user,
+	)
+	return err
+}
+
+// GetScheduledCalls returns the calls scheduled on the chain, in the order they will be executed.
+// If a contract name is given, only the calls scheduled by that contract are returned.
+func (ch *Chain) GetScheduledCalls(contractName ...string) []*isc.ScheduledCall {
+	par := dict.Dict{}
+	if len(contractName) > 0 {
+		par[root.ParamHname] = isc.Hn(contractName[0]).Bytes()
+	}
+	res, err := ch.CallView(root.Contract.Name, root.ViewGetScheduledCalls.Name, par)
+	require.NoError(ch.Env.T, err)
+	calls, err := root.DecodeScheduledCalls(collections.NewMapReadOnly(res, root.VarScheduledCalls))
+	require.NoError(ch.Env.T, err)
+	return calls
+}
+
 // DeployWasmContract is syntactic sugar for uploading Wasm binary from file and
 // deploying the smart contract in one call
 func (ch *Chain) DeployWasmContract(keyPair *cryptolib.KeyPair, name, fname string, params ...interface{}) error {
@@ -631,6 +705,12 @@ func (*Chain) GetConsensusWorkflowStatus() chain.ConsensusWorkflowStatus {
 	panic("unimplemented")
 }
 
+// GetConsensusStatus implements chain.Chain
+func (*Chain) GetConsensusStatus(context.Context) *chain.ConsensusStatus {
+	// There is no committee in solo, the blocks are produced without a consensus.
+	return &chain.ConsensusStatus{Committees: []*chain.CommitteeConsensusStatus{}}
+}
+
 // Store implements chain.Chain
 func (ch *Chain) Store() indexedstore.IndexedStore {
 	return ch.store
@@ -704,3 +784,7 @@ func (ch *Chain) LatestBlockIndex() uint32 {
 func (ch *Chain) GetMempoolContents() io.Reader {
 	panic("unimplemented")
 }
+
+func (ch *Chain) GetMempoolOffLedgerRequests(context.Context) []isc.OffLedgerRequest {
+	return ch.mempool.OffLedgerRequests()
+}
@@ -1,6 +1,7 @@
 package solo
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
@@ -65,6 +66,27 @@ func (b *jsonRPCSoloBackend) EVMTraceTransaction(
 	)
 }
 
+func (b *jsonRPCSoloBackend) EVMTraceBlock(
+	aliasOutput *isc.AliasOutputWithID,
+	blockTime time.Time,
+	iscRequestsInBlock []isc.Request,
+	tracer tracers.Tracer,
+	onTxStart func(txHash common.Hash),
+) error {
+	return chainutil.EVMTraceBlock(
+		b.Chain,
+		aliasOutput,
+		blockTime,
+		iscRequestsInBlock,
+		tracer,
+		onTxStart,
+	)
+}
+
+func (b *jsonRPCSoloBackend) EVMTraceCall(aliasOutput *isc.AliasOutputWithID, callMsg ethereum.CallMsg, tracer tracers.Tracer) error {
+	return chainutil.EVMTraceCall(b.Chain, aliasOutput, callMsg, tracer)
+}
+
 func (b *jsonRPCSoloBackend) ISCCallView(chainState state.State, scName, funName string, args dict.Dict) (dict.Dict, error) {
 	return b.Chain.CallViewAtState(chainState, scName, funName, args)
 }
@@ -93,6 +115,10 @@ func (b *jsonRPCSoloBackend) ISCStateByTrieRoot(trieRoot trie.Hash) (state.State
 	return b.Chain.store.StateByTrieRoot(trieRoot)
 }
 
+func (b *jsonRPCSoloBackend) ISCMempoolOffLedgerRequests() []isc.OffLedgerRequest {
+	return b.Chain.GetMempoolOffLedgerRequests(context.Background())
+}
+
 func (b *jsonRPCSoloBackend) BaseToken() *parameters.BaseToken {
 	return b.baseToken
 }
@@ -44,6 +44,14 @@ func (ch *Chain) RunRequestsSync(reqs []isc.Request, trace string) (results []*v
 	return ch.runRequestsNolock(reqs, trace)
 }
 
+// RunScheduledCalls produces a block without requests, which runs the calls scheduled by contracts,
+// that are due at the current time. That's what the committee does on an idle chain.
+func (ch *Chain) RunScheduledCalls() {
+	ch.runVMMutex.Lock()
+	defer ch.runVMMutex.Unlock()
+	ch.runRequestsNolock(nil, "scheduled calls")
+}
+
 func (ch *Chain) estimateGas(req isc.Request) (result *vm.RequestResult) {
 	ch.runVMMutex.Lock()
 	defer ch.runVMMutex.Unlock()
@@ -224,5 +224,57 @@ func (db *storeDB) restoreSnapshot(root trie.Hash, r io.Reader) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	return trie.Verify(trieStore(db), root)
+}
+
+// increment when changing the delta snapshot format
+const deltaSnapshotVersion = 0
+
+func (db *storeDB) takeDeltaSnapshot(baseRoot, root trie.Hash, w io.Writer) error {
+	if !db.hasBlock(baseRoot) {
+		return fmt.Errorf("base %w %s", ErrTrieRootNotFound, baseRoot)
+	}
+	block, err := db.readBlock(root)
+	if err != nil {
+		return err
+	}
+	ww := rwutil.NewWriter(w)
+	ww.WriteUint8(deltaSnapshotVersion)
+	ww.WriteN(baseRoot[:])
+	ww.WriteBytes(block.Bytes())
+	if ww.Err != nil {
+		return ww.Err
+	}
+	return trie.TakeDeltaSnapshot(trieStore(db), baseRoot, block.TrieRoot(), w)
+}
+
+func (db *storeDB) restoreDeltaSnapshot(baseRoot trie.Hash, r io.Reader) (trie.Hash, error) {
+	rr := rwutil.NewReader(r)
+	v := rr.ReadUint8()
+	if rr.Err == nil && v != deltaSnapshotVersion {
+		return trie.Hash{}, errors.New("delta snapshot version mismatch")
+	}
+	var deltaBaseRoot trie.Hash
+	rr.ReadN(deltaBaseRoot[:])
+	blockBytes := rr.ReadBytes()
+	if rr.Err != nil {
+		return trie.Hash{}, rr.Err
+	}
+	if deltaBaseRoot != baseRoot {
+		return trie.Hash{}, fmt.Errorf("delta snapshot is based on trie root %s instead of %s", deltaBaseRoot, baseRoot)
+	}
+	block, err := BlockFromBytes(blockBytes)
+	if err != nil {
+		return trie.Hash{}, err
+	}
+	db.saveBlock(block)
+
+	err = trie.RestoreSnapshot(r, trieStore(db))
+	if err != nil {
+		return trie.Hash{}, err
+	}
+	if _, err = db.trieReader(block.TrieRoot()); err != nil {
+		return trie.Hash{}, fmt.Errorf("trie root %s was not restored: %w", block.TrieRoot(), err)
+	}
+	return block.TrieRoot(), nil
 }
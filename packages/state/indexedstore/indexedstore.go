@@ -4,7 +4,10 @@
 package indexedstore
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"sync"
 
 	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/trie"
@@ -12,6 +15,14 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 )
 
+// ErrStateNotAvailable is returned if the state of a past block is not in the store,
+// e.g. because it was pruned, or the node was started from a snapshot of a later state.
+var ErrStateNotAvailable = errors.New("state not available")
+
+func stateNotAvailable(index uint32, err error) error {
+	return fmt.Errorf("%w: state of block %d: %v", ErrStateNotAvailable, index, err)
+}
+
 // IndexedStore augments a Store with functions to search blocks by index.
 type IndexedStore interface {
 	state.Store
@@ -24,6 +35,12 @@ type IndexedStore interface {
 
 type istore struct {
 	state.Store
+
+	// the first block of the available history is cached, as searching it takes
+	// several lookups; it is updated when the store is pruned
+	historyMutex        sync.Mutex
+	firstAvailable      uint32
+	firstAvailableKnown bool
 }
 
 // New returns an IndexedStore implemented by getting the blockinfo from the latest state.
@@ -38,7 +55,11 @@ func (s *istore) BlockByIndex(index uint32) (state.Block, error) {
 	if err != nil {
 		return nil, err
 	}
-	return s.BlockByTrieRoot(root)
+	block, err := s.BlockByTrieRoot(root)
+	if err != nil {
+		return nil, stateNotAvailable(index, err)
+	}
+	return block, nil
 }
 
 func (s *istore) StateByIndex(index uint32) (state.State, error) {
@@ -46,7 +67,49 @@ func (s *istore) StateByIndex(index uint32) (state.State, error) {
 	if err != nil {
 		return nil, err
 	}
-	return s.StateByTrieRoot(block.TrieRoot())
+	chainState, err := s.StateByTrieRoot(block.TrieRoot())
+	if err != nil {
+		return nil, stateNotAvailable(index, err)
+	}
+	return chainState, nil
+}
+
+func (s *istore) Prune(trieRoot trie.Hash) (trie.PruneStats, error) {
+	stats, err := s.Store.Prune(trieRoot)
+	if err != nil {
+		return stats, err
+	}
+	s.historyMutex.Lock()
+	defer s.historyMutex.Unlock()
+	if largestPruned, err := s.LargestPrunedBlockIndex(); err == nil && s.firstAvailableKnown {
+		s.firstAvailable = max(s.firstAvailable, largestPruned+1)
+	}
+	return stats, nil
+}
+
+func (s *istore) availableHistory() (first, latest uint32, err error) {
+	latest, err = s.LatestBlockIndex()
+	if err != nil {
+		return 0, 0, err
+	}
+	s.historyMutex.Lock()
+	defer s.historyMutex.Unlock()
+	// the cached block is still the first one, unless the state manager has
+	// fetched older blocks in the meantime
+	if s.firstAvailableKnown && s.firstAvailable <= latest {
+		if s.firstAvailable == 0 || !s.isBlockAvailable(s.firstAvailable-1) {
+			return s.firstAvailable, latest, nil
+		}
+	}
+	first = searchFirstAvailable(s, latest)
+	s.firstAvailable = first
+	s.firstAvailableKnown = true
+	return first, latest, nil
+}
+
+func (s *istore) isBlockAvailable(index uint32) bool {
+	_, err := s.BlockByIndex(index)
+	return err == nil
 }
 
 func (s *istore) findTrieRootByIndex(index uint32) (trie.Hash, error) {
@@ -83,16 +146,16 @@ func (s *istore) findTrieRootByIndex(index uint32) (trie.Hash, error) {
 		}
 		bi, ok := blocklogStateAccess.BlockInfo(earliestAvailableBlockIndex + 1) // get +1 to make things easier and get the actual block (because we do previousL1Commitment)
 		if !ok {
-			return trie.Hash{}, fmt.Errorf("iterating the chain: blocklog missing block index %d on active state %d", earliestAvailableBlockIndex, state.BlockIndex())
+			return trie.Hash{}, stateNotAvailable(index, fmt.Errorf("iterating the chain: blocklog missing block index %d on active state %d", earliestAvailableBlockIndex, state.BlockIndex()))
 		}
 		state, err = s.StateByTrieRoot(bi.PreviousL1Commitment().TrieRoot())
 		if err != nil {
-			return trie.Hash{}, err
+			return trie.Hash{}, stateNotAvailable(index, err)
 		}
 	}
 	nextBlockInfo, ok := blocklog.NewStateAccess(state).BlockInfo(targetBlockIndex)
 	if !ok {
-		return trie.Hash{}, fmt.Errorf("blocklog missing block index %d on active state %d", targetBlockIndex, state.BlockIndex())
+		return trie.Hash{}, stateNotAvailable(index, fmt.Errorf("blocklog missing block index %d on active state %d", targetBlockIndex, state.BlockIndex()))
 	}
 	return nextBlockInfo.PreviousL1Commitment().TrieRoot(), nil
 }
@@ -130,7 +193,7 @@ func (s *fakeistore) BlockByIndex(index uint32) (state.Block, error) {
 	for block.StateIndex() > index {
 		block, err = s.BlockByTrieRoot(block.PreviousL1Commitment().TrieRoot())
 		if err != nil {
-			return nil, err
+			return nil, stateNotAvailable(index, err)
 		}
 	}
 	return block, nil
@@ -143,3 +206,43 @@ func (s *fakeistore) StateByIndex(index uint32) (state.State, error) {
 	}
 	return s.StateByTrieRoot(block.TrieRoot())
 }
+
+// AvailableHistory returns the range of the blocks, which states are available in the store.
+// The states of the blocks before the range were pruned, or were never in the store, if the
+// node was started from a snapshot. Some older states (e.g. the checkpoints kept by the
+// pruning service) may be available too.
+func AvailableHistory(s IndexedStore) (first, latest uint32, err error) {
+	if is, ok := s.(*istore); ok {
+		return is.availableHistory()
+	}
+	latest, err = s.LatestBlockIndex()
+	if err != nil {
+		return 0, 0, err
+	}
+	return searchFirstAvailable(s, latest), latest, nil
+}
+
+func searchFirstAvailable(s IndexedStore, latest uint32) uint32 {
+	first := uint32(0)
+	if largestPruned, err := s.LargestPrunedBlockIndex(); err == nil && largestPruned < latest {
+		first = largestPruned + 1
+	}
+	// the states are expected to be available from some block up to the latest one
+	offset := sort.Search(int(latest-first), func(i int) bool {
+		_, err := s.BlockByIndex(first + uint32(i))
+		return err == nil
+	})
+	return first + uint32(offset)
+}
+
+// WithAvailableHistory adds the range of the available blocks to an ErrStateNotAvailable error.
+func WithAvailableHistory(s IndexedStore, err error) error {
+	if !errors.Is(err, ErrStateNotAvailable) {
+		return err
+	}
+	first, latest, historyErr := AvailableHistory(s)
+	if historyErr != nil {
+		return err
+	}
+	return fmt.Errorf("%w; the states of blocks %d..%d are available", err, first, latest)
+}
@@ -535,6 +535,20 @@ func TestRestoreSnapshotEmptyDB(t *testing.T) {
 	require.EqualValues(t, expectedMap, toMap(db))
 }
 
+func TestRestoreSnapshotCorrupted(t *testing.T) {
+	trieRoot, _, snapshot := makeRandomDBSnapshot(t, 10)
+
+	// corrupt the value, which is stored outside of the trie node
+	snapshotBytes := snapshot.Bytes()
+	valueIndex := bytes.Index(snapshotBytes, []byte(strings.Repeat("v", 70)))
+	require.Positive(t, valueIndex)
+	snapshotBytes[valueIndex] = 'w'
+
+	cs := mustChainStore{state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())}
+	err := cs.RestoreSnapshot(trieRoot, bytes.NewReader(snapshotBytes))
+	require.ErrorContains(t, err, "does not match its commitment")
+}
+
 func TestRestoreSnapshotNonEmptyDB(t *testing.T) {
 	trieRoot, _, snapshot := makeRandomDBSnapshot(t, 10)
 
@@ -595,6 +609,60 @@ func TestPrunedSnapshot(t *testing.T) {
 	require.False(t, cs.IsEmpty())
 }
 
+func TestDeltaSnapshots(t *testing.T) {
+	csOrig, _ := makeRandomDB(t, 10)
+	block5 := csOrig.BlockByIndex(5)
+	block8 := csOrig.BlockByIndex(8)
+	block10 := csOrig.BlockByIndex(10)
+
+	snapshot := new(bytes.Buffer)
+	err := csOrig.TakeSnapshot(block5.TrieRoot(), snapshot)
+	require.NoError(t, err)
+	delta8 := new(bytes.Buffer)
+	err = csOrig.TakeDeltaSnapshot(block5.TrieRoot(), block8.TrieRoot(), delta8)
+	require.NoError(t, err)
+	delta10 := new(bytes.Buffer)
+	err = csOrig.TakeDeltaSnapshot(block8.TrieRoot(), block10.TrieRoot(), delta10)
+	require.NoError(t, err)
+	require.Less(t, delta10.Len(), snapshot.Len())
+
+	db := mapdb.NewMapDB()
+	cs := mustChainStore{state.NewStoreWithUniqueWriteMutex(db)}
+
+	// deltas cannot be applied without the base trie
+	_, err = cs.RestoreDeltaSnapshots(block5.TrieRoot(), bytes.NewReader(delta8.Bytes()))
+	require.ErrorIs(t, err, state.ErrTrieRootNotFound)
+
+	err = cs.RestoreSnapshot(block5.TrieRoot(), bytes.NewReader(snapshot.Bytes()))
+	require.NoError(t, err)
+
+	// deltas must form a chain
+	_, err = cs.RestoreDeltaSnapshots(block5.TrieRoot(), bytes.NewReader(delta10.Bytes()))
+	require.Error(t, err)
+
+	root, err := cs.RestoreDeltaSnapshots(block5.TrieRoot(), bytes.NewReader(delta8.Bytes()), bytes.NewReader(delta10.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, block10.TrieRoot(), root)
+	require.EqualValues(t, block10.Hash(), cs.BlockByTrieRoot(root).Hash())
+	cs.checkTrie(block5.TrieRoot())
+	cs.checkTrie(block8.TrieRoot())
+	cs.checkTrie(block10.TrieRoot())
+
+	state10 := cs.StateByTrieRoot(root)
+	for i := byte(1); i <= 10; i++ {
+		require.EqualValues(t, []byte("v"), state10.Get(kv.Key(fmt.Sprintf("k%d", i))))
+	}
+	require.EqualValues(t, []byte{10}, state10.Get("k"))
+	require.EqualValues(t, []byte{5}, cs.StateByTrieRoot(block5.TrieRoot()).Get("k"))
+
+	// all the restored tries are independent: pruning them all leaves the DB (almost) empty
+	for _, block := range []state.Block{block8, block5, block10} {
+		_, err = cs.Prune(block.TrieRoot())
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, addLargestPrunedBlockIndex(map[string][]byte{}, 10), toMap(db))
+}
+
 func toMap(store kvstore.KVStore) map[string][]byte {
 	m := make(map[string][]byte)
 	store.Iterate(kvstore.EmptyPrefix, func(k, v []byte) bool {
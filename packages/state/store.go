@@ -5,6 +5,7 @@ package state
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"sync"
 	"time"
@@ -265,3 +266,26 @@ func (s *store) RestoreSnapshot(root trie.Hash, r io.Reader) error {
 
 	return s.db.restoreSnapshot(root, r)
 }
+
+func (s *store) TakeDeltaSnapshot(baseRoot, root trie.Hash, w io.Writer) error {
+	return s.db.takeDeltaSnapshot(baseRoot, root, w)
+}
+
+func (s *store) RestoreDeltaSnapshots(baseRoot trie.Hash, deltas ...io.Reader) (trie.Hash, error) {
+	if !s.db.hasBlock(baseRoot) {
+		return trie.Hash{}, fmt.Errorf("base %w %s", ErrTrieRootNotFound, baseRoot)
+	}
+
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	root := baseRoot
+	for i, delta := range deltas {
+		var err error
+		root, err = s.db.restoreDeltaSnapshot(root, delta)
+		if err != nil {
+			return trie.Hash{}, fmt.Errorf("failed to restore delta snapshot %v: %w", i, err)
+		}
+	}
+	return root, nil
+}
@@ -84,6 +84,16 @@ type Store interface {
 	// RestoreSnapshot restores the block and trie from the given snapshot.
 	// It is not required for the previous trie root to be present in the DB.
 	RestoreSnapshot(trie.Hash, io.Reader) error
+
+	// TakeDeltaSnapshot takes a snapshot of the block at the given trie root and
+	// of the trie nodes, which are not present in the trie at the given base root.
+	TakeDeltaSnapshot(baseRoot trie.Hash, root trie.Hash, w io.Writer) error
+
+	// RestoreDeltaSnapshots applies the chain of delta snapshots on top of the
+	// trie at the given base root, which must be present in the DB. Each delta
+	// must be based on the trie root of the previous one. The trie root of the
+	// last delta is returned.
+	RestoreDeltaSnapshots(baseRoot trie.Hash, deltas ...io.Reader) (trie.Hash, error)
 }
 
 // A Block contains the mutations between the previous and current states,
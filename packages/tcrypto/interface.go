@@ -66,6 +66,9 @@ type DKShare interface {
 	BLSCommits() *share.PubPoly                                 // TODO: Abstract the BLS signing part to some interface and keep the keys inside.
 	BLSPriShare() *share.PriShare                               // TODO: Abstract the BLS signing part to some interface and keep the keys inside.
 	//
+	// Resharing of the key to another committee.
+	ReshareDeals(n, t, blsThreshold uint16) ([]*ReshareDeal, error)
+	//
 	// For tests only.
 	AssignNodePubKeys(nodePubKeys []*cryptolib.PublicKey)
 	AssignCommonData(dks DKShare)
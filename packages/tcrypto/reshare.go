@@ -0,0 +1,192 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package tcrypto
+
+import (
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/share"
+	"go.dedis.ch/kyber/v3/suites"
+
+	"github.com/iotaledger/wasp/packages/cryptolib"
+)
+
+// ReshareDeal is a part of the key share of a member of the current committee (the dealer),
+// dealt to a single member of the next committee. The dealer shares its own key shares
+// using random polynomials and publishes the commitments to them. The constant terms of
+// the commitments are the public shares of the dealer, thus the deals can be checked
+// against the public part of the current DKShare.
+type ReshareDeal struct {
+	Dealer     uint16        // Index of the dealer in the current committee.
+	EdCommits  []kyber.Point // Commitments to the polynomial sharing the Ed25519 key share.
+	EdShare    kyber.Scalar  // Evaluation of that polynomial for the receiver.
+	BLSCommits []kyber.Point // Commitments to the polynomial sharing the BLS key share.
+	BLSShare   kyber.Scalar  // Evaluation of that polynomial for the receiver.
+}
+
+// ReshareDeals produces the deals of this node for all the n members of the next committee.
+// The next committee will have thresholds t and blsThreshold.
+func (s *dkShareImpl) ReshareDeals(n, t, blsThreshold uint16) ([]*ReshareDeal, error) {
+	if s.index == nil || s.edPrivateShare == nil || s.blsPrivateShare == nil {
+		return nil, errors.New("the node has no private share of the key")
+	}
+	if n < 1 || t < 1 || t > n || blsThreshold < 1 || blsThreshold > n {
+		return nil, fmt.Errorf("wrong reshare parameters: N = %d, T = %d, BLS T = %d", n, t, blsThreshold)
+	}
+	edPoly := share.NewPriPoly(s.edSuite, int(t), s.edPrivateShare, s.edSuite.RandomStream())
+	_, edCommits := edPoly.Commit(nil).Info()
+	blsPoly := share.NewPriPoly(s.blsSuite, int(blsThreshold), s.blsPrivateShare, s.blsSuite.RandomStream())
+	_, blsCommits := blsPoly.Commit(nil).Info()
+	deals := make([]*ReshareDeal, n)
+	for i := range deals {
+		deals[i] = &ReshareDeal{
+			Dealer:     *s.index,
+			EdCommits:  edCommits,
+			EdShare:    edPoly.Eval(i).V,
+			BLSCommits: blsCommits,
+			BLSShare:   blsPoly.Eval(i).V,
+		}
+	}
+	return deals, nil
+}
+
+// NewResharedDKShare combines the deals received from at least T members of the current committee
+// into a share of the same key for the member with the specified index in the next committee.
+// The deals are interpolated over the indexes of the dealers, so any T of them are enough.
+// Only the public part of the current DKShare is used, so the node don't have to be a member of
+// the current committee. Each deal is checked against the public shares of its dealer, and
+// the resulting DKShare must have the same shared public keys as the current one.
+func NewResharedDKShare(
+	current DKShare,
+	deals []*ReshareDeal,
+	index uint16,
+	n uint16,
+	t uint16,
+	blsThreshold uint16,
+	nodePrivKey *cryptolib.PrivateKey,
+	nodePubKeys []*cryptolib.PublicKey,
+	edSuite suites.Suite,
+	blsSuite Suite,
+) (DKShare, error) {
+	if needed := max(current.GetT(), current.BLSThreshold()); len(deals) < int(needed) {
+		return nil, fmt.Errorf("have %d deals, at least %d are needed", len(deals), needed)
+	}
+	dealers := make(map[uint16]bool, len(deals))
+	edShares := make([]*share.PriShare, len(deals))
+	edCommits := make([][]*share.PubShare, t)
+	blsShares := make([]*share.PriShare, len(deals))
+	blsCommits := make([][]*share.PubShare, blsThreshold)
+	for i, deal := range deals {
+		if err := checkReshareDeal(deal, current, index, t, blsThreshold, edSuite, blsSuite); err != nil {
+			return nil, err
+		}
+		if dealers[deal.Dealer] {
+			return nil, fmt.Errorf("duplicate deal of dealer %d", deal.Dealer)
+		}
+		dealers[deal.Dealer] = true
+		edShares[i] = &share.PriShare{I: int(deal.Dealer), V: deal.EdShare}
+		for k := range edCommits {
+			edCommits[k] = append(edCommits[k], &share.PubShare{I: int(deal.Dealer), V: deal.EdCommits[k]})
+		}
+		blsShares[i] = &share.PriShare{I: int(deal.Dealer), V: deal.BLSShare}
+		for k := range blsCommits {
+			blsCommits[k] = append(blsCommits[k], &share.PubShare{I: int(deal.Dealer), V: deal.BLSCommits[k]})
+		}
+	}
+	//
+	// The new polynomials are the Lagrange interpolations of the dealt polynomials at 0,
+	// so their constant terms are the same secrets, as were shared by the current committee.
+	edPrivateShare, err := share.RecoverSecret(edSuite, edShares, len(deals), int(current.GetN()))
+	if err != nil {
+		return nil, fmt.Errorf("cannot combine the Ed25519 deals: %w", err)
+	}
+	edPublicCommits, err := combineReshareCommits(edSuite, edCommits, int(current.GetN()))
+	if err != nil {
+		return nil, fmt.Errorf("cannot combine the Ed25519 commitments: %w", err)
+	}
+	blsPrivateShare, err := share.RecoverSecret(blsSuite, blsShares, len(deals), int(current.GetN()))
+	if err != nil {
+		return nil, fmt.Errorf("cannot combine the BLS deals: %w", err)
+	}
+	blsPublicCommits, err := combineReshareCommits(blsSuite, blsCommits, int(current.GetN()))
+	if err != nil {
+		return nil, fmt.Errorf("cannot combine the BLS commitments: %w", err)
+	}
+	if !edPublicCommits[0].Equal(current.DSSSharedPublic()) {
+		return nil, errors.New("the reshared Ed25519 key differs from the current one")
+	}
+	if !blsPublicCommits[0].Equal(current.BLSSharedPublic()) {
+		return nil, errors.New("the reshared BLS key differs from the current one")
+	}
+	edPublicPoly := share.NewPubPoly(edSuite, nil, edPublicCommits)
+	blsPublicPoly := share.NewPubPoly(blsSuite, nil, blsPublicCommits)
+	edPublicShares := make([]kyber.Point, n)
+	blsPublicShares := make([]kyber.Point, n)
+	for i := range edPublicShares {
+		edPublicShares[i] = edPublicPoly.Eval(i).V
+		blsPublicShares[i] = blsPublicPoly.Eval(i).V
+	}
+	dkShare, err := NewDKShare(
+		index,
+		n,
+		t,
+		nodePrivKey,
+		nodePubKeys,
+		edSuite,
+		current.DSSSharedPublic(),
+		edPublicCommits,
+		edPublicShares,
+		edPrivateShare,
+		blsSuite,
+		blsThreshold,
+		current.BLSSharedPublic(),
+		blsPublicCommits,
+		blsPublicShares,
+		blsPrivateShare,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !dkShare.GetAddress().Equal(current.GetAddress()) {
+		return nil, errors.New("the reshared key does not correspond to the address")
+	}
+	return dkShare, nil
+}
+
+func checkReshareDeal(deal *ReshareDeal, current DKShare, index, t, blsThreshold uint16, edSuite suites.Suite, blsSuite Suite) error {
+	if int(deal.Dealer) >= len(current.DSSPublicShares()) || int(deal.Dealer) >= len(current.BLSPublicShares()) {
+		return fmt.Errorf("unknown dealer %d", deal.Dealer)
+	}
+	if len(deal.EdCommits) != int(t) || len(deal.BLSCommits) != int(blsThreshold) {
+		return fmt.Errorf("deal of dealer %d has wrong number of commitments", deal.Dealer)
+	}
+	if !deal.EdCommits[0].Equal(current.DSSPublicShares()[deal.Dealer]) {
+		return fmt.Errorf("deal of dealer %d does not share its Ed25519 key share", deal.Dealer)
+	}
+	if !deal.BLSCommits[0].Equal(current.BLSPublicShares()[deal.Dealer]) {
+		return fmt.Errorf("deal of dealer %d does not share its BLS key share", deal.Dealer)
+	}
+	if !share.NewPubPoly(edSuite, nil, deal.EdCommits).Check(&share.PriShare{I: int(index), V: deal.EdShare}) {
+		return fmt.Errorf("deal of dealer %d has invalid Ed25519 share", deal.Dealer)
+	}
+	if !share.NewPubPoly(blsSuite, nil, deal.BLSCommits).Check(&share.PriShare{I: int(index), V: deal.BLSShare}) {
+		return fmt.Errorf("deal of dealer %d has invalid BLS share", deal.Dealer)
+	}
+	return nil
+}
+
+// combineReshareCommits interpolates each coefficient of the dealt polynomials at 0.
+func combineReshareCommits(g kyber.Group, commits [][]*share.PubShare, n int) ([]kyber.Point, error) {
+	ret := make([]kyber.Point, len(commits))
+	for k := range commits {
+		commit, err := share.RecoverCommit(g, commits[k], len(commits[k]), n)
+		if err != nil {
+			return nil, err
+		}
+		ret[k] = commit
+	}
+	return ret, nil
+}
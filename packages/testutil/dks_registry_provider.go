@@ -15,6 +15,7 @@ import (
 // DkgRegistryProvider stands for a mock for dkg.DKShareRegistryProvider.
 type DkgRegistryProvider struct {
 	DB          map[string][]byte
+	NextDB      map[string][]byte
 	nodePrivKey *cryptolib.PrivateKey
 }
 
@@ -24,6 +25,7 @@ var _ registry.DKShareRegistryProvider = &DkgRegistryProvider{}
 func NewDkgRegistryProvider(nodePrivKey *cryptolib.PrivateKey) *DkgRegistryProvider {
 	return &DkgRegistryProvider{
 		DB:          map[string][]byte{},
+		NextDB:      map[string][]byte{},
 		nodePrivKey: nodePrivKey,
 	}
 }
@@ -42,3 +44,38 @@ func (p *DkgRegistryProvider) LoadDKShare(sharedAddress iotago.Address) (tcrypto
 	}
 	return tcrypto.DKShareFromBytes(dkShareBytes, tcrypto.DefaultEd25519Suite(), tcrypto.DefaultBLSSuite(), p.nodePrivKey)
 }
+
+// SaveNextDKShare implements dkg.DKShareRegistryProvider.
+func (p *DkgRegistryProvider) SaveNextDKShare(dkShare tcrypto.DKShare) error {
+	p.NextDB[dkShare.GetAddress().String()] = dkShare.Bytes()
+	return nil
+}
+
+// LoadNextDKShare implements dkg.DKShareRegistryProvider.
+func (p *DkgRegistryProvider) LoadNextDKShare(sharedAddress iotago.Address) (tcrypto.DKShare, error) {
+	dkShareBytes := p.NextDB[sharedAddress.String()]
+	if dkShareBytes == nil {
+		return nil, fmt.Errorf("next DKShare not found for %v", sharedAddress.String())
+	}
+	return tcrypto.DKShareFromBytes(dkShareBytes, tcrypto.DefaultEd25519Suite(), tcrypto.DefaultBLSSuite(), p.nodePrivKey)
+}
+
+// ActivateNextDKShare implements dkg.DKShareRegistryProvider.
+func (p *DkgRegistryProvider) ActivateNextDKShare(sharedAddress iotago.Address) (tcrypto.DKShare, error) {
+	dkShare, err := p.LoadNextDKShare(sharedAddress)
+	if err != nil {
+		return nil, err
+	}
+	p.DB[sharedAddress.String()] = p.NextDB[sharedAddress.String()]
+	delete(p.NextDB, sharedAddress.String())
+	return dkShare, nil
+}
+
+// NewDKGAuditLog creates a DKG audit log, that is kept in memory only.
+func NewDKGAuditLog() registry.DKGAuditLogProvider {
+	auditLog, err := registry.NewDKGAuditLog("", iotago.PrefixTestnet)
+	if err != nil {
+		panic(err)
+	}
+	return auditLog
+}
@@ -35,6 +35,17 @@ func SetupKeys(peerCount uint16) ([]string, []*cryptolib.KeyPair) {
 	return peeringURLs, peerIdentities
 }
 
+// TrustAll creates a trusted network manager, that trusts all the specified peers.
+func TrustAll(peeringURLs []string, peerIdentities []*cryptolib.KeyPair) peering.TrustedNetworkManager {
+	tnm := testutil.NewTrustedNetworkManager()
+	for i := range peeringURLs {
+		if _, err := tnm.TrustPeer(peeringURLs[i], peerIdentities[i].GetPublicKey(), peeringURLs[i]); err != nil {
+			panic(err)
+		}
+	}
+	return tnm
+}
+
 func PublicKeys(peerIdentities []*cryptolib.KeyPair) []*cryptolib.PublicKey {
 	pubKeys := make([]*cryptolib.PublicKey, len(peerIdentities))
 	for i := range pubKeys {
@@ -57,10 +68,11 @@ func SetupDkg(
 	// Initialize the DKG subsystem in each node.
 	dkgNodes := make([]*dkg.Node, len(peeringURLs))
 	dkShareRegistryProviders := make([]registry.DKShareRegistryProvider, len(peeringURLs))
+	trustedNetworkManager := TrustAll(peeringURLs, peerIdentities)
 	for i := range peeringURLs {
 		dkShareRegistryProviders[i] = testutil.NewDkgRegistryProvider(peerIdentities[i].GetPrivateKey())
 		dkgNode, err := dkg.NewNode(
-			peerIdentities[i], networkProviders[i], dkShareRegistryProviders[i],
+			peerIdentities[i], networkProviders[i], trustedNetworkManager, dkShareRegistryProviders[i], testutil.NewDKGAuditLog(),
 			testlogger.WithLevel(log.With("peeringURL", peeringURLs[i]), logger.LevelError, false),
 		)
 		require.NoError(t, err)
@@ -69,6 +81,7 @@ func SetupDkg(
 	//
 	// Initiate the key generation from some client node.
 	dkShare, err := dkgNodes[0].GenerateDistributedKey(
+		"test",
 		PublicKeys(peerIdentities),
 		threshold,
 		100*time.Second,
@@ -0,0 +1,98 @@
+package toolset
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/hive.go/app/configuration"
+	"github.com/iotaledger/wasp/packages/registry"
+)
+
+// encryptKeys migrates the node identity and the DKShares stored in plain text to the encrypted form.
+// The node must be stopped while running it, and then started with the same passphrase or key file.
+// The passphrase is read from the standard input, so that it does not end up in the shell history
+// or in the process list.
+func encryptKeys(args []string) error {
+	fs := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
+	identityFilePathFlag := fs.String(FlagToolIdentityFilePath, "waspdb/identity/identity.key", "the path to the node identity PEM file")
+	dkSharesPathFlag := fs.String(FlagToolDKSharesPath, "waspdb/dkshares", "the path to the distributed key shares registries folder")
+	passphraseStdinFlag := fs.Bool(FlagToolPassphraseStdin, false, "read the passphrase used to encrypt the keys from the first line of the standard input")
+	keyFilePathFlag := fs.String(FlagToolKeyFilePath, "", "the path to the file holding the secret used to encrypt the keys")
+
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolEncryptKeys)
+		fs.PrintDefaults()
+		fmt.Printf("\nexample: %s --%s %s\n",
+			ToolEncryptKeys,
+			FlagToolKeyFilePath,
+			"/run/secrets/wasp-keys",
+		)
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	var passphrase string
+	if *passphraseStdinFlag {
+		var err error
+		if passphrase, err = readPassphrase(os.Stdin); err != nil {
+			return err
+		}
+	}
+
+	keyEncryption, err := registry.NewKeyEncryptionFromParams(passphrase, *keyFilePathFlag)
+	if err != nil {
+		return err
+	}
+	if keyEncryption == nil {
+		return fmt.Errorf("either --%s or --%s must be specified", FlagToolPassphraseStdin, FlagToolKeyFilePath)
+	}
+
+	files := []string{*identityFilePathFlag}
+	dkShareFiles, err := filepath.Glob(filepath.Join(*dkSharesPathFlag, "*.json"))
+	if err != nil {
+		return err
+	}
+	files = append(files, dkShareFiles...)
+
+	encryptedCount := 0
+	skippedCount := 0
+	for _, file := range files {
+		encrypted, err := keyEncryption.EncryptFile(file)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return fmt.Errorf("unable to encrypt %s: %w", file, err)
+		}
+		if encrypted {
+			encryptedCount++
+		} else {
+			skippedCount++
+		}
+	}
+
+	fmt.Printf("Encrypted %d key file(s), %d were encrypted already.\n", encryptedCount, skippedCount)
+
+	return nil
+}
+
+func readPassphrase(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("unable to read the passphrase: %w", err)
+	}
+	passphrase := strings.TrimRight(line, "\r\n")
+	if passphrase == "" {
+		return "", errors.New("the passphrase read from the standard input is empty")
+	}
+	return passphrase, nil
+}
@@ -13,8 +13,13 @@ import (
 )
 
 const (
-	FlagToolNodeURL = "nodeURL"
-	ToolNodeHealth  = "node-health"
+	FlagToolNodeURL          = "nodeURL"
+	FlagToolIdentityFilePath = "identityFilePath"
+	FlagToolDKSharesPath     = "dkSharesPath"
+	FlagToolPassphraseStdin  = "passphraseStdin"
+	FlagToolKeyFilePath      = "keyFilePath"
+	ToolNodeHealth           = "node-health"
+	ToolEncryptKeys          = "encrypt-keys"
 )
 
 // ShouldHandleTools checks if tools were requested.
@@ -39,7 +44,8 @@ func HandleTools() {
 	}
 
 	tools := map[string]func([]string) error{
-		ToolNodeHealth: nodeHealth,
+		ToolNodeHealth:  nodeHealth,
+		ToolEncryptKeys: encryptKeys,
 	}
 
 	tool, exists := tools[strings.ToLower(args[1])]
@@ -64,6 +70,7 @@ func HandleTools() {
 
 func listTools() {
 	fmt.Printf("%-20s queries the health endpoint of a wasp node\n", fmt.Sprintf("%s:", ToolNodeHealth))
+	fmt.Printf("%-20s encrypts the node identity and the distributed key shares in place\n", fmt.Sprintf("%s:", ToolEncryptKeys))
 }
 
 func parseFlagSet(fs *flag.FlagSet, args []string) error {
@@ -32,6 +32,7 @@ func BasicOutputFromPostData(
 			Params:         metadata.Params,
 			Allowance:      metadata.Allowance,
 			GasBudget:      metadata.GasBudget,
+			ReturnPath:     metadata.ReturnPath,
 		},
 		par.Options,
 	)
@@ -233,7 +233,21 @@ func MakeAnchorTransaction(essence *iotago.TransactionEssence, sig iotago.Signat
 	}
 }
 
-func CreateAndSignTx(inputs iotago.Inputs, inputsCommitment []byte, outputs iotago.Outputs, wallet *cryptolib.KeyPair, networkID uint64) (*iotago.Transaction, error) {
+// SignEssence sets the inputs commitment of the essence and signs it with the given key pair
+func SignEssence(essence *iotago.TransactionEssence, inputsCommitment []byte, keyPair cryptolib.VariantKeyPair) (iotago.Signature, error) {
+	if len(inputsCommitment) != iotago.InputsCommitmentLength {
+		return nil, iotago.ErrInvalidInputsCommitment
+	}
+	copy(essence.InputsCommitment[:], inputsCommitment)
+
+	signMsg, err := essence.SigningMessage()
+	if err != nil {
+		return nil, err
+	}
+	return keyPair.AsAddressSigner().Sign(keyPair.Address(), signMsg)
+}
+
+func CreateAndSignTx(inputs iotago.Inputs, inputsCommitment []byte, outputs iotago.Outputs, wallet cryptolib.VariantKeyPair, networkID uint64) (*iotago.Transaction, error) {
 	unorderedEssence := &iotago.TransactionEssence{
 		NetworkID: networkID,
 		Inputs:    inputs,
@@ -254,16 +268,13 @@ func CreateAndSignTx(inputs iotago.Inputs, inputsCommitment []byte, outputs iota
 	}
 	// --
 
-	sigs, err := essence.Sign(
-		inputsCommitment,
-		wallet.GetPrivateKey().AddressKeysForEd25519Address(wallet.Address()),
-	)
+	sig, err := SignEssence(essence, inputsCommitment, wallet)
 	if err != nil {
 		return nil, err
 	}
 
 	return &iotago.Transaction{
 		Essence: essence,
-		Unlocks: MakeSignatureAndReferenceUnlocks(len(inputs), sigs[0]),
+		Unlocks: MakeSignatureAndReferenceUnlocks(len(inputs), sig),
 	}, nil
 }
@@ -4,7 +4,7 @@ import "bytes"
 
 // Diff computes the difference between two given trie roots, returning the collections
 // of nodes that are exclusive to each trie.
-func Diff(store KVStore, root1, root2 Hash) (onlyOn1, onlyOn2 map[Hash]*NodeData) {
+func Diff(store KVReader, root1, root2 Hash) (onlyOn1, onlyOn2 map[Hash]*NodeData) {
 	type nodeData struct {
 		*NodeData
 		key []byte
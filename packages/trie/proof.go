@@ -1,5 +1,12 @@
 package trie
 
+import (
+	"fmt"
+	"io"
+
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
 // MerkleProof is a proof of inclusion or absence
 type MerkleProof struct {
 	Key  []byte
@@ -54,3 +61,87 @@ func (tr *TrieReader) MerkleProof(key []byte) *MerkleProof {
 	}
 	return ret
 }
+
+func MerkleProofFromBytes(data []byte) (*MerkleProof, error) {
+	return rwutil.ReadFromBytes(data, new(MerkleProof))
+}
+
+func (p *MerkleProof) Bytes() []byte {
+	return rwutil.WriteToBytes(p)
+}
+
+func (p *MerkleProof) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	key := rr.ReadBytes()
+	if rr.Err == nil {
+		p.Key, rr.Err = decodeToUnpackedBytes(key)
+	}
+	p.Path = make([]*MerkleProofElement, rr.ReadSize16())
+	for i := range p.Path {
+		p.Path[i] = new(MerkleProofElement)
+		rr.Read(p.Path[i])
+	}
+	return rr.Err
+}
+
+func (p *MerkleProof) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	var key []byte
+	key, ww.Err = encodeUnpackedBytes(p.Key)
+	ww.WriteBytes(key)
+	ww.WriteSize16(len(p.Path))
+	for _, e := range p.Path {
+		ww.Write(e)
+	}
+	return ww.Err
+}
+
+func (e *MerkleProofElement) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	pathExtension := rr.ReadBytes()
+	if rr.Err == nil {
+		e.PathExtension, rr.Err = decodeToUnpackedBytes(pathExtension)
+	}
+	childrenFlags := rr.ReadUint16()
+	for i := range e.Children {
+		e.Children[i] = nil
+		if childrenFlags&(1<<i) != 0 {
+			e.Children[i] = new(Hash)
+			rr.Read(e.Children[i])
+		}
+	}
+	e.Terminal = nil
+	if rr.ReadBool() {
+		e.Terminal = rr.ReadBytes()
+	}
+	e.ChildIndex = int(rr.ReadByte())
+	if rr.Err == nil && e.ChildIndex > pathExtensionIndex {
+		rr.Err = fmt.Errorf("invalid child index %d in Merkle proof", e.ChildIndex)
+	}
+	return rr.Err
+}
+
+func (e *MerkleProofElement) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	var pathExtension []byte
+	pathExtension, ww.Err = encodeUnpackedBytes(e.PathExtension)
+	ww.WriteBytes(pathExtension)
+	childrenFlags := uint16(0)
+	for i, child := range e.Children {
+		if child != nil {
+			childrenFlags |= 1 << i
+		}
+	}
+	ww.WriteUint16(childrenFlags)
+	for _, child := range e.Children {
+		if child != nil {
+			ww.Write(child)
+		}
+	}
+	ww.WriteBool(e.Terminal != nil)
+	if e.Terminal != nil {
+		ww.WriteBytes(e.Terminal)
+	}
+	ww.WriteByte(byte(e.ChildIndex))
+	return ww.Err
+}
@@ -50,6 +50,12 @@ func (p *MerkleProof) IsProofOfAbsence() bool {
 	return len(r) == 0
 }
 
+// IsProofOfKey checks if the proof is about the given key. It does not verify the proof,
+// so a proof received from an untrusted party must be validated too
+func (p *MerkleProof) IsProofOfKey(key []byte) bool {
+	return bytes.Equal(p.Key, unpackBytes(key))
+}
+
 // Validate check the proof against the provided root commitments
 func (p *MerkleProof) Validate(rootBytes []byte) error {
 	if len(p.Path) == 0 {
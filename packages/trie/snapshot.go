@@ -7,6 +7,33 @@ import (
 )
 
 func (tr *TrieReader) TakeSnapshot(w io.Writer) error {
+	return tr.takeSnapshot(w, func(*NodeData) bool { return true })
+}
+
+// TakeDeltaSnapshot writes the nodes of the trie with the given root, which are
+// not part of the trie with the given base root. The format is the same as of
+// TakeSnapshot, so the delta can be restored with RestoreSnapshot, provided that
+// the store already contains the base trie.
+func TakeDeltaSnapshot(store KVReader, baseRoot, root Hash, w io.Writer) error {
+	if _, err := NewTrieReader(store, baseRoot); err != nil {
+		return err
+	}
+	tr, err := NewTrieReader(store, root)
+	if err != nil {
+		return err
+	}
+	_, onlyOnRoot := Diff(store, baseRoot, root)
+	// If a node is shared by both tries, so is its entire subtree
+	return tr.takeSnapshot(w, func(n *NodeData) bool {
+		_, ok := onlyOnRoot[n.Commitment]
+		return ok
+	})
+}
+
+// takeSnapshot writes the nodes in depth-first order, parents before children,
+// as RestoreSnapshot relies on it to count the references. Subtrees of the nodes,
+// which are not included, are skipped.
+func (tr *TrieReader) takeSnapshot(w io.Writer, includeFun func(*NodeData) bool) error {
 	// Some duplicated nodes and values might be written more than once in the snapshot;
 	// Using a size-capped map to prevent this.
 	// If the cap is reached, the generated snapshot will contain duplicate information,
@@ -17,6 +44,9 @@ func (tr *TrieReader) TakeSnapshot(w io.Writer) error {
 
 	ww := rwutil.NewWriter(w)
 	tr.IterateNodes(func(_ []byte, n *NodeData, depth int) IterateNodesAction {
+		if !includeFun(n) {
+			return IterateSkipSubtree
+		}
 		if _, seen := seenNodes[n.Commitment]; seen {
 			return IterateContinue
 		}
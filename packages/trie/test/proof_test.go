@@ -30,6 +30,12 @@ func TestProofScenariosBlake2b(t *testing.T) {
 				p := trr.MerkleProof([]byte(k))
 				err = p.Validate(root.Bytes())
 				require.NoError(t, err)
+
+				pBack, err := trie.MerkleProofFromBytes(p.Bytes())
+				require.NoError(t, err)
+				require.Equal(t, p.Bytes(), pBack.Bytes())
+				err = pBack.Validate(root.Bytes())
+				require.NoError(t, err)
 				if len(v) > 0 {
 					cID := trie.CommitToData([]byte(v))
 					err = p.ValidateWithTerminal(root.Bytes(), cID.Bytes())
@@ -0,0 +1,36 @@
+package trie
+
+import (
+	"fmt"
+)
+
+// Verify checks that all the nodes of the trie with the given root are
+// present in the store and that the values, which are stored outside of the
+// nodes, match their terminal commitments. It is meant to be used after
+// restoring the trie from the snapshot, which is not trusted.
+func Verify(store KVReader, root Hash) error {
+	ns := openNodeStore(store)
+	toCheck := []Hash{root}
+	for len(toCheck) > 0 {
+		commitment := toCheck[len(toCheck)-1]
+		toCheck = toCheck[:len(toCheck)-1]
+		n, ok := ns.FetchNodeData(commitment)
+		if !ok {
+			return fmt.Errorf("trie node %s not found", commitment)
+		}
+		if n.Terminal != nil && !n.Terminal.IsValue {
+			value := ns.valueStore.Get(n.Terminal.Bytes())
+			if len(value) == 0 {
+				return fmt.Errorf("value of trie node %s not found", commitment)
+			}
+			if !CommitToData(value).Equals(n.Terminal) {
+				return fmt.Errorf("value of trie node %s does not match its commitment", commitment)
+			}
+		}
+		n.iterateChildren(func(_ byte, childCommitment Hash) bool {
+			toCheck = append(toCheck, childCommitment)
+			return true
+		})
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package users
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
+)
+
+const (
+	// APIKeyPrefix is the prefix of the tokens of API keys, to tell them apart from JWTs.
+	APIKeyPrefix = "wasp_"
+
+	apiKeyIDLength     = 8
+	apiKeySecretLength = 32
+
+	// APIKeyLastUsedPrecision is the precision of the last-used time of the API keys.
+	// It limits how often the users are stored because of API key usage.
+	APIKeyLastUsedPrecision = time.Minute
+)
+
+var (
+	ErrInvalidAPIKey = errors.New("invalid API key")
+	ErrExpiredAPIKey = errors.New("API key is expired")
+)
+
+// APIKey is a long-lived token bound to a user, granting a subset of the permissions of the user.
+type APIKey struct {
+	ID          string
+	Name        string
+	SecretHash  []byte
+	Permissions map[string]struct{}
+	CreatedAt   time.Time
+	// ExpiresAt is zero if the key doesn't expire
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+}
+
+// NewAPIKey generates a new API key and returns it, together with the token that has to be sent by the clients.
+// The token is not stored, so it can't be retrieved later on.
+func NewAPIKey(name string, permissions map[string]struct{}, expiresAt time.Time) (*APIKey, string, error) {
+	if name == "" {
+		return nil, "", errors.New("API key name must not be empty")
+	}
+
+	id := make([]byte, apiKeyIDLength)
+	if _, err := rand.Read(id); err != nil {
+		return nil, "", err
+	}
+	secret := make([]byte, apiKeySecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+
+	apiKey := &APIKey{
+		ID:          hex.EncodeToString(id),
+		Name:        name,
+		SecretHash:  hashAPIKeySecret(hex.EncodeToString(secret)),
+		Permissions: permissions,
+		CreatedAt:   time.Now(),
+		ExpiresAt:   expiresAt,
+	}
+
+	return apiKey, APIKeyPrefix + apiKey.ID + "_" + hex.EncodeToString(secret), nil
+}
+
+func hashAPIKeySecret(secret string) []byte {
+	hash := sha256.Sum256([]byte(secret))
+	return hash[:]
+}
+
+// IsAPIKeyToken returns true if the token is an API key token rather than a JWT.
+func IsAPIKeyToken(token string) bool {
+	return strings.HasPrefix(token, APIKeyPrefix)
+}
+
+// parseAPIKeyToken splits an API key token into the ID of the key and its secret.
+func parseAPIKeyToken(token string) (id, secret string, err error) {
+	if !IsAPIKeyToken(token) {
+		return "", "", ErrInvalidAPIKey
+	}
+
+	id, secret, ok := strings.Cut(strings.TrimPrefix(token, APIKeyPrefix), "_")
+	if !ok || len(id) != 2*apiKeyIDLength || len(secret) != 2*apiKeySecretLength {
+		return "", "", ErrInvalidAPIKey
+	}
+
+	return id, secret, nil
+}
+
+func (k *APIKey) verifySecret(secret string) bool {
+	return subtle.ConstantTimeCompare(k.SecretHash, hashAPIKeySecret(secret)) == 1
+}
+
+// IsExpired returns true if the key can't be used anymore at the given time.
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
+
+// PermissionsSlice returns the permissions of the API key as a slice.
+func (k *APIKey) PermissionsSlice() []string {
+	ret := make([]string, 0, len(k.Permissions))
+
+	for permission := range k.Permissions {
+		ret = append(ret, permission)
+	}
+
+	return ret
+}
+
+// Clone returns a copy of an API key.
+func (k *APIKey) Clone() *APIKey {
+	permissionsCopy := make(map[string]struct{}, len(k.Permissions))
+	for permission := range k.Permissions {
+		permissionsCopy[permission] = struct{}{}
+	}
+
+	return &APIKey{
+		ID:          k.ID,
+		Name:        k.Name,
+		SecretHash:  lo.CopySlice(k.SecretHash),
+		Permissions: permissionsCopy,
+		CreatedAt:   k.CreatedAt,
+		ExpiresAt:   k.ExpiresAt,
+		LastUsedAt:  k.LastUsedAt,
+	}
+}
+
+// effectivePermissions returns the permissions of the API key that are still granted to its user.
+// A role of the key is granted, if the roles of the user grant everything it includes.
+func (k *APIKey) effectivePermissions(user *User) map[string]struct{} {
+	ret := make(map[string]struct{}, len(k.Permissions))
+
+	for permission := range k.Permissions {
+		if permissions.CanGrant(user.Permissions, permission) {
+			ret[permission] = struct{}{}
+		}
+	}
+
+	return ret
+}
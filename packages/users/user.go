@@ -14,6 +14,8 @@ type User struct {
 	PasswordHash []byte
 	PasswordSalt []byte
 	Permissions  map[string]struct{}
+	// APIKeys of the user, by ID
+	APIKeys map[string]*APIKey
 }
 
 func NewUser(username, passwordHashHex, passwordSaltHex string, permissions map[string]struct{}) (*User, error) {
@@ -59,11 +61,17 @@ func (u *User) Clone() onchangemap.Item[string, util.ComparableString] {
 		permissionsCopy[k] = struct{}{}
 	}
 
+	apiKeysCopy := make(map[string]*APIKey, len(u.APIKeys))
+	for id, apiKey := range u.APIKeys {
+		apiKeysCopy[id] = apiKey.Clone()
+	}
+
 	return &User{
 		Name:         u.Name,
 		PasswordHash: lo.CopySlice(u.PasswordHash),
 		PasswordSalt: lo.CopySlice(u.PasswordSalt),
 		Permissions:  permissionsCopy,
+		APIKeys:      apiKeysCopy,
 	}
 }
 
@@ -4,17 +4,16 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"slices"
+	"time"
 
 	"golang.org/x/exp/maps"
 
 	"github.com/iotaledger/hive.go/web/basicauth"
+	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
 	"github.com/iotaledger/wasp/packages/onchangemap"
 	"github.com/iotaledger/wasp/packages/util"
 )
 
-var AllowedPermissions = []string{"read", "write"}
-
 // UserManager handles the list of users that are stored in the user config.
 // It calls a function if the list changed.
 type UserManager struct {
@@ -31,7 +30,7 @@ func NewUserManager(storeCallback func([]*User) error) *UserManager {
 }
 
 func isPermissionAllowed(permission string) bool {
-	return slices.Contains(AllowedPermissions, permission)
+	return permissions.Validate(permission) == nil
 }
 
 func (m *UserManager) SanitizePermissions(permissions map[string]struct{}) map[string]struct{} {
@@ -116,6 +115,111 @@ func (m *UserManager) RemoveUser(name string) error {
 	return m.onChangeMap.Delete(util.ComparableString(name))
 }
 
+// AddAPIKey creates a new API key for a user and returns it, together with its token.
+// The permissions of the key must be a subset of the permissions of the user.
+func (m *UserManager) AddAPIKey(name, keyName string, keyPermissions map[string]struct{}, expiresAt time.Time) (*APIKey, string, error) {
+	apiKey, token, err := NewAPIKey(keyName, keyPermissions, expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var addErr error
+	_, err = m.onChangeMap.Modify(util.ComparableString(name), func(user *User) bool {
+		for permission := range keyPermissions {
+			if !permissions.CanGrant(user.Permissions, permission) {
+				addErr = fmt.Errorf("permission \"%s\" is not granted to user \"%s\"", permission, name)
+				return false
+			}
+		}
+		for _, existing := range user.APIKeys {
+			if existing.Name == keyName {
+				addErr = fmt.Errorf("API key \"%s\" already exists for user \"%s\"", keyName, name)
+				return false
+			}
+		}
+
+		if user.APIKeys == nil {
+			user.APIKeys = make(map[string]*APIKey)
+		}
+		user.APIKeys[apiKey.ID] = apiKey
+		return true
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to add API key for user \"%s\": %w", name, err)
+	}
+	if addErr != nil {
+		return nil, "", addErr
+	}
+
+	return apiKey.Clone(), token, nil
+}
+
+// RevokeAPIKey removes an API key of a user.
+func (m *UserManager) RevokeAPIKey(name, apiKeyID string) error {
+	var found bool
+	_, err := m.onChangeMap.Modify(util.ComparableString(name), func(user *User) bool {
+		if _, found = user.APIKeys[apiKeyID]; !found {
+			return false
+		}
+
+		delete(user.APIKeys, apiKeyID)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("unable to revoke API key of user \"%s\": %w", name, err)
+	}
+	if !found {
+		return fmt.Errorf("unable to revoke API key of user \"%s\": API key \"%s\" does not exist", name, apiKeyID)
+	}
+
+	return nil
+}
+
+// AuthenticateAPIKey returns the user an API key token belongs to, and the permissions granted by the key.
+// The last-used time of the key is updated with a precision of APIKeyLastUsedPrecision.
+func (m *UserManager) AuthenticateAPIKey(token string) (*User, map[string]struct{}, error) {
+	apiKeyID, secret, err := parseAPIKeyToken(token)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var owner *User
+	for _, user := range m.Users() {
+		if _, ok := user.APIKeys[apiKeyID]; ok {
+			owner = user
+			break
+		}
+	}
+	if owner == nil {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	apiKey := owner.APIKeys[apiKeyID]
+	if !apiKey.verifySecret(secret) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if apiKey.IsExpired(now) {
+		return nil, nil, ErrExpiredAPIKey
+	}
+
+	if now.Sub(apiKey.LastUsedAt) >= APIKeyLastUsedPrecision {
+		// the key might have been revoked in the meantime, the modification is skipped in that case
+		_, _ = m.onChangeMap.Modify(owner.ID(), func(user *User) bool {
+			storedKey, ok := user.APIKeys[apiKeyID]
+			if !ok {
+				return false
+			}
+
+			storedKey.LastUsedAt = now
+			return true
+		})
+	}
+
+	return owner, apiKey.effectivePermissions(owner), nil
+}
+
 // DerivePasswordKey derives a password key by hashing the given password with a salt.
 func DerivePasswordKey(password string, passwordSaltHex ...string) ([]byte, []byte, error) {
 	if password == "" {
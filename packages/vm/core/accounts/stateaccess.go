@@ -25,6 +25,10 @@ func (sa *StateAccess) Nonce(agentID isc.AgentID, chainID isc.ChainID) uint64 {
 	return AccountNonce(sa.state, agentID, chainID)
 }
 
+func (sa *StateAccess) BaseTokensBalance(agentID isc.AgentID, chainID isc.ChainID) uint64 {
+	return GetBaseTokensBalance(sa.state, agentID, chainID)
+}
+
 func (sa *StateAccess) AccountExists(agentID isc.AgentID, chainID isc.ChainID) bool {
 	return accountExists(sa.state, agentID, chainID)
 }
@@ -131,22 +131,26 @@ func GetRequestRecordDataByRequestID(stateReader kv.KVStoreReader, reqID isc.Req
 
 func GetEventsByBlockIndex(partition kv.KVStoreReader, blockIndex uint32, totalRequests uint16) [][]byte {
 	var ret [][]byte
-	events := collections.NewMapReadOnly(partition, prefixRequestEvents)
 	for reqIdx := uint16(0); reqIdx < totalRequests; reqIdx++ {
-		eventIndex := uint16(0)
-		for {
-			key := NewEventLookupKey(blockIndex, reqIdx, eventIndex).Bytes()
-			eventData := events.GetAt(key)
-			if eventData == nil {
-				break
-			}
-			ret = append(ret, eventData)
-			eventIndex++
-		}
+		ret = append(ret, GetEventsByRequestIndex(partition, blockIndex, reqIdx)...)
 	}
 	return ret
 }
 
+// GetEventsByRequestIndex returns the events issued by the request with the given index in the block.
+// The position of an event in the returned slice is its index within the request.
+func GetEventsByRequestIndex(partition kv.KVStoreReader, blockIndex uint32, requestIndex uint16) [][]byte {
+	var ret [][]byte
+	events := collections.NewMapReadOnly(partition, prefixRequestEvents)
+	for eventIndex := uint16(0); ; eventIndex++ {
+		eventData := events.GetAt(NewEventLookupKey(blockIndex, requestIndex, eventIndex).Bytes())
+		if eventData == nil {
+			return ret
+		}
+		ret = append(ret, eventData)
+	}
+}
+
 func GetBlockInfo(partition kv.KVStoreReader, blockIndex uint32) (*BlockInfo, bool) {
 	data := getBlockInfoBytes(partition, blockIndex)
 	if data == nil {
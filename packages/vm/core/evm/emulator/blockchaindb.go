@@ -146,6 +146,11 @@ func (bc *BlockchainDB) GetPendingCumulativeGasUsed() uint64 {
 	return r.CumulativeGasUsed
 }
 
+// GetPendingTxIndex returns the index that the next tx will have in the pending block
+func (bc *BlockchainDB) GetPendingTxIndex() uint64 {
+	return uint64(bc.getTxArray(bc.GetPendingBlockNumber()).Len())
+}
+
 func (bc *BlockchainDB) AddTransaction(tx *types.Transaction, receipt *types.Receipt) {
 	blockNumber := bc.GetPendingBlockNumber()
 
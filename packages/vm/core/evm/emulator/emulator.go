@@ -205,7 +205,7 @@ func coreMsgFromCallMsg(call ethereum.CallMsg, gasEstimateMode bool, statedb *St
 }
 
 // CallContract executes a contract call, without committing changes to the state
-func (e *EVMEmulator) CallContract(call ethereum.CallMsg, gasEstimateMode bool) (*core.ExecutionResult, error) {
+func (e *EVMEmulator) CallContract(call ethereum.CallMsg, gasEstimateMode bool, tracer tracers.Tracer) (*core.ExecutionResult, error) {
 	// Ensure message is initialized properly.
 	if call.Gas == 0 {
 		call.Gas = e.ctx.GasLimits().Call
@@ -222,7 +222,7 @@ func (e *EVMEmulator) CallContract(call ethereum.CallMsg, gasEstimateMode bool)
 	i := statedb.Snapshot()
 	defer statedb.RevertToSnapshot(i)
 
-	return e.applyMessage(coreMsgFromCallMsg(call, gasEstimateMode, statedb), statedb, pendingHeader, nil)
+	return e.applyMessage(coreMsgFromCallMsg(call, gasEstimateMode, statedb), statedb, pendingHeader, tracer)
 }
 
 func (e *EVMEmulator) applyMessage(
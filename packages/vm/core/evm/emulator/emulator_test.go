@@ -78,7 +78,7 @@ func estimateGas(callMsg ethereum.CallMsg, e *EVMEmulator) (uint64, error) {
 	var lastErr error
 	for hi >= lo {
 		callMsg.Gas = (lo + hi) / 2
-		res, err := e.CallContract(callMsg, true)
+		res, err := e.CallContract(callMsg, true, nil)
 		if err != nil {
 			return 0, fmt.Errorf("CallContract failed: %w", err)
 		}
@@ -457,7 +457,7 @@ func TestStorageContract(t *testing.T) {
 		require.NoError(t, err)
 		require.NotEmpty(t, callArguments)
 
-		res, err := emu.CallContract(ethereum.CallMsg{To: &contractAddress, Data: callArguments}, false)
+		res, err := emu.CallContract(ethereum.CallMsg{To: &contractAddress, Data: callArguments}, false, nil)
 		require.NoError(t, err)
 		require.NotEmpty(t, res)
 
@@ -485,7 +485,7 @@ func TestStorageContract(t *testing.T) {
 		res, err := emu.CallContract(ethereum.CallMsg{
 			To:   &contractAddress,
 			Data: callArguments,
-		}, false)
+		}, false, nil)
 		require.NoError(t, err)
 		require.NotEmpty(t, res)
 
@@ -529,7 +529,7 @@ func TestERC20Contract(t *testing.T) {
 		callArguments, err2 := contractABI.Pack(name, args...)
 		require.NoError(t, err2)
 
-		res, err2 := emu.CallContract(ethereum.CallMsg{To: &contractAddress, Data: callArguments}, false)
+		res, err2 := emu.CallContract(ethereum.CallMsg{To: &contractAddress, Data: callArguments}, false, nil)
 		require.NoError(t, err2)
 
 		v := new(big.Int)
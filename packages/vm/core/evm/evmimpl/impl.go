@@ -115,7 +115,7 @@ func applyTransaction(ctx isc.Sandbox) dict.Dict {
 	}
 
 	// Execute the tx in the emulator.
-	receipt, result, err := emu.SendTransaction(tx, getTracer(ctx), false)
+	receipt, result, err := emu.SendTransaction(tx, getTracer(ctx, emu, tx), false)
 
 	// Any gas burned by the EVM is converted to ISC gas units and burned as
 	// ISC gas.
@@ -414,7 +414,7 @@ func callContract(ctx isc.Sandbox) dict.Dict {
 	ctx.RequireCaller(isc.NewEthereumAddressAgentID(ctx.ChainID(), callMsg.From))
 
 	emu := createEmulator(ctx)
-	res, err := emu.CallContract(callMsg, ctx.Gas().EstimateGasMode())
+	res, err := emu.CallContract(callMsg, ctx.Gas().EstimateGasMode(), getTracer(ctx, emu, nil))
 	ctx.RequireNoError(err)
 	ctx.RequireNoError(tryGetRevertError(res))
 
@@ -30,11 +30,23 @@ func MintBlock(evmPartition kv.KVStore, chainInfo *isc.ChainInfo, blockTimestamp
 	createBlockchainDB(evmPartition, chainInfo).MintBlock(timestamp(blockTimestamp))
 }
 
-func getTracer(ctx isc.Sandbox) tracers.Tracer {
+// getTracer returns the tracer for the EVM tx (or call, if tx is nil) that is
+// about to be executed, or nil if it is not being traced.
+func getTracer(ctx isc.Sandbox, emu *emulator.EVMEmulator, tx *types.Transaction) tracers.Tracer {
 	tracer := ctx.EVMTracer()
 	if tracer == nil {
 		return nil
 	}
+	if tx == nil && (tracer.TxIndex != nil || tracer.OnTxStart != nil) {
+		// tracing the txs of a block, EVM calls made by other requests are not part of it
+		return nil
+	}
+	if tracer.TxIndex != nil && *tracer.TxIndex != emu.BlockchainDB().GetPendingTxIndex() {
+		return nil
+	}
+	if tracer.OnTxStart != nil {
+		tracer.OnTxStart(tx.Hash())
+	}
 	return tracer.Tracer
 }
 
@@ -5,6 +5,7 @@ package evmtest
 
 import (
 	"bytes"
+	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -2022,6 +2023,86 @@ func TestTraceTransaction(t *testing.T) {
 	}
 }
 
+func TestTraceBlock(t *testing.T) {
+	env := InitEVM(t)
+	ethKey1, ethAddr1 := env.Chain.NewEthereumAccountWithL2Funds()
+	ethKey2, ethAddr2 := env.Chain.NewEthereumAccountWithL2Funds()
+	storage := env.deployStorageContract(ethKey1)
+
+	// put two EVM txs in the same block
+	var reqs []isc.Request
+	for i, key := range []*ecdsa.PrivateKey{ethKey1, ethKey2} {
+		tx, err := storage.buildEthTx([]ethCallOptions{{sender: key}}, "store", uint32(i))
+		require.NoError(t, err)
+		req, err := isc.NewEVMOffLedgerTxRequest(env.Chain.ChainID, tx)
+		require.NoError(t, err)
+		reqs = append(reqs, req)
+		// an EVM call in between is executed, but it is not a tx of the block
+		callData, err := storage.abi.Pack("retrieve")
+		require.NoError(t, err)
+		reqs = append(reqs, isc.NewEVMOffLedgerCallRequest(env.Chain.ChainID, storage.callMsg(ethereum.CallMsg{
+			From: ethAddr1,
+			Data: callData,
+		})))
+	}
+	env.Chain.RunOffLedgerRequests(reqs)
+	block, err := env.evmChain.BlockByNumber(nil)
+	require.NoError(t, err)
+	require.Len(t, block.Transactions(), 2)
+
+	// tracing a single tx traces only that tx, even if it is not the last one in the block
+	trace, err := env.evmChain.TraceTransaction(block.Transactions()[0].Hash(), &tracers.TraceConfig{})
+	require.NoError(t, err)
+	var callFrame jsonrpc.CallFrame
+	require.NoError(t, json.Unmarshal(trace.(json.RawMessage), &callFrame))
+	require.EqualValues(t, ethAddr1, common.HexToAddress(callFrame.From))
+
+	results, err := env.evmChain.TraceBlockByNumber(block.Number(), nil)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for i, addr := range []common.Address{ethAddr1, ethAddr2} {
+		require.Equal(t, block.Transactions()[i].Hash(), results[i].TxHash)
+		var frame jsonrpc.CallFrame
+		require.NoError(t, json.Unmarshal(results[i].Result, &frame))
+		require.EqualValues(t, addr, common.HexToAddress(frame.From))
+		require.EqualValues(t, storage.address, common.HexToAddress(frame.To))
+	}
+
+	prestateTracer := "prestateTracer"
+	results, err = env.evmChain.TraceBlockByHash(block.Hash(), &tracers.TraceConfig{Tracer: &prestateTracer})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for i, addr := range []common.Address{ethAddr1, ethAddr2} {
+		var prestate map[common.Address]json.RawMessage
+		require.NoError(t, json.Unmarshal(results[i].Result, &prestate))
+		require.Contains(t, prestate, addr)
+		require.Contains(t, prestate, storage.address)
+	}
+}
+
+func TestTraceCall(t *testing.T) {
+	env := InitEVM(t)
+	ethKey, ethAddr := env.Chain.NewEthereumAccountWithL2Funds()
+	storage := env.deployStorageContract(ethKey)
+
+	callData, err := storage.abi.Pack("store", uint32(43))
+	require.NoError(t, err)
+	trace, err := env.evmChain.TraceCall(ethereum.CallMsg{
+		From: ethAddr,
+		To:   &storage.address,
+		Data: callData,
+	}, nil, &tracers.TraceConfig{})
+	require.NoError(t, err)
+	var callFrame jsonrpc.CallFrame
+	require.NoError(t, json.Unmarshal(trace.(json.RawMessage), &callFrame))
+	require.EqualValues(t, ethAddr, common.HexToAddress(callFrame.From))
+	require.EqualValues(t, storage.address, common.HexToAddress(callFrame.To))
+	require.Empty(t, callFrame.Error)
+
+	// state changes are discarded
+	require.EqualValues(t, 42, storage.retrieve())
+}
+
 func TestMagicContractExamples(t *testing.T) {
 	env := InitEVM(t)
 	ethKey, _ := env.Chain.NewEthereumAccountWithL2Funds()
@@ -35,6 +35,34 @@ func getFeePolicy(ctx isc.SandboxView) dict.Dict {
 	return ret
 }
 
+// setDynamicFeePolicy enables the dynamic gas price, or disables it if no policy is given.
+// While enabled, the GasPerToken ratio of the fee policy is adjusted by the VM after each block.
+// Input:
+// - governance.ParamDynamicFeePolicyBytes (optional) bytes of the dynamic fee policy record
+func setDynamicFeePolicy(ctx isc.Sandbox) dict.Dict {
+	ctx.RequireCallerIsChainOwner()
+
+	data := ctx.Params().Get(governance.ParamDynamicFeePolicyBytes)
+	if data == nil {
+		ctx.State().Del(governance.VarDynamicFeePolicyBytes)
+		return nil
+	}
+	_, err := gas.DynamicFeePolicyFromBytes(data)
+	ctx.RequireNoError(err)
+
+	ctx.State().Set(governance.VarDynamicFeePolicyBytes, data)
+	return nil
+}
+
+// getDynamicFeePolicy returns the dynamic fee policy in serialized form, or nothing if the gas price is static
+func getDynamicFeePolicy(ctx isc.SandboxView) dict.Dict {
+	ret := dict.New()
+	if p := governance.MustGetDynamicFeePolicy(ctx.StateR()); p != nil {
+		ret.Set(governance.ParamDynamicFeePolicyBytes, p.Bytes())
+	}
+	return ret
+}
+
 var errInvalidGasRatio = coreerrors.Register("invalid gas ratio").Create()
 
 func setEVMGasRatio(ctx isc.Sandbox) dict.Dict {
@@ -34,6 +34,8 @@ var Processor = governance.Contract.Processor(nil,
 	governance.ViewGetEVMGasRatio.WithHandler(getEVMGasRatio),
 	governance.FuncSetGasLimits.WithHandler(setGasLimits),
 	governance.ViewGetGasLimits.WithHandler(getGasLimits),
+	governance.FuncSetDynamicFeePolicy.WithHandler(setDynamicFeePolicy),
+	governance.ViewGetDynamicFeePolicy.WithHandler(getDynamicFeePolicy),
 
 	// chain info
 	governance.ViewGetChainInfo.WithHandler(getChainInfo),
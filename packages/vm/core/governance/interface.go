@@ -33,6 +33,10 @@ var (
 	ViewGetFeePolicy = coreutil.ViewFunc("getFeePolicy")
 	ViewGetGasLimits = coreutil.ViewFunc("getGasLimits")
 
+	// dynamic gas price
+	FuncSetDynamicFeePolicy = coreutil.Func("setDynamicFeePolicy")
+	ViewGetDynamicFeePolicy = coreutil.ViewFunc("getDynamicFeePolicy")
+
 	// evm fees
 	FuncSetEVMGasRatio = coreutil.Func("setEVMGasRatio")
 	ViewGetEVMGasRatio = coreutil.ViewFunc("getEVMGasRatio")
@@ -73,6 +77,9 @@ const (
 	VarGasFeePolicyBytes = "g"
 	VarGasLimitsBytes    = "l"
 
+	// dynamic gas price (not set if disabled)
+	VarDynamicFeePolicyBytes = "d"
+
 	// access nodes
 	VarAccessNodes          = "an"
 	VarAccessNodeCandidates = "ac"
@@ -104,6 +111,9 @@ const (
 	ParamEVMGasRatio    = "e"
 	ParamGasLimitsBytes = "l"
 
+	// dynamic gas price
+	ParamDynamicFeePolicyBytes = "d"
+
 	// chain info
 	ParamChainID = "c"
 
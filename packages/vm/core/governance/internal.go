@@ -103,6 +103,39 @@ func GetGasLimits(state kv.KVStoreReader) (*gas.Limits, error) {
 	return gas.LimitsFromBytes(data)
 }
 
+// GetDynamicFeePolicy returns the dynamic fee policy from the state, or nil if the gas price is static
+func GetDynamicFeePolicy(state kv.KVStoreReader) (*gas.DynamicFeePolicy, error) {
+	data := state.Get(VarDynamicFeePolicyBytes)
+	if data == nil {
+		return nil, nil
+	}
+	return gas.DynamicFeePolicyFromBytes(data)
+}
+
+func MustGetDynamicFeePolicy(state kv.KVStoreReader) *gas.DynamicFeePolicy {
+	p, err := GetDynamicFeePolicy(state)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// AdjustGasPerToken is called by the VM when closing a block. If the dynamic fee policy is enabled,
+// it updates the GasPerToken ratio of the fee policy depending on the gas burned in the block.
+func AdjustGasPerToken(state kv.KVStore, gasBurned uint64) {
+	dynamicFeePolicy := MustGetDynamicFeePolicy(state)
+	if dynamicFeePolicy == nil {
+		return
+	}
+	feePolicy := MustGetGasFeePolicy(state)
+	next := dynamicFeePolicy.NextGasPerToken(feePolicy.GasPerToken, gasBurned, MustGetGasLimits(state))
+	if next == feePolicy.GasPerToken {
+		return
+	}
+	feePolicy.GasPerToken = next
+	state.Set(VarGasFeePolicyBytes, feePolicy.Bytes())
+}
+
 func GetBlockKeepAmount(state kv.KVStoreReader) int32 {
 	return codec.MustDecodeInt32(state.Get(VarBlockKeepAmount), DefaultBlockKeepAmount)
 }
@@ -12,10 +12,17 @@ var (
 	FuncGrantDeployPermission    = coreutil.Func("grantDeployPermission")
 	FuncRevokeDeployPermission   = coreutil.Func("revokeDeployPermission")
 	FuncRequireDeployPermissions = coreutil.Func("requireDeployPermissions")
+	FuncUpgradeContract          = coreutil.Func("upgradeContract")
+	FuncRetireContract           = coreutil.Func("retireContract")
+	FuncSetUpgradeAuthority      = coreutil.Func("setUpgradeAuthority")
+	FuncScheduleCall             = coreutil.Func("scheduleCall")
+	FuncCancelScheduledCall      = coreutil.Func("cancelScheduledCall")
 
 	// Views
-	ViewFindContract       = coreutil.ViewFunc("findContract")
-	ViewGetContractRecords = coreutil.ViewFunc("getContractRecords")
+	ViewFindContract        = coreutil.ViewFunc("findContract")
+	ViewGetContractRecords  = coreutil.ViewFunc("getContractRecords")
+	ViewGetUpgradeAuthority = coreutil.ViewFunc("getUpgradeAuthority")
+	ViewGetScheduledCalls   = coreutil.ViewFunc("getScheduledCalls")
 )
 
 // state variables
@@ -24,6 +31,15 @@ const (
 	VarContractRegistry         = "r"
 	VarDeployPermissionsEnabled = "a"
 	VarDeployPermissions        = "p"
+	VarUpgradeAuthorities       = "u"
+	VarRetiredContracts         = "t"
+	VarScheduledCalls           = "s"
+	VarScheduledCallNextID      = "n"
+	// VarScheduledCallsByTime indexes the scheduled calls by due time and ID, so that the due ones can be
+	// found without decoding all the calls
+	VarScheduledCallsByTime = "q"
+	// VarScheduledCallsCount is the number of calls scheduled by each contract
+	VarScheduledCallsCount = "c"
 )
 
 // request parameters
@@ -35,4 +51,18 @@ const (
 	ParamContractRecData          = "dt"
 	ParamContractFound            = "cf"
 	ParamDeployPermissionsEnabled = "de"
+	ParamUpgradeAuthority         = "ua"
+	ParamMigrationEntryPoint      = "me"
+	ParamScheduledCall            = "sc"
+	ParamScheduledCallID          = "si"
+)
+
+const (
+	// MaxScheduledCalls is the maximum number of calls that can be scheduled on the chain at the same time
+	MaxScheduledCalls = 256
+	// MaxScheduledCallsPerContract is the maximum number of calls a single contract can have scheduled at the same time
+	MaxScheduledCallsPerContract = 16
+	// MaxScheduledCallsPerBlock is the maximum number of due scheduled calls executed at the start of a block.
+	// The remaining ones are executed in the following blocks.
+	MaxScheduledCallsPerBlock = 16
 )
@@ -1,10 +1,14 @@
 package root
 
 import (
+	"encoding/binary"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/collections"
 )
 
@@ -37,6 +41,203 @@ func FindContract(state kv.KVStoreReader, hname isc.Hname) *ContractRecord {
 	return nil
 }
 
+func GetUpgradeAuthorities(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, VarUpgradeAuthorities)
+}
+
+func GetUpgradeAuthoritiesR(state kv.KVStoreReader) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, VarUpgradeAuthorities)
+}
+
+// GetUpgradeAuthority returns the agent allowed to upgrade or retire the contract, besides the chain owner.
+// Returns nil if only the chain owner is allowed to, e.g. for contracts deployed before upgrades were introduced.
+func GetUpgradeAuthority(state kv.KVStoreReader, hname isc.Hname) isc.AgentID {
+	data := GetUpgradeAuthoritiesR(state).GetAt(hname.Bytes())
+	if data == nil {
+		return nil
+	}
+	agentID, err := isc.AgentIDFromBytes(data)
+	if err != nil {
+		panic(fmt.Errorf("GetUpgradeAuthority: %w", err))
+	}
+	return agentID
+}
+
+func GetRetiredContracts(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, VarRetiredContracts)
+}
+
+// IsContractRetired returns true if a contract with the given hname was retired.
+// The hname of a retired contract can't be reused, because its state and accounts are kept.
+func IsContractRetired(state kv.KVStoreReader, hname isc.Hname) bool {
+	return collections.NewMapReadOnly(state, VarRetiredContracts).HasAt(hname.Bytes())
+}
+
+func GetScheduledCalls(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, VarScheduledCalls)
+}
+
+func GetScheduledCallsR(state kv.KVStoreReader) *collections.ImmutableMap {
+	return collections.NewMapReadOnly(state, VarScheduledCalls)
+}
+
+// GetScheduledCall returns the scheduled call with the given ID, or nil if it does not exist
+func GetScheduledCall(state kv.KVStoreReader, id uint32) *isc.ScheduledCall {
+	data := GetScheduledCallsR(state).GetAt(codec.EncodeUint32(id))
+	if data == nil {
+		return nil
+	}
+	call, err := isc.ScheduledCallFromBytes(data)
+	if err != nil {
+		panic(fmt.Errorf("GetScheduledCall: %w", err))
+	}
+	return call
+}
+
+// NextScheduledCallID returns a new unique ID for a scheduled call
+func NextScheduledCallID(state kv.KVStore) uint32 {
+	id := codec.MustDecodeUint32(state.Get(VarScheduledCallNextID), 0)
+	state.Set(VarScheduledCallNextID, codec.EncodeUint32(id+1))
+	return id
+}
+
+// DecodeScheduledCalls decodes all the scheduled calls in the map, sorted by due time and ID
+func DecodeScheduledCalls(m *collections.ImmutableMap) ([]*isc.ScheduledCall, error) {
+	var ret []*isc.ScheduledCall
+	var err error
+	m.Iterate(func(_ []byte, value []byte) bool {
+		var call *isc.ScheduledCall
+		call, err = isc.ScheduledCallFromBytes(value)
+		if err != nil {
+			return false
+		}
+		ret = append(ret, call)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if !ret[i].Time.Equal(ret[j].Time) {
+			return ret[i].Time.Before(ret[j].Time)
+		}
+		return ret[i].ID < ret[j].ID
+	})
+	return ret, nil
+}
+
+// scheduledCallTimeKey is the key of the call in the time index. The due time goes first and
+// is encoded in big endian, so the keys are sorted in the order the calls must be executed.
+func scheduledCallTimeKey(call *isc.ScheduledCall) []byte {
+	key := make([]byte, 12)
+	binary.BigEndian.PutUint64(key[:8], uint64(call.Time.UnixNano()))
+	binary.BigEndian.PutUint32(key[8:], call.ID)
+	return key
+}
+
+func getScheduledCallsCount(state kv.KVStore) *collections.Map {
+	return collections.NewMap(state, VarScheduledCallsCount)
+}
+
+// ScheduledCallsCount returns the number of calls currently scheduled by the given contract
+func ScheduledCallsCount(state kv.KVStoreReader, hname isc.Hname) uint32 {
+	return codec.MustDecodeUint32(collections.NewMapReadOnly(state, VarScheduledCallsCount).GetAt(hname.Bytes()), 0)
+}
+
+func setScheduledCallsCount(state kv.KVStore, hname isc.Hname, count uint32) {
+	if count == 0 {
+		getScheduledCallsCount(state).DelAt(hname.Bytes())
+		return
+	}
+	getScheduledCallsCount(state).SetAt(hname.Bytes(), codec.EncodeUint32(count))
+}
+
+// AddScheduledCall stores a new scheduled call, the ID of the call must be already assigned
+func AddScheduledCall(state kv.KVStore, call *isc.ScheduledCall) {
+	GetScheduledCalls(state).SetAt(codec.EncodeUint32(call.ID), call.Bytes())
+	collections.NewMap(state, VarScheduledCallsByTime).SetAt(scheduledCallTimeKey(call), codec.EncodeUint32(call.ID))
+	setScheduledCallsCount(state, call.Contract, ScheduledCallsCount(state, call.Contract)+1)
+}
+
+// RemoveScheduledCall removes a scheduled call along with its index entries
+func RemoveScheduledCall(state kv.KVStore, call *isc.ScheduledCall) {
+	GetScheduledCalls(state).DelAt(codec.EncodeUint32(call.ID))
+	collections.NewMap(state, VarScheduledCallsByTime).DelAt(scheduledCallTimeKey(call))
+	setScheduledCallsCount(state, call.Contract, ScheduledCallsCount(state, call.Contract)-1)
+}
+
+// iterateScheduledCallsByTime calls f with the IDs of the scheduled calls, in the order they must be executed
+func iterateScheduledCallsByTime(state kv.KVStoreReader, f func(t time.Time, id uint32) bool) {
+	prefix := collections.MapElemKey(VarScheduledCallsByTime, nil)
+	state.IterateKeysSorted(prefix, func(key kv.Key) bool {
+		elemKey := []byte(key[len(prefix):])
+		return f(time.Unix(0, int64(binary.BigEndian.Uint64(elemKey[:8]))), binary.BigEndian.Uint32(elemKey[8:]))
+	})
+}
+
+// NextScheduledCallTime returns the due time of the next scheduled call, false if there are no scheduled calls
+func NextScheduledCallTime(state kv.KVStoreReader) (ret time.Time, ok bool) {
+	iterateScheduledCallsByTime(state, func(t time.Time, _ uint32) bool {
+		ret, ok = t, true
+		return false
+	})
+	return ret, ok
+}
+
+// DueScheduledCalls returns at most MaxScheduledCallsPerBlock scheduled calls which are due at the given time,
+// in the order they must be executed. Only the due calls are decoded.
+func DueScheduledCalls(state kv.KVStoreReader, now time.Time) []*isc.ScheduledCall {
+	var ret []*isc.ScheduledCall
+	iterateScheduledCallsByTime(state, func(t time.Time, id uint32) bool {
+		if t.After(now) || len(ret) >= MaxScheduledCallsPerBlock {
+			return false
+		}
+		call := GetScheduledCall(state, id)
+		if call == nil {
+			panic(fmt.Errorf("DueScheduledCalls: inconsistent index, call %d not found", id))
+		}
+		ret = append(ret, call)
+		return true
+	})
+	return ret
+}
+
+// AdvanceScheduledCall is called when the call is executed at the given time.
+// A recurring call is rescheduled to its next due time, a one-time call is removed.
+func AdvanceScheduledCall(state kv.KVStore, call *isc.ScheduledCall, now time.Time) {
+	next, ok := call.NextTime(now)
+	if !ok {
+		RemoveScheduledCall(state, call)
+		return
+	}
+	byTime := collections.NewMap(state, VarScheduledCallsByTime)
+	byTime.DelAt(scheduledCallTimeKey(call))
+	call.Time = next
+	byTime.SetAt(scheduledCallTimeKey(call), codec.EncodeUint32(call.ID))
+	GetScheduledCalls(state).SetAt(codec.EncodeUint32(call.ID), call.Bytes())
+}
+
+// DeleteScheduledCallsOf removes all the calls scheduled by the given contract
+func DeleteScheduledCallsOf(state kv.KVStore, hname isc.Hname) {
+	if ScheduledCallsCount(state, hname) == 0 {
+		return
+	}
+	var toDelete []*isc.ScheduledCall
+	GetScheduledCalls(state).Iterate(func(_ []byte, value []byte) bool {
+		call, err := isc.ScheduledCallFromBytes(value)
+		if err != nil {
+			panic(fmt.Errorf("DeleteScheduledCallsOf: %w", err))
+		}
+		if call.Contract == hname {
+			toDelete = append(toDelete, call)
+		}
+		return true
+	})
+	for _, call := range toDelete {
+		RemoveScheduledCall(state, call)
+	}
+}
+
 // DecodeContractRegistry encodes the whole contract registry from the map into a Go map.
 func DecodeContractRegistry(contractRegistry *collections.ImmutableMap) (map[isc.Hname]*ContractRecord, error) {
 	ret := make(map[isc.Hname]*ContractRecord)
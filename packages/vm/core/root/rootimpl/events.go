@@ -24,3 +24,40 @@ func eventRevoke(ctx isc.Sandbox, deployer isc.AgentID) {
 	ww.Write(deployer)
 	ctx.Event("coreroot.revoke", ww.Bytes())
 }
+
+func eventUpgrade(ctx isc.Sandbox, hname isc.Hname, oldProgHash, newProgHash hashing.HashValue) {
+	ww := rwutil.NewBytesWriter()
+	ww.Write(&hname)
+	ww.Write(&oldProgHash)
+	ww.Write(&newProgHash)
+	ctx.Event("coreroot.upgrade", ww.Bytes())
+}
+
+func eventRetire(ctx isc.Sandbox, hname isc.Hname) {
+	ww := rwutil.NewBytesWriter()
+	ww.Write(&hname)
+	ctx.Event("coreroot.retire", ww.Bytes())
+}
+
+func eventSetUpgradeAuthority(ctx isc.Sandbox, hname isc.Hname, authority isc.AgentID) {
+	ww := rwutil.NewBytesWriter()
+	ww.Write(&hname)
+	isc.AgentIDToWriter(ww, authority)
+	ctx.Event("coreroot.upgradeauthority", ww.Bytes())
+}
+
+func eventScheduleCall(ctx isc.Sandbox, call *isc.ScheduledCall) {
+	ww := rwutil.NewBytesWriter()
+	ww.WriteUint32(call.ID)
+	ww.Write(&call.Contract)
+	ww.Write(&call.Target.Contract)
+	ww.Write(&call.Target.EntryPoint)
+	ctx.Event("coreroot.schedule", ww.Bytes())
+}
+
+func eventCancelScheduledCall(ctx isc.Sandbox, call *isc.ScheduledCall) {
+	ww := rwutil.NewBytesWriter()
+	ww.WriteUint32(call.ID)
+	ww.Write(&call.Contract)
+	ctx.Event("coreroot.cancelschedule", ww.Bytes())
+}
@@ -4,6 +4,7 @@
 // - maintaining (setting, delegating) chain owner ID
 // - maintaining (granting, revoking) smart contract deployment rights
 // - deployment of smart contracts on the chain and maintenance of contract registry
+// - maintenance of the calls scheduled by contracts
 
 package rootimpl
 
@@ -30,8 +31,15 @@ var Processor = root.Contract.Processor(nil,
 	root.FuncGrantDeployPermission.WithHandler(grantDeployPermission),
 	root.FuncRequireDeployPermissions.WithHandler(requireDeployPermissions),
 	root.FuncRevokeDeployPermission.WithHandler(revokeDeployPermission),
+	root.FuncUpgradeContract.WithHandler(upgradeContract),
+	root.FuncRetireContract.WithHandler(retireContract),
+	root.FuncSetUpgradeAuthority.WithHandler(setUpgradeAuthority),
+	root.FuncScheduleCall.WithHandler(scheduleCall),
+	root.FuncCancelScheduledCall.WithHandler(cancelScheduledCall),
 	root.ViewFindContract.WithHandler(findContract),
 	root.ViewGetContractRecords.WithHandler(getContractRecords),
+	root.ViewGetUpgradeAuthority.WithHandler(getUpgradeAuthority),
+	root.ViewGetScheduledCalls.WithHandler(getScheduledCalls),
 )
 
 func SetInitialState(state kv.KVStore) {
@@ -103,6 +111,8 @@ func deployContract(ctx isc.Sandbox) dict.Dict {
 		ProgramHash: progHash,
 		Name:        name,
 	})
+	// the deployer is allowed to upgrade and retire the contract
+	root.GetUpgradeAuthorities(ctx.State()).SetAt(isc.Hn(name).Bytes(), ctx.Caller().Bytes())
 	ctx.Call(isc.Hn(name), isc.EntryPointInit, initParams, nil)
 	eventDeploy(ctx, progHash, name)
 	return nil
@@ -137,6 +147,165 @@ func requireDeployPermissions(ctx isc.Sandbox) dict.Dict {
 	return nil
 }
 
+// upgradeContract replaces the program of a deployed contract, keeping its hname, state and accounts.
+// If a migration entry point is given, it is called on the new program with the remaining params,
+// and the upgrade is reverted if the migration fails.
+// Inputs:
+//   - ParamHname isc.Hname of the contract
+//   - ParamProgramHash HashValue of the new program
+//   - ParamMigrationEntryPoint string (optional) name of the entry point of the new program to call after the upgrade
+func upgradeContract(ctx isc.Sandbox) dict.Dict {
+	params := ctx.Params()
+	hname := params.MustGetHname(root.ParamHname)
+	progHash := params.MustGetHashValue(root.ParamProgramHash)
+	migrationEntryPoint := params.MustGetString(root.ParamMigrationEntryPoint, "")
+
+	rec := mustGetUpgradableContract(ctx, hname)
+	oldProgHash := rec.ProgramHash
+
+	err := ctx.Privileged().TryLoadContract(progHash)
+	ctx.RequireNoError(err, "root.upgradeContract.fail: ")
+
+	rec.ProgramHash = progHash
+	root.GetContractRegistry(ctx.State()).SetAt(hname.Bytes(), rec.Bytes())
+
+	if migrationEntryPoint != "" {
+		// pass to the migration function all params not consumed so far
+		migrationParams := dict.New()
+		params.Dict.Iterate("", func(key kv.Key, value []byte) bool {
+			if key != root.ParamHname && key != root.ParamProgramHash && key != root.ParamMigrationEntryPoint {
+				migrationParams.Set(key, value)
+			}
+			return true
+		})
+		ctx.Call(hname, isc.Hn(migrationEntryPoint), migrationParams, nil)
+	}
+	eventUpgrade(ctx, hname, oldProgHash, progHash)
+	return nil
+}
+
+// retireContract removes a deployed contract from the registry, so it can't be called anymore.
+// The state of the contract is kept, and its hname can't be used again. The assets owned by
+// the contract are moved to the caller (the chain owner or the upgrade authority).
+// Input:
+//   - ParamHname isc.Hname of the contract
+func retireContract(ctx isc.Sandbox) dict.Dict {
+	hname := ctx.Params().MustGetHname(root.ParamHname)
+	rec := mustGetUpgradableContract(ctx, hname)
+	sweepContractAccount(ctx, hname)
+
+	root.GetContractRegistry(ctx.State()).DelAt(hname.Bytes())
+	root.GetUpgradeAuthorities(ctx.State()).DelAt(hname.Bytes())
+	root.GetRetiredContracts(ctx.State()).SetAt(hname.Bytes(), rec.ProgramHash.Bytes())
+	root.DeleteScheduledCallsOf(ctx.State(), hname)
+	eventRetire(ctx, hname)
+	return nil
+}
+
+// setUpgradeAuthority sets the agent which is allowed to upgrade and retire the contract.
+// If no authority is given, only the chain owner is allowed to.
+// Inputs:
+//   - ParamHname isc.Hname of the contract
+//   - ParamUpgradeAuthority isc.AgentID (optional)
+func setUpgradeAuthority(ctx isc.Sandbox) dict.Dict {
+	hname := ctx.Params().MustGetHname(root.ParamHname)
+	authority := ctx.Params().MustGetAgentID(root.ParamUpgradeAuthority, nil)
+	mustGetUpgradableContract(ctx, hname)
+
+	if authority == nil {
+		root.GetUpgradeAuthorities(ctx.State()).DelAt(hname.Bytes())
+	} else {
+		root.GetUpgradeAuthorities(ctx.State()).SetAt(hname.Bytes(), authority.Bytes())
+	}
+	eventSetUpgradeAuthority(ctx, hname, authority)
+	return nil
+}
+
+// getUpgradeAuthority returns the upgrade authority of the contract, if any
+// Input:
+//   - ParamHname isc.Hname of the contract
+//
+// Output:
+//   - ParamUpgradeAuthority isc.AgentID (empty if only the chain owner can upgrade the contract)
+func getUpgradeAuthority(ctx isc.SandboxView) dict.Dict {
+	hname := ctx.Params().MustGetHname(root.ParamHname)
+	ret := dict.New()
+	if authority := root.GetUpgradeAuthority(ctx.StateR(), hname); authority != nil {
+		ret.Set(root.ParamUpgradeAuthority, authority.Bytes())
+	}
+	return ret
+}
+
+// scheduleCall registers a call to be executed by the chain at the start of a block.
+// Only contracts of the chain can schedule calls, the gas is paid from the account of the contract.
+// A contract can have at most MaxScheduledCallsPerContract calls scheduled at the same time.
+// Input:
+//   - ParamScheduledCall isc.ScheduledCall (the ID and the contract are ignored)
+//
+// Output:
+//   - ParamScheduledCallID uint32
+func scheduleCall(ctx isc.Sandbox) dict.Dict {
+	caller := mustGetCallerContract(ctx)
+	call, err := isc.ScheduledCallFromBytes(ctx.Params().MustGetBytes(root.ParamScheduledCall))
+	ctx.RequireNoError(err)
+	if call.Interval < 0 {
+		panic(errInvalidScheduledCall)
+	}
+	if root.GetScheduledCalls(ctx.State()).Len() >= root.MaxScheduledCalls ||
+		root.ScheduledCallsCount(ctx.State(), caller) >= root.MaxScheduledCallsPerContract {
+		panic(errTooManyScheduledCalls)
+	}
+
+	call.ID = root.NextScheduledCallID(ctx.State())
+	call.Contract = caller
+	root.AddScheduledCall(ctx.State(), call)
+	eventScheduleCall(ctx, call)
+	return dict.Dict{root.ParamScheduledCallID: codec.EncodeUint32(call.ID)}
+}
+
+// cancelScheduledCall removes a scheduled call. Only the contract that scheduled the call
+// and the chain owner are allowed to cancel it.
+// Input:
+//   - ParamScheduledCallID uint32
+func cancelScheduledCall(ctx isc.Sandbox) dict.Dict {
+	id := ctx.Params().MustGetUint32(root.ParamScheduledCallID)
+	call := root.GetScheduledCall(ctx.State(), id)
+	if call == nil {
+		panic(errScheduledCallNotFound.Create(id))
+	}
+	ctx.RequireCallerAnyOf([]isc.AgentID{
+		ctx.ChainOwnerID(),
+		isc.NewContractAgentID(ctx.ChainID(), call.Contract),
+	})
+	root.RemoveScheduledCall(ctx.State(), call)
+	eventCancelScheduledCall(ctx, call)
+	return nil
+}
+
+// getScheduledCalls returns the calls scheduled on the chain
+// Input:
+//   - ParamHname isc.Hname (optional) only return the calls scheduled by this contract
+//
+// Output:
+//   - VarScheduledCalls map of ID => isc.ScheduledCall
+func getScheduledCalls(ctx isc.SandboxView) dict.Dict {
+	hname := ctx.Params().MustGetHname(root.ParamHname, 0)
+	ret := dict.New()
+	dst := collections.NewMap(ret, root.VarScheduledCalls)
+	root.GetScheduledCallsR(ctx.StateR()).Iterate(func(elemKey []byte, value []byte) bool {
+		if hname != 0 {
+			call, err := isc.ScheduledCallFromBytes(value)
+			ctx.RequireNoError(err)
+			if call.Contract != hname {
+				return true
+			}
+		}
+		dst.SetAt(elemKey, value)
+		return true
+	})
+	return ret
+}
+
 // findContract view finds and returns encoded record of the contract
 // Input:
 // - ParamHname
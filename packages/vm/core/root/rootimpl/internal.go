@@ -1,10 +1,15 @@
 package rootimpl
 
 import (
+	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/collections"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/vm"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/corecontracts"
 	"github.com/iotaledger/wasp/packages/vm/core/errors/coreerrors"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
 )
@@ -32,7 +37,15 @@ func isAuthorizedToDeploy(ctx isc.Sandbox) bool {
 	return collections.NewMap(ctx.State(), root.VarDeployPermissions).HasAt(caller.Bytes())
 }
 
-var errContractAlreadyExists = coreerrors.Register("contract with hname %08x already exists")
+var (
+	errContractAlreadyExists = coreerrors.Register("contract with hname %08x already exists")
+	errContractRetired       = coreerrors.Register("contract with hname %08x was retired")
+	errContractNotUpgradable = coreerrors.Register("core contract %08x can't be upgraded or retired")
+	errScheduledCallNotFound = coreerrors.Register("scheduled call %d not found")
+	errInvalidScheduledCall  = coreerrors.Register("invalid scheduled call").Create()
+	errTooManyScheduledCalls = coreerrors.Register("too many scheduled calls").Create()
+	errCallerNotContract     = coreerrors.Register("caller must be a contract of the chain").Create()
+)
 
 func storeContractRecord(state kv.KVStore, rec *root.ContractRecord) {
 	hname := isc.Hn(rec.Name)
@@ -41,5 +54,62 @@ func storeContractRecord(state kv.KVStore, rec *root.ContractRecord) {
 	if contractRegistry.HasAt(hname.Bytes()) {
 		panic(errContractAlreadyExists.Create(hname))
 	}
+	if root.IsContractRetired(state, hname) {
+		panic(errContractRetired.Create(hname))
+	}
 	contractRegistry.SetAt(hname.Bytes(), rec.Bytes())
 }
+
+// isAuthorizedToUpgrade checks if caller is authorized to upgrade or retire the contract
+func isAuthorizedToUpgrade(ctx isc.Sandbox, hname isc.Hname) bool {
+	caller := ctx.Caller()
+	if caller.Equals(ctx.ChainOwnerID()) {
+		// chain owner is always authorized
+		return true
+	}
+	authority := root.GetUpgradeAuthority(ctx.State(), hname)
+	return authority != nil && caller.Equals(authority)
+}
+
+// mustGetUpgradableContract returns the record of the contract, if it exists, it is not a core contract
+// and the caller is authorized to upgrade it
+func mustGetUpgradableContract(ctx isc.Sandbox, hname isc.Hname) *root.ContractRecord {
+	if corecontracts.IsCoreHname(hname) {
+		panic(errContractNotUpgradable.Create(hname))
+	}
+	rec := root.FindContract(ctx.State(), hname)
+	if rec == nil {
+		panic(vm.ErrContractNotFound.Create(hname))
+	}
+	if !isAuthorizedToUpgrade(ctx, hname) {
+		panic(vm.ErrUnauthorized)
+	}
+	return rec
+}
+
+// sweepContractAccount moves all the assets owned by the contract to the caller,
+// they would be unreachable otherwise, once the contract is retired.
+func sweepContractAccount(ctx isc.Sandbox, hname isc.Hname) {
+	contractAgentID := isc.NewContractAgentID(ctx.ChainID(), hname)
+	params := dict.Dict{accounts.ParamAgentID: codec.EncodeAgentID(contractAgentID)}
+	assets, err := isc.AssetsFromDict(ctx.CallView(accounts.Contract.Hname(), accounts.ViewBalance.Hname(), params))
+	ctx.RequireNoError(err)
+	nftIDs := collections.NewArrayReadOnly(ctx.CallView(accounts.Contract.Hname(), accounts.ViewAccountNFTs.Hname(), params), accounts.ParamNFTIDs)
+	for i := uint32(0); i < nftIDs.Len(); i++ {
+		var nftID iotago.NFTID
+		copy(nftID[:], nftIDs.GetAt(i))
+		assets.AddNFTs(nftID)
+	}
+	if !assets.IsEmpty() {
+		ctx.Privileged().MustMoveBetweenAccounts(contractAgentID, ctx.Caller(), assets)
+	}
+}
+
+// mustGetCallerContract returns the hname of the caller, which must be a contract of the same chain
+func mustGetCallerContract(ctx isc.Sandbox) isc.Hname {
+	caller, ok := ctx.Caller().(*isc.ContractAgentID)
+	if !ok || !caller.BelongsToChain(ctx.ChainID()) {
+		panic(errCallerNotContract)
+	}
+	return caller.Hname()
+}
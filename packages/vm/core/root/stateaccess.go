@@ -0,0 +1,22 @@
+package root
+
+import (
+	"time"
+
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/subrealm"
+)
+
+type StateAccess struct {
+	state kv.KVStoreReader
+}
+
+func NewStateAccess(store kv.KVStoreReader) *StateAccess {
+	state := subrealm.NewReadOnly(store, kv.Key(Contract.Hname().Bytes()))
+	return &StateAccess{state: state}
+}
+
+// NextScheduledCallTime returns the due time of the next call scheduled on the chain, false if there are none
+func (sa *StateAccess) NextScheduledCallTime() (time.Time, bool) {
+	return NextScheduledCallTime(sa.state)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/origin"
 	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
 	"github.com/iotaledger/wasp/packages/vm/core/corecontracts"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 )
@@ -262,3 +263,40 @@ func TestBlocklogFoundriesWithPruning(t *testing.T) {
 	err = ch.DestroyFoundry(sn, ch.OriginatorPrivateKey)
 	require.NoError(t, err)
 }
+
+func TestAvailableHistory(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true})
+	ch := env.NewChain()
+	for i := 0; i < 5; i++ {
+		err := ch.DepositBaseTokensToL2(100_000, nil)
+		require.NoError(t, err)
+	}
+	latest := ch.LatestBlockIndex()
+
+	first, latestAvailable, err := indexedstore.AvailableHistory(ch.Store())
+	require.NoError(t, err)
+	require.EqualValues(t, 0, first)
+	require.Equal(t, latest, latestAvailable)
+
+	// prune the oldest states
+	for i := uint32(0); i < 3; i++ {
+		block, err2 := ch.Store().BlockByIndex(i)
+		require.NoError(t, err2)
+		_, err2 = ch.Store().Prune(block.TrieRoot())
+		require.NoError(t, err2)
+	}
+
+	first, latestAvailable, err = indexedstore.AvailableHistory(ch.Store())
+	require.NoError(t, err)
+	require.EqualValues(t, 3, first)
+	require.Equal(t, latest, latestAvailable)
+
+	_, err = ch.Store().StateByIndex(2)
+	require.ErrorIs(t, err, indexedstore.ErrStateNotAvailable)
+	err = indexedstore.WithAvailableHistory(ch.Store(), err)
+	require.ErrorIs(t, err, indexedstore.ErrStateNotAvailable)
+	require.Contains(t, err.Error(), fmt.Sprintf("the states of blocks 3..%d are available", latest))
+
+	_, err = ch.Store().StateByIndex(3)
+	require.NoError(t, err)
+}
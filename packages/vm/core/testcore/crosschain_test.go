@@ -0,0 +1,135 @@
+package testcore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/isc/coreutil"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+)
+
+var (
+	crossChainTestSend     = coreutil.Func("send")
+	crossChainTestRefunded = coreutil.Func("refunded")
+	crossChainTestFailed   = coreutil.Func("failed")
+	crossChainTestGetState = coreutil.ViewFunc("getState")
+)
+
+var crossChainTestContract = coreutil.NewContract("cross-chain test")
+
+var crossChainTestProcessor = crossChainTestContract.Processor(nil,
+	// sends 1Mi base tokens to a contract on another chain, with a return path
+	crossChainTestSend.WithHandler(func(ctx isc.Sandbox) dict.Dict {
+		targetChainID := ctx.Params().MustGetChainID("c")
+		returnPath := &isc.ReturnPath{GasBudget: 100_000}
+		if deadline := ctx.Params().MustGetInt64("d", 0); deadline != 0 {
+			returnPath.Deadline = ctx.Timestamp().Add(time.Duration(deadline))
+		}
+		returnPath.Callback = ctx.Params().MustGetHname("cb", 0)
+		ctx.Send(isc.RequestParameters{
+			TargetAddress: targetChainID.AsAddress(),
+			Assets:        isc.NewAssetsBaseTokens(1 * isc.Million),
+			Metadata: &isc.SendMetadata{
+				TargetContract: ctx.Params().MustGetHname("t"),
+				EntryPoint:     ctx.Params().MustGetHname("e"),
+				GasBudget:      1_000_000,
+				ReturnPath:     returnPath,
+			},
+		})
+		return nil
+	}),
+	crossChainTestRefunded.WithHandler(func(ctx isc.Sandbox) dict.Dict {
+		ctx.TransferAllowedFunds(ctx.AccountID())
+		refunds := codec.MustDecodeUint32(ctx.State().Get("n"), 0)
+		ctx.State().Set("n", codec.EncodeUint32(refunds+1))
+		ctx.State().Set("e", ctx.Params().Get(isc.ParamReturnError))
+		return nil
+	}),
+	crossChainTestFailed.WithHandler(func(ctx isc.Sandbox) dict.Dict {
+		panic("callback failed")
+	}),
+	crossChainTestGetState.WithHandler(func(ctx isc.SandboxView) dict.Dict {
+		return dict.Dict{"n": ctx.StateR().Get("n"), "e": ctx.StateR().Get("e")}
+	}),
+)
+
+func TestCrossChainRefund(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true}).
+		WithNativeContract(crossChainTestProcessor)
+	ch1 := env.NewChain()
+	ch2, _ := env.NewChainExt(nil, 0, "chain2")
+
+	name := crossChainTestContract.Name
+	err := ch1.DeployContract(nil, name, crossChainTestContract.ProgramHash)
+	require.NoError(t, err)
+	contractAgentID := isc.NewContractAgentID(ch1.ChainID, crossChainTestContract.Hname())
+	err = ch1.TransferAllowanceTo(isc.NewAssetsBaseTokens(10*isc.Million), contractAgentID, nil)
+	require.NoError(t, err)
+
+	getState := func() (uint32, string) {
+		res, err2 := ch1.CallView(name, crossChainTestGetState.Name)
+		require.NoError(t, err2)
+		return codec.MustDecodeUint32(res.Get("n"), 0), codec.MustDecodeString(res.Get("e"), "")
+	}
+	send := func(target, entryPoint isc.Hname, deadline time.Duration, callback isc.Hname) {
+		ch2.WaitForRequestsMark()
+		_, err2 := ch1.PostRequestSync(solo.NewCallParams(name, crossChainTestSend.Name,
+			"c", ch2.ChainID,
+			"t", target,
+			"e", entryPoint,
+			"d", int64(deadline),
+			"cb", callback,
+		).WithMaxAffordableGasBudget(), nil)
+		require.NoError(t, err2)
+		require.True(t, ch2.WaitForRequestsThrough(1))
+	}
+
+	// the target contract doesn't exist: the assets are refunded through the callback
+	balance := ch1.L2BaseTokens(contractAgentID)
+	send(isc.Hn("nonexistent"), isc.Hn("func"), 0, crossChainTestRefunded.Hname())
+	require.Contains(t, ch2.LastReceipt().ResolvedError, "not found")
+	require.True(t, ch1.WaitUntil(func() bool {
+		n, _ := getState()
+		return n == 1
+	}))
+	_, refundErr := getState()
+	require.Contains(t, refundErr, "not found")
+	refunded := ch1.L2BaseTokens(contractAgentID)
+	require.Less(t, refunded, balance)
+	require.Greater(t, refunded, balance-1*isc.Million)
+
+	// nothing is left behind in the account of the sender on the target chain
+	require.Zero(t, ch2.L2BaseTokens(contractAgentID))
+
+	// the deadline has passed when the request reaches the target chain: it is not executed,
+	// and the assets are credited back to the sender contract without a callback
+	send(accounts.Contract.Hname(), accounts.FuncDeposit.Hname(), -time.Hour, 0)
+	require.Contains(t, ch2.LastReceipt().ResolvedError, "cross-chain request expired")
+	require.True(t, ch1.WaitUntil(func() bool {
+		return ch1.L2BaseTokens(contractAgentID) > refunded-1*isc.Million+500_000
+	}))
+	n, _ := getState()
+	require.EqualValues(t, 1, n)
+
+	// the callback fails: the assets are credited to the sender contract anyway
+	balance = ch1.L2BaseTokens(contractAgentID)
+	send(isc.Hn("nonexistent"), isc.Hn("func"), 0, crossChainTestFailed.Hname())
+	require.Contains(t, ch2.LastReceipt().ResolvedError, "not found")
+	require.True(t, ch1.WaitUntil(func() bool {
+		return ch1.L2BaseTokens(contractAgentID) > balance-1*isc.Million+500_000
+	}))
+	require.Contains(t, ch1.LastReceipt().ResolvedError, "callback failed")
+	n, _ = getState()
+	require.EqualValues(t, 1, n)
+
+	// a successful request is not refunded
+	send(accounts.Contract.Hname(), accounts.FuncDeposit.Hname(), time.Hour, crossChainTestRefunded.Hname())
+	require.Empty(t, ch2.LastReceipt().ResolvedError)
+	require.Greater(t, ch2.L2BaseTokens(contractAgentID), uint64(0))
+}
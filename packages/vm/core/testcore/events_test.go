@@ -1,18 +1,24 @@
 package testcore
 
 import (
+	"context"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	hivedb "github.com/iotaledger/hive.go/kvstore/database"
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/contracts/native/inccounter"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/eventindex"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/isc/coreutil"
 	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 )
 
@@ -249,3 +255,90 @@ func checkEventCounter(t *testing.T, event *isc.Event, value uint64) {
 	require.NoError(t, err)
 	require.EqualValues(t, counter, value)
 }
+
+func TestEventIndex(t *testing.T) {
+	ch := setupTest(t)
+
+	postEvents := func(n uint32, user *cryptolib.KeyPair) {
+		_, err := ch.PostRequestSync(
+			solo.NewCallParams(manyEventsContract.Name, funcManyEvents.Name, "n", n).
+				AddBaseTokens(1_000_000).
+				WithMaxAffordableGasBudget(),
+			user,
+		)
+		require.NoError(t, err)
+	}
+	postEvents(3, nil)
+	firstBlock := ch.LatestBlockIndex()
+	postEvents(3, nil)
+	user, _ := ch.Env.NewKeyPairWithFunds()
+	postEvents(2, user)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	index, err := eventindex.New(ch.Store(), hivedb.EngineMapDB, t.TempDir(), testlogger.NewLogger(t))
+	require.NoError(t, err)
+	go index.Run(ctx)
+
+	// the index is filled from the chain state
+	latest := ch.LatestBlockIndex()
+	indexedUntil := func(blockIndex uint32) func() bool {
+		return func() bool {
+			last := index.LastBlockIndexed()
+			return last != nil && *last >= blockIndex
+		}
+	}
+	require.Eventually(t, indexedUntil(latest), 10*time.Second, 10*time.Millisecond)
+
+	query := func(q eventindex.Query) *eventindex.Page {
+		page, err2 := index.Events(&q)
+		require.NoError(t, err2)
+		return page
+	}
+	contract := manyEventsContract.Hname()
+	require.Len(t, query(eventindex.Query{Contract: contract}).Events, 8)
+	require.Len(t, query(eventindex.Query{Topic: "event.test"}).Events, 8)
+	require.Len(t, query(eventindex.Query{Topic: "event.big"}).Events, 0)
+	require.Len(t, query(eventindex.Query{Sender: ch.OriginatorAgentID, Contract: contract}).Events, 6)
+	require.Len(t, query(eventindex.Query{Sender: isc.NewAgentID(user.Address()), Contract: contract}).Events, 2)
+	require.Len(t, query(eventindex.Query{Contract: contract, FromBlock: firstBlock, ToBlock: firstBlock}).Events, 3)
+
+	// cursor pagination
+	page := query(eventindex.Query{Contract: contract, Limit: 5})
+	require.Len(t, page.Events, 5)
+	require.NotNil(t, page.NextCursor)
+	page2 := query(eventindex.Query{Contract: contract, Limit: 5, Cursor: page.NextCursor})
+	require.Len(t, page2.Events, 3)
+	require.Nil(t, page2.NextCursor)
+	require.Greater(t, page2.Events[0].BlockIndex, page.Events[0].BlockIndex)
+	_, err = index.Events(&eventindex.Query{Cursor: []byte{1}})
+	require.ErrorIs(t, err, eventindex.ErrInvalidCursor)
+
+	// a block applied again is indexed again, without duplicating its events
+	index.BlockApplied(latest)
+	postEvents(1, nil)
+	index.BlockApplied(ch.LatestBlockIndex())
+	require.Eventually(t, indexedUntil(ch.LatestBlockIndex()), 10*time.Second, 10*time.Millisecond)
+	require.Len(t, query(eventindex.Query{Contract: contract}).Events, 9)
+
+	// the indexed events are kept after the blocks are pruned from the chain state
+	for i := uint32(0); i <= firstBlock; i++ {
+		block, err2 := ch.Store().BlockByIndex(i)
+		require.NoError(t, err2)
+		_, err2 = ch.Store().Prune(block.TrieRoot())
+		require.NoError(t, err2)
+	}
+	require.Len(t, query(eventindex.Query{Contract: contract, ToBlock: firstBlock}).Events, 3)
+
+	// a new index is rebuilt from the blocks, which are still in the state
+	rebuilt, err := eventindex.New(ch.Store(), hivedb.EngineMapDB, t.TempDir(), testlogger.NewLogger(t))
+	require.NoError(t, err)
+	go rebuilt.Run(ctx)
+	require.Eventually(t, func() bool {
+		last := rebuilt.LastBlockIndexed()
+		return last != nil && *last >= ch.LatestBlockIndex()
+	}, 10*time.Second, 10*time.Millisecond)
+	page, err = rebuilt.Events(&eventindex.Query{Contract: contract})
+	require.NoError(t, err)
+	require.Len(t, page.Events, 6)
+}
@@ -899,3 +899,47 @@ func TestGasPayout(t *testing.T) {
 	require.Equal(t, governance.DefaultMinBaseTokensOnCommonAccount, commonBal5.BaseTokens)
 	require.Equal(t, user1Bal4.BaseTokens+gasFees-10, user1Bal5.BaseTokens)
 }
+
+func TestGovernanceDynamicFeePolicy(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true})
+	ch := env.NewChain()
+	require.Nil(t, ch.GetDynamicFeePolicy())
+
+	fp := ch.GetGasFeePolicy()
+	fp.GasPerToken = util.Ratio32{A: 50, B: 1}
+	ch.SetGasFeePolicy(nil, fp)
+	require.Equal(t, util.Ratio32{A: 50, B: 1}, ch.GetGasFeePolicy().GasPerToken)
+
+	// the price does not change while the dynamic fee policy is disabled
+	ch.DepositBaseTokensToL2(1*isc.Million, nil)
+	require.Equal(t, util.Ratio32{A: 50, B: 1}, ch.GetGasFeePolicy().GasPerToken)
+
+	// blocks are far below the target utilization: the price goes down until the bound is reached
+	dfp := gas.DefaultDynamicFeePolicy()
+	ch.SetDynamicFeePolicy(nil, dfp)
+	require.Equal(t, dfp, ch.GetDynamicFeePolicy())
+	prev := ch.GetGasFeePolicy().GasPerToken
+	require.Greater(t, prev.A, uint32(50))
+	for i := 0; i < 10; i++ {
+		ch.DepositBaseTokensToL2(1*isc.Million, nil)
+		next := ch.GetGasFeePolicy().GasPerToken
+		require.GreaterOrEqual(t, next.A, prev.A)
+		prev = next
+	}
+	require.Equal(t, dfp.MaxGasPerToken, prev)
+
+	// the owner can disable the dynamic fee policy
+	ch.SetDynamicFeePolicy(nil, nil)
+	require.Nil(t, ch.GetDynamicFeePolicy())
+	ch.DepositBaseTokensToL2(1*isc.Million, nil)
+	require.Equal(t, dfp.MaxGasPerToken, ch.GetGasFeePolicy().GasPerToken)
+
+	// only the chain owner can enable it
+	userWallet, _ := ch.Env.NewKeyPairWithFunds()
+	_, err := ch.PostRequestSync(solo.NewCallParams(
+		governance.Contract.Name,
+		governance.FuncSetDynamicFeePolicy.Name,
+		governance.ParamDynamicFeePolicyBytes, dfp.Bytes(),
+	).WithMaxAffordableGasBudget(), userWallet)
+	require.ErrorContains(t, err, "unauthorized")
+}
@@ -1,16 +1,35 @@
 package testcore
 
 import (
+	"context"
+	"errors"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/clients/lightclient"
+	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/testutil/utxodb"
 	"github.com/iotaledger/wasp/packages/vm/core/blob"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+	"github.com/iotaledger/wasp/packages/webapi/dto"
+	"github.com/iotaledger/wasp/packages/webapi/models"
 )
 
+type utxodbOutputFetcher struct {
+	*utxodb.UtxoDB
+}
+
+func (f utxodbOutputFetcher) OutputByID(_ context.Context, outputID iotago.OutputID) (iotago.Output, error) {
+	if out := f.GetOutput(outputID); out != nil {
+		return out, nil
+	}
+	return nil, errors.New("output not found")
+}
+
 func TestProofs(t *testing.T) {
 	t.Run("check PoI blob", func(t *testing.T) {
 		env := solo.New(t)
@@ -50,6 +69,47 @@ func TestProofs(t *testing.T) {
 
 		require.NoError(t, err)
 	})
+	t.Run("check PoI receipt with light client", func(t *testing.T) {
+		env := solo.New(t)
+		ch := env.NewChain()
+
+		err := ch.DepositBaseTokensToL2(100_000, nil)
+		require.NoError(t, err)
+
+		lastBlockReceipts := ch.GetRequestReceiptsForBlock()
+		rec := lastBlockReceipts[len(lastBlockReceipts)-1]
+
+		key := append(blocklog.Contract.Hname().Bytes(), blocklog.RequestReceiptKey(rec.LookupKey())...)
+		anchorOutput := ch.GetAnchorOutputFromL1()
+		response := models.MapStateProofResponse(&dto.StateProof{
+			BlockIndex:    anchorOutput.GetStateIndex(),
+			Key:           key,
+			Value:         rec.Bytes(),
+			Proof:         ch.GetMerkleProofRaw(key),
+			L1Commitment:  ch.GetL1Commitment(),
+			AliasOutputID: anchorOutput.OutputID(),
+		})
+		l1 := utxodbOutputFetcher{env.L1Ledger()}
+
+		verified, err := lightclient.VerifyReceiptProof(context.Background(), l1, ch.ChainID, rec.Request.ID(), &response)
+		require.NoError(t, err)
+		require.Equal(t, rec.Bytes(), verified.Bytes())
+
+		// a receipt of another request is rejected
+		_, err = lightclient.VerifyReceiptProof(context.Background(), l1, ch.ChainID, isc.RequestID{}, &response)
+		require.ErrorIs(t, err, lightclient.ErrInvalidProof)
+
+		// a tampered receipt is rejected
+		tampered := response
+		tampered.Value = iotago.EncodeHex(append(rec.Bytes(), 0))
+		_, err = lightclient.VerifyReceiptProof(context.Background(), l1, ch.ChainID, rec.Request.ID(), &tampered)
+		require.ErrorIs(t, err, lightclient.ErrInvalidProof)
+
+		// the proof is not valid for another chain
+		ch2, _ := env.NewChainExt(nil, 0, "chain2")
+		_, err = lightclient.VerifyReceiptProof(context.Background(), l1, ch2.ChainID, rec.Request.ID(), &response)
+		require.ErrorIs(t, err, lightclient.ErrInvalidProof)
+	})
 	t.Run("check PoI past state", func(t *testing.T) {
 		env := solo.New(t)
 		ch := env.NewChain()
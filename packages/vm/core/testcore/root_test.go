@@ -4,11 +4,16 @@
 package testcore
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/isc/coreutil"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/solo"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
 	"github.com/iotaledger/wasp/packages/vm/core/blob"
@@ -175,3 +180,271 @@ func TestChangeOwnerUnauthorized(t *testing.T) {
 	_, ownerAgentID, _ := chain.GetInfo()
 	require.EqualValues(t, chain.OriginatorAgentID, ownerAgentID)
 }
+
+var (
+	upgradeTestSetValue   = coreutil.Func("setValue")
+	upgradeTestMigrate    = coreutil.Func("migrate")
+	upgradeTestGetValue   = coreutil.ViewFunc("getValue")
+	upgradeTestGetVersion = coreutil.ViewFunc("getVersion")
+)
+
+func upgradeTestProcessor(version uint32) (*coreutil.ContractInfo, *coreutil.ContractProcessor) {
+	contract := coreutil.NewContract(fmt.Sprintf("upgrade test v%d", version))
+	handlers := []isc.ProcessorEntryPoint{
+		upgradeTestSetValue.WithHandler(func(ctx isc.Sandbox) dict.Dict {
+			ctx.State().Set("v", ctx.Params().Get("v"))
+			return nil
+		}),
+		upgradeTestGetValue.WithHandler(func(ctx isc.SandboxView) dict.Dict {
+			return dict.Dict{"v": ctx.StateR().Get("v"), "m": ctx.StateR().Get("m")}
+		}),
+		upgradeTestGetVersion.WithHandler(func(ctx isc.SandboxView) dict.Dict {
+			return dict.Dict{"v": codec.EncodeUint32(version)}
+		}),
+	}
+	if version > 1 {
+		handlers = append(handlers, upgradeTestMigrate.WithHandler(func(ctx isc.Sandbox) dict.Dict {
+			ctx.RequireCaller(isc.NewContractAgentID(ctx.ChainID(), root.Contract.Hname()))
+			ctx.State().Set("m", ctx.Params().Get("m"))
+			return nil
+		}))
+	}
+	return contract, contract.Processor(nil, handlers...)
+}
+
+func TestUpgradeContract(t *testing.T) {
+	v1, v1Processor := upgradeTestProcessor(1)
+	v2, v2Processor := upgradeTestProcessor(2)
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true}).
+		WithNativeContract(v1Processor).
+		WithNativeContract(v2Processor)
+	ch := env.NewChain()
+
+	const name = "upgradable"
+	err := ch.DeployContract(nil, name, v1.ProgramHash)
+	require.NoError(t, err)
+	require.EqualValues(t, ch.OriginatorAgentID, getUpgradeAuthority(t, ch, name))
+	_, err = ch.PostRequestSync(solo.NewCallParams(name, upgradeTestSetValue.Name, "v", codec.EncodeUint32(42)).
+		WithMaxAffordableGasBudget(), nil)
+	require.NoError(t, err)
+
+	// only the upgrade authority can upgrade the contract
+	user, userAddr := env.NewKeyPairWithFunds()
+	userAgentID := isc.NewAgentID(userAddr)
+	err = ch.UpgradeContract(user, name, v2.ProgramHash, "")
+	require.ErrorContains(t, err, "unauthorized")
+
+	_, err = ch.PostRequestSync(solo.NewCallParams(root.Contract.Name, root.FuncSetUpgradeAuthority.Name,
+		root.ParamHname, isc.Hn(name),
+		root.ParamUpgradeAuthority, userAgentID,
+	).WithMaxAffordableGasBudget(), nil)
+	require.NoError(t, err)
+	require.EqualValues(t, userAgentID, getUpgradeAuthority(t, ch, name))
+
+	// a failing migration reverts the upgrade
+	err = ch.UpgradeContract(user, name, v1.ProgramHash, upgradeTestMigrate.Name)
+	require.Error(t, err)
+	rec, err := ch.FindContract(name)
+	require.NoError(t, err)
+	require.EqualValues(t, v1.ProgramHash, rec.ProgramHash)
+
+	// the upgrade keeps the hname and the state of the contract
+	err = ch.UpgradeContract(user, name, v2.ProgramHash, upgradeTestMigrate.Name, "m", codec.EncodeUint32(7))
+	require.NoError(t, err)
+	rec, err = ch.FindContract(name)
+	require.NoError(t, err)
+	require.EqualValues(t, v2.ProgramHash, rec.ProgramHash)
+	require.EqualValues(t, name, rec.Name)
+
+	res, err := ch.CallView(name, upgradeTestGetVersion.Name)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, codec.MustDecodeUint32(res.Get("v")))
+	res, err = ch.CallView(name, upgradeTestGetValue.Name)
+	require.NoError(t, err)
+	require.EqualValues(t, 42, codec.MustDecodeUint32(res.Get("v")))
+	require.EqualValues(t, 7, codec.MustDecodeUint32(res.Get("m")))
+
+	events, err := ch.GetEventsForContract(root.Contract.Name)
+	require.NoError(t, err)
+	require.Equal(t, "coreroot.upgrade", events[len(events)-1].Topic)
+
+	// core contracts can't be upgraded or retired
+	err = ch.UpgradeContract(nil, accounts.Contract.Name, v2.ProgramHash, "")
+	require.ErrorContains(t, err, "can't be upgraded or retired")
+	err = ch.RetireContract(nil, governance.Contract.Name)
+	require.ErrorContains(t, err, "can't be upgraded or retired")
+
+	// a missing contract is reported as such, regardless of the caller
+	err = ch.RetireContract(user, "missing")
+	require.ErrorContains(t, err, "not found")
+
+	// the assets of a retired contract are moved to the caller
+	contractAgentID := isc.NewContractAgentID(ch.ChainID, isc.Hn(name))
+	err = ch.TransferAllowanceTo(isc.NewAssetsBaseTokens(1*isc.Million), contractAgentID, user)
+	require.NoError(t, err)
+	require.EqualValues(t, 1*isc.Million, ch.L2BaseTokens(contractAgentID))
+	userBalanceBefore := ch.L2BaseTokens(userAgentID)
+
+	// a retired contract can't be called nor deployed again
+	err = ch.RetireContract(user, name)
+	require.NoError(t, err)
+	require.Zero(t, ch.L2BaseTokens(contractAgentID))
+	require.Greater(t, ch.L2BaseTokens(userAgentID), userBalanceBefore)
+	_, err = ch.FindContract(name)
+	require.Error(t, err)
+	_, err = ch.CallView(name, upgradeTestGetVersion.Name)
+	require.Error(t, err)
+	require.Nil(t, getUpgradeAuthority(t, ch, name))
+	err = ch.DeployContract(nil, name, v1.ProgramHash)
+	require.ErrorContains(t, err, "was retired")
+}
+
+func getUpgradeAuthority(t *testing.T, ch *solo.Chain, name string) isc.AgentID {
+	res, err := ch.CallView(root.Contract.Name, root.ViewGetUpgradeAuthority.Name, root.ParamHname, isc.Hn(name))
+	require.NoError(t, err)
+	if !res.Has(root.ParamUpgradeAuthority) {
+		return nil
+	}
+	return codec.MustDecodeAgentID(res.Get(root.ParamUpgradeAuthority))
+}
+
+var (
+	schedulerTestSchedule = coreutil.Func("schedule")
+	schedulerTestCancel   = coreutil.Func("cancel")
+	schedulerTestTick     = coreutil.Func("tick")
+	schedulerTestGetTicks = coreutil.ViewFunc("getTicks")
+)
+
+var schedulerTestContract = coreutil.NewContract("scheduler test")
+
+var schedulerTestProcessor = schedulerTestContract.Processor(nil,
+	schedulerTestSchedule.WithHandler(func(ctx isc.Sandbox) dict.Dict {
+		at := ctx.Timestamp().Add(time.Hour)
+		interval := time.Duration(ctx.Params().MustGetInt64("i", 0))
+		id := ctx.ScheduleCall(ctx.Contract(), schedulerTestTick.Hname(), nil, 1_000_000, at, interval)
+		return dict.Dict{"id": codec.EncodeUint32(id)}
+	}),
+	schedulerTestCancel.WithHandler(func(ctx isc.Sandbox) dict.Dict {
+		ctx.CancelScheduledCall(ctx.Params().MustGetUint32("id"))
+		return nil
+	}),
+	schedulerTestTick.WithHandler(func(ctx isc.Sandbox) dict.Dict {
+		ctx.RequireCaller(ctx.AccountID())
+		ticks := codec.MustDecodeUint32(ctx.State().Get("t"), 0)
+		ctx.State().Set("t", codec.EncodeUint32(ticks+1))
+		return nil
+	}),
+	schedulerTestGetTicks.WithHandler(func(ctx isc.SandboxView) dict.Dict {
+		return dict.Dict{"t": ctx.StateR().Get("t")}
+	}),
+)
+
+func TestScheduledCalls(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true}).
+		WithNativeContract(schedulerTestProcessor)
+	ch := env.NewChain()
+
+	name := schedulerTestContract.Name
+	err := ch.DeployContract(nil, name, schedulerTestContract.ProgramHash)
+	require.NoError(t, err)
+	contractAgentID := isc.NewContractAgentID(ch.ChainID, schedulerTestContract.Hname())
+	err = ch.TransferAllowanceTo(isc.NewAssetsBaseTokens(1*isc.Million), contractAgentID, nil)
+	require.NoError(t, err)
+
+	getTicks := func() uint32 {
+		res, err2 := ch.CallView(name, schedulerTestGetTicks.Name)
+		require.NoError(t, err2)
+		return codec.MustDecodeUint32(res.Get("t"), 0)
+	}
+	// any request produces a block, which runs the due calls first
+	runBlock := func() {
+		_, err2 := ch.PostRequestSync(solo.NewCallParams(schedulerTestContract.Name, schedulerTestGetTicks.Name).
+			WithMaxAffordableGasBudget(), nil)
+		require.NoError(t, err2)
+	}
+
+	// only contracts can schedule calls
+	_, err = ch.PostRequestSync(solo.NewCallParams(root.Contract.Name, root.FuncScheduleCall.Name,
+		root.ParamScheduledCall, (&isc.ScheduledCall{}).Bytes(),
+	).WithMaxAffordableGasBudget(), nil)
+	require.ErrorContains(t, err, "caller must be a contract")
+
+	res, err := ch.PostRequestSync(solo.NewCallParams(name, schedulerTestSchedule.Name,
+		"i", codec.EncodeInt64(int64(time.Hour)),
+	).WithMaxAffordableGasBudget(), nil)
+	require.NoError(t, err)
+	id := codec.MustDecodeUint32(res.Get("id"))
+
+	calls := ch.GetScheduledCalls(name)
+	require.Len(t, calls, 1)
+	require.EqualValues(t, id, calls[0].ID)
+	require.Equal(t, schedulerTestContract.Hname(), calls[0].Contract)
+	require.Equal(t, schedulerTestTick.Hname(), calls[0].Target.EntryPoint)
+	require.Empty(t, ch.GetScheduledCalls(governance.Contract.Name))
+
+	// the call is not due yet
+	runBlock()
+	require.EqualValues(t, 0, getTicks())
+
+	balanceBefore := ch.L2BaseTokens(contractAgentID)
+	env.AdvanceClockBy(time.Hour)
+	runBlock()
+	require.EqualValues(t, 1, getTicks())
+	require.Less(t, ch.L2BaseTokens(contractAgentID), balanceBefore, "gas must be paid by the contract")
+
+	// the call is logged as the first receipt of the block, with the contract as sender
+	receipts := ch.GetRequestReceiptsForBlock()
+	require.Len(t, receipts, 2)
+	scheduledReq, ok := receipts[0].Request.(isc.ScheduledCallRequest)
+	require.True(t, ok)
+	require.EqualValues(t, id, scheduledReq.ScheduledCall().ID)
+	require.True(t, contractAgentID.Equals(scheduledReq.SenderAccount()))
+	require.Nil(t, receipts[0].Error)
+
+	// the recurring call is rescheduled, and runs on an idle chain too
+	runBlock()
+	require.EqualValues(t, 1, getTicks())
+	env.AdvanceClockBy(time.Hour)
+	ch.RunScheduledCalls()
+	require.EqualValues(t, 2, getTicks())
+	require.Len(t, ch.GetRequestReceiptsForBlock(), 1)
+	require.Len(t, ch.GetScheduledCalls(), 1)
+
+	// only the contract and the chain owner can cancel the call
+	user, _ := env.NewKeyPairWithFunds()
+	_, err = ch.PostRequestSync(solo.NewCallParams(root.Contract.Name, root.FuncCancelScheduledCall.Name,
+		root.ParamScheduledCallID, id,
+	).WithMaxAffordableGasBudget(), user)
+	require.ErrorContains(t, err, "unauthorized")
+
+	_, err = ch.PostRequestSync(solo.NewCallParams(name, schedulerTestCancel.Name, "id", id).
+		WithMaxAffordableGasBudget(), nil)
+	require.NoError(t, err)
+	require.Empty(t, ch.GetScheduledCalls())
+	env.AdvanceClockBy(time.Hour)
+	runBlock()
+	require.EqualValues(t, 2, getTicks())
+
+	// one-time calls are removed after running, retiring the contract removes its calls
+	_, err = ch.PostRequestSync(solo.NewCallParams(name, schedulerTestSchedule.Name).
+		WithMaxAffordableGasBudget(), nil)
+	require.NoError(t, err)
+	env.AdvanceClockBy(time.Hour)
+	runBlock()
+	require.EqualValues(t, 3, getTicks())
+	require.Empty(t, ch.GetScheduledCalls())
+
+	// the number of calls scheduled by a contract is limited
+	for i := 0; i < root.MaxScheduledCallsPerContract; i++ {
+		_, err = ch.PostRequestSync(solo.NewCallParams(name, schedulerTestSchedule.Name).
+			WithMaxAffordableGasBudget(), nil)
+		require.NoError(t, err)
+	}
+	_, err = ch.PostRequestSync(solo.NewCallParams(name, schedulerTestSchedule.Name).
+		WithMaxAffordableGasBudget(), nil)
+	require.ErrorContains(t, err, "too many scheduled calls")
+	require.Len(t, ch.GetScheduledCalls(), root.MaxScheduledCallsPerContract)
+	err = ch.RetireContract(nil, name)
+	require.NoError(t, err)
+	require.Empty(t, ch.GetScheduledCalls())
+}
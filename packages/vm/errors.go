@@ -32,4 +32,5 @@ var (
 	ErrIllegalCall               = coreerrors.Register("illegal call - entrypoint cannot be called from contracts")
 	ErrSendMultipleNFTs          = coreerrors.Register("cannot send more than 1 NFT").Create()
 	ErrEVMExecutionReverted      = coreerrors.Register("execution reverted: %s") // hex-encoded revert data
+	ErrCrossChainRequestExpired  = coreerrors.Register("cross-chain request expired").Create()
 )
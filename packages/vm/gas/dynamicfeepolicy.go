@@ -0,0 +1,172 @@
+package gas
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+
+	"github.com/iotaledger/wasp/packages/util"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+const (
+	DefaultTargetBlockUtilization = 50
+	DefaultMaxChangeDenominator   = 8
+)
+
+// DynamicFeePolicy lets the GasPerToken ratio of the FeePolicy follow the utilization of the blocks,
+// similar to the base fee of EIP-1559: after each block the gas price moves up when the block burned
+// more gas than the target, and down when it burned less, by at most 1/MaxChangeDenominator.
+// The resulting GasPerToken ratio always stays within [MinGasPerToken, MaxGasPerToken].
+type DynamicFeePolicy struct {
+	// MinGasPerToken is the lower bound of the gas per token ratio (i.e. the highest gas price)
+	MinGasPerToken util.Ratio32 `json:"minGasPerToken" swagger:"desc(The lower bound of the gas per token ratio (A/B) (gas/token)),required"`
+
+	// MaxGasPerToken is the upper bound of the gas per token ratio (i.e. the lowest gas price)
+	MaxGasPerToken util.Ratio32 `json:"maxGasPerToken" swagger:"desc(The upper bound of the gas per token ratio (A/B) (gas/token)),required"`
+
+	// TargetBlockUtilization is the percentage of Limits.MaxGasPerBlock at which the gas price stays the same
+	TargetBlockUtilization uint8 `json:"targetBlockUtilization" swagger:"desc(The block utilization (percentage of the max gas per block) at which the gas price stays the same),required"`
+
+	// MaxChangeDenominator bounds the change of the gas price per block to 1/MaxChangeDenominator
+	MaxChangeDenominator uint8 `json:"maxChangeDenominator" swagger:"desc(The change of the gas price per block is at most 1/maxChangeDenominator),required"`
+}
+
+func DefaultDynamicFeePolicy() *DynamicFeePolicy {
+	return &DynamicFeePolicy{
+		MinGasPerToken:         util.Ratio32{A: 1, B: 1},
+		MaxGasPerToken:         DefaultGasPerToken,
+		TargetBlockUtilization: DefaultTargetBlockUtilization,
+		MaxChangeDenominator:   DefaultMaxChangeDenominator,
+	}
+}
+
+// compareGasPerToken returns -1, 0 or 1 if a is lower, equal or higher than b, respectively
+func compareGasPerToken(a, b util.Ratio32) int {
+	x := uint64(a.A) * uint64(b.B)
+	y := uint64(b.A) * uint64(a.B)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (p *DynamicFeePolicy) IsValid() bool {
+	if p.MinGasPerToken.HasZeroComponent() || p.MaxGasPerToken.HasZeroComponent() {
+		return false
+	}
+	if compareGasPerToken(p.MinGasPerToken, p.MaxGasPerToken) > 0 {
+		return false
+	}
+	if p.TargetBlockUtilization == 0 || p.TargetBlockUtilization > 100 {
+		return false
+	}
+	return p.MaxChangeDenominator != 0
+}
+
+// NextGasPerToken calculates the gas per token ratio of the next block, given the ratio
+// of the current block and the gas burned in it.
+// The free gas ratio '0:0' is never adjusted.
+func (p *DynamicFeePolicy) NextGasPerToken(current util.Ratio32, gasBurned uint64, limits *Limits) util.Ratio32 {
+	if current.IsZero() || current.HasZeroComponent() {
+		return current
+	}
+
+	target := new(big.Int).SetUint64(limits.MaxGasPerBlock)
+	target.Mul(target, big.NewInt(int64(p.TargetBlockUtilization)))
+	target.Div(target, big.NewInt(100))
+	if target.Sign() == 0 {
+		return p.clamp(current)
+	}
+
+	// price' = price * (target*d + burned - target) / (target*d)
+	den := new(big.Int).Mul(target, big.NewInt(int64(p.MaxChangeDenominator)))
+	num := new(big.Int).Add(den, new(big.Int).SetUint64(gasBurned))
+	num.Sub(num, target)
+
+	// the price is B/A: adjust the bigger component to keep the precision
+	next := current
+	if current.A >= current.B {
+		next.A = scaleRatioComponent(current.A, den, num)
+	} else {
+		next.B = scaleRatioComponent(current.B, num, den)
+	}
+
+	if gasBurned > target.Uint64() && next == current {
+		// make sure the price goes up when the blocks are over the target
+		if current.A >= current.B && next.A > 1 {
+			next.A--
+		} else if next.B < math.MaxUint32 {
+			next.B++
+		}
+	}
+	return p.clamp(next)
+}
+
+func scaleRatioComponent(x uint32, num, den *big.Int) uint32 {
+	ret := new(big.Int).SetUint64(uint64(x))
+	ret.Mul(ret, num)
+	ret.Div(ret, den)
+	if !ret.IsUint64() || ret.Uint64() > math.MaxUint32 {
+		return math.MaxUint32
+	}
+	if ret.Uint64() == 0 {
+		return 1
+	}
+	return uint32(ret.Uint64())
+}
+
+func (p *DynamicFeePolicy) clamp(gasPerToken util.Ratio32) util.Ratio32 {
+	if compareGasPerToken(gasPerToken, p.MinGasPerToken) < 0 {
+		return p.MinGasPerToken
+	}
+	if compareGasPerToken(gasPerToken, p.MaxGasPerToken) > 0 {
+		return p.MaxGasPerToken
+	}
+	return gasPerToken
+}
+
+func DynamicFeePolicyFromBytes(data []byte) (*DynamicFeePolicy, error) {
+	return rwutil.ReadFromBytes(data, new(DynamicFeePolicy))
+}
+
+func (p *DynamicFeePolicy) Bytes() []byte {
+	return rwutil.WriteToBytes(p)
+}
+
+func (p *DynamicFeePolicy) String() string {
+	return fmt.Sprintf(
+		"DynamicFeePolicy(gasPerToken: [%s, %s], target utilization: %d%%, max change: 1/%d)",
+		p.MinGasPerToken,
+		p.MaxGasPerToken,
+		p.TargetBlockUtilization,
+		p.MaxChangeDenominator,
+	)
+}
+
+func (p *DynamicFeePolicy) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	rr.Read(&p.MinGasPerToken)
+	rr.Read(&p.MaxGasPerToken)
+	p.TargetBlockUtilization = rr.ReadUint8()
+	p.MaxChangeDenominator = rr.ReadUint8()
+	if rr.Err == nil && !p.IsValid() {
+		rr.Err = errors.New("invalid dynamic fee policy")
+	}
+	return rr.Err
+}
+
+func (p *DynamicFeePolicy) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.Write(&p.MinGasPerToken)
+	ww.Write(&p.MaxGasPerToken)
+	ww.WriteUint8(p.TargetBlockUtilization)
+	ww.WriteUint8(p.MaxChangeDenominator)
+	return ww.Err
+}
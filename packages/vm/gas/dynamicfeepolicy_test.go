@@ -0,0 +1,42 @@
+package gas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/util"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+func TestDynamicFeePolicySerde(t *testing.T) {
+	rwutil.BytesTest(t, DefaultDynamicFeePolicy(), DynamicFeePolicyFromBytes)
+
+	invalid := DefaultDynamicFeePolicy()
+	invalid.MinGasPerToken, invalid.MaxGasPerToken = invalid.MaxGasPerToken, invalid.MinGasPerToken
+	_, err := DynamicFeePolicyFromBytes(invalid.Bytes())
+	require.Error(t, err)
+}
+
+func TestDynamicFeePolicyNextGasPerToken(t *testing.T) {
+	p := DefaultDynamicFeePolicy()
+	p.MinGasPerToken = util.Ratio32{A: 1, B: 10}
+	limits := &Limits{MaxGasPerBlock: 1000}
+
+	// on target: the price stays the same
+	require.Equal(t, util.Ratio32{A: 80, B: 1}, p.NextGasPerToken(util.Ratio32{A: 80, B: 1}, 500, limits))
+	// full block: the price goes up by 1/8
+	require.Equal(t, util.Ratio32{A: 71, B: 1}, p.NextGasPerToken(util.Ratio32{A: 80, B: 1}, 1000, limits))
+	// empty block: the price goes down by 1/8
+	require.Equal(t, util.Ratio32{A: 91, B: 1}, p.NextGasPerToken(util.Ratio32{A: 80, B: 1}, 0, limits))
+	// the price never goes below the bound
+	require.Equal(t, p.MaxGasPerToken, p.NextGasPerToken(util.Ratio32{A: 95, B: 1}, 0, limits))
+	// when the price is above 1 token per gas, B is adjusted
+	require.Equal(t, util.Ratio32{A: 1, B: 9}, p.NextGasPerToken(util.Ratio32{A: 1, B: 8}, 1000, limits))
+	// the price never goes above the bound
+	require.Equal(t, p.MinGasPerToken, p.NextGasPerToken(util.Ratio32{A: 1, B: 10}, 1000, limits))
+	// slightly over the target: the price still goes up
+	require.Equal(t, util.Ratio32{A: 79, B: 1}, p.NextGasPerToken(util.Ratio32{A: 80, B: 1}, 501, limits))
+	// free gas is not adjusted
+	require.Equal(t, util.Ratio32{}, p.NextGasPerToken(util.Ratio32{}, 1000, limits))
+}
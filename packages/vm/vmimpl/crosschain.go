@@ -0,0 +1,197 @@
+package vmimpl
+
+import (
+	"math/big"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/transaction"
+	"github.com/iotaledger/wasp/packages/util/panicutil"
+	"github.com/iotaledger/wasp/packages/vm"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+	"github.com/iotaledger/wasp/packages/vm/vmexceptions"
+)
+
+// returnPath returns the return path of a refundable cross-chain request, or nil
+func (reqctx *requestContext) returnPath() *isc.ReturnPath {
+	req, ok := reqctx.req.(isc.OnLedgerRequest)
+	if !ok {
+		return nil
+	}
+	return req.ReturnPath()
+}
+
+// checkCrossChainDeadline panics if the request is a refundable cross-chain request past its deadline
+func (reqctx *requestContext) checkCrossChainDeadline() {
+	returnPath := reqctx.returnPath()
+	if returnPath != nil && returnPath.IsExpired(reqctx.vm.task.FinalStateTimestamp()) {
+		panic(vm.ErrCrossChainRequestExpired)
+	}
+}
+
+// refundCrossChainRequest sends the assets of a failed refundable cross-chain request back to the
+// sender contract on the origin chain. If the refund is not possible (e.g. the assets don't cover
+// the storage deposit), the assets stay in the account of the sender on this chain.
+func (reqctx *requestContext) refundCrossChainRequest(reqErr *isc.VMError) {
+	returnPath := reqctx.returnPath()
+	if returnPath == nil {
+		return
+	}
+	sender, ok := reqctx.req.SenderAccount().(*isc.ContractAgentID)
+	if !ok || sender.ChainID().Equals(reqctx.ChainID()) {
+		return
+	}
+
+	txSnapshot := reqctx.vm.createTxBuilderSnapshot()
+	stateSnapshot := reqctx.uncommittedState.Clone()
+	err := panicutil.CatchPanic(func() {
+		reqctx.sendRefund(reqctx.req.(isc.OnLedgerRequest), sender, returnPath, reqErr)
+	})
+	if err != nil {
+		reqctx.vm.restoreTxBuilderSnapshot(txSnapshot)
+		reqctx.uncommittedState = stateSnapshot
+		reqctx.vm.task.Log.Warnf("unable to refund cross-chain request %s: %v", reqctx.req.ID(), err)
+	}
+}
+
+// creditFailedRefund credits the assets of a failed refund request to the contract that sent the
+// original cross-chain request, so that they don't get stuck in the account of the other chain
+// when the callback fails.
+func (reqctx *requestContext) creditFailedRefund() {
+	req, ok := reqctx.req.(isc.OnLedgerRequest)
+	if !ok || !req.IsRefund() {
+		return
+	}
+	sender, ok := req.SenderAccount().(*isc.ContractAgentID)
+	if !ok || sender.ChainID().Equals(reqctx.ChainID()) || sender.Hname() != accounts.Contract.Hname() {
+		return
+	}
+	recipient := reqctx.refundRecipient(req)
+	if recipient == nil {
+		return
+	}
+
+	assets, nft := reqctx.refundableAssets(req, sender)
+	if nft != nil {
+		assets.AddNFTs(nft.ID)
+	}
+	if assets.IsEmpty() {
+		return
+	}
+	stateSnapshot := reqctx.uncommittedState.Clone()
+	err := panicutil.CatchPanic(func() {
+		mustMoveBetweenAccounts(reqctx.uncommittedState, sender, recipient, assets, reqctx.ChainID())
+	})
+	if err != nil {
+		reqctx.uncommittedState = stateSnapshot
+		reqctx.vm.task.Log.Warnf("unable to credit failed refund %s: %v", reqctx.req.ID(), err)
+	}
+}
+
+// refundRecipient returns the contract the refund request is meant for, see sendRefund
+func (reqctx *requestContext) refundRecipient(req isc.OnLedgerRequest) isc.AgentID {
+	target := req.CallTarget()
+	if target.Contract != accounts.Contract.Hname() {
+		return isc.NewContractAgentID(reqctx.ChainID(), target.Contract)
+	}
+	recipient, err := isc.AgentIDFromBytes(req.Params().Get(accounts.ParamAgentID))
+	if err != nil {
+		return nil
+	}
+	return recipient
+}
+
+// refundableAssets returns the assets of the request that are still in the account of the sender
+func (reqctx *requestContext) refundableAssets(req isc.OnLedgerRequest, sender isc.AgentID) (*isc.Assets, *isc.NFT) {
+	var balance *isc.Assets
+	var nfts []iotago.NFTID
+	withContractState(reqctx.uncommittedState, accounts.Contract, func(s kv.KVStore) {
+		balance = accounts.GetAccountFungibleTokens(s, sender, reqctx.ChainID())
+		nfts = accounts.GetAccountNFTs(s, sender)
+	})
+
+	ret := isc.NewAssetsBaseTokens(min(req.Assets().BaseTokens, balance.BaseTokens))
+	for _, nt := range req.Assets().NativeTokens {
+		amount := balance.AmountNativeToken(nt.ID)
+		if amount.Cmp(nt.Amount) > 0 {
+			amount = nt.Amount
+		}
+		if amount.Sign() > 0 {
+			ret.AddNativeTokens(nt.ID, new(big.Int).Set(amount))
+		}
+	}
+
+	var nft *isc.NFT
+	if req.NFT() != nil {
+		for _, id := range nfts {
+			if id == req.NFT().ID {
+				nft = req.NFT()
+				break
+			}
+		}
+	}
+	return ret, nft
+}
+
+func (reqctx *requestContext) sendRefund(req isc.OnLedgerRequest, sender *isc.ContractAgentID, returnPath *isc.ReturnPath, reqErr *isc.VMError) {
+	assets, nft := reqctx.refundableAssets(req, sender)
+
+	// the refund request on the origin chain either credits the assets to the sender contract,
+	// or calls its callback, which is expected to take the assets from the allowance
+	metadata := &isc.RequestMetadata{
+		SenderContract: isc.ContractIdentityFromHname(accounts.Contract.Hname()),
+		TargetContract: accounts.Contract.Hname(),
+		EntryPoint:     accounts.FuncTransferAllowanceTo.Hname(),
+		Params:         dict.Dict{accounts.ParamAgentID: sender.Bytes()},
+		GasBudget:      gas.LimitsDefault.MinGasPerRequest,
+	}
+	if !returnPath.Callback.IsNil() {
+		metadata.TargetContract = sender.Hname()
+		metadata.EntryPoint = returnPath.Callback
+		metadata.Params = dict.Dict{
+			isc.ParamReturnRequestID: req.ID().Bytes(),
+			isc.ParamReturnError:     codec.EncodeString(reqErr.Error()),
+		}
+		if returnPath.GasBudget > 0 {
+			metadata.GasBudget = returnPath.GasBudget
+		}
+	}
+
+	metadata.IsRefund = true
+
+	// the gas of the refund request is paid from the refunded base tokens. The fee policy of the
+	// origin chain is unknown here, so the fee is estimated with the fee policy of this chain.
+	allowance := assets.Clone()
+	fee := reqctx.vm.chainInfo.GasFeePolicy.FeeFromGas(metadata.GasBudget)
+	if allowance.BaseTokens > fee {
+		allowance.BaseTokens -= fee
+	} else {
+		allowance.BaseTokens = 0
+	}
+	if nft != nil {
+		allowance.AddNFTs(nft.ID)
+	}
+	metadata.Allowance = allowance
+
+	chainAddress := reqctx.ChainID().AsAddress()
+	basicOutput := transaction.MakeBasicOutput(sender.Address(), chainAddress, assets, metadata, isc.SendOptions{})
+	var out iotago.Output = basicOutput
+	if nft != nil {
+		out = transaction.NftOutputFromBasicOutput(basicOutput, nft)
+	}
+	if out.Deposit() < parameters.L1().Protocol.RentStructure.MinRent(out) {
+		panic(vmexceptions.ErrNotEnoughFundsForSD)
+	}
+
+	baseTokenAdjustmentL2 := reqctx.vm.txbuilder.AddOutput(out)
+	reqctx.adjustL2BaseTokensIfNeeded(baseTokenAdjustmentL2, sender)
+	debitFromAccount(reqctx.uncommittedState, sender, isc.AssetsFromOutput(out), reqctx.ChainID())
+	if nft != nil {
+		debitNFTFromAccount(reqctx.uncommittedState, sender, nft.ID, reqctx.ChainID())
+	}
+}
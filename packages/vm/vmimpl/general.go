@@ -161,6 +161,23 @@ func (reqctx *requestContext) deployContract(programHash hashing.HashValue, name
 	reqctx.Call(root.Contract.Hname(), root.FuncDeployContract.Hname(), par, nil)
 }
 
+func (reqctx *requestContext) scheduleCall(call *isc.ScheduledCall) uint32 {
+	reqctx.Debugf("vmcontext.ScheduleCall: %s", call.String())
+
+	par := dict.New()
+	par.Set(root.ParamScheduledCall, call.Bytes())
+	result := reqctx.Call(root.Contract.Hname(), root.FuncScheduleCall.Hname(), par, nil)
+	return codec.MustDecodeUint32(result.Get(root.ParamScheduledCallID))
+}
+
+func (reqctx *requestContext) cancelScheduledCall(id uint32) {
+	reqctx.Debugf("vmcontext.CancelScheduledCall: %d", id)
+
+	par := dict.New()
+	par.Set(root.ParamScheduledCallID, codec.EncodeUint32(id))
+	reqctx.Call(root.Contract.Hname(), root.FuncCancelScheduledCall.Hname(), par, nil)
+}
+
 func (reqctx *requestContext) registerError(messageFormat string) *isc.VMErrorTemplate {
 	reqctx.Debugf("vmcontext.RegisterError: messageFormat: '%s'", messageFormat)
 
@@ -212,6 +212,8 @@ func (reqctx *requestContext) callTheContract() (*vm.RequestResult, error) {
 				reqctx.Debugf(string(debug.Stack()))
 			}
 		}()
+		// refundable cross-chain requests are not executed after their deadline
+		reqctx.checkCrossChainDeadline()
 		// ensure there are enough funds to cover the specified allowance
 		reqctx.checkAllowance()
 
@@ -237,6 +239,13 @@ func (reqctx *requestContext) callTheContract() (*vm.RequestResult, error) {
 		// charge gas fee no matter what
 		reqctx.chargeGasFee()
 
+		if executionErr != nil {
+			// send the assets of a failed refundable cross-chain request back to the origin chain
+			reqctx.refundCrossChainRequest(executionErr)
+			// keep the assets of a failed refund on this chain, credited to the original sender
+			reqctx.creditFailedRefund()
+		}
+
 		// write receipt no matter what
 		result.Receipt = reqctx.writeReceiptToBlockLog(executionErr)
 
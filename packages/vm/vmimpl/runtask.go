@@ -18,6 +18,7 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/vm/core/migrations"
 	"github.com/iotaledger/wasp/packages/vm/core/migrations/allmigrations"
+	"github.com/iotaledger/wasp/packages/vm/core/root"
 	"github.com/iotaledger/wasp/packages/vm/vmexceptions"
 	"github.com/iotaledger/wasp/packages/vm/vmtxbuilder"
 )
@@ -36,7 +37,8 @@ func Run(task *vm.VMTask) (res *vm.VMTaskResult, err error) {
 
 // runTask runs batch of requests on VM
 func runTask(task *vm.VMTask) *vm.VMTaskResult {
-	if len(task.Requests) == 0 {
+	// A block without requests is produced on an idle chain, to run the due scheduled calls.
+	if len(task.Requests) == 0 && !task.WillProduceBlock() {
 		panic("invalid params: must be at least 1 request")
 	}
 
@@ -57,26 +59,38 @@ func runTask(task *vm.VMTask) *vm.VMTaskResult {
 
 	vmctx.init(prevL1Commitment)
 
+	maintenanceMode := governance.NewStateAccess(stateDraft).MaintenanceStatus()
+
+	// run the calls scheduled by the contracts, which are due at the start of the block
+	var scheduledCallResults []*vm.RequestResult
+	var numScheduledSuccess uint16
+	if vmctx.task.WillProduceBlock() && !maintenanceMode {
+		scheduledCallResults, numScheduledSuccess = vmctx.runScheduledCalls(vmctx.task.Log)
+	}
+
 	// run the batch of requests
 	requestResults, numSuccess, numOffLedger, unprocessable := vmctx.runRequests(
 		vmctx.task.Requests,
-		governance.NewStateAccess(stateDraft).MaintenanceStatus(),
+		uint16(len(scheduledCallResults)),
+		maintenanceMode,
 		vmctx.task.Log,
 	)
 
-	vmctx.assertConsistentGasTotals(requestResults)
+	vmctx.assertConsistentGasTotals(append(lo.CopySlice(scheduledCallResults), requestResults...))
 
 	taskResult := &vm.VMTaskResult{
-		Task:           task,
-		StateDraft:     stateDraft,
-		RequestResults: requestResults,
+		Task:                 task,
+		StateDraft:           stateDraft,
+		RequestResults:       requestResults,
+		ScheduledCallResults: scheduledCallResults,
 	}
 
 	if !vmctx.task.WillProduceBlock() {
 		return taskResult
 	}
 
-	numProcessed := uint16(len(requestResults))
+	numProcessed := uint16(len(scheduledCallResults) + len(requestResults))
+	numSuccess += numScheduledSuccess
 
 	vmctx.task.Log.Debugf("runTask, ran %d requests. success: %d, offledger: %d",
 		numProcessed, numSuccess, numOffLedger)
@@ -155,8 +169,42 @@ func (vmctx *vmContext) getAnchorOutputSD() uint64 {
 	return vmctx.task.AnchorOutput.Amount - totalL2Funds.BaseTokens
 }
 
+// runScheduledCalls runs the scheduled calls which are due at the timestamp of the block.
+// Each call is rescheduled (or removed) before running it, so that a call that fails or is skipped
+// is not retried in the next block.
+func (vmctx *vmContext) runScheduledCalls(log *logger.Logger) (results []*vm.RequestResult, numSuccess uint16) {
+	now := vmctx.stateDraft.Timestamp()
+	var dueCalls []*isc.ScheduledCall
+	withContractState(vmctx.stateDraft, root.Contract, func(s kv.KVStore) {
+		dueCalls = root.DueScheduledCalls(s, now)
+	})
+
+	for _, call := range dueCalls {
+		req := isc.NewScheduledCallRequest(vmctx.ChainID(), call)
+		vmctx.withStateUpdate(func(chainState kv.KVStore) {
+			withContractState(chainState, root.Contract, func(s kv.KVStore) {
+				root.AdvanceScheduledCall(s, call, now)
+			})
+		})
+
+		result, _, skipReason := vmctx.runRequest(req, uint16(len(results)), false)
+		if skipReason != nil {
+			log.Infof("scheduled call skipped by the VM: %s, reason: %v", call, skipReason)
+			continue
+		}
+		results = append(results, result)
+		if result.Receipt.Error != nil {
+			log.Debugf("runTask, ERROR running scheduled call: %s, error: %v", call, result.Receipt.Error)
+			continue
+		}
+		numSuccess++
+	}
+	return results, numSuccess
+}
+
 func (vmctx *vmContext) runRequests(
 	reqs []isc.Request,
+	firstRequestIndex uint16,
 	maintenanceMode bool,
 	log *logger.Logger,
 ) (
@@ -169,7 +217,7 @@ func (vmctx *vmContext) runRequests(
 	allReqs := lo.CopySlice(reqs)
 
 	// main loop over the batch of requests
-	requestIndexCounter := uint16(0)
+	requestIndexCounter := firstRequestIndex
 	for reqIndex := 0; reqIndex < len(allReqs); reqIndex++ {
 		req := allReqs[reqIndex]
 		result, unprocessableToRetry, skipReason := vmctx.runRequest(req, requestIndexCounter, maintenanceMode)
@@ -5,6 +5,7 @@ package vmimpl
 
 import (
 	"math/big"
+	"time"
 
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/hashing"
@@ -91,6 +92,22 @@ func (s *contractSandbox) StateAnchor() *isc.StateAnchor {
 	return s.reqctx.vm.stateAnchor()
 }
 
+func (s *contractSandbox) ScheduleCall(target, entryPoint isc.Hname, params dict.Dict, gasBudget uint64, at time.Time, interval time.Duration) uint32 {
+	s.Ctx.GasBurn(gas.BurnCodeCallContract)
+	return s.reqctx.scheduleCall(&isc.ScheduledCall{
+		Target:    isc.NewCallTarget(target, entryPoint),
+		Params:    params,
+		GasBudget: gasBudget,
+		Time:      at,
+		Interval:  interval,
+	})
+}
+
+func (s *contractSandbox) CancelScheduledCall(id uint32) {
+	s.Ctx.GasBurn(gas.BurnCodeCallContract)
+	s.reqctx.cancelScheduledCall(id)
+}
+
 func (s *contractSandbox) RequestIndex() uint16 {
 	return s.reqctx.requestIndex
 }
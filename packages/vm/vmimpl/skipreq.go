@@ -40,6 +40,10 @@ func (reqctx *requestContext) earlyCheckReasonToSkip(maintenanceMode bool) error
 		return errors.New("skipped due to maintenance mode")
 	}
 
+	if _, ok := reqctx.req.(isc.ScheduledCallRequest); ok {
+		// scheduled calls are issued by the VM itself
+		return nil
+	}
 	if reqctx.req.IsOffLedger() {
 		return reqctx.checkReasonToSkipOffLedger()
 	}
@@ -111,6 +111,11 @@ func (vmctx *vmContext) extractBlock(
 	var rotationAddr iotago.Address
 	vmctx.withStateUpdate(func(chainState kv.KVStore) {
 		rotationAddr = vmctx.saveBlockInfo(numRequests, numSuccess, numOffLedger)
+		if rotationAddr == nil {
+			withContractState(chainState, governance.Contract, func(s kv.KVStore) {
+				governance.AdjustGasPerToken(s, vmctx.blockGas.burned)
+			})
+		}
 		withContractState(chainState, evm.Contract, func(s kv.KVStore) {
 			evmimpl.MintBlock(s, vmctx.chainInfo, vmctx.task.TimeAssumption)
 		})
@@ -28,8 +28,9 @@ type VMTask struct {
 	ValidatorFeeTarget isc.AgentID
 	// If EstimateGasMode is enabled, gas fee will be calculated but not charged
 	EstimateGasMode bool
-	// If EVMTracer is set, all requests will be executed normally up until the EVM
-	// tx with the given index, which will then be executed with the given tracer.
+	// If EVMTracer is set, all requests will be executed normally, and the EVM
+	// tx with the given index (or all EVM txs, if no index is given) will be
+	// executed with the given tracer.
 	EVMTracer            *isc.EVMTracer
 	EnableGasBurnLogging bool // for testing and Solo only
 
@@ -52,6 +53,9 @@ type VMTaskResult struct {
 	InputsCommitment []byte
 	// RequestResults contains one result for each non-skipped request
 	RequestResults []*RequestResult
+	// ScheduledCallResults contains one result for each non-skipped call scheduled by a contract,
+	// which were executed at the start of the block, before the requests
+	ScheduledCallResults []*RequestResult
 }
 
 type RequestResult struct {
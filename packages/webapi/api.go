@@ -84,7 +84,9 @@ func Init(
 	userManager *userspkg.UserManager,
 	chainRecordRegistryProvider registry.ChainRecordRegistryProvider,
 	dkShareRegistryProvider registry.DKShareRegistryProvider,
+	dkgAuditLogProvider registry.DKGAuditLogProvider,
 	nodeIdentityProvider registry.NodeIdentityProvider,
+	keyEncryption *registry.KeyEncryption,
 	chainsProvider chains.Provider,
 	dkgNodeProvider dkg.NodeProvider,
 	shutdownHandler *shutdown.ShutdownHandler,
@@ -93,9 +95,12 @@ func Init(
 	requestCacheTTL time.Duration,
 	websocketService *websocket.Service,
 	indexDbPath string,
+	eventIndexEnabled bool,
+	eventIndexDbPath string,
 	pub *publisher.Publisher,
 	jsonrpcParams *jsonrpc.Parameters,
-) {
+	offLedgerRateLimitParams *services.OffLedgerRateLimitParameters,
+) interfaces.EventIndexService {
 	// load mock files to generate correct echo swagger documentation
 	mocker := NewMocker()
 	mocker.LoadMockFiles()
@@ -103,21 +108,23 @@ func Init(
 	chainService := services.NewChainService(logger, chainsProvider, chainMetricsProvider, chainRecordRegistryProvider)
 	committeeService := services.NewCommitteeService(chainsProvider, networkProvider, dkShareRegistryProvider)
 	registryService := services.NewRegistryService(chainsProvider, chainRecordRegistryProvider)
-	offLedgerService := services.NewOffLedgerService(chainService, networkProvider, requestCacheTTL)
+	offLedgerService := services.NewOffLedgerService(chainService, networkProvider, requestCacheTTL, offLedgerRateLimitParams, chainMetricsProvider)
 	metricsService := services.NewMetricsService(chainsProvider, chainMetricsProvider)
 	peeringService := services.NewPeeringService(chainsProvider, networkProvider, trustedNetworkManager)
 	evmService := services.NewEVMService(chainsProvider, chainService, networkProvider, pub, indexDbPath, chainMetricsProvider, jsonrpcParams, logger.Named("EVMService"))
+	eventIndexService := services.NewEventIndexService(eventIndexEnabled, eventIndexDbPath, chainService, pub, logger.Named("EventIndexService"))
 	nodeService := services.NewNodeService(chainRecordRegistryProvider, nodeIdentityProvider, chainsProvider, shutdownHandler, trustedNetworkManager)
-	dkgService := services.NewDKGService(dkShareRegistryProvider, dkgNodeProvider, trustedNetworkManager)
+	dkgService := services.NewDKGService(dkShareRegistryProvider, dkgAuditLogProvider, dkgNodeProvider, trustedNetworkManager)
 	userService := services.NewUserService(userManager)
+	backupService := services.NewBackupService(chainsProvider, chainRecordRegistryProvider, dkShareRegistryProvider, nodeIdentityProvider, keyEncryption)
 	// --
 
 	authMiddleware := authentication.AddAuthentication(server, userManager, nodeIdentityProvider, authConfig, mocker)
 
 	controllersToLoad := []interfaces.APIController{
-		chain.NewChainController(logger, chainService, committeeService, evmService, nodeService, offLedgerService, registryService),
+		chain.NewChainController(logger, chainService, committeeService, evmService, eventIndexService, nodeService, offLedgerService, registryService),
 		apimetrics.NewMetricsController(chainService, metricsService),
-		node.NewNodeController(waspVersion, config, dkgService, nodeService, peeringService),
+		node.NewNodeController(waspVersion, config, dkgService, nodeService, peeringService, backupService),
 		requests.NewRequestsController(chainService, offLedgerService, peeringService),
 		users.NewUsersController(userService),
 		corecontracts.NewCoreContractsController(chainService),
@@ -126,4 +133,7 @@ func Init(
 	AddHealthEndpoint(server, chainService, metricsService)
 	addWebSocketEndpoint(server, websocketService)
 	loadControllers(server, mocker, controllersToLoad, authMiddleware)
+
+	// the event index keeps running in the background, it has to be closed on shutdown
+	return eventIndexService
 }
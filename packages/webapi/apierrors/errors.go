@@ -19,6 +19,14 @@ func UserCanNotBeDeleted(username string, explanation string) *HTTPError {
 	return NewHTTPError(http.StatusBadRequest, fmt.Sprintf("User: %v not be deleted. Reason: %v", username, explanation), nil)
 }
 
+func PermissionNotGrantableError(permission string) *HTTPError {
+	return NewHTTPError(http.StatusForbidden, fmt.Sprintf("Permission: %v exceeds your own permissions", permission), nil)
+}
+
+func APIKeyNotFoundError(username, apiKeyID string) *HTTPError {
+	return NewHTTPError(http.StatusNotFound, fmt.Sprintf("API key: %v of user: %v not found", apiKeyID, username), nil)
+}
+
 func BodyIsEmptyError() *HTTPError {
 	return InvalidPropertyError("body", errors.New("a valid body is required"))
 }
@@ -52,10 +60,18 @@ func InvalidOffLedgerRequestError(err error) *HTTPError {
 	return NewHTTPError(http.StatusBadRequest, "Supplied offledger request is invalid", err)
 }
 
+func TooManyRequestsError(err error) *HTTPError {
+	return NewHTTPError(http.StatusTooManyRequests, "Too many requests", err)
+}
+
 func NoRecordFoundError(err error) *HTTPError {
 	return NewHTTPError(http.StatusNotFound, "Record not found", err)
 }
 
+func StateNotAvailableError(err error) *HTTPError {
+	return NewHTTPError(http.StatusGone, "The state of the requested block is not available on this node", err)
+}
+
 func ReceiptError(err error) *HTTPError {
 	return NewHTTPError(http.StatusBadRequest, "Failed to get receipt", err)
 }
@@ -63,3 +79,7 @@ func ReceiptError(err error) *HTTPError {
 func Timeout(msg string) *HTTPError {
 	return NewHTTPError(http.StatusRequestTimeout, msg, nil)
 }
+
+func BackupVerificationError(err error) *HTTPError {
+	return NewHTTPError(http.StatusBadRequest, "Backup does not match the chain", err)
+}
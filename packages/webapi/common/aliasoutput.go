@@ -0,0 +1,50 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+
+	chainpkg "github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/subrealm"
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
+	"github.com/iotaledger/wasp/packages/transaction"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+)
+
+var ErrBlockNotConfirmed = errors.New("block not confirmed on L1")
+
+func BlocklogPartition(chainState kv.KVStoreReader) kv.KVStoreReader {
+	return subrealm.NewReadOnly(chainState, kv.Key(blocklog.Contract.Hname().Bytes()))
+}
+
+// AliasOutputOfBlock returns the alias output, which anchors the given block on L1.
+// Only the blocks up to the latest confirmed one are anchored.
+func AliasOutputOfBlock(ch chainpkg.Chain, blockIndex uint32) (*isc.AliasOutputWithID, error) {
+	confirmedAO, err := ch.LatestAliasOutput(chainpkg.ConfirmedState)
+	if err != nil {
+		return nil, err
+	}
+	if blockIndex > confirmedAO.GetStateIndex() {
+		return nil, fmt.Errorf("%w: block %d, the latest confirmed block is %d", ErrBlockNotConfirmed, blockIndex, confirmedAO.GetStateIndex())
+	}
+	if blockIndex == confirmedAO.GetStateIndex() {
+		return confirmedAO, nil
+	}
+
+	// the alias output of a past block is recorded in the block info of the next block
+	l1c, err := transaction.L1CommitmentFromAliasOutput(confirmedAO.GetAliasOutput())
+	if err != nil {
+		return nil, err
+	}
+	confirmedState, err := ch.Store().StateByTrieRoot(l1c.TrieRoot())
+	if err != nil {
+		return nil, err
+	}
+	blockInfo, ok := blocklog.GetBlockInfo(BlocklogPartition(confirmedState), blockIndex+1)
+	if !ok || blockInfo.PreviousAliasOutput == nil {
+		return nil, fmt.Errorf("%w: the block info of block %d is pruned", indexedstore.ErrStateNotAvailable, blockIndex+1)
+	}
+	return blockInfo.PreviousAliasOutput, nil
+}
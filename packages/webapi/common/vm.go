@@ -1,6 +1,7 @@
 package common
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
 	"github.com/iotaledger/wasp/packages/trie"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 )
@@ -26,15 +28,17 @@ func ParseReceipt(chain chainpkg.Chain, receipt *blocklog.RequestReceipt) (*isc.
 	return iscReceipt, nil
 }
 
-func CallView(ch chainpkg.Chain, contractName, functionName isc.Hname, params dict.Dict, blockIndexOrHash string) (dict.Dict, error) {
-	var chainState state.State
-	var err error
+// ChainState returns the state of the chain at the given block index or trie root, or the latest state,
+// if blockIndexOrHash is empty. If the state is not available in the store, the returned error wraps
+// indexedstore.ErrStateNotAvailable and includes the range of the available blocks.
+func ChainState(ch chainpkg.Chain, blockIndexOrHash string) (state.State, error) {
 	switch {
 	case blockIndexOrHash == "":
-		chainState, err = ch.LatestState(chainpkg.ActiveOrCommittedState)
+		chainState, err := ch.LatestState(chainpkg.ActiveOrCommittedState)
 		if err != nil {
 			return nil, fmt.Errorf("error getting latest chain state: %w", err)
 		}
+		return chainState, nil
 	case strings.HasPrefix(blockIndexOrHash, "0x"):
 		hashBytes, err := iotago.DecodeHex(blockIndexOrHash)
 		if err != nil {
@@ -44,19 +48,34 @@ func CallView(ch chainpkg.Chain, contractName, functionName isc.Hname, params di
 		if err != nil {
 			return nil, fmt.Errorf("invalid block hash: %v", blockIndexOrHash)
 		}
-		chainState, err = ch.Store().StateByTrieRoot(trieRoot)
+		if !ch.Store().HasTrieRoot(trieRoot) {
+			return nil, indexedstore.WithAvailableHistory(ch.Store(), fmt.Errorf("%w: trie root %s", indexedstore.ErrStateNotAvailable, trieRoot))
+		}
+		chainState, err := ch.Store().StateByTrieRoot(trieRoot)
 		if err != nil {
 			return nil, fmt.Errorf("error getting block by trie root: %w", err)
 		}
+		return chainState, nil
 	default:
 		blockIndex, err := strconv.ParseUint(blockIndexOrHash, 10, 32)
 		if err != nil {
 			return nil, fmt.Errorf("invalid block number: %v", blockIndexOrHash)
 		}
-		chainState, err = ch.Store().StateByIndex(uint32(blockIndex))
+		chainState, err := ch.Store().StateByIndex(uint32(blockIndex))
+		if errors.Is(err, indexedstore.ErrStateNotAvailable) {
+			return nil, indexedstore.WithAvailableHistory(ch.Store(), err)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("error getting block by index: %w", err)
 		}
+		return chainState, nil
+	}
+}
+
+func CallView(ch chainpkg.Chain, contractName, functionName isc.Hname, params dict.Dict, blockIndexOrHash string) (dict.Dict, error) {
+	chainState, err := ChainState(ch, blockIndexOrHash)
+	if err != nil {
+		return nil, err
 	}
 	return chainutil.CallView(chainState, ch, contractName, functionName, params)
 }
@@ -1,9 +1,11 @@
 package chain
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
 	"github.com/iotaledger/wasp/packages/webapi/apierrors"
 	"github.com/iotaledger/wasp/packages/webapi/common"
 	"github.com/iotaledger/wasp/packages/webapi/controllers/controllerutils"
@@ -54,6 +56,9 @@ func (c *Controller) executeCallView(e echo.Context) error {
 	}
 
 	result, err := common.CallView(ch, contractHName, functionHName, args, callViewRequest.Block)
+	if errors.Is(err, indexedstore.ErrStateNotAvailable) {
+		return apierrors.StateNotAvailableError(err)
+	}
 	if err != nil {
 		return apierrors.ContractExecutionError(err)
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/labstack/echo/v4"
 
 	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
 	"github.com/iotaledger/wasp/packages/webapi/apierrors"
 	"github.com/iotaledger/wasp/packages/webapi/controllers/controllerutils"
 	"github.com/iotaledger/wasp/packages/webapi/interfaces"
@@ -57,6 +58,8 @@ func (c *Controller) getChainInfo(e echo.Context) error {
 	chainInfo, err := c.chainService.GetChainInfoByChainID(chainID, e.QueryParam(params.ParamBlockIndexOrTrieRoot))
 	if errors.Is(err, interfaces.ErrChainNotFound) {
 		return e.NoContent(http.StatusNotFound)
+	} else if errors.Is(err, indexedstore.ErrStateNotAvailable) {
+		return apierrors.StateNotAvailableError(err)
 	} else if err != nil {
 		return err
 	}
@@ -64,7 +67,9 @@ func (c *Controller) getChainInfo(e echo.Context) error {
 	evmChainID := uint16(0)
 	if chainInfo.IsActive {
 		evmChainID, err = c.chainService.GetEVMChainID(chainID, e.QueryParam(params.ParamBlockIndexOrTrieRoot))
-		if err != nil {
+		if errors.Is(err, indexedstore.ErrStateNotAvailable) {
+			return apierrors.StateNotAvailableError(err)
+		} else if err != nil {
 			return err
 		}
 	}
@@ -129,8 +134,10 @@ func (c *Controller) getState(e echo.Context) error {
 		return apierrors.InvalidPropertyError(params.ParamStateKey, err)
 	}
 
-	state, err := c.chainService.GetState(chainID, stateKey)
-	if err != nil {
+	state, err := c.chainService.GetState(chainID, stateKey, e.QueryParam(params.ParamBlockIndexOrTrieRoot))
+	if errors.Is(err, indexedstore.ErrStateNotAvailable) {
+		return apierrors.StateNotAvailableError(err)
+	} else if err != nil {
 		panic(err)
 	}
 
@@ -0,0 +1,34 @@
+package chain
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/webapi/apierrors"
+	"github.com/iotaledger/wasp/packages/webapi/controllers/controllerutils"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+)
+
+const consensusStatusTimeout = 10 * time.Second
+
+func (c *Controller) getConsensusStatus(e echo.Context) error {
+	controllerutils.SetOperation(e, "get_consensus_status")
+	ch, _, err := controllerutils.ChainFromParams(e, c.chainService)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(e.Request().Context(), consensusStatusTimeout)
+	defer cancel()
+
+	status := ch.GetConsensusStatus(ctx)
+	if status == nil {
+		return apierrors.Timeout("timeout while collecting the consensus status")
+	}
+
+	return e.JSON(http.StatusOK, models.MapConsensusStatusResponse(status, parameters.L1().Protocol.Bech32HRP))
+}
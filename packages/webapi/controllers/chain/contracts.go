@@ -1,10 +1,13 @@
 package chain
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
+	"github.com/iotaledger/wasp/packages/webapi/apierrors"
 	"github.com/iotaledger/wasp/packages/webapi/controllers/controllerutils"
 	"github.com/iotaledger/wasp/packages/webapi/models"
 	"github.com/iotaledger/wasp/packages/webapi/params"
@@ -18,7 +21,9 @@ func (c *Controller) getContracts(e echo.Context) error {
 	}
 
 	contracts, err := c.chainService.GetContracts(chainID, e.QueryParam(params.ParamBlockIndexOrTrieRoot))
-	if err != nil {
+	if errors.Is(err, indexedstore.ErrStateNotAvailable) {
+		return apierrors.StateNotAvailableError(err)
+	} else if err != nil {
 		return err
 	}
 
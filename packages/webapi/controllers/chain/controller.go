@@ -18,30 +18,33 @@ import (
 type Controller struct {
 	log *loggerpkg.Logger
 
-	chainService     interfaces.ChainService
-	evmService       interfaces.EVMService
-	nodeService      interfaces.NodeService
-	committeeService interfaces.CommitteeService
-	offLedgerService interfaces.OffLedgerService
-	registryService  interfaces.RegistryService
+	chainService      interfaces.ChainService
+	evmService        interfaces.EVMService
+	eventIndexService interfaces.EventIndexService
+	nodeService       interfaces.NodeService
+	committeeService  interfaces.CommitteeService
+	offLedgerService  interfaces.OffLedgerService
+	registryService   interfaces.RegistryService
 }
 
 func NewChainController(log *loggerpkg.Logger,
 	chainService interfaces.ChainService,
 	committeeService interfaces.CommitteeService,
 	evmService interfaces.EVMService,
+	eventIndexService interfaces.EventIndexService,
 	nodeService interfaces.NodeService,
 	offLedgerService interfaces.OffLedgerService,
 	registryService interfaces.RegistryService,
 ) interfaces.APIController {
 	return &Controller{
-		log:              log,
-		chainService:     chainService,
-		evmService:       evmService,
-		committeeService: committeeService,
-		nodeService:      nodeService,
-		offLedgerService: offLedgerService,
-		registryService:  registryService,
+		log:               log,
+		chainService:      chainService,
+		evmService:        evmService,
+		eventIndexService: eventIndexService,
+		committeeService:  committeeService,
+		nodeService:       nodeService,
+		offLedgerService:  offLedgerService,
+		registryService:   registryService,
 	}
 }
 
@@ -74,6 +77,8 @@ func (c *Controller) RegisterPublic(publicAPI echoswagger.ApiGroup, mocker inter
 	publicAPI.GET("chains/:chainID/state/:stateKey", c.getState).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamPath("", params.ParamStateKey, params.DescriptionStateKey).
+		AddParamQuery("", params.ParamBlockIndexOrTrieRoot, params.DescriptionBlockIndexOrTrieRoot, false).
+		AddResponse(http.StatusGone, "The state of the requested block is not available on this node", nil, nil).
 		AddResponse(http.StatusOK, "Result", mocker.Get(models.StateResponse{}), nil).
 		SetSummary("Fetch the raw value associated with the given key in the chain state").
 		SetOperationId("getStateValue")
@@ -86,6 +91,40 @@ func (c *Controller) RegisterPublic(publicAPI echoswagger.ApiGroup, mocker inter
 		SetSummary("Get a receipt from a request ID").
 		SetOperationId("getReceipt")
 
+	publicAPI.GET("chains/:chainID/proofs/:blockIndex/state/:stateKey", c.getStateProof).
+		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
+		AddParamPath(uint32(0), params.ParamBlockIndex, params.DescriptionBlockIndex).
+		AddParamPath("", params.ParamStateKey, params.DescriptionStateKey).
+		AddResponse(http.StatusNotFound, "The block is not confirmed on L1 or its state is not available", nil, nil).
+		AddResponse(http.StatusOK, "The Merkle proof of the key", mocker.Get(models.StateProofResponse{}), nil).
+		SetSummary("Get the Merkle proof of a key in the state of a block, anchored on L1").
+		SetDescription("Returns a proof of inclusion, or a proof of absence if the key is not in the state.").
+		SetOperationId("getStateProof")
+
+	publicAPI.GET("chains/:chainID/proofs/:blockIndex/receipts/:requestID", c.getReceiptProof).
+		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
+		AddParamPath(uint32(0), params.ParamBlockIndex, params.DescriptionBlockIndex).
+		AddParamPath("", params.ParamRequestID, params.DescriptionRequestID).
+		AddResponse(http.StatusNotFound, "The block is not confirmed on L1, or the receipt is not in its state", nil, nil).
+		AddResponse(http.StatusOK, "The Merkle proof of the receipt", mocker.Get(models.StateProofResponse{}), nil).
+		SetSummary("Get the Merkle proof of a request receipt in the state of a block, anchored on L1").
+		SetOperationId("getReceiptProof")
+
+	publicAPI.GET("chains/:chainID/events", c.getIndexedEvents).
+		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
+		AddParamQuery("", params.ParamContractHName, params.DescriptionContractHName, false).
+		AddParamQuery("", params.ParamTopic, params.DescriptionTopic, false).
+		AddParamQuery("", params.ParamSender, params.DescriptionSender, false).
+		AddParamQuery(uint32(0), params.ParamFromBlock, params.DescriptionFromBlock, false).
+		AddParamQuery(uint32(0), params.ParamToBlock, params.DescriptionToBlock, false).
+		AddParamQuery("", params.ParamCursor, params.DescriptionCursor, false).
+		AddParamQuery(0, params.ParamLimit, params.DescriptionLimit, false).
+		AddResponse(http.StatusNotFound, "The event index is not enabled on the node", nil, nil).
+		AddResponse(http.StatusOK, "The indexed events", mocker.Get(models.IndexedEventsResponse{}), nil).
+		SetSummary("Get the events issued by the contracts of the chain, from the event index of the node").
+		SetDescription("Unlike the blocklog, the event index keeps the events of pruned blocks. The events are returned in the order they were issued.").
+		SetOperationId("getIndexedEvents")
+
 	dictExample := dict.Dict{
 		"key1": []byte("value1"),
 	}.JSONDict()
@@ -125,61 +164,68 @@ func (c *Controller) RegisterPublic(publicAPI echoswagger.ApiGroup, mocker inter
 }
 
 func (c *Controller) RegisterAdmin(adminAPI echoswagger.ApiGroup, mocker interfaces.Mocker) {
-	adminAPI.GET("chains", c.getChainList, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("chains", c.getChainList, authentication.ValidatePermissions([]string{permissions.ChainRead})).
 		AddResponse(http.StatusOK, "A list of all available chains", mocker.Get([]models.ChainInfoResponse{}), nil).
 		SetOperationId("getChains").
 		SetSummary("Get a list of all chains")
 
-	adminAPI.POST("chains/:chainID/activate", c.activateChain, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("chains/:chainID/activate", c.activateChain, authentication.ValidatePermissions([]string{permissions.ChainWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddResponse(http.StatusNotModified, "Chain was not activated", nil, nil).
 		AddResponse(http.StatusOK, "Chain was successfully activated", nil, nil).
 		SetOperationId("activateChain").
 		SetSummary("Activate a chain")
 
-	adminAPI.POST("chains/:chainID/deactivate", c.deactivateChain, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("chains/:chainID/deactivate", c.deactivateChain, authentication.ValidatePermissions([]string{permissions.ChainWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddResponse(http.StatusNotModified, "Chain was not deactivated", nil, nil).
 		AddResponse(http.StatusOK, "Chain was successfully deactivated", nil, nil).
 		SetOperationId("deactivateChain").
 		SetSummary("Deactivate a chain")
 
-	adminAPI.GET("chains/:chainID/committee", c.getCommitteeInfo, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("chains/:chainID/committee", c.getCommitteeInfo, authentication.ValidatePermissions([]string{permissions.ChainRead})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamQuery("", params.ParamBlockIndexOrTrieRoot, params.DescriptionBlockIndexOrTrieRoot, false).
 		AddResponse(http.StatusOK, "A list of all nodes tied to the chain", mocker.Get(models.CommitteeInfoResponse{}), nil).
 		SetOperationId("getCommitteeInfo").
 		SetSummary("Get information about the deployed committee")
 
-	adminAPI.GET("chains/:chainID/contracts", c.getContracts, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("chains/:chainID/consensus", c.getConsensusStatus, authentication.ValidatePermissions([]string{permissions.ChainRead})).
+		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
+		AddResponse(http.StatusOK, "The consensus status of the committees, this node is a member of", mocker.Get(models.ConsensusStatusResponse{}), nil).
+		AddResponse(http.StatusRequestTimeout, "The consensus status could not be collected in time", nil, nil).
+		SetOperationId("getConsensusStatus").
+		SetSummary("Get the status of the consensus instances and committee members")
+
+	adminAPI.GET("chains/:chainID/contracts", c.getContracts, authentication.ValidatePermissions([]string{permissions.ChainRead})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamQuery("", params.ParamBlockIndexOrTrieRoot, params.DescriptionBlockIndexOrTrieRoot, false).
 		AddResponse(http.StatusOK, "A list of all available contracts", mocker.Get([]models.ContractInfoResponse{}), nil).
 		SetOperationId("getContracts").
 		SetSummary("Get all available chain contracts")
 
-	adminAPI.POST("chains/:chainID/chainrecord", c.setChainRecord, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("chains/:chainID/chainrecord", c.setChainRecord, authentication.ValidatePermissions([]string{permissions.ChainWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamBody(mocker.Get(models.ChainRecord{}), "ChainRecord", "Chain Record", true).
 		AddResponse(http.StatusCreated, "Chain record was saved", nil, nil).
 		SetSummary("Sets the chain record.").
 		SetOperationId("setChainRecord")
 
-	adminAPI.PUT("chains/:chainID/access-node/:peer", c.addAccessNode, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.PUT("chains/:chainID/access-node/:peer", c.addAccessNode, authentication.ValidatePermissions([]string{permissions.ChainWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamPath("", params.ParamPeer, params.DescriptionPeer).
 		AddResponse(http.StatusCreated, "Access node was successfully added", nil, nil).
 		SetSummary("Configure a trusted node to be an access node.").
 		SetOperationId("addAccessNode")
 
-	adminAPI.DELETE("chains/:chainID/access-node/:peer", c.removeAccessNode, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.DELETE("chains/:chainID/access-node/:peer", c.removeAccessNode, authentication.ValidatePermissions([]string{permissions.ChainWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamPath("", params.ParamPeer, params.DescriptionPeer).
 		AddResponse(http.StatusOK, "Access node was successfully removed", nil, nil).
 		SetSummary("Remove an access node.").
 		SetOperationId("removeAccessNode")
 
-	adminAPI.GET("chains/:chainID/mempool", c.getMempoolContents, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("chains/:chainID/mempool", c.getMempoolContents, authentication.ValidatePermissions([]string{permissions.ChainRead})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		SetResponseContentType("application/octet-stream").
 		AddResponse(http.StatusOK, "stream of JSON representation of the requests in the mempool", []byte{}, nil).
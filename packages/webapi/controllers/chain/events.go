@@ -0,0 +1,98 @@
+package chain
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/eventindex"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/webapi/apierrors"
+	"github.com/iotaledger/wasp/packages/webapi/controllers/controllerutils"
+	"github.com/iotaledger/wasp/packages/webapi/interfaces"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+	"github.com/iotaledger/wasp/packages/webapi/params"
+)
+
+const (
+	defaultIndexedEventsLimit = 100
+	maxIndexedEventsLimit     = 1000
+)
+
+func decodeUint32Query(e echo.Context, key string) (uint32, error) {
+	value := e.QueryParam(key)
+	if value == "" {
+		return 0, nil
+	}
+	ret, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, apierrors.InvalidPropertyError(key, err)
+	}
+	return uint32(ret), nil
+}
+
+func decodeEventQuery(e echo.Context) (*eventindex.Query, error) {
+	var err error
+	query := &eventindex.Query{
+		Topic: e.QueryParam(params.ParamTopic),
+		Limit: defaultIndexedEventsLimit,
+	}
+
+	if contract := e.QueryParam(params.ParamContractHName); contract != "" {
+		if query.Contract, err = isc.HnameFromString(contract); err != nil {
+			return nil, apierrors.InvalidPropertyError(params.ParamContractHName, err)
+		}
+	}
+	if sender := e.QueryParam(params.ParamSender); sender != "" {
+		if query.Sender, err = isc.AgentIDFromString(sender); err != nil {
+			return nil, apierrors.InvalidPropertyError(params.ParamSender, err)
+		}
+	}
+	if query.FromBlock, err = decodeUint32Query(e, params.ParamFromBlock); err != nil {
+		return nil, err
+	}
+	if query.ToBlock, err = decodeUint32Query(e, params.ParamToBlock); err != nil {
+		return nil, err
+	}
+	if cursor := e.QueryParam(params.ParamCursor); cursor != "" {
+		if query.Cursor, err = iotago.DecodeHex(cursor); err != nil {
+			return nil, apierrors.InvalidPropertyError(params.ParamCursor, err)
+		}
+	}
+	if limit := e.QueryParam(params.ParamLimit); limit != "" {
+		if query.Limit, err = strconv.Atoi(limit); err != nil || query.Limit <= 0 || query.Limit > maxIndexedEventsLimit {
+			return nil, apierrors.InvalidPropertyError(params.ParamLimit, errors.New("the limit must be between 1 and 1000"))
+		}
+	}
+
+	return query, nil
+}
+
+func (c *Controller) getIndexedEvents(e echo.Context) error {
+	controllerutils.SetOperation(e, "get_indexed_events")
+	chainID, err := controllerutils.ChainIDFromParams(e, c.chainService)
+	if err != nil {
+		return err
+	}
+
+	query, err := decodeEventQuery(e)
+	if err != nil {
+		return err
+	}
+
+	page, err := c.eventIndexService.GetEvents(chainID, query)
+	if errors.Is(err, interfaces.ErrEventIndexDisabled) {
+		return apierrors.NoRecordFoundError(err)
+	}
+	if errors.Is(err, eventindex.ErrInvalidCursor) {
+		return apierrors.InvalidPropertyError(params.ParamCursor, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, models.MapIndexedEventsResponse(page))
+}
@@ -0,0 +1,80 @@
+package chain
+
+import (
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/webapi/apierrors"
+	"github.com/iotaledger/wasp/packages/webapi/controllers/controllerutils"
+	"github.com/iotaledger/wasp/packages/webapi/dto"
+	"github.com/iotaledger/wasp/packages/webapi/interfaces"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+	"github.com/iotaledger/wasp/packages/webapi/params"
+)
+
+func decodeProofBlockIndex(e echo.Context) (uint32, error) {
+	blockIndex, err := params.DecodeUInt(e, params.ParamBlockIndex)
+	if err != nil {
+		return 0, err
+	}
+	if blockIndex > math.MaxUint32 {
+		return 0, apierrors.InvalidPropertyError(params.ParamBlockIndex, errors.New("block index out of range"))
+	}
+	return uint32(blockIndex), nil
+}
+
+func proofResponse(e echo.Context, proof *dto.StateProof, err error) error {
+	if errors.Is(err, interfaces.ErrProofNotAvailable) {
+		return apierrors.NoRecordFoundError(err)
+	}
+	if err != nil {
+		return err
+	}
+	return e.JSON(http.StatusOK, models.MapStateProofResponse(proof))
+}
+
+func (c *Controller) getStateProof(e echo.Context) error {
+	controllerutils.SetOperation(e, "get_state_proof")
+	chainID, err := controllerutils.ChainIDFromParams(e, c.chainService)
+	if err != nil {
+		return err
+	}
+
+	blockIndex, err := decodeProofBlockIndex(e)
+	if err != nil {
+		return err
+	}
+
+	stateKey, err := iotago.DecodeHex(e.Param(params.ParamStateKey))
+	if err != nil {
+		return apierrors.InvalidPropertyError(params.ParamStateKey, err)
+	}
+
+	proof, err := c.chainService.GetStateProof(chainID, blockIndex, stateKey)
+	return proofResponse(e, proof, err)
+}
+
+func (c *Controller) getReceiptProof(e echo.Context) error {
+	controllerutils.SetOperation(e, "get_receipt_proof")
+	chainID, err := controllerutils.ChainIDFromParams(e, c.chainService)
+	if err != nil {
+		return err
+	}
+
+	blockIndex, err := decodeProofBlockIndex(e)
+	if err != nil {
+		return err
+	}
+
+	requestID, err := params.DecodeRequestID(e)
+	if err != nil {
+		return err
+	}
+
+	proof, err := c.chainService.GetReceiptProof(chainID, blockIndex, requestID)
+	return proofResponse(e, proof, err)
+}
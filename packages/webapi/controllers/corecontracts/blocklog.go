@@ -24,7 +24,7 @@ func (c *Controller) getControlAddresses(e echo.Context) error {
 	if err != nil {
 		return c.handleViewCallError(err, chainID)
 	}
-	controlAddresses, err := corecontracts.GetControlAddresses(ch)
+	controlAddresses, err := corecontracts.GetControlAddresses(ch, e.QueryParam(params.ParamBlockIndexOrTrieRoot))
 	if err != nil {
 		return c.handleViewCallError(err, chainID)
 	}
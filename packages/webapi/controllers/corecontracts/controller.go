@@ -8,6 +8,7 @@ import (
 
 	"github.com/iotaledger/wasp/packages/authentication"
 	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
 	"github.com/iotaledger/wasp/packages/webapi/apierrors"
 	"github.com/iotaledger/wasp/packages/webapi/interfaces"
 	"github.com/iotaledger/wasp/packages/webapi/models"
@@ -30,6 +31,9 @@ func (c *Controller) handleViewCallError(err error, chainID isc.ChainID) error {
 	if errors.Is(err, interfaces.ErrChainNotFound) {
 		return apierrors.ChainNotFoundError(chainID.String())
 	}
+	if errors.Is(err, indexedstore.ErrStateNotAvailable) {
+		return apierrors.StateNotAvailableError(err)
+	}
 	return apierrors.ContractExecutionError(err)
 }
 
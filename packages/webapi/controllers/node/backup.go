@@ -0,0 +1,56 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/wasp/packages/webapi/apierrors"
+	"github.com/iotaledger/wasp/packages/webapi/interfaces"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+	"github.com/iotaledger/wasp/packages/webapi/params"
+	"github.com/iotaledger/wasp/packages/webapi/services"
+)
+
+func (c *Controller) backupChain(e echo.Context) error {
+	chainID, err := params.DecodeChainID(e)
+	if err != nil {
+		return err
+	}
+
+	backup, err := c.backupService.PrepareChainBackup(chainID)
+	if errors.Is(err, interfaces.ErrChainNotFound) {
+		return apierrors.ChainNotFoundError(chainID.String())
+	}
+	if err != nil {
+		return err
+	}
+
+	e.Response().Header().Set(echo.HeaderContentType, echo.MIMEOctetStream)
+	e.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", backup.Manifest.FileName()))
+	e.Response().WriteHeader(http.StatusOK)
+
+	// The status is already sent, so the error can only interrupt the stream.
+	return c.backupService.WriteChainBackup(backup, e.Response())
+}
+
+func (c *Controller) restoreChain(e echo.Context) error {
+	chainID, err := params.DecodeChainID(e)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.backupService.RestoreChain(chainID, e.Request().Body)
+	switch {
+	case errors.Is(err, interfaces.ErrChainNotFound):
+		return apierrors.ChainNotFoundError(chainID.String())
+	case errors.Is(err, services.ErrBackupVerificationFailed):
+		return apierrors.BackupVerificationError(err)
+	case err != nil:
+		return err
+	}
+
+	return e.JSON(http.StatusOK, models.MapChainRestoreResponse(result))
+}
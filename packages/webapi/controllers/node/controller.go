@@ -11,6 +11,7 @@ import (
 	"github.com/iotaledger/wasp/packages/webapi/interfaces"
 	"github.com/iotaledger/wasp/packages/webapi/models"
 	"github.com/iotaledger/wasp/packages/webapi/params"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
 	"github.com/iotaledger/wasp/packages/webapi/services"
 )
 
@@ -20,15 +21,17 @@ type Controller struct {
 	dkgService     *services.DKGService
 	nodeService    interfaces.NodeService
 	peeringService interfaces.PeeringService
+	backupService  interfaces.BackupService
 }
 
-func NewNodeController(waspVersion string, config *configuration.Configuration, dkgService *services.DKGService, nodeService interfaces.NodeService, peeringService interfaces.PeeringService) interfaces.APIController {
+func NewNodeController(waspVersion string, config *configuration.Configuration, dkgService *services.DKGService, nodeService interfaces.NodeService, peeringService interfaces.PeeringService, backupService interfaces.BackupService) interfaces.APIController {
 	return &Controller{
 		waspVersion:    waspVersion,
 		config:         config,
 		dkgService:     dkgService,
 		nodeService:    nodeService,
 		peeringService: peeringService,
+		backupService:  backupService,
 	}
 }
 
@@ -49,63 +52,114 @@ func (c *Controller) RegisterAdmin(adminAPI echoswagger.ApiGroup, mocker interfa
 		SetOperationId("getInfo").
 		SetSummary("Returns private information about this node.")
 
-	adminAPI.GET("node/peers/trusted", c.getTrustedPeers, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("node/peers/trusted", c.getTrustedPeers, authentication.ValidatePermissions([]string{permissions.PeeringRead})).
 		AddResponse(http.StatusOK, "A list of trusted peers", mocker.Get([]models.PeeringNodeIdentityResponse{}), nil).
 		SetSummary("Get trusted peers").
 		SetOperationId("getTrustedPeers")
 
-	adminAPI.DELETE("node/peers/trusted/:peer", c.distrustPeer, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.DELETE("node/peers/trusted/:peer", c.distrustPeer, authentication.ValidatePermissions([]string{permissions.PeeringWrite})).
 		AddParamPath("", params.ParamPeer, params.DescriptionPeer).
 		AddResponse(http.StatusNotFound, "Peer not found", nil, nil).
 		AddResponse(http.StatusOK, "Peer was successfully distrusted", nil, nil).
 		SetSummary("Distrust a peering node").
 		SetOperationId("distrustPeer")
 
-	adminAPI.GET("node/owner/certificate", c.nodeOwnerCertificate, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("node/owner/certificate", c.nodeOwnerCertificate, authentication.ValidatePermissions([]string{permissions.NodeRead})).
 		AddResponse(http.StatusOK, "Node Certificate", mocker.Get(models.NodeOwnerCertificateResponse{}), nil).
 		SetSummary("Gets the node owner").
 		SetOperationId("ownerCertificate")
 
-	adminAPI.POST("node/peers/trusted", c.trustPeer, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("node/peers/trusted", c.trustPeer, authentication.ValidatePermissions([]string{permissions.PeeringWrite})).
 		AddParamBody(mocker.Get(models.PeeringTrustRequest{}), "", "Info of the peer to trust", true).
 		AddResponse(http.StatusOK, "Peer was successfully trusted", nil, nil).
 		SetSummary("Trust a peering node").
 		SetOperationId("trustPeer")
 
-	adminAPI.POST("node/dks", c.generateDKS, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("node/dks", c.generateDKS, authentication.ValidatePermissions([]string{permissions.DKGWrite})).
 		AddParamBody(mocker.Get(models.DKSharesPostRequest{}), "DKSharesPostRequest", "Request parameters", true).
 		AddResponse(http.StatusOK, "DK shares info", mocker.Get(models.DKSharesInfo{}), nil).
 		SetSummary("Generate a new distributed key").
 		SetOperationId("generateDKS")
 
-	adminAPI.GET("node/dks/:sharedAddress", c.getDKSInfo, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("node/dks/audit", c.getDKGAuditLog, authentication.ValidatePermissions([]string{permissions.DKGRead})).
+		AddResponse(http.StatusOK, "The DKG procedures, the oldest first", mocker.Get([]models.DKGAuditEntry{}), nil).
+		SetSummary("Get the log of the DKG procedures this node initiated or took part in").
+		SetOperationId("getDKGAuditLog")
+
+	adminAPI.GET("node/dks/:sharedAddress", c.getDKSInfo, authentication.ValidatePermissions([]string{permissions.DKGRead})).
 		AddParamPath("", params.ParamSharedAddress, params.DescriptionSharedAddress).
 		AddResponse(http.StatusNotFound, "Shared address not found", nil, nil).
 		AddResponse(http.StatusOK, "DK shares info", mocker.Get(models.DKSharesInfo{}), nil).
 		SetSummary("Get information about the shared address DKS configuration").
 		SetOperationId("getDKSInfo")
 
-	adminAPI.GET("node/peers/identity", c.getIdentity, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.POST("node/dks/:sharedAddress/reshare", c.reshareDKS, authentication.ValidatePermissions([]string{permissions.DKGWrite})).
+		AddParamPath("", params.ParamSharedAddress, params.DescriptionSharedAddress).
+		AddParamBody(mocker.Get(models.DKSharesPostRequest{}), "DKSharesPostRequest", "Request parameters, the peers and the threshold of the next committee", true).
+		AddResponse(http.StatusOK, "DK shares info of the next committee", mocker.Get(models.DKSharesInfo{}), nil).
+		SetSummary("Move the distributed key to another committee, the shared address stays the same").
+		SetOperationId("reshareDKS")
+
+	adminAPI.POST("node/dks/:sharedAddress/reshare/approve", c.approveReshareDKS, authentication.ValidatePermissions([]string{permissions.DKGWrite})).
+		AddParamPath("", params.ParamSharedAddress, params.DescriptionSharedAddress).
+		AddParamBody(mocker.Get(models.DKSharesPostRequest{}), "DKSharesPostRequest", "Request parameters, the peers and the threshold of the next committee, the timeout is the validity of the approval", true).
+		AddResponse(http.StatusOK, "The resharing is approved", nil, nil).
+		SetSummary("Allow this node to deal its key share, when the distributed key is reshared to the specified committee").
+		SetOperationId("approveReshareDKS")
+
+	adminAPI.GET("node/dks/:sharedAddress/next", c.getNextDKSInfo, authentication.ValidatePermissions([]string{permissions.DKGRead})).
+		AddParamPath("", params.ParamSharedAddress, params.DescriptionSharedAddress).
+		AddResponse(http.StatusNotFound, "No reshared key for the shared address", nil, nil).
+		AddResponse(http.StatusOK, "DK shares info", mocker.Get(models.DKSharesInfo{}), nil).
+		SetSummary("Get information about the reshared DKS configuration, which is not activated yet").
+		SetOperationId("getNextDKSInfo")
+
+	adminAPI.POST("node/dks/:sharedAddress/activate", c.activateNextDKS, authentication.ValidatePermissions([]string{permissions.DKGWrite})).
+		AddParamPath("", params.ParamSharedAddress, params.DescriptionSharedAddress).
+		AddResponse(http.StatusNotFound, "No reshared key for the shared address", nil, nil).
+		AddResponse(http.StatusOK, "DK shares info", mocker.Get(models.DKSharesInfo{}), nil).
+		SetSummary("Replace the DKS configuration of the shared address with the reshared one").
+		SetOperationId("activateNextDKS")
+
+	adminAPI.GET("node/peers/identity", c.getIdentity, authentication.ValidatePermissions([]string{permissions.PeeringRead})).
 		AddResponse(http.StatusOK, "This node peering identity", mocker.Get(models.PeeringNodeIdentityResponse{}), nil).
 		SetSummary("Get basic peer info of the current node").
 		SetOperationId("getPeeringIdentity")
 
-	adminAPI.GET("node/peers", c.getRegisteredPeers, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("node/peers", c.getRegisteredPeers, authentication.ValidatePermissions([]string{permissions.PeeringRead})).
 		AddResponse(http.StatusOK, "A list of all peers", mocker.Get([]models.PeeringNodeStatusResponse{}), nil).
 		SetSummary("Get basic information about all configured peers").
 		SetOperationId("getAllPeers")
 
-	adminAPI.POST("node/shutdown", c.shutdownNode, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("node/shutdown", c.shutdownNode, authentication.ValidatePermissions([]string{permissions.NodeWrite})).
 		AddResponse(http.StatusOK, "The node has been shut down", nil, nil).
 		SetSummary("Shut down the node").
 		SetOperationId("shutdownNode")
 
+	adminAPI.GET(routes.BackupChainPath, c.backupChain, authentication.ValidateNodePermissions([]string{permissions.NodeWrite})).
+		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
+		SetResponseContentType("application/octet-stream").
+		AddResponse(http.StatusNotFound, "Chain not found", nil, nil).
+		AddResponse(http.StatusOK, "The backup of the latest state of the chain anchored on L1, including the chain registry entries", []byte{}, nil).
+		SetSummary("Create an online backup of the chain").
+		SetOperationId("backupChain")
+
+	adminAPI.POST(routes.RestoreChainPath, c.restoreChain, authentication.ValidateNodePermissions([]string{permissions.NodeWrite})).
+		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
+		SetRequestContentType("application/octet-stream").
+		AddParamBody([]byte{}, "backup", "The backup of the chain", true).
+		AddResponse(http.StatusNotFound, "Chain not found", nil, nil).
+		AddResponse(http.StatusBadRequest, "Backup does not match the chain", nil, nil).
+		AddResponse(http.StatusOK, "The backup has been restored", mocker.Get(models.ChainRestoreResponse{}), nil).
+		SetSummary("Restore the backup of the chain and verify it against the state anchored on L1").
+		SetOperationId("restoreChain")
+
 	fakeConfigMap := make(map[string]interface{})
 	fakeConfigMap["app.checkForUpdates"] = true
 	fakeConfigMap["logger.level"] = "info"
 	fakeConfigMap["inx.maxConnectionAttempts"] = 30
 
-	adminAPI.GET("node/config", c.getConfiguration, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("node/config", c.getConfiguration, authentication.ValidatePermissions([]string{permissions.NodeRead})).
 		AddResponse(http.StatusOK, "Dumped configuration", fakeConfigMap, nil).
 		SetOperationId("getConfiguration").
 		SetSummary("Return the Wasp configuration")
@@ -1,17 +1,28 @@
 package node
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/labstack/echo/v4"
 
 	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/authentication"
+	"github.com/iotaledger/wasp/packages/tcrypto"
 	"github.com/iotaledger/wasp/packages/webapi/apierrors"
 	"github.com/iotaledger/wasp/packages/webapi/models"
 	"github.com/iotaledger/wasp/packages/webapi/params"
 )
 
+// initiatedBy is the name of the authenticated user, it is recorded in the DKG audit logs.
+func initiatedBy(e echo.Context) string {
+	if authContext, ok := e.Get("auth").(*authentication.AuthContext); ok {
+		return authContext.Name()
+	}
+	return ""
+}
+
 func (c *Controller) generateDKS(e echo.Context) error {
 	generateDKSRequest := models.DKSharesPostRequest{}
 
@@ -19,7 +30,7 @@ func (c *Controller) generateDKS(e echo.Context) error {
 		return apierrors.InvalidPropertyError("body", err)
 	}
 
-	sharesInfo, err := c.dkgService.GenerateDistributedKey(generateDKSRequest.PeerPubKeysOrNames, generateDKSRequest.Threshold, time.Duration(generateDKSRequest.TimeoutMS)*time.Millisecond)
+	sharesInfo, err := c.dkgService.GenerateDistributedKey(initiatedBy(e), generateDKSRequest.PeerPubKeysOrNames, generateDKSRequest.Threshold, time.Duration(generateDKSRequest.TimeoutMS)*time.Millisecond)
 	if err != nil {
 		panic(err)
 	}
@@ -40,3 +51,79 @@ func (c *Controller) getDKSInfo(e echo.Context) error {
 
 	return e.JSON(http.StatusOK, sharesInfo)
 }
+
+func (c *Controller) reshareDKS(e echo.Context) error {
+	_, sharedAddress, err := iotago.ParseBech32(e.Param(params.ParamSharedAddress))
+	if err != nil {
+		return apierrors.InvalidPropertyError(params.ParamSharedAddress, err)
+	}
+
+	reshareDKSRequest := models.DKSharesPostRequest{}
+	if err := e.Bind(&reshareDKSRequest); err != nil {
+		return apierrors.InvalidPropertyError("body", err)
+	}
+
+	sharesInfo, err := c.dkgService.ReshareDistributedKey(initiatedBy(e), sharedAddress, reshareDKSRequest.PeerPubKeysOrNames, reshareDKSRequest.Threshold, time.Duration(reshareDKSRequest.TimeoutMS)*time.Millisecond)
+	if err != nil {
+		panic(err)
+	}
+
+	return e.JSON(http.StatusOK, sharesInfo)
+}
+
+func (c *Controller) approveReshareDKS(e echo.Context) error {
+	_, sharedAddress, err := iotago.ParseBech32(e.Param(params.ParamSharedAddress))
+	if err != nil {
+		return apierrors.InvalidPropertyError(params.ParamSharedAddress, err)
+	}
+
+	approveRequest := models.DKSharesPostRequest{}
+	if err := e.Bind(&approveRequest); err != nil {
+		return apierrors.InvalidPropertyError("body", err)
+	}
+
+	err = c.dkgService.ApproveReshare(initiatedBy(e), sharedAddress, approveRequest.PeerPubKeysOrNames, approveRequest.Threshold, time.Duration(approveRequest.TimeoutMS)*time.Millisecond)
+	if err != nil {
+		panic(err)
+	}
+
+	return e.NoContent(http.StatusOK)
+}
+
+func (c *Controller) getNextDKSInfo(e echo.Context) error {
+	_, sharedAddress, err := iotago.ParseBech32(e.Param(params.ParamSharedAddress))
+	if err != nil {
+		return apierrors.InvalidPropertyError(params.ParamSharedAddress, err)
+	}
+
+	sharesInfo, err := c.dkgService.GetNextShares(sharedAddress)
+	if errors.Is(err, tcrypto.ErrDKShareNotFound) {
+		return apierrors.NoRecordFoundError(err)
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	return e.JSON(http.StatusOK, sharesInfo)
+}
+
+func (c *Controller) activateNextDKS(e echo.Context) error {
+	_, sharedAddress, err := iotago.ParseBech32(e.Param(params.ParamSharedAddress))
+	if err != nil {
+		return apierrors.InvalidPropertyError(params.ParamSharedAddress, err)
+	}
+
+	sharesInfo, err := c.dkgService.ActivateNextShares(sharedAddress)
+	if errors.Is(err, tcrypto.ErrDKShareNotFound) {
+		return apierrors.NoRecordFoundError(err)
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	return e.JSON(http.StatusOK, sharesInfo)
+}
+
+func (c *Controller) getDKGAuditLog(e echo.Context) error {
+	return e.JSON(http.StatusOK, c.dkgService.GetAuditLog())
+}
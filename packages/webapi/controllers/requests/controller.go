@@ -35,6 +35,7 @@ func (c *Controller) RegisterPublic(publicAPI echoswagger.ApiGroup, mocker inter
 			"Offledger request as JSON. Request encoded in Hex",
 			true).
 		AddResponse(http.StatusAccepted, "Request submitted", nil, nil).
+		AddResponse(http.StatusTooManyRequests, "Too many requests, retry after the number of seconds in the Retry-After header", nil, nil).
 		SetSummary("Post an off-ledger request").
 		SetOperationId("offLedger")
 }
@@ -1,7 +1,10 @@
 package requests
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 
@@ -10,6 +13,7 @@ import (
 	"github.com/iotaledger/wasp/packages/webapi/apierrors"
 	"github.com/iotaledger/wasp/packages/webapi/controllers/controllerutils"
 	"github.com/iotaledger/wasp/packages/webapi/models"
+	"github.com/iotaledger/wasp/packages/webapi/services"
 )
 
 func (c *Controller) handleOffLedgerRequest(e echo.Context) error {
@@ -36,7 +40,14 @@ func (c *Controller) handleOffLedgerRequest(e echo.Context) error {
 		return apierrors.InvalidPropertyError("Request", err)
 	}
 
-	err = c.offLedgerService.EnqueueOffLedgerRequest(chainID, requestDecoded)
+	err = c.offLedgerService.EnqueueOffLedgerRequest(chainID, requestDecoded, e.RealIP())
+	var rateLimitErr *services.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		if rateLimitErr.RetryAfter > 0 {
+			e.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(rateLimitErr.RetryAfter.Seconds()))))
+		}
+		return apierrors.TooManyRequestsError(err)
+	}
 	if err != nil {
 		return apierrors.ContractExecutionError(err) // TODO contract execution error? doesn't seem right...
 	}
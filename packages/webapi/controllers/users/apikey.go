@@ -0,0 +1,82 @@
+package users
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/wasp/packages/webapi/apierrors"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+	"github.com/iotaledger/wasp/packages/webapi/params"
+)
+
+func (c *Controller) getAPIKeys(e echo.Context) error {
+	userName := e.Param(params.ParamUsername)
+
+	if err := c.checkManageable(e, userName); err != nil {
+		return err
+	}
+
+	apiKeys, err := c.userService.GetAPIKeys(userName)
+	if err != nil {
+		return apierrors.UserNotFoundError(userName)
+	}
+
+	return e.JSON(http.StatusOK, apiKeys)
+}
+
+func (c *Controller) addAPIKey(e echo.Context) error {
+	userName := e.Param(params.ParamUsername)
+
+	if err := c.checkManageable(e, userName); err != nil {
+		return err
+	}
+
+	var addAPIKeyModel models.AddAPIKeyRequest
+
+	if err := e.Bind(&addAPIKeyModel); err != nil {
+		return apierrors.InvalidPropertyError("body", err)
+	}
+
+	if addAPIKeyModel.Name == "" {
+		return apierrors.InvalidPropertyError("name", errors.New("name is empty"))
+	}
+
+	if err := validatePermissions(addAPIKeyModel.Permissions); err != nil {
+		return apierrors.InvalidPropertyError("permissions", err)
+	}
+
+	// the key can be used by whoever creates it, so it can't carry more than the caller has
+	if err := checkGrantable(e, addAPIKeyModel.Permissions); err != nil {
+		return err
+	}
+
+	var expiresAt time.Time
+	if addAPIKeyModel.ExpiresInSeconds > 0 {
+		expiresAt = time.Now().Add(time.Duration(addAPIKeyModel.ExpiresInSeconds) * time.Second)
+	}
+
+	apiKey, err := c.userService.AddAPIKey(userName, addAPIKeyModel.Name, addAPIKeyModel.Permissions, expiresAt)
+	if err != nil {
+		return apierrors.InvalidPropertyError("body", err)
+	}
+
+	return e.JSON(http.StatusCreated, apiKey)
+}
+
+func (c *Controller) revokeAPIKey(e echo.Context) error {
+	userName := e.Param(params.ParamUsername)
+	apiKeyID := e.Param(params.ParamAPIKeyID)
+
+	if err := c.checkManageable(e, userName); err != nil {
+		return err
+	}
+
+	if err := c.userService.RevokeAPIKey(userName, apiKeyID); err != nil {
+		return apierrors.APIKeyNotFoundError(userName, apiKeyID)
+	}
+
+	return e.NoContent(http.StatusOK)
+}
@@ -7,12 +7,44 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/iotaledger/wasp/packages/authentication"
+	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
 	"github.com/iotaledger/wasp/packages/webapi/apierrors"
 	"github.com/iotaledger/wasp/packages/webapi/interfaces"
 	"github.com/iotaledger/wasp/packages/webapi/models"
 	"github.com/iotaledger/wasp/packages/webapi/params"
 )
 
+func validatePermissions(granted []string) error {
+	for _, permission := range granted {
+		if err := permissions.Validate(permission); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkGrantable rejects the permissions the caller does not hold, so that e.g.
+// a users-admin can't grant write to itself through another user.
+func checkGrantable(e echo.Context, granted []string) error {
+	authContext := e.Get("auth").(*authentication.AuthContext)
+	for _, permission := range granted {
+		if !authContext.CanGrant(permission) {
+			return apierrors.PermissionNotGrantableError(permission)
+		}
+	}
+	return nil
+}
+
+// checkManageable rejects changes to the users having permissions the caller does not hold,
+// otherwise the caller could take over such a user, e.g. by changing its password.
+func (c *Controller) checkManageable(e echo.Context, userName string) error {
+	user, err := c.userService.GetUser(userName)
+	if err != nil {
+		return apierrors.UserNotFoundError(userName)
+	}
+	return checkGrantable(e, user.Permissions)
+}
+
 func (c *Controller) addUser(e echo.Context) error {
 	var addUserModel models.AddUserRequest
 
@@ -20,6 +52,14 @@ func (c *Controller) addUser(e echo.Context) error {
 		return apierrors.InvalidPropertyError("body", err)
 	}
 
+	if err := validatePermissions(addUserModel.Permissions); err != nil {
+		return apierrors.InvalidPropertyError("permissions", err)
+	}
+
+	if err := checkGrantable(e, addUserModel.Permissions); err != nil {
+		return err
+	}
+
 	if err := c.userService.AddUser(addUserModel.Username, addUserModel.Password, addUserModel.Permissions); err != nil {
 		panic(err)
 	}
@@ -34,6 +74,10 @@ func (c *Controller) updateUserPassword(e echo.Context) error {
 		return apierrors.InvalidPropertyError(params.ParamUsername, errors.New("username is empty"))
 	}
 
+	if err := c.checkManageable(e, userName); err != nil {
+		return err
+	}
+
 	var updateUserPasswordModel models.UpdateUserPasswordRequest
 
 	if err := e.Bind(&updateUserPasswordModel); err != nil {
@@ -59,13 +103,24 @@ func (c *Controller) updateUserPermissions(e echo.Context) error {
 		return apierrors.InvalidPropertyError(params.ParamUsername, errors.New("username is empty"))
 	}
 
+	if err := c.checkManageable(e, userName); err != nil {
+		return err
+	}
+
 	var updateUserPermissionsModel models.UpdateUserPermissionsRequest
 
 	if err := e.Bind(&updateUserPermissionsModel); err != nil {
 		return apierrors.InvalidPropertyError("body", err)
 	}
 
-	// TODO: Later on, compare the permissions with the permission Wasp actually uses.
+	if err := validatePermissions(updateUserPermissionsModel.Permissions); err != nil {
+		return apierrors.InvalidPropertyError("permissions", err)
+	}
+
+	if err := checkGrantable(e, updateUserPermissionsModel.Permissions); err != nil {
+		return err
+	}
+
 	if err := c.userService.UpdateUserPermissions(userName, updateUserPermissionsModel.Permissions); err != nil {
 		return apierrors.UserNotFoundError(userName)
 	}
@@ -85,6 +140,10 @@ func (c *Controller) deleteUser(e echo.Context) error {
 		return apierrors.InvalidPropertyError(params.ParamUsername, errors.New("username is empty"))
 	}
 
+	if err := c.checkManageable(e, userName); err != nil {
+		return err
+	}
+
 	if err := c.userService.DeleteUser(userName); err != nil {
 		if errors.Is(err, interfaces.ErrCantDeleteLastUser) {
 			return apierrors.UserCanNotBeDeleted(userName, err.Error())
@@ -7,12 +7,24 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/collections"
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/kv/kvdecoder"
+	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 	"github.com/iotaledger/wasp/packages/webapi/common"
 )
 
-func GetControlAddresses(ch chain.Chain) (*isc.ControlAddresses, error) {
-	aliasOutputID, err := ch.LatestAliasOutput(chain.ConfirmedState)
+func GetControlAddresses(ch chain.Chain, blockIndexOrTrieRoot string) (*isc.ControlAddresses, error) {
+	var aliasOutputID *isc.AliasOutputWithID
+	var err error
+	if blockIndexOrTrieRoot == "" {
+		aliasOutputID, err = ch.LatestAliasOutput(chain.ConfirmedState)
+	} else {
+		var chainState state.State
+		chainState, err = common.ChainState(ch, blockIndexOrTrieRoot)
+		if err != nil {
+			return nil, err
+		}
+		aliasOutputID, err = common.AliasOutputOfBlock(ch, chainState.BlockIndex())
+	}
 	if err != nil {
 		return nil, err
 	}
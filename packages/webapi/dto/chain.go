@@ -1,7 +1,10 @@
 package dto
 
 import (
+	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/trie"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
 	"github.com/iotaledger/wasp/packages/vm/gas"
 )
@@ -28,6 +31,14 @@ type ChainInfo struct {
 	PublicURL    string
 
 	Metadata PublicChainMetadata
+	History  *StateHistory // nil, if the chain is not running on the node
+}
+
+// StateHistory is the range of the blocks, which states are available on the node
+type StateHistory struct {
+	ArchiveMode              bool
+	FirstAvailableBlockIndex uint32
+	LatestBlockIndex         uint32
 }
 
 func MapChainInfo(info *isc.ChainInfo, isActive bool) *ChainInfo {
@@ -49,3 +60,13 @@ func MapChainInfo(info *isc.ChainInfo, isActive bool) *ChainInfo {
 
 	return chainInfo
 }
+
+// StateProof is a Merkle proof of a state key, anchored in an alias output on L1
+type StateProof struct {
+	BlockIndex    uint32
+	Key           []byte
+	Value         []byte // nil, if it is a proof of absence
+	Proof         *trie.MerkleProof
+	L1Commitment  *state.L1Commitment
+	AliasOutputID iotago.OutputID
+}
@@ -1,6 +1,11 @@
 package dto
 
-import iotago "github.com/iotaledger/iota.go/v3"
+import (
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/tcrypto"
+)
 
 type ChainNodeStatus struct {
 	AccessAPI    string
@@ -15,3 +20,12 @@ type ChainNodeInfo struct {
 	CandidateNodes []*ChainNodeStatus
 	CommitteeNodes []*ChainNodeStatus
 }
+
+type ChainRestoreResult struct {
+	ChainID          isc.ChainID
+	StateIndex       uint32
+	L1Commitment     *state.L1Commitment
+	L1StateIndex     uint32
+	UpToDate         bool
+	RestoredDKShares []tcrypto.DKShare
+}
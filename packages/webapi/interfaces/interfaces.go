@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"time"
 
@@ -10,7 +11,9 @@ import (
 	"github.com/pangpanglabs/echoswagger/v2"
 
 	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/chainbackup"
 	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/eventindex"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/registry"
 	"github.com/iotaledger/wasp/packages/webapi/dto"
@@ -20,6 +23,8 @@ import (
 var (
 	ErrChainNotFound      = errors.New("chain not found")
 	ErrCantDeleteLastUser = errors.New("you can't delete the last user")
+	ErrProofNotAvailable  = errors.New("proof not available")
+	ErrEventIndexDisabled = errors.New("the event index is not enabled on this node")
 )
 
 type APIController interface {
@@ -38,7 +43,9 @@ type ChainService interface {
 	GetChainInfoByChainID(chainID isc.ChainID, blockIndexOrTrieRoot string) (*dto.ChainInfo, error)
 	GetContracts(chainID isc.ChainID, blockIndexOrTrieRoot string) (dto.ContractsMap, error)
 	GetEVMChainID(chainID isc.ChainID, blockIndexOrTrieRoot string) (uint16, error)
-	GetState(chainID isc.ChainID, stateKey []byte) (state []byte, err error)
+	GetState(chainID isc.ChainID, stateKey []byte, blockIndexOrTrieRoot string) (state []byte, err error)
+	GetStateProof(chainID isc.ChainID, blockIndex uint32, stateKey []byte) (*dto.StateProof, error)
+	GetReceiptProof(chainID isc.ChainID, blockIndex uint32, requestID isc.RequestID) (*dto.StateProof, error)
 	WaitForRequestProcessed(ctx context.Context, chainID isc.ChainID, requestID isc.RequestID, waitForL1Confirmation bool, timeout time.Duration) (*isc.Receipt, error)
 }
 
@@ -47,6 +54,12 @@ type EVMService interface {
 	HandleWebsocket(ctx context.Context, chainID isc.ChainID, echoCtx echo.Context) error
 }
 
+type EventIndexService interface {
+	GetEvents(chainID isc.ChainID, query *eventindex.Query) (*eventindex.Page, error)
+	// Close stops the indexing and closes the indexes of the chains
+	Close()
+}
+
 type MetricsService interface {
 	GetNodeMessageMetrics() *dto.NodeMessageMetrics
 	GetChainMessageMetrics(chainID isc.ChainID) *dto.ChainMessageMetrics
@@ -64,6 +77,12 @@ type NodeService interface {
 	ShutdownNode()
 }
 
+type BackupService interface {
+	PrepareChainBackup(chainID isc.ChainID) (*chainbackup.Backup, error)
+	WriteChainBackup(backup *chainbackup.Backup, w io.Writer) error
+	RestoreChain(chainID isc.ChainID, r io.Reader) (*dto.ChainRestoreResult, error)
+}
+
 type RegistryService interface {
 	GetChainRecordByChainID(chainID isc.ChainID) (*registry.ChainRecord, error)
 }
@@ -83,7 +102,7 @@ type PeeringService interface {
 }
 
 type OffLedgerService interface {
-	EnqueueOffLedgerRequest(chainID isc.ChainID, request []byte) error
+	EnqueueOffLedgerRequest(chainID isc.ChainID, request []byte, remoteIP string) error
 	ParseRequest(payload []byte) (isc.OffLedgerRequest, error)
 }
 
@@ -94,6 +113,9 @@ type UserService interface {
 	GetUsers() []*models.User
 	UpdateUserPassword(username string, password string) error
 	UpdateUserPermissions(username string, permissions []string) error
+	AddAPIKey(username string, name string, permissions []string, expiresAt time.Time) (*models.AddAPIKeyResponse, error)
+	GetAPIKeys(username string) ([]*models.APIKey, error)
+	RevokeAPIKey(username string, apiKeyID string) error
 }
 
 type Mocker interface {
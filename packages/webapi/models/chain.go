@@ -3,6 +3,8 @@ package models
 import (
 	"net/url"
 
+	iotago "github.com/iotaledger/iota.go/v3"
+
 	"github.com/iotaledger/wasp/packages/vm/gas"
 	"github.com/iotaledger/wasp/packages/webapi/dto"
 	"github.com/iotaledger/wasp/packages/webapi/routes"
@@ -71,12 +73,39 @@ type ChainInfoResponse struct {
 	GasLimits    *gas.Limits         `json:"gasLimits" swagger:"desc(The gas limits),required"`
 	PublicURL    string              `json:"publicURL" swagger:"desc(The fully qualified public url leading to the chains metadata),required"`
 	Metadata     PublicChainMetadata `json:"metadata" swagger:"desc(The metadata of the chain),required"`
+	History      *StateHistory       `json:"history" swagger:"desc(The range of the blocks, which states are available on the node; missing if the chain is not running)"`
+}
+
+type StateHistory struct {
+	ArchiveMode              bool   `json:"archiveMode" swagger:"desc(Whether the node keeps the states of all the blocks),required"`
+	FirstAvailableBlockIndex uint32 `json:"firstAvailableBlockIndex" swagger:"desc(The oldest block, from which the states of all the blocks are available),required,min(0)"`
+	LatestBlockIndex         uint32 `json:"latestBlockIndex" swagger:"desc(The latest block),required,min(0)"`
 }
 
 type StateResponse struct {
 	State string `json:"state" swagger:"desc(The state of the requested key (Hex-encoded)),required"`
 }
 
+type StateProofResponse struct {
+	BlockIndex    uint32 `json:"blockIndex" swagger:"desc(The index of the block, in which state the proof is made),required,min(0)"`
+	Key           string `json:"key" swagger:"desc(The state key (Hex-encoded)),required"`
+	Value         string `json:"value" swagger:"desc(The value of the key, empty if the key is not in the state (Hex-encoded)),required"`
+	Proof         string `json:"proof" swagger:"desc(The Merkle proof of the key (Hex-encoded)),required"`
+	L1Commitment  string `json:"l1Commitment" swagger:"desc(The L1 commitment of the block (Hex-encoded)),required"`
+	AliasOutputID string `json:"aliasOutputId" swagger:"desc(The ID of the alias output, which anchors the block on L1 (Hex-encoded)),required"`
+}
+
+func MapStateProofResponse(proof *dto.StateProof) StateProofResponse {
+	return StateProofResponse{
+		BlockIndex:    proof.BlockIndex,
+		Key:           iotago.EncodeHex(proof.Key),
+		Value:         iotago.EncodeHex(proof.Value),
+		Proof:         iotago.EncodeHex(proof.Proof.Bytes()),
+		L1Commitment:  iotago.EncodeHex(proof.L1Commitment.Bytes()),
+		AliasOutputID: proof.AliasOutputID.ToHex(),
+	}
+}
+
 func mapMetadataUrls(response *ChainInfoResponse) {
 	if response.PublicURL == "" {
 		return
@@ -114,6 +143,14 @@ func MapChainInfoResponse(chainInfo *dto.ChainInfo, evmChainID uint16) ChainInfo
 		},
 	}
 
+	if chainInfo.History != nil {
+		chainInfoResponse.History = &StateHistory{
+			ArchiveMode:              chainInfo.History.ArchiveMode,
+			FirstAvailableBlockIndex: chainInfo.History.FirstAvailableBlockIndex,
+			LatestBlockIndex:         chainInfo.History.LatestBlockIndex,
+		}
+	}
+
 	if chainInfo.ChainOwnerID != nil {
 		chainInfoResponse.ChainOwnerID = chainInfo.ChainOwnerID.String()
 	}
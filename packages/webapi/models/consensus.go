@@ -0,0 +1,161 @@
+package models
+
+import (
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/chain/cmt_log"
+	"github.com/iotaledger/wasp/packages/isc"
+)
+
+type ConsensusStatusResponse struct {
+	Committees []*CommitteeConsensusStatus `json:"committees" swagger:"desc(The committees of the chain, this node is a member of. The active one goes first.),required"`
+}
+
+type CommitteeConsensusStatus struct {
+	Address   string                     `json:"address" swagger:"desc(The committee address (Bech32).),required"`
+	Active    bool                       `json:"active" swagger:"desc(Whether or not it is the latest active committee.),required"`
+	CmtLog    *CmtLogStatus              `json:"cmtLog,omitempty" swagger:"desc(The committee log, as maintained by this node. Missing if there is no log for the committee.)"`
+	Peers     []*ConsensusPeerStatus     `json:"peers" swagger:"desc(The liveness of the committee members, as seen by this node.),required"`
+	Instances []*ConsensusInstanceStatus `json:"instances" swagger:"desc(The consensus instances running on this node, ordered by the log index.),required"`
+}
+
+type CmtLogStatus struct {
+	MinLogIndex    uint32           `json:"minLogIndex" swagger:"desc(The minimal log index this node can participate in.),required"`
+	AgreedLogIndex uint32           `json:"agreedLogIndex" swagger:"desc(The log index agreed by the committee, 0 if none.),required"`
+	OutputLogIndex uint32           `json:"outputLogIndex" swagger:"desc(The log index of the consensus to run now, 0 if none.),required"`
+	LocalView      *LocalViewStatus `json:"localView" swagger:"desc(The alias outputs tracked by this node.),required"`
+}
+
+type LocalViewStatus struct {
+	ConfirmedAliasOutputID string                `json:"confirmedAliasOutputId" swagger:"desc(The latest alias output confirmed by L1, empty if unclear. (Hex)),required"`
+	TipAliasOutputID       string                `json:"tipAliasOutputId" swagger:"desc(The alias output to build the next block on, empty if the node has to wait. (Hex)),required"`
+	Pending                []*LocalViewEntryInfo `json:"pending" swagger:"desc(The alias outputs produced by the committee, but not confirmed yet.),required"`
+}
+
+type LocalViewEntryInfo struct {
+	AliasOutputID         string `json:"aliasOutputId" swagger:"desc(The alias output published. (Hex)),required"`
+	StateIndex            uint32 `json:"stateIndex" swagger:"desc(The state index of the alias output.),required"`
+	ConsumedAliasOutputID string `json:"consumedAliasOutputId" swagger:"desc(The alias output consumed by the transaction. (Hex)),required"`
+	Rejected              bool   `json:"rejected" swagger:"desc(Whether or not the alias output was rejected.),required"`
+	LogIndex              uint32 `json:"logIndex" swagger:"desc(The log index of the consensus that produced the output, 0 if unknown.),required"`
+}
+
+type ConsensusPeerStatus struct {
+	Index       uint16     `json:"index" swagger:"desc(The index of the peer in the committee.),required"`
+	PublicKey   string     `json:"publicKey" swagger:"desc(The public key of the peer. (Hex)),required"`
+	PeeringURL  string     `json:"peeringURL" swagger:"desc(The peering URL of the peer, empty if unknown.),required"`
+	Connected   bool       `json:"connected" swagger:"desc(Whether or not the peering connection is alive.),required"`
+	LogIndex    uint32     `json:"logIndex" swagger:"desc(The highest log index proposed by the peer, 0 if nothing was received from it.),required"`
+	LastMessage *time.Time `json:"lastMessage,omitempty" swagger:"desc(The time of the last consensus message received from the peer. Missing if none.)"`
+}
+
+type ConsensusInstanceStatus struct {
+	LogIndex          uint32 `json:"logIndex" swagger:"desc(The log index of the instance.),required"`
+	BaseAliasOutputID string `json:"baseAliasOutputId" swagger:"desc(The alias output proposed as an input, empty if the input is not received yet. (Hex)),required"`
+	Responded         bool   `json:"responded" swagger:"desc(Whether or not the instance responded. The fields below are empty otherwise.),required"`
+	InputReceived     bool   `json:"inputReceived" swagger:"desc(Whether or not the instance was started by the chain.),required"`
+	OutputReady       bool   `json:"outputReady" swagger:"desc(Whether or not the instance has provided its output to the chain.),required"`
+	Output            string `json:"output" swagger:"desc(The output status: Running, Completed or Skipped.),required"`
+	Terminated        bool   `json:"terminated" swagger:"desc(Whether or not the instance can be terminated.),required"`
+	SyncACS           string `json:"syncACS" swagger:"desc(The state of the asynchronous common subset step.),required"`
+	SyncDSS           string `json:"syncDSS" swagger:"desc(The state of the distributed signature step.),required"`
+	SyncMP            string `json:"syncMP" swagger:"desc(The state of the mempool step.),required"`
+	SyncSM            string `json:"syncSM" swagger:"desc(The state of the state manager step.),required"`
+	SyncVM            string `json:"syncVM" swagger:"desc(The state of the VM step.),required"`
+	SyncTX            string `json:"syncTX" swagger:"desc(The state of the transaction building step.),required"`
+}
+
+func MapConsensusStatusResponse(status *chain.ConsensusStatus, bech32HRP iotago.NetworkPrefix) *ConsensusStatusResponse {
+	ret := &ConsensusStatusResponse{
+		Committees: make([]*CommitteeConsensusStatus, len(status.Committees)),
+	}
+	for i, cmt := range status.Committees {
+		ret.Committees[i] = mapCommitteeConsensusStatus(cmt, bech32HRP)
+	}
+	return ret
+}
+
+func mapCommitteeConsensusStatus(cmt *chain.CommitteeConsensusStatus, bech32HRP iotago.NetworkPrefix) *CommitteeConsensusStatus {
+	ret := &CommitteeConsensusStatus{
+		Address:   cmt.Address.Bech32(bech32HRP),
+		Active:    cmt.Active,
+		Peers:     make([]*ConsensusPeerStatus, len(cmt.Peers)),
+		Instances: make([]*ConsensusInstanceStatus, len(cmt.Instances)),
+	}
+	if cmt.CmtLog != nil {
+		ret.CmtLog = mapCmtLogStatus(cmt.CmtLog)
+	}
+	for i, peer := range cmt.Peers {
+		ret.Peers[i] = &ConsensusPeerStatus{
+			Index:      peer.Index,
+			PublicKey:  peer.PubKey.String(),
+			PeeringURL: peer.PeeringURL,
+			Connected:  peer.Connected,
+			LogIndex:   peer.LogIndex.AsUint32(),
+		}
+		if !peer.LastMessage.IsZero() {
+			lastMessage := peer.LastMessage
+			ret.Peers[i].LastMessage = &lastMessage
+		}
+	}
+	for i, inst := range cmt.Instances {
+		ret.Instances[i] = mapConsensusInstanceStatus(inst)
+	}
+	return ret
+}
+
+func mapCmtLogStatus(cls *cmt_log.Status) *CmtLogStatus {
+	ret := &CmtLogStatus{
+		MinLogIndex:    cls.MinLogIndex.AsUint32(),
+		AgreedLogIndex: cls.AgreedLogIndex.AsUint32(),
+		LocalView: &LocalViewStatus{
+			ConfirmedAliasOutputID: aliasOutputIDOrEmpty(cls.LocalView.Confirmed),
+			TipAliasOutputID:       aliasOutputIDOrEmpty(cls.LocalView.Tip),
+			Pending:                make([]*LocalViewEntryInfo, len(cls.LocalView.Pending)),
+		},
+	}
+	if cls.Output != nil {
+		ret.OutputLogIndex = cls.Output.GetLogIndex().AsUint32()
+	}
+	for i, entry := range cls.LocalView.Pending {
+		ret.LocalView.Pending[i] = &LocalViewEntryInfo{
+			AliasOutputID:         entry.Output.OutputID().ToHex(),
+			StateIndex:            entry.Output.GetStateIndex(),
+			ConsumedAliasOutputID: entry.Consumed.ToHex(),
+			Rejected:              entry.Rejected,
+			LogIndex:              entry.LogIndex.AsUint32(),
+		}
+	}
+	return ret
+}
+
+func mapConsensusInstanceStatus(inst *chain.ConsensusInstanceStatus) *ConsensusInstanceStatus {
+	ret := &ConsensusInstanceStatus{
+		LogIndex:          inst.LogIndex.AsUint32(),
+		BaseAliasOutputID: aliasOutputIDOrEmpty(inst.BaseAliasOutput),
+	}
+	if inst.Status == nil {
+		return ret
+	}
+	ret.Responded = true
+	ret.InputReceived = inst.Status.InputReceived
+	ret.OutputReady = inst.Status.OutputReady
+	ret.Output = inst.Status.Cons.Output.String()
+	ret.Terminated = inst.Status.Cons.Terminated
+	ret.SyncACS = inst.Status.Cons.SyncACS
+	ret.SyncDSS = inst.Status.Cons.SyncDSS
+	ret.SyncMP = inst.Status.Cons.SyncMP
+	ret.SyncSM = inst.Status.Cons.SyncSM
+	ret.SyncVM = inst.Status.Cons.SyncVM
+	ret.SyncTX = inst.Status.Cons.SyncTX
+	return ret
+}
+
+func aliasOutputIDOrEmpty(ao *isc.AliasOutputWithID) string {
+	if ao == nil {
+		return ""
+	}
+	return ao.OutputID().ToHex()
+}
@@ -1,5 +1,12 @@
 package models
 
+import (
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/registry"
+)
+
 // DKSharesPostRequest is a POST request for creating new DKShare.
 type DKSharesPostRequest struct {
 	PeerPubKeysOrNames []string `json:"peerIdentities" swagger:"desc(Names or hex encoded public keys of trusted peers to run DKG on.),required"`
@@ -16,3 +23,40 @@ type DKSharesInfo struct {
 	PublicKeyShares []string `json:"publicKeyShares" swagger:"desc(Public key shares for all the peers. (Hex)),required"`
 	Threshold       uint16   `json:"threshold" swagger:"required,min(1)"`
 }
+
+// DKGAuditEntry is a record of a DKG procedure (a key generation or a resharing) this node took part in.
+type DKGAuditEntry struct {
+	Time          time.Time `json:"time" swagger:"desc(The time the procedure was completed or refused.),required"`
+	Procedure     string    `json:"procedure" swagger:"desc(The kind of the procedure: generate, reshare or reshare-approval.),required"`
+	Role          string    `json:"role" swagger:"desc(The role of this node in the procedure: initiator or participant.),required"`
+	ProcedureID   string    `json:"procedureId" swagger:"desc(The identifier of the procedure.),required"`
+	Initiator     string    `json:"initiator" swagger:"desc(The public key of the initiator node. (Hex)),required"`
+	InitiatedBy   string    `json:"initiatedBy" swagger:"desc(The user, that requested the procedure or the approval on this node. Empty if the procedure was initiated by another node.),required"`
+	Peers         []string  `json:"peers" swagger:"desc(The public keys of the nodes sharing the key. (Hex)),required"`
+	Threshold     uint16    `json:"threshold" swagger:"required"`
+	SharedAddress string    `json:"sharedAddress" swagger:"desc(The shared address produced or reshared by the procedure. Empty if failed before.),required"`
+	Error         string    `json:"error" swagger:"desc(The reason of the failure. Empty if the procedure succeeded.),required"`
+}
+
+func MapDKGAuditEntry(entry *registry.DKGAuditEntry, bech32HRP iotago.NetworkPrefix) *DKGAuditEntry {
+	ret := &DKGAuditEntry{
+		Time:        entry.Time,
+		Procedure:   entry.Procedure,
+		Role:        entry.Role,
+		ProcedureID: entry.ProcedureID,
+		InitiatedBy: entry.InitiatedBy,
+		Peers:       make([]string, len(entry.Peers)),
+		Threshold:   entry.Threshold,
+		Error:       entry.Error,
+	}
+	if entry.Initiator != nil {
+		ret.Initiator = entry.Initiator.String()
+	}
+	for i := range entry.Peers {
+		ret.Peers[i] = entry.Peers[i].String()
+	}
+	if entry.SharedAddress != nil {
+		ret.SharedAddress = entry.SharedAddress.Bech32(bech32HRP)
+	}
+	return ret
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/eventindex"
+	"github.com/iotaledger/wasp/packages/isc"
+)
+
+type IndexedEventJSON struct {
+	ContractID   isc.Hname `json:"contractID" swagger:"desc(ID of the Contract that issued the event),required,min(1)"`
+	Topic        string    `json:"topic" swagger:"desc(topic),required"`
+	Timestamp    uint64    `json:"timestamp" swagger:"desc(timestamp),required"`
+	Payload      string    `json:"payload" swagger:"desc(payload),required"`
+	BlockIndex   uint32    `json:"blockIndex" swagger:"desc(The index of the block, in which the event was issued),required,min(0)"`
+	RequestIndex uint16    `json:"requestIndex" swagger:"desc(The index of the request in the block),required,min(0)"`
+	EventIndex   uint16    `json:"eventIndex" swagger:"desc(The index of the event in the request),required,min(0)"`
+	Sender       string    `json:"sender" swagger:"desc(The sender of the request, which issued the event),required"`
+}
+
+type IndexedEventsResponse struct {
+	Events           []*IndexedEventJSON `json:"events" swagger:"required"`
+	NextCursor       string              `json:"nextCursor" swagger:"desc(The cursor of the next page, empty if there are no more events (Hex-encoded)),required"`
+	LastIndexedBlock *uint32             `json:"lastIndexedBlock,omitempty" swagger:"desc(The last block, which events were indexed)"`
+}
+
+func MapIndexedEventsResponse(page *eventindex.Page) *IndexedEventsResponse {
+	events := make([]*IndexedEventJSON, len(page.Events))
+	for i, e := range page.Events {
+		sender := ""
+		if e.Sender != nil {
+			sender = e.Sender.String()
+		}
+		events[i] = &IndexedEventJSON{
+			ContractID:   e.Event.ContractID,
+			Topic:        e.Event.Topic,
+			Timestamp:    e.Event.Timestamp,
+			Payload:      iotago.EncodeHex(e.Event.Payload),
+			BlockIndex:   e.BlockIndex,
+			RequestIndex: e.RequestIndex,
+			EventIndex:   e.EventIndex,
+			Sender:       sender,
+		}
+	}
+
+	nextCursor := ""
+	if page.NextCursor != nil {
+		nextCursor = iotago.EncodeHex(page.NextCursor)
+	}
+
+	return &IndexedEventsResponse{
+		Events:           events,
+		NextCursor:       nextCursor,
+		LastIndexedBlock: page.LastIndexedBlock,
+	}
+}
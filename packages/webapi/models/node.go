@@ -3,6 +3,7 @@ package models
 import (
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/webapi/dto"
 )
 
 type NodeOwnerCertificateResponse struct {
@@ -81,3 +82,28 @@ type InfoResponse struct {
 	PeeringURL string    `json:"peeringURL" swagger:"desc(The net id of the node),required"`
 	L1Params   *L1Params `json:"l1Params" swagger:"desc(The L1 parameters),required"`
 }
+
+type ChainRestoreResponse struct {
+	ChainID          string   `json:"chainId" swagger:"desc(ChainID (Bech32-encoded)),required"`
+	StateIndex       uint32   `json:"stateIndex" swagger:"desc(The state index of the restored backup),required,min(1)"`
+	L1Commitment     string   `json:"l1Commitment" swagger:"desc(The L1 commitment of the restored state (Hex)),required"`
+	L1StateIndex     uint32   `json:"l1StateIndex" swagger:"desc(The index of the latest state anchored on L1),required,min(1)"`
+	UpToDate         bool     `json:"upToDate" swagger:"desc(Whether the restored state is the latest state anchored on L1; otherwise the node fetches the newer blocks from its peers),required"`
+	RestoredDKShares []string `json:"restoredDKShares" swagger:"desc(The addresses of the DK shares restored from the backup (Bech32)),required"`
+}
+
+func MapChainRestoreResponse(result *dto.ChainRestoreResult) *ChainRestoreResponse {
+	restoredDKShares := make([]string, len(result.RestoredDKShares))
+	for i, dkShare := range result.RestoredDKShares {
+		restoredDKShares[i] = dkShare.GetAddress().Bech32(parameters.L1().Protocol.Bech32HRP)
+	}
+
+	return &ChainRestoreResponse{
+		ChainID:          result.ChainID.String(),
+		StateIndex:       result.StateIndex,
+		L1Commitment:     iotago.EncodeHex(result.L1Commitment.Bytes()),
+		L1StateIndex:     result.L1StateIndex,
+		UpToDate:         result.UpToDate,
+		RestoredDKShares: restoredDKShares,
+	}
+}
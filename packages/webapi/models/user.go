@@ -1,14 +1,16 @@
 package models
 
+import "time"
+
 type User struct {
 	Username    string   `json:"username" swagger:"required"`
-	Permissions []string `json:"permissions" swagger:"required"`
+	Permissions []string `json:"permissions" swagger:"desc(The roles of the user, optionally restricted to a chain (role@chainID)),required"`
 }
 
 type AddUserRequest struct {
 	Username    string   `json:"username" swagger:"required"`
 	Password    string   `json:"password" swagger:"required"`
-	Permissions []string `json:"permissions" swagger:"required"`
+	Permissions []string `json:"permissions" swagger:"desc(The roles of the user, optionally restricted to a chain (role@chainID)),required"`
 }
 
 type UpdateUserPasswordRequest struct {
@@ -16,5 +18,25 @@ type UpdateUserPasswordRequest struct {
 }
 
 type UpdateUserPermissionsRequest struct {
-	Permissions []string `json:"permissions" swagger:"required"`
+	Permissions []string `json:"permissions" swagger:"desc(The roles of the user, optionally restricted to a chain (role@chainID)),required"`
+}
+
+type APIKey struct {
+	ID          string    `json:"id" swagger:"desc(The ID of the API key),required"`
+	Name        string    `json:"name" swagger:"desc(The name of the API key),required"`
+	Permissions []string  `json:"permissions" swagger:"desc(The roles granted by the API key),required"`
+	CreatedAt   time.Time `json:"createdAt" swagger:"desc(The creation time of the API key),required"`
+	ExpiresAt   time.Time `json:"expiresAt" swagger:"desc(The expiry time of the API key, zero if it doesn't expire),required"`
+	LastUsedAt  time.Time `json:"lastUsedAt" swagger:"desc(The last time the API key was used (with a precision of one minute), zero if it was never used),required"`
+}
+
+type AddAPIKeyRequest struct {
+	Name             string   `json:"name" swagger:"desc(The name of the API key, unique per user),required"`
+	Permissions      []string `json:"permissions" swagger:"desc(The roles granted by the API key, a subset of the roles of the user),required"`
+	ExpiresInSeconds uint64   `json:"expiresInSeconds" swagger:"desc(The lifetime of the API key in seconds, 0 if the key doesn't expire)"`
+}
+
+type AddAPIKeyResponse struct {
+	ID    string `json:"id" swagger:"desc(The ID of the API key),required"`
+	Token string `json:"token" swagger:"desc(The token to send as bearer token. It is not stored by the node and can't be retrieved later on.),required"`
 }
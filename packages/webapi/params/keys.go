@@ -1,6 +1,7 @@
 package params
 
 const (
+	ParamAPIKeyID             = "apiKeyID"
 	ParamAgentID              = "agentID"
 	ParamBlobHash             = "blobHash"
 	ParamBlockIndex           = "blockIndex"
@@ -16,11 +17,19 @@ const (
 	ParamTxHash               = "txHash"
 	ParamUsername             = "username"
 	ParamBlockIndexOrTrieRoot = "block"
+	ParamTopic                = "topic"
+	ParamSender               = "sender"
+	ParamFromBlock            = "fromBlock"
+	ParamToBlock              = "toBlock"
+	ParamCursor               = "cursor"
+	ParamLimit                = "limit"
 )
 
 const (
+	DescriptionAPIKeyID             = "The ID of the API key"
 	DescriptionAgentID              = "AgentID (Bech32 for WasmVM | Hex for EVM)"
 	DescriptionBlobHash             = "BlobHash (Hex)"
+	DescriptionBlockIndex           = "BlockIndex (uint32)"
 	DescriptionChainID              = "ChainID (Bech32)"
 	DescriptionContractHName        = "The contract hname (Hex)"
 	DescriptionFieldKey             = "FieldKey (String)"
@@ -32,4 +41,10 @@ const (
 	DescriptionTxHash               = "Transaction hash (Hex)"
 	DescriptionUsername             = "The username"
 	DescriptionBlockIndexOrTrieRoot = "Block index or trie root"
+	DescriptionTopic                = "The topic of the event"
+	DescriptionSender               = "The sender of the request, which issued the event (AgentID)"
+	DescriptionFromBlock            = "The first block of the range (uint32)"
+	DescriptionToBlock              = "The last block of the range (uint32), the latest block if omitted"
+	DescriptionCursor               = "The cursor returned with the previous page (Hex)"
+	DescriptionLimit                = "The maximum number of events returned, between 1 and 1000 (default: 100)"
 )
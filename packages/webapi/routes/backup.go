@@ -0,0 +1,15 @@
+package routes
+
+import "strings"
+
+// The chain backups are transferred in the bodies of these routes, so they are exempt from
+// the global limits of the body length and of the request duration, and have their own ones.
+const (
+	BackupChainPath  = "node/backup/:chainID"
+	RestoreChainPath = "node/restore/:chainID"
+)
+
+// IsBackupPath returns true, if the route path is one of the routes transferring the chain backups.
+func IsBackupPath(path string) bool {
+	return strings.HasSuffix(path, "/"+BackupChainPath) || strings.HasSuffix(path, "/"+RestoreChainPath)
+}
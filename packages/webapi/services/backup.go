@@ -0,0 +1,170 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/chainbackup"
+	"github.com/iotaledger/wasp/packages/chains"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/registry"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/tcrypto"
+	"github.com/iotaledger/wasp/packages/transaction"
+	"github.com/iotaledger/wasp/packages/webapi/dto"
+	"github.com/iotaledger/wasp/packages/webapi/interfaces"
+)
+
+var ErrBackupVerificationFailed = errors.New("backup verification failed")
+
+type BackupService struct {
+	chainsProvider              chains.Provider
+	chainRecordRegistryProvider registry.ChainRecordRegistryProvider
+	dkShareRegistryProvider     registry.DKShareRegistryProvider
+	nodeIdentityProvider        registry.NodeIdentityProvider
+	keyEncryption               *registry.KeyEncryption
+}
+
+// NewBackupService creates the backup service. The DK shares are included in
+// the backups only if the keyEncryption is set, they are sealed with it.
+func NewBackupService(
+	chainsProvider chains.Provider,
+	chainRecordRegistryProvider registry.ChainRecordRegistryProvider,
+	dkShareRegistryProvider registry.DKShareRegistryProvider,
+	nodeIdentityProvider registry.NodeIdentityProvider,
+	keyEncryption *registry.KeyEncryption,
+) interfaces.BackupService {
+	return &BackupService{
+		chainsProvider:              chainsProvider,
+		chainRecordRegistryProvider: chainRecordRegistryProvider,
+		dkShareRegistryProvider:     dkShareRegistryProvider,
+		nodeIdentityProvider:        nodeIdentityProvider,
+		keyEncryption:               keyEncryption,
+	}
+}
+
+// latestAnchoredState returns the latest alias output of the chain confirmed on L1
+// and the commitment to the state it anchors.
+func latestAnchoredState(ch chain.Chain) (*isc.AliasOutputWithID, *state.L1Commitment, error) {
+	aliasOutput, err := ch.LatestAliasOutput(chain.ConfirmedState)
+	if err != nil {
+		return nil, nil, err
+	}
+	if aliasOutput == nil {
+		return nil, nil, errors.New("the chain has not received its state from L1 yet")
+	}
+	l1Commitment, err := transaction.L1CommitmentFromAliasOutput(aliasOutput.GetAliasOutput())
+	if err != nil {
+		return nil, nil, err
+	}
+	return aliasOutput, l1Commitment, nil
+}
+
+// PrepareChainBackup collects everything that is needed to back up the latest
+// state of the chain confirmed on L1. The chain keeps running meanwhile: the
+// trie of the state is immutable, so the snapshot taken later is consistent.
+// The DK shares are left out, if the key encryption of the node is disabled.
+func (b *BackupService) PrepareChainBackup(chainID isc.ChainID) (*chainbackup.Backup, error) {
+	ch, err := b.chainsProvider().Get(chainID)
+	if err != nil {
+		return nil, err
+	}
+	aliasOutput, l1Commitment, err := latestAnchoredState(ch)
+	if err != nil {
+		return nil, err
+	}
+	block, err := ch.Store().BlockByTrieRoot(l1Commitment.TrieRoot())
+	if err != nil {
+		return nil, fmt.Errorf("the state %v anchored on L1 is not available in the store: %w", aliasOutput.GetStateIndex(), err)
+	}
+	chainRecord, err := b.chainRecordRegistryProvider.ChainRecord(chainID)
+	if err != nil {
+		return nil, err
+	}
+	dkShares := make([]tcrypto.DKShare, 0, 1)
+	if b.keyEncryption != nil {
+		dkShare, err := b.dkShareRegistryProvider.LoadDKShare(aliasOutput.GetStateAddress())
+		switch {
+		case err == nil:
+			dkShares = append(dkShares, dkShare)
+		case !errors.Is(err, tcrypto.ErrDKShareNotFound):
+			return nil, err
+		}
+	}
+	return chainbackup.NewBackup(chainID, block, chainRecord, dkShares), nil
+}
+
+func (b *BackupService) WriteChainBackup(backup *chainbackup.Backup, w io.Writer) error {
+	ch, err := b.chainsProvider().Get(backup.Manifest.ChainID)
+	if err != nil {
+		return err
+	}
+	return chainbackup.Write(w, backup, ch.Store(), b.keyEncryption)
+}
+
+// RestoreChain restores the backup of the chain to the running node. The state
+// of the backup must be the latest state anchored on L1, or an older state
+// linked to it by the blocks available on the node. The registry entries are
+// merged: access nodes of the backup are added to the chain record and only the
+// missing DK shares are restored.
+func (b *BackupService) RestoreChain(chainID isc.ChainID, r io.Reader) (*dto.ChainRestoreResult, error) {
+	ch, err := b.chainsProvider().Get(chainID)
+	if err != nil {
+		return nil, err
+	}
+	aliasOutput, l1Commitment, err := latestAnchoredState(ch)
+	if err != nil {
+		return nil, err
+	}
+	l1StateIndex := aliasOutput.GetStateIndex()
+
+	nodePrivKey := b.nodeIdentityProvider.NodeIdentity().GetPrivateKey()
+	backup, err := chainbackup.Read(r, nodePrivKey, b.keyEncryption, func(backup *chainbackup.Backup, r io.Reader) error {
+		manifest := backup.Manifest
+		if !manifest.ChainID.Equals(chainID) {
+			return fmt.Errorf("%w: backup of chain %s cannot be restored to chain %s", ErrBackupVerificationFailed, manifest.ChainID, chainID)
+		}
+		if err := chainbackup.VerifyManifest(ch.Store(), manifest, l1StateIndex, l1Commitment); err != nil {
+			return fmt.Errorf("%w: %v", ErrBackupVerificationFailed, err)
+		}
+		if err := chainbackup.RestoreState(ch.Store(), backup, r); err != nil {
+			return fmt.Errorf("%w: %v", ErrBackupVerificationFailed, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = b.chainRecordRegistryProvider.UpdateChainRecord(chainID, func(rec *registry.ChainRecord) bool {
+		modified := false
+		for _, accessNode := range backup.ChainRecord.AccessNodes {
+			modified = rec.AddAccessNode(accessNode) || modified
+		}
+		return modified
+	}); err != nil {
+		return nil, fmt.Errorf("error saving chain record: %w", err)
+	}
+
+	restoredDKShares := make([]tcrypto.DKShare, 0, len(backup.DKShares))
+	for _, dkShare := range backup.DKShares {
+		if _, err = b.dkShareRegistryProvider.LoadDKShare(dkShare.GetAddress()); err == nil {
+			continue // Existing keys are never overwritten
+		}
+		if err = b.dkShareRegistryProvider.SaveDKShare(dkShare); err != nil {
+			return nil, fmt.Errorf("error saving DK share: %w", err)
+		}
+		restoredDKShares = append(restoredDKShares, dkShare)
+	}
+
+	return &dto.ChainRestoreResult{
+		ChainID:          chainID,
+		StateIndex:       backup.Manifest.StateIndex,
+		L1Commitment:     backup.Manifest.L1Commitment,
+		L1StateIndex:     l1StateIndex,
+		UpToDate:         backup.Manifest.L1Commitment.Equals(l1Commitment),
+		RestoredDKShares: restoredDKShares,
+	}, nil
+}
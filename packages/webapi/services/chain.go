@@ -14,6 +14,7 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/collections"
 	"github.com/iotaledger/wasp/packages/metrics"
 	"github.com/iotaledger/wasp/packages/registry"
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
 	"github.com/iotaledger/wasp/packages/vm/core/evm"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
 	"github.com/iotaledger/wasp/packages/webapi/apierrors"
@@ -172,6 +173,15 @@ func (c *ChainService) GetChainInfoByChainID(chainID isc.ChainID, blockIndexOrTr
 
 	chainInfo := dto.MapChainInfo(governanceChainInfo, chainRecord.Active)
 
+	// the history is not known, if the chain has no state in the store yet
+	if first, latest, err := indexedstore.AvailableHistory(ch.Store()); err == nil {
+		chainInfo.History = &dto.StateHistory{
+			ArchiveMode:              c.chainsProvider().IsArchiveNode(),
+			FirstAvailableBlockIndex: first,
+			LatestBlockIndex:         latest,
+		}
+	}
+
 	return chainInfo, nil
 }
 
@@ -193,18 +203,18 @@ func (c *ChainService) GetContracts(chainID isc.ChainID, blockIndexOrTrieRoot st
 	return contracts, nil
 }
 
-func (c *ChainService) GetState(chainID isc.ChainID, stateKey []byte) (state []byte, err error) {
+func (c *ChainService) GetState(chainID isc.ChainID, stateKey []byte, blockIndexOrTrieRoot string) (state []byte, err error) {
 	ch, err := c.GetChainByID(chainID)
 	if err != nil {
 		return nil, err
 	}
 
-	latestState, err := ch.LatestState(chainpkg.ActiveOrCommittedState)
+	chainState, err := common.ChainState(ch, blockIndexOrTrieRoot)
 	if err != nil {
 		return nil, err
 	}
 
-	return latestState.Get(kv.Key(stateKey)), nil
+	return chainState.Get(kv.Key(stateKey)), nil
 }
 
 func (c *ChainService) WaitForRequestProcessed(ctx context.Context, chainID isc.ChainID, requestID isc.RequestID, waitForL1Confirmation bool, timeout time.Duration) (*isc.Receipt, error) {
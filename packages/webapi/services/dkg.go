@@ -22,19 +22,22 @@ const (
 
 type DKGService struct {
 	dkShareRegistryProvider registry.DKShareRegistryProvider
+	dkgAuditLogProvider     registry.DKGAuditLogProvider
 	dkgNodeProvider         dkg.NodeProvider
 	trustedNetworkManager   peering.TrustedNetworkManager
 }
 
-func NewDKGService(dkShareRegistryProvider registry.DKShareRegistryProvider, dkgNodeProvider dkg.NodeProvider, trustedNetworkManager peering.TrustedNetworkManager) *DKGService {
+func NewDKGService(dkShareRegistryProvider registry.DKShareRegistryProvider, dkgAuditLogProvider registry.DKGAuditLogProvider, dkgNodeProvider dkg.NodeProvider, trustedNetworkManager peering.TrustedNetworkManager) *DKGService {
 	return &DKGService{
 		dkShareRegistryProvider: dkShareRegistryProvider,
+		dkgAuditLogProvider:     dkgAuditLogProvider,
 		dkgNodeProvider:         dkgNodeProvider,
 		trustedNetworkManager:   trustedNetworkManager,
 	}
 }
 
-func (d *DKGService) GenerateDistributedKey(peerPubKeysOrNames []string, threshold uint16, timeout time.Duration) (*models.DKSharesInfo, error) {
+// GenerateDistributedKey runs the DKG on behalf of the user initiatedBy, the user is recorded in the audit log of this node.
+func (d *DKGService) GenerateDistributedKey(initiatedBy string, peerPubKeysOrNames []string, threshold uint16, timeout time.Duration) (*models.DKSharesInfo, error) {
 	trustedPeers, err := d.trustedNetworkManager.TrustedPeersByPubKeyOrName(peerPubKeysOrNames)
 	if err != nil {
 		return nil, err
@@ -43,7 +46,7 @@ func (d *DKGService) GenerateDistributedKey(peerPubKeysOrNames []string, thresho
 		return tp.PubKey()
 	})
 
-	dkShare, err := d.dkgNodeProvider().GenerateDistributedKey(peerPubKeys, threshold, roundRetry, stepRetry, timeout)
+	dkShare, err := d.dkgNodeProvider().GenerateDistributedKey(initiatedBy, peerPubKeys, threshold, roundRetry, stepRetry, timeout)
 	if err != nil {
 		return nil, err
 	}
@@ -56,6 +59,59 @@ func (d *DKGService) GenerateDistributedKey(peerPubKeysOrNames []string, thresho
 	return dkShareInfo, nil
 }
 
+// ReshareDistributedKey moves the key of the shared address to another committee. The new shares
+// are stored as the next DKShares on the nodes of that committee, and have to be activated there.
+func (d *DKGService) ReshareDistributedKey(initiatedBy string, sharedAddress iotago.Address, peerPubKeysOrNames []string, threshold uint16, timeout time.Duration) (*models.DKSharesInfo, error) {
+	trustedPeers, err := d.trustedNetworkManager.TrustedPeersByPubKeyOrName(peerPubKeysOrNames)
+	if err != nil {
+		return nil, err
+	}
+	peerPubKeys := lo.Map(trustedPeers, func(tp *peering.TrustedPeer, _ int) *cryptolib.PublicKey {
+		return tp.PubKey()
+	})
+
+	dkShare, err := d.dkgNodeProvider().ReshareDistributedKey(initiatedBy, sharedAddress, peerPubKeys, threshold, stepRetry, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.createDKModel(dkShare)
+}
+
+// ApproveReshare allows this node to deal its key share, if the key of the shared address is reshared
+// to the specified committee during the validity period. The resharing has to be approved on T members
+// of the current committee, the initiator approves it implicitly.
+func (d *DKGService) ApproveReshare(approvedBy string, sharedAddress iotago.Address, peerPubKeysOrNames []string, threshold uint16, validity time.Duration) error {
+	trustedPeers, err := d.trustedNetworkManager.TrustedPeersByPubKeyOrName(peerPubKeysOrNames)
+	if err != nil {
+		return err
+	}
+	peerPubKeys := lo.Map(trustedPeers, func(tp *peering.TrustedPeer, _ int) *cryptolib.PublicKey {
+		return tp.PubKey()
+	})
+
+	return d.dkgNodeProvider().ApproveReshare(approvedBy, sharedAddress, peerPubKeys, threshold, validity)
+}
+
+func (d *DKGService) GetNextShares(sharedAddress iotago.Address) (*models.DKSharesInfo, error) {
+	dkShare, err := d.dkShareRegistryProvider.LoadNextDKShare(sharedAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.createDKModel(dkShare)
+}
+
+// ActivateNextShares replaces the DKShare of the shared address with the one produced by resharing.
+func (d *DKGService) ActivateNextShares(sharedAddress iotago.Address) (*models.DKSharesInfo, error) {
+	dkShare, err := d.dkShareRegistryProvider.ActivateNextDKShare(sharedAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.createDKModel(dkShare)
+}
+
 func (d *DKGService) GetShares(sharedAddress iotago.Address) (*models.DKSharesInfo, error) {
 	dkShare, err := d.dkShareRegistryProvider.LoadDKShare(sharedAddress)
 	if err != nil {
@@ -70,6 +126,14 @@ func (d *DKGService) GetShares(sharedAddress iotago.Address) (*models.DKSharesIn
 	return dkShareInfo, nil
 }
 
+// GetAuditLog returns the DKG procedures this node took part in, the oldest first.
+func (d *DKGService) GetAuditLog() []*models.DKGAuditEntry {
+	entries := d.dkgAuditLogProvider.DKGAuditEntries()
+	return lo.Map(entries, func(entry *registry.DKGAuditEntry, _ int) *models.DKGAuditEntry {
+		return models.MapDKGAuditEntry(entry, parameters.L1().Protocol.Bech32HRP)
+	})
+}
+
 func (d *DKGService) createDKModel(dkShare tcrypto.DKShare) (*models.DKSharesInfo, error) {
 	publicKey, err := dkShare.DSSSharedPublic().MarshalBinary()
 	if err != nil {
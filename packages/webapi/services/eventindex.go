@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sync"
+
+	hivedb "github.com/iotaledger/hive.go/kvstore/database"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/wasp/packages/eventindex"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/publisher"
+	"github.com/iotaledger/wasp/packages/webapi/interfaces"
+)
+
+var errEventIndexClosed = errors.New("the event index is closed")
+
+type EventIndexService struct {
+	enabled      bool
+	dbPath       string
+	chainService interfaces.ChainService
+	log          *logger.Logger
+
+	ctx          context.Context
+	cancel       context.CancelFunc
+	hook         *event.Hook[func(*publisher.ISCEvent[[]*isc.Event])]
+	running      sync.WaitGroup
+	indexesMutex sync.Mutex
+	indexes      map[isc.ChainID]*eventindex.Index
+	closed       bool
+}
+
+func NewEventIndexService(enabled bool, dbPath string, chainService interfaces.ChainService, pub *publisher.Publisher, log *logger.Logger) interfaces.EventIndexService {
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &EventIndexService{
+		enabled:      enabled,
+		dbPath:       dbPath,
+		chainService: chainService,
+		log:          log,
+		ctx:          ctx,
+		cancel:       cancel,
+		indexes:      map[isc.ChainID]*eventindex.Index{},
+	}
+
+	if enabled {
+		e.hook = pub.Events.BlockEvents.Hook(func(ev *publisher.ISCEvent[[]*isc.Event]) {
+			index, err := e.getIndex(ev.ChainID)
+			if errors.Is(err, errEventIndexClosed) {
+				return
+			}
+			if err != nil {
+				e.log.Warnf("unable to index the events of block %d of chain %s: %v", ev.BlockIndex, ev.ChainID, err)
+				return
+			}
+			index.BlockApplied(ev.BlockIndex)
+		})
+	}
+
+	return e
+}
+
+// Close stops the indexing of the chains and closes their indexes. It is called on the shutdown of the webapi.
+func (e *EventIndexService) Close() {
+	if e.hook != nil {
+		e.hook.Unhook()
+	}
+
+	e.indexesMutex.Lock()
+	e.closed = true
+	e.cancel()
+	e.indexesMutex.Unlock()
+
+	e.running.Wait()
+	for chainID, index := range e.indexes {
+		if err := index.Close(); err != nil {
+			e.log.Warnf("failed to close the event index of chain %s: %v", chainID, err)
+		}
+	}
+}
+
+// getIndex returns the event index of the chain. The index is opened (or created and filled from the
+// chain state) the first time a block of the chain is published, or its events are queried.
+func (e *EventIndexService) getIndex(chainID isc.ChainID) (*eventindex.Index, error) {
+	e.indexesMutex.Lock()
+	defer e.indexesMutex.Unlock()
+
+	if e.closed {
+		return nil, errEventIndexClosed
+	}
+	if e.indexes[chainID] != nil {
+		return e.indexes[chainID], nil
+	}
+
+	ch, err := e.chainService.GetChainByID(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := eventindex.New(ch.Store(), hivedb.EngineRocksDB, path.Join(e.dbPath, chainID.String()), e.log.Named(chainID.ShortString()))
+	if err != nil {
+		return nil, err
+	}
+	// the index is kept up to date until the service is closed
+	e.running.Add(1)
+	go func() {
+		defer e.running.Done()
+		index.Run(e.ctx)
+	}()
+
+	e.indexes[chainID] = index
+	return index, nil
+}
+
+func (e *EventIndexService) GetEvents(chainID isc.ChainID, query *eventindex.Query) (*eventindex.Page, error) {
+	if !e.enabled {
+		return nil, interfaces.ErrEventIndexDisabled
+	}
+
+	index, err := e.getIndex(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return index.Events(query)
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/iotaledger/wasp/packages/chain/mempool"
 	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/metrics"
 	"github.com/iotaledger/wasp/packages/peering"
 	"github.com/iotaledger/wasp/packages/util/expiringcache"
 	"github.com/iotaledger/wasp/packages/webapi/interfaces"
@@ -15,13 +17,21 @@ type OffLedgerService struct {
 	chainService    interfaces.ChainService
 	networkProvider peering.NetworkProvider
 	requestCache    *expiringcache.ExpiringCache[isc.RequestID, bool]
+	rateLimiter     *offLedgerRateLimiter
 }
 
-func NewOffLedgerService(chainService interfaces.ChainService, networkProvider peering.NetworkProvider, requestCacheTTL time.Duration) interfaces.OffLedgerService {
+func NewOffLedgerService(
+	chainService interfaces.ChainService,
+	networkProvider peering.NetworkProvider,
+	requestCacheTTL time.Duration,
+	rateLimitParams *OffLedgerRateLimitParameters,
+	chainMetricsProvider *metrics.ChainMetricsProvider,
+) interfaces.OffLedgerService {
 	return &OffLedgerService{
 		chainService:    chainService,
 		networkProvider: networkProvider,
 		requestCache:    expiringcache.New[isc.RequestID, bool](requestCacheTTL),
+		rateLimiter:     newOffLedgerRateLimiter(rateLimitParams, chainMetricsProvider),
 	}
 }
 
@@ -43,7 +53,12 @@ func (c *OffLedgerService) ParseRequest(binaryRequest []byte) (isc.OffLedgerRequ
 	return req, nil
 }
 
-func (c *OffLedgerService) EnqueueOffLedgerRequest(chainID isc.ChainID, binaryRequest []byte) error {
+func (c *OffLedgerService) EnqueueOffLedgerRequest(chainID isc.ChainID, binaryRequest []byte, remoteIP string) error {
+	// the cheap checks first, the request is not even parsed if the client is sending too many of them
+	if err := c.rateLimiter.allowClient(chainID, remoteIP); err != nil {
+		return err
+	}
+
 	request, err := c.ParseRequest(binaryRequest)
 	if err != nil {
 		return err
@@ -66,6 +81,11 @@ func (c *OffLedgerService) EnqueueOffLedgerRequest(chainID isc.ChainID, binaryRe
 		return errors.New("request is for a different chain")
 	}
 
+	// the sender is known only after the signature is verified
+	if err := c.rateLimiter.allowSender(chainID, request.SenderAccount()); err != nil {
+		return err
+	}
+
 	// check chain exists
 	chain, err := c.chainService.GetChainByID(chainID)
 	if err != nil {
@@ -73,6 +93,10 @@ func (c *OffLedgerService) EnqueueOffLedgerRequest(chainID isc.ChainID, binaryRe
 	}
 
 	if err := chain.ReceiveOffLedgerRequest(request, c.networkProvider.Self().PubKey()); err != nil {
+		if errors.Is(err, mempool.ErrAccountLimitReached) {
+			c.rateLimiter.rejectedByAccountLimit(chainID)
+			return &RateLimitError{Limit: RateLimitAccount, Cause: err}
+		}
 		return fmt.Errorf("tx not added to the mempool: %v", err.Error())
 	}
 
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/metrics"
+)
+
+// names of the off-ledger rate limits, used in the errors and in the metrics
+const (
+	RateLimitGlobal  = "global"
+	RateLimitIP      = "ip"
+	RateLimitSender  = "sender"
+	RateLimitAccount = "account"
+)
+
+// RateLimit is a token bucket, refilled with RequestsPerSecond tokens every second up to Burst tokens.
+// The limit is disabled if RequestsPerSecond is 0.
+type RateLimit struct {
+	RequestsPerSecond int
+	Burst             int
+}
+
+func (l RateLimit) enabled() bool {
+	return l.RequestsPerSecond > 0
+}
+
+func (l RateLimit) newLimiter() *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(l.RequestsPerSecond), max(l.Burst, 1))
+}
+
+// OffLedgerRateLimits are the limits of the off-ledger requests sent to a single chain.
+type OffLedgerRateLimits struct {
+	Global    RateLimit
+	PerIP     RateLimit
+	PerSender RateLimit
+}
+
+type OffLedgerRateLimitParameters struct {
+	Default  OffLedgerRateLimits
+	PerChain map[isc.ChainIDKey]OffLedgerRateLimits
+	// the limiters of the clients that haven't sent requests for this duration are dropped
+	IdleTimeout time.Duration
+	// at most this number of clients (IP addresses or senders) have their own limiter on a chain,
+	// the clients above it share a single limiter; 0 means unlimited
+	MaxClients int
+}
+
+// NewOffLedgerRateLimitParameters creates the parameters from the default limits and a list of
+// per-chain overrides in the '<chainID>:<global>:<per-IP>:<per-sender>' format (requests per second).
+// The burst sizes of the default limits are kept for the overrides.
+func NewOffLedgerRateLimitParameters(defaultLimits OffLedgerRateLimits, chainLimits []string, idleTimeout time.Duration, maxClients int) (*OffLedgerRateLimitParameters, error) {
+	ret := &OffLedgerRateLimitParameters{
+		Default:     defaultLimits,
+		PerChain:    make(map[isc.ChainIDKey]OffLedgerRateLimits),
+		IdleTimeout: idleTimeout,
+		MaxClients:  maxClients,
+	}
+	for _, config := range chainLimits {
+		configSplit := strings.Split(config, ":")
+		if len(configSplit) != 4 {
+			return nil, fmt.Errorf("%s is not in the '<chainID>:<global>:<per-IP>:<per-sender>' format", config)
+		}
+		chainID, err := isc.ChainIDFromString(configSplit[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s in %s is not a chain ID: %w", configSplit[0], config, err)
+		}
+		requestsPerSecond := make([]int, 3)
+		for i, s := range configSplit[1:] {
+			if requestsPerSecond[i], err = strconv.Atoi(s); err != nil || requestsPerSecond[i] < 0 {
+				return nil, fmt.Errorf("%s in %s is not a valid number of requests per second", s, config)
+			}
+		}
+		limits := defaultLimits
+		limits.Global.RequestsPerSecond = requestsPerSecond[0]
+		limits.PerIP.RequestsPerSecond = requestsPerSecond[1]
+		limits.PerSender.RequestsPerSecond = requestsPerSecond[2]
+		ret.PerChain[chainID.Key()] = limits
+	}
+	return ret, nil
+}
+
+func (p *OffLedgerRateLimitParameters) limitsForChain(chainID isc.ChainID) OffLedgerRateLimits {
+	if limits, ok := p.PerChain[chainID.Key()]; ok {
+		return limits
+	}
+	return p.Default
+}
+
+// RateLimitError is returned if an off-ledger request is rejected because of a rate limit.
+type RateLimitError struct {
+	// Limit is the name of the limit that was exceeded
+	Limit string
+	// RetryAfter is the time after which the request is expected to be accepted; 0 if unknown
+	RetryAfter time.Duration
+	// Cause is the error of the mempool, if the request was rejected by it
+	Cause error
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Cause != nil {
+		return e.Cause.Error()
+	}
+	return fmt.Sprintf("%s rate limit exceeded", e.Limit)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.Cause
+}
+
+type clientRateLimiter struct {
+	limiter      *rate.Limiter
+	lastActivity time.Time
+}
+
+// clientRateLimiters are the limiters of the clients of a single kind (IP addresses or senders).
+// The number of the limiters is capped, so that the memory can't be exhausted by sending
+// requests from many addresses: the clients above the cap share the overflow limiter.
+type clientRateLimiters struct {
+	limit      RateLimit
+	maxClients int
+	clients    map[string]*clientRateLimiter
+	overflow   *rate.Limiter
+}
+
+func newClientRateLimiters(limit RateLimit, maxClients int) *clientRateLimiters {
+	return &clientRateLimiters{
+		limit:      limit,
+		maxClients: maxClients,
+		clients:    make(map[string]*clientRateLimiter),
+	}
+}
+
+func (c *clientRateLimiters) limiter(client string, now time.Time) *rate.Limiter {
+	l, ok := c.clients[client]
+	if !ok {
+		if c.maxClients > 0 && len(c.clients) >= c.maxClients {
+			if c.overflow == nil {
+				c.overflow = c.limit.newLimiter()
+			}
+			return c.overflow
+		}
+		l = &clientRateLimiter{limiter: c.limit.newLimiter()}
+		c.clients[client] = l
+	}
+	l.lastActivity = now
+	return l.limiter
+}
+
+func (c *clientRateLimiters) cleanup(now time.Time, idleTimeout time.Duration) {
+	for client, l := range c.clients {
+		if now.Sub(l.lastActivity) > idleTimeout {
+			delete(c.clients, client)
+		}
+	}
+}
+
+type chainRateLimiters struct {
+	limits    OffLedgerRateLimits
+	metrics   *metrics.ChainWebAPIMetrics
+	global    *rate.Limiter
+	perIP     *clientRateLimiters
+	perSender *clientRateLimiters
+}
+
+// offLedgerRateLimiter keeps the token buckets of the off-ledger requests, separately for each chain.
+type offLedgerRateLimiter struct {
+	mutex                sync.Mutex
+	params               *OffLedgerRateLimitParameters
+	chainMetricsProvider *metrics.ChainMetricsProvider
+	chains               map[isc.ChainIDKey]*chainRateLimiters
+	lastCleanup          time.Time
+}
+
+func newOffLedgerRateLimiter(params *OffLedgerRateLimitParameters, chainMetricsProvider *metrics.ChainMetricsProvider) *offLedgerRateLimiter {
+	return &offLedgerRateLimiter{
+		params:               params,
+		chainMetricsProvider: chainMetricsProvider,
+		chains:               make(map[isc.ChainIDKey]*chainRateLimiters),
+		lastCleanup:          time.Now(),
+	}
+}
+
+func (l *offLedgerRateLimiter) chain(chainID isc.ChainID) *chainRateLimiters {
+	if c, ok := l.chains[chainID.Key()]; ok {
+		return c
+	}
+	c := &chainRateLimiters{
+		limits:  l.params.limitsForChain(chainID),
+		metrics: l.chainMetricsProvider.GetChainMetrics(chainID).WebAPI,
+	}
+	c.perIP = newClientRateLimiters(c.limits.PerIP, l.params.MaxClients)
+	c.perSender = newClientRateLimiters(c.limits.PerSender, l.params.MaxClients)
+	if c.limits.Global.enabled() {
+		c.global = c.limits.Global.newLimiter()
+	}
+	c.metrics.SetOffLedgerRateLimit(RateLimitGlobal, c.limits.Global.RequestsPerSecond)
+	c.metrics.SetOffLedgerRateLimit(RateLimitIP, c.limits.PerIP.RequestsPerSecond)
+	c.metrics.SetOffLedgerRateLimit(RateLimitSender, c.limits.PerSender.RequestsPerSecond)
+	l.chains[chainID.Key()] = c
+	return c
+}
+
+// cleanup drops the limiters of the idle clients. A dropped limiter would be full anyway,
+// if the idle timeout is longer than the time needed to refill the bucket.
+func (l *offLedgerRateLimiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < l.params.IdleTimeout {
+		return
+	}
+	l.lastCleanup = now
+	for _, c := range l.chains {
+		c.perIP.cleanup(now, l.params.IdleTimeout)
+		c.perSender.cleanup(now, l.params.IdleTimeout)
+	}
+}
+
+type namedLimiter struct {
+	name    string
+	limiter *rate.Limiter
+}
+
+// allow takes a token from each of the limiters, or none of them if one of the limiters is empty.
+func allow(c *chainRateLimiters, limiters []namedLimiter, now time.Time) error {
+	reservations := make([]*rate.Reservation, 0, len(limiters))
+	var rateLimitErr *RateLimitError
+	for _, l := range limiters {
+		r := l.limiter.ReserveN(now, 1)
+		reservations = append(reservations, r)
+		if !r.OK() {
+			rateLimitErr = &RateLimitError{Limit: l.name}
+			break
+		}
+		if delay := r.DelayFrom(now); delay > 0 && (rateLimitErr == nil || delay > rateLimitErr.RetryAfter) {
+			rateLimitErr = &RateLimitError{Limit: l.name, RetryAfter: delay}
+		}
+	}
+	if rateLimitErr == nil {
+		return nil
+	}
+	for _, r := range reservations {
+		r.CancelAt(now)
+	}
+	c.metrics.IncOffLedgerRateLimited(rateLimitErr.Limit)
+	return rateLimitErr
+}
+
+// allowClient checks the global and the per-IP limits of the chain.
+func (l *offLedgerRateLimiter) allowClient(chainID isc.ChainID, ip string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	l.cleanup(now)
+	c := l.chain(chainID)
+	limiters := make([]namedLimiter, 0, 2)
+	if c.global != nil {
+		limiters = append(limiters, namedLimiter{RateLimitGlobal, c.global})
+	}
+	if c.limits.PerIP.enabled() {
+		limiters = append(limiters, namedLimiter{RateLimitIP, c.perIP.limiter(ip, now)})
+	}
+	return allow(c, limiters, now)
+}
+
+// allowSender checks the per-sender limit of the chain. The sender must have been verified already,
+// otherwise anyone could use up the tokens of any sender.
+func (l *offLedgerRateLimiter) allowSender(chainID isc.ChainID, sender isc.AgentID) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	l.cleanup(now)
+	c := l.chain(chainID)
+	if !c.limits.PerSender.enabled() {
+		return nil
+	}
+	return allow(c, []namedLimiter{{RateLimitSender, c.perSender.limiter(sender.String(), now)}}, now)
+}
+
+// rejectedByAccountLimit records a request rejected by the mempool, because its sender has too many requests in it.
+func (l *offLedgerRateLimiter) rejectedByAccountLimit(chainID isc.ChainID) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.chain(chainID).metrics.IncOffLedgerRateLimited(RateLimitAccount)
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/metrics"
+)
+
+func TestOffLedgerRateLimiter(t *testing.T) {
+	chainID := isc.RandomChainID()
+	otherChainID := isc.RandomChainID()
+	params, err := NewOffLedgerRateLimitParameters(OffLedgerRateLimits{
+		Global:    RateLimit{RequestsPerSecond: 1, Burst: 3},
+		PerIP:     RateLimit{RequestsPerSecond: 1, Burst: 2},
+		PerSender: RateLimit{RequestsPerSecond: 1, Burst: 1},
+	}, []string{otherChainID.String() + ":0:0:0"}, time.Minute, 0)
+	require.NoError(t, err)
+	l := newOffLedgerRateLimiter(params, metrics.NewChainMetricsProvider())
+
+	// the per-IP limit is reached first
+	require.NoError(t, l.allowClient(chainID, "1.1.1.1"))
+	require.NoError(t, l.allowClient(chainID, "1.1.1.1"))
+	err = l.allowClient(chainID, "1.1.1.1")
+	require.ErrorAs(t, err, new(*RateLimitError))
+	require.Equal(t, RateLimitIP, err.(*RateLimitError).Limit)
+	require.Greater(t, err.(*RateLimitError).RetryAfter, time.Duration(0))
+
+	// the rejected request didn't take a token from the global limit
+	require.NoError(t, l.allowClient(chainID, "2.2.2.2"))
+	err = l.allowClient(chainID, "3.3.3.3")
+	require.ErrorAs(t, err, new(*RateLimitError))
+	require.Equal(t, RateLimitGlobal, err.(*RateLimitError).Limit)
+
+	sender := isc.NewAgentID(cryptolib.NewKeyPair().Address())
+	require.NoError(t, l.allowSender(chainID, sender))
+	err = l.allowSender(chainID, sender)
+	require.ErrorAs(t, err, new(*RateLimitError))
+	require.Equal(t, RateLimitSender, err.(*RateLimitError).Limit)
+
+	// the limits are disabled for the other chain
+	for i := 0; i < 10; i++ {
+		require.NoError(t, l.allowClient(otherChainID, "1.1.1.1"))
+		require.NoError(t, l.allowSender(otherChainID, sender))
+	}
+
+	_, err = NewOffLedgerRateLimitParameters(OffLedgerRateLimits{}, []string{chainID.String() + ":1:2"}, time.Minute, 0)
+	require.Error(t, err)
+}
+
+func TestOffLedgerRateLimiterMaxClients(t *testing.T) {
+	chainID := isc.RandomChainID()
+	params, err := NewOffLedgerRateLimitParameters(OffLedgerRateLimits{
+		PerIP: RateLimit{RequestsPerSecond: 1, Burst: 1},
+	}, nil, time.Minute, 2)
+	require.NoError(t, err)
+	l := newOffLedgerRateLimiter(params, metrics.NewChainMetricsProvider())
+
+	require.NoError(t, l.allowClient(chainID, "1.1.1.1"))
+	require.NoError(t, l.allowClient(chainID, "2.2.2.2"))
+	require.Len(t, l.chain(chainID).perIP.clients, 2)
+
+	// the clients above the limit share a single limiter
+	require.NoError(t, l.allowClient(chainID, "3.3.3.3"))
+	require.ErrorAs(t, l.allowClient(chainID, "4.4.4.4"), new(*RateLimitError))
+	require.Len(t, l.chain(chainID).perIP.clients, 2)
+}
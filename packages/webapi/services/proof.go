@@ -0,0 +1,95 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	chainpkg "github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
+	"github.com/iotaledger/wasp/packages/transaction"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+	"github.com/iotaledger/wasp/packages/webapi/common"
+	"github.com/iotaledger/wasp/packages/webapi/dto"
+	"github.com/iotaledger/wasp/packages/webapi/interfaces"
+)
+
+// anchoredState returns the state of the given block, together with the alias output, which
+// anchors the state on L1.
+func anchoredState(ch chainpkg.Chain, blockIndex uint32) (state.State, *isc.AliasOutputWithID, error) {
+	anchorAO, err := common.AliasOutputOfBlock(ch, blockIndex)
+	if errors.Is(err, common.ErrBlockNotConfirmed) || errors.Is(err, indexedstore.ErrStateNotAvailable) {
+		return nil, nil, fmt.Errorf("%w: %v", interfaces.ErrProofNotAvailable, err)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l1c, err := transaction.L1CommitmentFromAliasOutput(anchorAO.GetAliasOutput())
+	if err != nil {
+		return nil, nil, err
+	}
+	chainState, err := ch.Store().StateByTrieRoot(l1c.TrieRoot())
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: the state of block %d is not available: %v", interfaces.ErrProofNotAvailable, blockIndex, err)
+	}
+	return chainState, anchorAO, nil
+}
+
+func stateProof(chainState state.State, anchorAO *isc.AliasOutputWithID, key []byte) (*dto.StateProof, error) {
+	l1c, err := transaction.L1CommitmentFromAliasOutput(anchorAO.GetAliasOutput())
+	if err != nil {
+		return nil, err
+	}
+	return &dto.StateProof{
+		BlockIndex:    chainState.BlockIndex(),
+		Key:           key,
+		Value:         chainState.Get(kv.Key(key)),
+		Proof:         chainState.GetMerkleProof(key),
+		L1Commitment:  l1c,
+		AliasOutputID: anchorAO.OutputID(),
+	}, nil
+}
+
+// GetStateProof returns the Merkle proof of any key in the state of the given block.
+// The proof of a key, which is not in the state, is a proof of absence.
+func (c *ChainService) GetStateProof(chainID isc.ChainID, blockIndex uint32, stateKey []byte) (*dto.StateProof, error) {
+	ch, err := c.GetChainByID(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	chainState, anchorAO, err := anchoredState(ch, blockIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return stateProof(chainState, anchorAO, stateKey)
+}
+
+// GetReceiptProof returns the Merkle proof of the receipt of a request, in the state of the given block.
+// The request must have been processed in that block, or in one of the blocks before it.
+func (c *ChainService) GetReceiptProof(chainID isc.ChainID, blockIndex uint32, requestID isc.RequestID) (*dto.StateProof, error) {
+	ch, err := c.GetChainByID(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	chainState, anchorAO, err := anchoredState(ch, blockIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := blocklog.GetRequestRecordDataByRequestID(common.BlocklogPartition(chainState), requestID)
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, fmt.Errorf("%w: the receipt of request %s is not in the state of block %d", interfaces.ErrProofNotAvailable, requestID, blockIndex)
+	}
+
+	receiptKey := blocklog.RequestReceiptKey(blocklog.NewRequestLookupKey(receipt.BlockIndex, receipt.RequestIndex))
+	return stateProof(chainState, anchorAO, append(blocklog.Contract.Hname().Bytes(), receiptKey...))
+}
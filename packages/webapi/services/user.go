@@ -1,6 +1,9 @@
 package services
 
 import (
+	"sort"
+	"time"
+
 	"golang.org/x/exp/maps"
 
 	"github.com/iotaledger/wasp/packages/users"
@@ -98,3 +101,44 @@ func (u *UserService) GetUser(username string) (*models.User, error) {
 		Permissions: permissionsFromMap(user.Permissions),
 	}, nil
 }
+
+func (u *UserService) AddAPIKey(username, name string, permissions []string, expiresAt time.Time) (*models.AddAPIKeyResponse, error) {
+	apiKey, token, err := u.userManager.AddAPIKey(username, name, permissionsToMap(permissions), expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AddAPIKeyResponse{
+		ID:    apiKey.ID,
+		Token: token,
+	}, nil
+}
+
+func (u *UserService) GetAPIKeys(username string) ([]*models.APIKey, error) {
+	user, err := u.userManager.User(username)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys := make([]*models.APIKey, 0, len(user.APIKeys))
+	for _, apiKey := range user.APIKeys {
+		apiKeys = append(apiKeys, &models.APIKey{
+			ID:          apiKey.ID,
+			Name:        apiKey.Name,
+			Permissions: permissionsFromMap(apiKey.Permissions),
+			CreatedAt:   apiKey.CreatedAt,
+			ExpiresAt:   apiKey.ExpiresAt,
+			LastUsedAt:  apiKey.LastUsedAt,
+		})
+	}
+
+	sort.Slice(apiKeys, func(i, j int) bool {
+		return apiKeys[i].CreatedAt.Before(apiKeys[j].CreatedAt)
+	})
+
+	return apiKeys, nil
+}
+
+func (u *UserService) RevokeAPIKey(username, apiKeyID string) error {
+	return u.userManager.RevokeAPIKey(username, apiKeyID)
+}
@@ -40,7 +40,10 @@ type CommandManager struct {
 	subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]
 }
 
-func NewCommandHandler(log *logger.Logger, subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]) *CommandManager {
+// TopicValidator checks the syntax of a topic before a client subscribes to it.
+type TopicValidator func(topic string) error
+
+func NewCommandHandler(log *logger.Logger, subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string], topicValidator TopicValidator) *CommandManager {
 	return &CommandManager{
 		log: log,
 		commands: []CommandHandler{
@@ -48,6 +51,7 @@ func NewCommandHandler(log *logger.Logger, subscriptionManager *subscriptionmana
 			&SubscriptionCommandHandler{
 				log:                 log,
 				subscriptionManager: subscriptionManager,
+				topicValidator:      topicValidator,
 			},
 		},
 		subscriptionManager: subscriptionManager,
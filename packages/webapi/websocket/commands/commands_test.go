@@ -26,7 +26,7 @@ func initTest() (*CommandManager, *websockethub.Hub, context.CancelFunc) {
 	subscriptionManager := subscriptionmanager.New[websockethub.ClientID, string]()
 	subscriptionManager.Connect(1)
 
-	manager := NewCommandHandler(log, subscriptionManager)
+	manager := NewCommandHandler(log, subscriptionManager, nil)
 	hub := websockethub.NewHub(log.Named("Hub"), &websocketserver.AcceptOptions{InsecureSkipVerify: true}, 500, 500, 500)
 
 	go func() { hub.Run(ctx) }()
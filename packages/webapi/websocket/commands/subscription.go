@@ -33,6 +33,7 @@ type SubscriptionEvent struct {
 type SubscriptionCommandHandler struct {
 	log                 *logger.Logger
 	subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]
+	topicValidator      TopicValidator
 }
 
 func (s *SubscriptionCommandHandler) SupportsCommand(commandType CommandType) bool {
@@ -51,6 +52,12 @@ func (s *SubscriptionCommandHandler) HandleCommand(client *websockethub.Client,
 		return errors.Wrap(ErrFailedToValidateCommand, "Topic is empty")
 	}
 
+	if command.Command == CommandSubscribe && s.topicValidator != nil {
+		if err = s.topicValidator(command.Topic); err != nil {
+			return errors.Wrapf(ErrFailedToValidateCommand, "Invalid topic %q: %s", command.Topic, err.Error())
+		}
+	}
+
 	switch command.Command {
 	case CommandSubscribe:
 		s.subscriptionManager.Subscribe(client.ID(), command.Topic)
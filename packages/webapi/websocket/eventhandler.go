@@ -16,6 +16,10 @@ type ISCEvent struct {
 	RequestID string                 `json:"requestID"` // (isc.RequestID)
 	ChainID   string                 `json:"chainID"`   // (isc.ChainID)
 	Payload   any                    `json:"payload"`
+
+	// used to apply the filter topics of the subscribers
+	contract isc.Hname
+	events   []*isc.Event
 }
 
 func MapISCEvent[T any](iscEvent *publisher.ISCEvent[T], mappedPayload any) *ISCEvent {
@@ -67,8 +71,14 @@ func (p *EventHandler) AttachToEvents() context.CancelFunc {
 				return
 			}
 
-			receipt := models.MapReceiptResponse(block.Payload.RequestReceipt)
-			iscEvent := MapISCEvent(block, receipt)
+			contract := block.Payload.RequestReceipt.DeserializedRequest().CallTarget().Contract
+			iscEvent := MapISCEvent(block, nil)
+			if !p.subscriptionValidator.shouldProcessReceipt(contract, iscEvent.Issuer) {
+				return
+			}
+
+			iscEvent.Payload = models.MapReceiptResponse(block.Payload.RequestReceipt)
+			iscEvent.contract = contract
 			p.publishEvent.Trigger(iscEvent)
 		}).Unhook,
 
@@ -77,7 +87,13 @@ func (p *EventHandler) AttachToEvents() context.CancelFunc {
 				return
 			}
 
-			iscEvent := MapISCEvent(block, block.Payload)
+			events := p.subscriptionValidator.filterBlockEvents(block.Payload)
+			if len(events) == 0 && len(block.Payload) > 0 {
+				return
+			}
+
+			iscEvent := MapISCEvent(block, events)
+			iscEvent.events = events
 			p.publishEvent.Trigger(iscEvent)
 		}).Unhook,
 	)
@@ -36,7 +36,8 @@ func initTest(ctx context.Context) (*publisher.Publisher, *EventHandler, *event.
 	subscriptionManager.Subscribe(1, "chains")
 
 	subscriptionValidator := NewSubscriptionValidator(map[publisher.ISCEventType]bool{
-		publisher.ISCEventKindNewBlock: true,
+		publisher.ISCEventKindNewBlock:    true,
+		publisher.ISCEventKindBlockEvents: true,
 	}, subscriptionManager)
 
 	eventHandler := NewEventHandler(pub, publisherEvent, subscriptionValidator)
@@ -72,3 +73,38 @@ func TestSuccessfulEventHandling(t *testing.T) {
 	require.ErrorIs(t, ctx.Err(), context.Canceled, "The context was not correctly canceled by the event receiver and timed out. "+
 		"This means no event was sent and this needs to fail the test")
 }
+
+func TestBlockEventsFiltering(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	pub, eventHandler, publisherEvent, subscriptionManager := initTest(ctx)
+
+	subscriptionManager.Subscribe(1, string(publisher.ISCEventKindBlockEvents))
+	require.Error(t, eventHandler.subscriptionValidator.ValidateTopic(TopicPrefixContract+"xyz"))
+	require.Error(t, eventHandler.subscriptionValidator.ValidateTopic(TopicPrefixSender+"xyz"))
+	require.NoError(t, eventHandler.subscriptionValidator.ValidateTopic(TopicPrefixContract+isc.Hn("foo").String()))
+	subscriptionManager.Subscribe(1, TopicPrefixContract+isc.Hn("foo").String())
+	subscriptionManager.Subscribe(1, TopicPrefixEventTopic+"foo.")
+
+	chainID := isc.RandomChainID()
+	events := []*isc.Event{
+		{ContractID: isc.Hn("foo"), Topic: "foo.transfer"},
+		{ContractID: isc.Hn("foo"), Topic: "bar.transfer"},
+		{ContractID: isc.Hn("bar"), Topic: "foo.transfer"},
+	}
+
+	publisherEvent.Hook(func(iscEvent *ISCEvent) {
+		require.Equal(t, []*isc.Event{events[0]}, iscEvent.Payload)
+		cancel()
+	})
+
+	pub.Events.BlockEvents.Trigger(&publisher.ISCEvent[[]*isc.Event]{
+		Kind:    publisher.ISCEventKindBlockEvents,
+		ChainID: chainID,
+		Issuer:  &isc.NilAgentID{},
+		Payload: events,
+	})
+
+	<-ctx.Done()
+	require.ErrorIs(t, ctx.Err(), context.Canceled, "The context was not correctly canceled by the event receiver and timed out. "+
+		"This means no event was sent and this needs to fail the test")
+}
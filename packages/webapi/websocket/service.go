@@ -53,7 +53,7 @@ func NewWebsocketService(log *logger.Logger, hub *websockethub.Hub, msgTypes []p
 
 	subscriptionValidator := NewSubscriptionValidator(msgTypesMap, subscriptionManager)
 	eventHandler := NewEventHandler(pub, publishEvent, subscriptionValidator)
-	commandHandler := commands.NewCommandHandler(log, subscriptionManager)
+	commandHandler := commands.NewCommandHandler(log, subscriptionManager, subscriptionValidator.ValidateTopic)
 
 	return &Service{
 		log:                   log.Named("Websocket Service"),
@@ -75,6 +75,11 @@ func (p *Service) onClientCreated(client *websockethub.Client) {
 				return
 			}
 
+			iscEvent = p.subscriptionValidator.filterEventForClient(client, iscEvent)
+			if iscEvent == nil {
+				return
+			}
+
 			if err := client.Send(client.Context(), iscEvent); err != nil {
 				p.log.Warnf("error sending message to client:[%d], err:[%v]", client.ID(), err)
 			}
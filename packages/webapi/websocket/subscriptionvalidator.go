@@ -2,22 +2,185 @@ package websocket
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/iotaledger/hive.go/web/subscriptionmanager"
 	"github.com/iotaledger/hive.go/web/websockethub"
+	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/publisher"
 )
 
+// Filter topics narrow down the events a client receives, in addition to the chain and message type topics.
+// Filters of the same kind are OR'ed, filters of different kinds are AND'ed.
+const (
+	// TopicPrefixContract filters block_events and receipts by the hname of the contract (contract/<hname>).
+	TopicPrefixContract = "contract/"
+	// TopicPrefixEventTopic filters block_events by the prefix of the event topic (event_topic/<prefix>).
+	TopicPrefixEventTopic = "event_topic/"
+	// TopicPrefixSender filters receipts by the AgentID of the request sender (sender/<agentID>).
+	TopicPrefixSender = "sender/"
+)
+
+type subscriptionFilter struct {
+	contracts     map[isc.Hname]int
+	eventTopics   map[string]int
+	senders       map[string]int
+	subscriptions int
+}
+
+func newSubscriptionFilter() *subscriptionFilter {
+	return &subscriptionFilter{
+		contracts:   make(map[isc.Hname]int),
+		eventTopics: make(map[string]int),
+		senders:     make(map[string]int),
+	}
+}
+
+func (f *subscriptionFilter) matchesContract(contract isc.Hname) bool {
+	if len(f.contracts) == 0 {
+		return true
+	}
+	_, ok := f.contracts[contract]
+	return ok
+}
+
+func (f *subscriptionFilter) matchesEventTopic(topic string) bool {
+	if len(f.eventTopics) == 0 {
+		return true
+	}
+	for prefix := range f.eventTopics {
+		if strings.HasPrefix(topic, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *subscriptionFilter) matchesSender(sender string) bool {
+	if len(f.senders) == 0 {
+		return true
+	}
+	_, ok := f.senders[sender]
+	return ok
+}
+
+func (f *subscriptionFilter) matchesEvent(event *isc.Event) bool {
+	return f.matchesContract(event.ContractID) && f.matchesEventTopic(event.Topic)
+}
+
+func (f *subscriptionFilter) matchesReceipt(contract isc.Hname, sender string) bool {
+	return f.matchesContract(contract) && f.matchesSender(sender)
+}
+
+// parseFilterTopic returns the kind and the normalized value of a filter topic, or ok=false if the topic is no filter topic.
+func parseFilterTopic(topic string) (kind, value string, ok bool, err error) {
+	switch {
+	case strings.HasPrefix(topic, TopicPrefixContract):
+		value = strings.TrimPrefix(topic, TopicPrefixContract)
+		hname, err := isc.HnameFromString(value)
+		if err != nil {
+			return "", "", true, err
+		}
+		return TopicPrefixContract, hname.String(), true, nil
+	case strings.HasPrefix(topic, TopicPrefixEventTopic):
+		value = strings.TrimPrefix(topic, TopicPrefixEventTopic)
+		if value == "" {
+			return "", "", true, fmt.Errorf("event topic prefix is empty")
+		}
+		return TopicPrefixEventTopic, value, true, nil
+	case strings.HasPrefix(topic, TopicPrefixSender):
+		value = strings.TrimPrefix(topic, TopicPrefixSender)
+		agentID, err := isc.AgentIDFromString(value)
+		if err != nil {
+			return "", "", true, fmt.Errorf("cannot parse sender: %w", err)
+		}
+		return TopicPrefixSender, agentID.String(), true, nil
+	default:
+		return "", "", false, nil
+	}
+}
+
 type SubscriptionValidator struct {
 	messageTypes        map[publisher.ISCEventType]bool
 	subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]
+
+	filtersMutex sync.RWMutex
+	filters      map[websockethub.ClientID]*subscriptionFilter
 }
 
 func NewSubscriptionValidator(messageTypes map[publisher.ISCEventType]bool, subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]) *SubscriptionValidator {
-	return &SubscriptionValidator{
+	validator := &SubscriptionValidator{
 		messageTypes:        messageTypes,
 		subscriptionManager: subscriptionManager,
+		filters:             make(map[websockethub.ClientID]*subscriptionFilter),
 	}
+
+	subscriptionManager.Events().TopicSubscribed.Hook(func(event *subscriptionmanager.ClientTopicEvent[websockethub.ClientID, string]) {
+		validator.updateFilter(event.ClientID, event.Topic, 1)
+	})
+	subscriptionManager.Events().TopicUnsubscribed.Hook(func(event *subscriptionmanager.ClientTopicEvent[websockethub.ClientID, string]) {
+		validator.updateFilter(event.ClientID, event.Topic, -1)
+	})
+	subscriptionManager.Events().ClientDisconnected.Hook(func(event *subscriptionmanager.ClientEvent[websockethub.ClientID]) {
+		validator.removeFilter(event.ClientID)
+	})
+
+	return validator
+}
+
+// ValidateTopic checks the syntax of the filter topics, other topics are always valid.
+func (p *SubscriptionValidator) ValidateTopic(topic string) error {
+	_, _, _, err := parseFilterTopic(topic)
+	return err
+}
+
+func (p *SubscriptionValidator) updateFilter(clientID websockethub.ClientID, topic string, delta int) {
+	kind, value, ok, err := parseFilterTopic(topic)
+	if !ok || err != nil {
+		return
+	}
+
+	p.filtersMutex.Lock()
+	defer p.filtersMutex.Unlock()
+
+	filter, exists := p.filters[clientID]
+	if !exists {
+		if delta < 0 {
+			return
+		}
+		filter = newSubscriptionFilter()
+		p.filters[clientID] = filter
+	}
+
+	switch kind {
+	case TopicPrefixContract:
+		hname, _ := isc.HnameFromString(value)
+		updateFilterCount(filter.contracts, hname, delta)
+	case TopicPrefixEventTopic:
+		updateFilterCount(filter.eventTopics, value, delta)
+	case TopicPrefixSender:
+		updateFilterCount(filter.senders, value, delta)
+	}
+
+	filter.subscriptions += delta
+	if filter.subscriptions <= 0 {
+		delete(p.filters, clientID)
+	}
+}
+
+func updateFilterCount[K comparable](counts map[K]int, key K, delta int) {
+	counts[key] += delta
+	if counts[key] <= 0 {
+		delete(counts, key)
+	}
+}
+
+func (p *SubscriptionValidator) removeFilter(clientID websockethub.ClientID) {
+	p.filtersMutex.Lock()
+	defer p.filtersMutex.Unlock()
+
+	delete(p.filters, clientID)
 }
 
 func (p *SubscriptionValidator) hasClientSubscribedToAllChains(client *websockethub.Client) bool {
@@ -56,6 +219,55 @@ func (p *SubscriptionValidator) shouldProcessEvent(chainID string, messageType p
 	return true
 }
 
+// shouldProcessReceipt validates if any subscriber is interested in a receipt of a request
+// to the given contract, sent by the given sender.
+func (p *SubscriptionValidator) shouldProcessReceipt(contract isc.Hname, sender string) bool {
+	p.filtersMutex.RLock()
+	defer p.filtersMutex.RUnlock()
+
+	if len(p.filters) < p.subscriptionManager.SubscribersSize() {
+		// there are subscribers without any filters
+		return true
+	}
+
+	for _, filter := range p.filters {
+		if filter.matchesReceipt(contract, sender) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterBlockEvents drops the events no subscriber is interested in.
+func (p *SubscriptionValidator) filterBlockEvents(events []*isc.Event) []*isc.Event {
+	p.filtersMutex.RLock()
+	defer p.filtersMutex.RUnlock()
+
+	if len(p.filters) < p.subscriptionManager.SubscribersSize() {
+		// there are subscribers without any filters
+		return events
+	}
+
+	return filterEvents(events, func(event *isc.Event) bool {
+		for _, filter := range p.filters {
+			if filter.matchesEvent(event) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func filterEvents(events []*isc.Event, matches func(event *isc.Event) bool) []*isc.Event {
+	filtered := make([]*isc.Event, 0, len(events))
+	for _, event := range events {
+		if matches(event) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
 // isClientAllowed validates if a certain subscriber has subscribed to a certain chainID and messageType.
 // it returns false if the client has not subscribed to those parameters
 // this usually means, that there is no need to process a certain outgoing event.
@@ -75,3 +287,35 @@ func (p *SubscriptionValidator) isClientAllowed(client *websockethub.Client, cha
 
 	return true
 }
+
+// filterEventForClient applies the filter topics of a client to an outgoing event.
+// it returns nil if the client is not interested in the event.
+func (p *SubscriptionValidator) filterEventForClient(client *websockethub.Client, iscEvent *ISCEvent) *ISCEvent {
+	p.filtersMutex.RLock()
+	defer p.filtersMutex.RUnlock()
+
+	filter, ok := p.filters[client.ID()]
+	if !ok {
+		return iscEvent
+	}
+
+	switch iscEvent.Kind {
+	case publisher.ISCEventKindReceipt:
+		if !filter.matchesReceipt(iscEvent.contract, iscEvent.Issuer) {
+			return nil
+		}
+	case publisher.ISCEventKindBlockEvents:
+		events := filterEvents(iscEvent.events, filter.matchesEvent)
+		if len(events) == 0 {
+			return nil
+		}
+		if len(events) < len(iscEvent.events) {
+			filtered := *iscEvent
+			filtered.Payload = events
+			filtered.events = events
+			return &filtered
+		}
+	}
+
+	return iscEvent
+}
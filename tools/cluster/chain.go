@@ -69,7 +69,7 @@ func (ch *Chain) OriginatorClient() *chainclient.Client {
 	return ch.Client(ch.OriginatorKeyPair)
 }
 
-func (ch *Chain) Client(keyPair *cryptolib.KeyPair, nodeIndex ...int) *chainclient.Client {
+func (ch *Chain) Client(keyPair cryptolib.VariantKeyPair, nodeIndex ...int) *chainclient.Client {
 	idx := 0
 	if len(nodeIndex) == 1 {
 		idx = nodeIndex[0]
@@ -82,7 +82,7 @@ func (ch *Chain) Client(keyPair *cryptolib.KeyPair, nodeIndex ...int) *chainclie
 	)
 }
 
-func (ch *Chain) SCClient(contractHname isc.Hname, sigScheme *cryptolib.KeyPair, nodeIndex ...int) *scclient.SCClient {
+func (ch *Chain) SCClient(contractHname isc.Hname, sigScheme cryptolib.VariantKeyPair, nodeIndex ...int) *scclient.SCClient {
 	return scclient.New(ch.Client(sigScheme, nodeIndex...), contractHname)
 }
 
@@ -110,7 +110,7 @@ func (e *ChainEnv) NewChainClient() *chainclient.Client {
 	return chainclient.New(e.Clu.L1Client(), e.Clu.WaspClient(0), e.Chain.ChainID, wallet)
 }
 
-func (e *ChainEnv) DepositFunds(amount uint64, keyPair *cryptolib.KeyPair) {
+func (e *ChainEnv) DepositFunds(amount uint64, keyPair cryptolib.VariantKeyPair) {
 	accountsClient := e.Chain.SCClient(accounts.Contract.Hname(), keyPair)
 	tx, err := accountsClient.PostRequest(accounts.FuncDeposit.Name, chainclient.PostRequestParams{
 		Transfer: isc.NewAssetsBaseTokens(amount),
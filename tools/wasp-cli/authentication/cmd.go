@@ -27,6 +27,11 @@ func Init(rootCmd *cobra.Command) {
 
 	authCmd.AddCommand(loginCmd)
 	authCmd.AddCommand(infoCmd)
+	authCmd.AddCommand(initRolesCmd())
+	authCmd.AddCommand(initUsersCmd())
+	authCmd.AddCommand(initAddUserCmd())
+	authCmd.AddCommand(initSetRolesCmd())
+	authCmd.AddCommand(initDeleteUserCmd())
 
 	loginCmd.PersistentFlags().StringVarP(&username, "username", "u", "", "username")
 	loginCmd.PersistentFlags().StringVarP(&password, "password", "p", "", "password")
@@ -0,0 +1,153 @@
+package authentication
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/iotaledger/wasp/clients/apiclient"
+	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/cliclients"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/tools/wasp-cli/waspcmd"
+)
+
+// rolesFromArgs validates the given roles and restricts them to the given chain, if any
+func rolesFromArgs(args []string, chainName string) []string {
+	roles := make([]string, len(args))
+	for i, role := range args {
+		if chainName != "" {
+			role = permissions.Scoped(role, config.GetChain(chainName))
+		}
+		log.Check(permissions.Validate(role))
+		roles[i] = role
+	}
+	return roles
+}
+
+func initRolesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "roles",
+		Short: "List the roles that can be granted to a user",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			header := []string{"role", "permissions"}
+			roles := permissions.Roles()
+			rows := make([][]string, len(roles))
+			for i, role := range roles {
+				rows[i] = []string{role, strings.Join(permissions.RolePermissions(role), ", ")}
+			}
+			log.PrintTable(header, rows)
+			log.Printf("\nRoles can be restricted to a single chain with %s<chainID>, or with the --chain flag.\n", permissions.ChainScopeSeparator)
+		},
+	}
+}
+
+func initUsersCmd() *cobra.Command {
+	var node string
+	cmd := &cobra.Command{
+		Use:   "users",
+		Short: "List the users of a Wasp node",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			users, _, err := cliclients.WaspClient(node).UsersApi.GetUsers(context.Background()).Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			header := []string{"username", "roles"}
+			rows := make([][]string, len(users))
+			for i, user := range users {
+				sort.Strings(user.Permissions)
+				rows[i] = []string{user.Username, strings.Join(user.Permissions, ", ")}
+			}
+			log.PrintTable(header, rows)
+		},
+	}
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	return cmd
+}
+
+func initAddUserCmd() *cobra.Command {
+	var node, chainName, userPassword string
+	cmd := &cobra.Command{
+		Use:   "add-user <username> [<role>...]",
+		Short: "Add a user to a Wasp node",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			roles := rolesFromArgs(args[1:], chainName)
+
+			if userPassword == "" {
+				log.Printf("Password: ")
+				// int cast is needed for windows
+				passwordBytes, err := term.ReadPassword(int(syscall.Stdin)) //nolint:unconvert
+				log.Check(err)
+				log.Printf("\n")
+				userPassword = string(passwordBytes)
+			}
+			if userPassword == "" {
+				log.Fatal("password must not be empty")
+			}
+
+			_, err := cliclients.WaspClient(node).UsersApi.AddUser(context.Background()).AddUserRequest(apiclient.AddUserRequest{
+				Username:    args[0],
+				Password:    userPassword,
+				Permissions: roles,
+			}).Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			log.Printf("User %s added\n", args[0])
+		},
+	}
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	cmd.Flags().StringVar(&chainName, "chain", "", "restrict the roles to the chain with this name")
+	cmd.Flags().StringVarP(&userPassword, "password", "p", "", "password of the new user")
+	return cmd
+}
+
+func initSetRolesCmd() *cobra.Command {
+	var node, chainName string
+	cmd := &cobra.Command{
+		Use:   "set-roles <username> [<role>...]",
+		Short: "Replace the roles of a user of a Wasp node",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			roles := rolesFromArgs(args[1:], chainName)
+
+			_, err := cliclients.WaspClient(node).UsersApi.ChangeUserPermissions(context.Background(), args[0]).
+				UpdateUserPermissionsRequest(apiclient.UpdateUserPermissionsRequest{
+					Permissions: roles,
+				}).Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			log.Printf("Roles of user %s updated\n", args[0])
+		},
+	}
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	cmd.Flags().StringVar(&chainName, "chain", "", "restrict the roles to the chain with this name")
+	return cmd
+}
+
+func initDeleteUserCmd() *cobra.Command {
+	var node string
+	cmd := &cobra.Command{
+		Use:   "delete-user <username>",
+		Short: "Delete a user of a Wasp node",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			_, err := cliclients.WaspClient(node).UsersApi.DeleteUser(context.Background(), args[0]).Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			log.Printf("User %s deleted\n", args[0])
+		},
+	}
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	return cmd
+}
@@ -25,7 +25,7 @@ func initPermissionlessAccessNodesCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 
 			chainID := config.GetChain(chain)
 			action := args[0]
@@ -63,7 +63,7 @@ func initPermissionlessAccessNodesCmd() *cobra.Command {
 	}
 
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	waspcmd.WithPeersFlag(cmd, &peers)
 
 	return cmd
@@ -34,7 +34,7 @@ func initListAccountsCmd() *cobra.Command {
 		Args:  cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 
 			client := cliclients.WaspClient(node)
 			chainID := config.GetChain(chain)
@@ -54,7 +54,7 @@ func initListAccountsCmd() *cobra.Command {
 	}
 
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	return cmd
 }
 
@@ -67,7 +67,7 @@ func initBalanceCmd() *cobra.Command {
 		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 			chainID := config.GetChain(chain)
 			agentID := util.AgentIDFromArgs(args, chainID)
 			client := cliclients.WaspClient(node)
@@ -88,7 +88,7 @@ func initBalanceCmd() *cobra.Command {
 	}
 
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	return cmd
 }
 
@@ -101,7 +101,7 @@ func initAccountNFTsCmd() *cobra.Command {
 		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 			chainID := config.GetChain(chain)
 			agentID := util.AgentIDFromArgs(args, chainID)
 			client := cliclients.WaspClient(node)
@@ -118,7 +118,7 @@ func initAccountNFTsCmd() *cobra.Command {
 	}
 
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	return cmd
 }
 
@@ -159,7 +159,7 @@ func initDepositCmd() *cobra.Command {
 		Args:  cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 
 			chainID := config.GetChain(chain)
 			if strings.Contains(args[0], ":") {
@@ -220,7 +220,7 @@ func initDepositCmd() *cobra.Command {
 
 	cmd.Flags().BoolVarP(&adjustStorageDeposit, "adjust-storage-deposit", "s", false, "adjusts the amount of base tokens sent, if it's lower than the min storage deposit required")
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 
 	return cmd
 }
@@ -23,7 +23,7 @@ func initActivateCmd() *cobra.Command {
 		Args:  cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chainName = defaultChainFallback(chainName)
+			chainName = DefaultChainFallback(chainName)
 			chainID := config.GetChain(chainName)
 			activateChain(node, chainName, chainID)
 		},
@@ -31,7 +31,7 @@ func initActivateCmd() *cobra.Command {
 
 	waspcmd.WithWaspNodeFlag(cmd, &node)
 
-	withChainFlag(cmd, &chainName)
+	WithChainFlag(cmd, &chainName)
 	return cmd
 }
 
@@ -71,7 +71,7 @@ func initDeactivateCmd() *cobra.Command {
 		Short: "Deactivates the chain on selected nodes",
 		Args:  cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			chainName = defaultChainFallback(chainName)
+			chainName = DefaultChainFallback(chainName)
 
 			chainID := config.GetChain(chainName)
 			node = waspcmd.DefaultWaspNodeFallback(node)
@@ -82,6 +82,6 @@ func initDeactivateCmd() *cobra.Command {
 		},
 	}
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chainName)
+	WithChainFlag(cmd, &chainName)
 	return cmd
 }
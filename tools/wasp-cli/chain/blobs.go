@@ -34,14 +34,14 @@ func initStoreBlobCmd() *cobra.Command {
 		Args:  cobra.MinimumNArgs(4),
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 
 			chainID := config.GetChain(chain)
 			uploadBlob(cliclients.WaspClient(node), chainID, util.EncodeParams(args, chainID))
 		},
 	}
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	return cmd
 }
 
@@ -64,7 +64,7 @@ func initShowBlobCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 
 			hash, err := hashing.HashValueFromHex(args[0])
 			log.Check(err)
@@ -95,7 +95,7 @@ func initShowBlobCmd() *cobra.Command {
 		},
 	}
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	return cmd
 }
 
@@ -108,7 +108,7 @@ func initListBlobsCmd() *cobra.Command {
 		Args:  cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 			client := cliclients.WaspClient(node)
 
 			blobsResponse, _, err := client.
@@ -131,6 +131,6 @@ func initListBlobsCmd() *cobra.Command {
 		},
 	}
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	return cmd
 }
@@ -28,7 +28,7 @@ func initBlockCmd() *cobra.Command {
 		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 
 			bi := fetchBlockInfo(args, node, chain)
 			log.Printf("Block index: %d\n", bi.BlockIndex)
@@ -43,7 +43,7 @@ func initBlockCmd() *cobra.Command {
 		},
 	}
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	return cmd
 }
 
@@ -132,7 +132,7 @@ func initRequestCmd() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 			chainID := config.GetChain(chain)
 
 			client := cliclients.WaspClient(node)
@@ -154,7 +154,7 @@ func initRequestCmd() *cobra.Command {
 		},
 	}
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	return cmd
 }
 
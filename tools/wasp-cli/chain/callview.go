@@ -25,7 +25,7 @@ func initCallViewCmd() *cobra.Command {
 		Args:  cobra.MinimumNArgs(2),
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 
 			client := cliclients.WaspClient(node)
 
@@ -49,7 +49,7 @@ func initCallViewCmd() *cobra.Command {
 		},
 	}
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 
 	return cmd
 }
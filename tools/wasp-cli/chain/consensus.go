@@ -0,0 +1,128 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package chain
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/wasp/clients/apiextensions"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/cliclients"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/tools/wasp-cli/waspcmd"
+)
+
+func initConsensusStatusCmd() *cobra.Command {
+	var node string
+	var chain string
+	cmd := &cobra.Command{
+		Use:   "consensus-status",
+		Short: "Show the status of the consensus instances and committee members, as seen by the node",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			chain = DefaultChainFallback(chain)
+
+			chainID := config.GetChain(chain)
+			client := cliclients.WaspClient(node)
+
+			status, err := apiextensions.GetConsensusStatus(context.Background(), client, chainID.String())
+			log.Check(err)
+
+			if len(status.Committees) == 0 {
+				log.Printf("The node is not a member of any committee of the chain.\n")
+				return
+			}
+			for i, cmt := range status.Committees {
+				if i > 0 {
+					log.Printf("\n")
+				}
+				printCommitteeConsensusStatus(cmt)
+			}
+		},
+	}
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	WithChainFlag(cmd, &chain)
+	return cmd
+}
+
+func printCommitteeConsensusStatus(cmt *models.CommitteeConsensusStatus) {
+	log.Printf("Committee: %s\n", cmt.Address)
+	log.Printf("Active: %v\n", cmt.Active)
+
+	if cmt.CmtLog != nil {
+		log.Printf("Log index: min=%d, agreed=%d, output=%d\n", cmt.CmtLog.MinLogIndex, cmt.CmtLog.AgreedLogIndex, cmt.CmtLog.OutputLogIndex)
+		log.Printf("Confirmed alias output: %s\n", orNA(cmt.CmtLog.LocalView.ConfirmedAliasOutputID))
+		log.Printf("Tip alias output: %s\n", orNA(cmt.CmtLog.LocalView.TipAliasOutputID))
+		if len(cmt.CmtLog.LocalView.Pending) > 0 {
+			log.Printf("\nPending alias outputs: %d\n", len(cmt.CmtLog.LocalView.Pending))
+			rows := make([][]string, len(cmt.CmtLog.LocalView.Pending))
+			for i, p := range cmt.CmtLog.LocalView.Pending {
+				rows[i] = []string{
+					strconv.FormatUint(uint64(p.StateIndex), 10),
+					p.AliasOutputID,
+					p.ConsumedAliasOutputID,
+					strconv.FormatUint(uint64(p.LogIndex), 10),
+					strconv.FormatBool(p.Rejected),
+				}
+			}
+			log.PrintTable([]string{"StateIndex", "AliasOutput", "Consumed", "LogIndex", "Rejected"}, rows)
+		}
+	} else {
+		log.Printf("Log index: N/A\n")
+	}
+
+	log.Printf("\nPeers: %d\n", len(cmt.Peers))
+	peerRows := make([][]string, len(cmt.Peers))
+	for i, p := range cmt.Peers {
+		lastMessage := "N/A"
+		if p.LastMessage != nil {
+			lastMessage = p.LastMessage.Format(time.RFC3339)
+		}
+		peerRows[i] = []string{
+			strconv.Itoa(int(p.Index)),
+			p.PublicKey,
+			orNA(p.PeeringURL),
+			strconv.FormatBool(p.Connected),
+			strconv.FormatUint(uint64(p.LogIndex), 10),
+			lastMessage,
+		}
+	}
+	log.PrintTable([]string{"Index", "PubKey", "PeeringURL", "Connected", "LogIndex", "LastMessage"}, peerRows)
+
+	log.Printf("\nConsensus instances: %d\n", len(cmt.Instances))
+	instRows := make([][]string, len(cmt.Instances))
+	for i, inst := range cmt.Instances {
+		instRows[i] = []string{
+			strconv.FormatUint(uint64(inst.LogIndex), 10),
+			orNA(inst.BaseAliasOutputID),
+		}
+		if !inst.Responded {
+			instRows[i] = append(instRows[i], "N/A", "N/A", "N/A", "N/A", "N/A", "N/A", "N/A")
+			continue
+		}
+		instRows[i] = append(instRows[i],
+			inst.Output,
+			inst.SyncACS,
+			inst.SyncDSS,
+			inst.SyncMP,
+			inst.SyncSM,
+			inst.SyncVM,
+			inst.SyncTX,
+		)
+	}
+	log.PrintTable([]string{"LogIndex", "BaseAliasOutput", "Output", "ACS", "DSS", "MP", "SM", "VM", "TX"}, instRows)
+}
+
+func orNA(s string) string {
+	if s == "" {
+		return "N/A"
+	}
+	return s
+}
@@ -67,7 +67,7 @@ func initDeployCmd() *cobra.Command {
 		Args:  cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chainName = defaultChainFallback(chainName)
+			chainName = DefaultChainFallback(chainName)
 
 			if !util.IsSlug(chainName) {
 				log.Fatalf("invalid chain name: %s, must be in slug format, only lowercase and hyphens, example: foo-bar", chainName)
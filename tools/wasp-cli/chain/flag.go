@@ -7,11 +7,11 @@ import (
 	"github.com/iotaledger/wasp/tools/wasp-cli/log"
 )
 
-func withChainFlag(cmd *cobra.Command, chainName *string) {
+func WithChainFlag(cmd *cobra.Command, chainName *string) {
 	cmd.Flags().StringVar(chainName, "chain", "", "target chain name")
 }
 
-func defaultChainFallback(chainName string) string {
+func DefaultChainFallback(chainName string) string {
 	if chainName != "" {
 		return chainName
 	}
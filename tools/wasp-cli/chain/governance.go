@@ -33,7 +33,7 @@ func initChangeAccessNodesCmd() *cobra.Command {
 		Args:  cobra.MinimumNArgs(2),
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 
 			if len(args)%2 != 0 {
 				log.Fatal("wrong number of arguments")
@@ -67,7 +67,7 @@ func initChangeAccessNodesCmd() *cobra.Command {
 	}
 
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	cmd.Flags().BoolVarP(&offLedger, "off-ledger", "o", false,
 		"post an off-ledger request",
 	)
@@ -85,7 +85,7 @@ func initDisableFeePolicyCmd() *cobra.Command {
 		Short: "set token charged by each gas to free.",
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 			client := cliclients.WaspClient(node)
 
 			callGovView := func(viewName string) dict.Dict {
@@ -123,7 +123,7 @@ func initDisableFeePolicyCmd() *cobra.Command {
 	}
 
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	cmd.Flags().BoolVarP(&offLedger, "off-ledger", "o", false,
 		"post an off-ledger request",
 	)
@@ -89,7 +89,7 @@ func initMetadataCmd() *cobra.Command {
 		Args:  cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chainAliasName = defaultChainFallback(chainAliasName)
+			chainAliasName = DefaultChainFallback(chainAliasName)
 			chainID := config.GetChain(chainAliasName)
 
 			updateMetadata(node, chainAliasName, chainID, withOffLedger, useCliURL, metadataArgs)
@@ -97,7 +97,7 @@ func initMetadataCmd() *cobra.Command {
 	}
 
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chainAliasName)
+	WithChainFlag(cmd, &chainAliasName)
 
 	cmd.Flags().BoolVarP(&withOffLedger, "off-ledger", "o", false,
 		"post an off-ledger request",
@@ -64,7 +64,7 @@ func initPostRequestCmd() *cobra.Command {
 		Args:  cobra.MinimumNArgs(2),
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 			chainID := config.GetChain(chain)
 			hname := args[0]
 			fname := args[1]
@@ -80,7 +80,7 @@ func initPostRequestCmd() *cobra.Command {
 	}
 
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	postRequestParams.initFlags(cmd)
 
 	return cmd
@@ -59,7 +59,7 @@ func buildPostRequestCmd(name, desc, hname, fname string, initFlags func(cmd *co
 		Args:  cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 			chainID := config.GetChain(chain)
 
 			allowanceTokens := util.ParseFungibleTokens(postrequestParams.allowance)
@@ -81,7 +81,7 @@ func buildPostRequestCmd(name, desc, hname, fname string, initFlags func(cmd *co
 		},
 	}
 	waspcmd.WithWaspNodeFlag(cmd, &node)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	postrequestParams.initFlags(cmd)
 	initFlags(cmd)
 
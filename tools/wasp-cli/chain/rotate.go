@@ -28,7 +28,7 @@ func initRotateCmd() *cobra.Command {
 		Short: "Issues a tx that changes the chain state controller",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 
 			prefix, newStateControllerAddr, err := iotago.ParseBech32(args[0])
 			log.Check(err)
@@ -38,7 +38,7 @@ func initRotateCmd() *cobra.Command {
 			rotateTo(chain, newStateControllerAddr)
 		},
 	}
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	return cmd
 }
 
@@ -57,7 +57,7 @@ func initRotateWithDKGCmd() *cobra.Command {
 		Short: "Runs the DKG on the selected peers, then issues a tx that changes the chain state controller",
 		Args:  cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			chain = defaultChainFallback(chain)
+			chain = DefaultChainFallback(chain)
 			node = waspcmd.DefaultWaspNodeFallback(node)
 
 			if !skipMaintenance {
@@ -72,7 +72,7 @@ func initRotateWithDKGCmd() *cobra.Command {
 
 	waspcmd.WithWaspNodeFlag(cmd, &node)
 	waspcmd.WithPeersFlag(cmd, &peers)
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	cmd.Flags().IntVarP(&quorum, "quorum", "", 0, "quorum (default: 3/4s of the number of committee nodes)")
 	cmd.Flags().BoolVar(&skipMaintenance, "skip-maintenance", false, "quorum (default: 3/4s of the number of committee nodes)")
 	cmd.Flags().BoolVarP(&offLedger, "off-ledger", "o", true,
@@ -155,7 +155,7 @@ func initChangeGovControllerCmd() *cobra.Command {
 		Short: "Changes the governance controller for a given chain (WARNING: you will lose control over the chain)",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			chain := config.GetChain(defaultChainFallback(chain))
+			chain := config.GetChain(DefaultChainFallback(chain))
 
 			_, newGovController, err := iotago.ParseBech32(args[0])
 			log.Check(err)
@@ -178,6 +178,6 @@ func initChangeGovControllerCmd() *cobra.Command {
 		},
 	}
 
-	withChainFlag(cmd, &chain)
+	WithChainFlag(cmd, &chain)
 	return cmd
 }
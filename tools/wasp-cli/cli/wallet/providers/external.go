@@ -0,0 +1,160 @@
+package providers
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/spf13/viper"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+)
+
+// The external signer is a separate process that keeps the keys and listens on a local (unix) socket.
+// For each connection, wasp-cli writes one SignerRequest and reads one SignerResponse,
+// both encoded as a single line of JSON.
+//
+//	{"method":"publicKey","addressIndex":0}
+//	{"publicKey":"0x..."}
+//
+//	{"method":"sign","addressIndex":0,"payload":"0x..."}
+//	{"signature":"0x..."}
+//
+// On failure, the signer responds with {"error":"..."}.
+const (
+	SignerMethodPublicKey = "publicKey"
+	SignerMethodSign      = "sign"
+
+	signerTimeout = 5 * time.Minute // signing may require a confirmation by the user
+)
+
+type SignerRequest struct {
+	Method       string `json:"method"`
+	AddressIndex uint32 `json:"addressIndex"`
+	Payload      string `json:"payload,omitempty"`
+}
+
+type SignerResponse struct {
+	PublicKey string `json:"publicKey,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ExternalSignerSocket returns the path of the socket of the external signer
+func ExternalSignerSocket() string {
+	socket := viper.GetString("wallet.signerSocket")
+	if socket == "" {
+		log.Fatal("external signer socket not set, call `init --provider external --signer-socket <path>` first")
+	}
+	return socket
+}
+
+type externalKeyPair struct {
+	socket       string
+	addressIndex uint32
+	publicKey    *cryptolib.PublicKey
+}
+
+var _ cryptolib.VariantKeyPair = &externalKeyPair{}
+
+// LoadExternalSigner returns a key pair whose signatures are produced by the external signer
+func LoadExternalSigner(addressIndex uint32) cryptolib.VariantKeyPair {
+	kp := &externalKeyPair{
+		socket:       ExternalSignerSocket(),
+		addressIndex: addressIndex,
+	}
+	res, err := kp.call(&SignerRequest{Method: SignerMethodPublicKey, AddressIndex: addressIndex})
+	log.Check(err)
+	kp.publicKey, err = cryptolib.PublicKeyFromString(res.PublicKey)
+	log.Check(err)
+	return kp
+}
+
+func (kp *externalKeyPair) call(req *SignerRequest) (*SignerResponse, error) {
+	conn, err := net.DialTimeout("unix", kp.socket, 5*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to the external signer: %w", err)
+	}
+	defer conn.Close()
+	if err = conn.SetDeadline(time.Now().Add(signerTimeout)); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = conn.Write(append(data, '\n')); err != nil {
+		return nil, err
+	}
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the response of the external signer: %w", err)
+	}
+	var res SignerResponse
+	if err = json.Unmarshal(line, &res); err != nil {
+		return nil, err
+	}
+	if res.Error != "" {
+		return nil, fmt.Errorf("external signer: %s", res.Error)
+	}
+	return &res, nil
+}
+
+func (kp *externalKeyPair) GetPublicKey() *cryptolib.PublicKey {
+	return kp.publicKey
+}
+
+func (kp *externalKeyPair) Address() *iotago.Ed25519Address {
+	return kp.publicKey.AsEd25519Address()
+}
+
+func (kp *externalKeyPair) AsAddressSigner() iotago.AddressSigner {
+	return kp
+}
+
+// Sign implements iotago.AddressSigner
+func (kp *externalKeyPair) Sign(addr iotago.Address, msg []byte) (iotago.Signature, error) {
+	if !addr.Equal(kp.Address()) {
+		return nil, fmt.Errorf("can't sign for address %s", addr)
+	}
+	signature, err := kp.signBytes(msg)
+	if err != nil {
+		return nil, err
+	}
+	ret := &iotago.Ed25519Signature{}
+	copy(ret.PublicKey[:], kp.publicKey.AsBytes())
+	copy(ret.Signature[:], signature)
+	return ret, nil
+}
+
+func (kp *externalKeyPair) SignBytes(data []byte) []byte {
+	signature, err := kp.signBytes(data)
+	log.Check(err)
+	return signature
+}
+
+func (kp *externalKeyPair) signBytes(data []byte) ([]byte, error) {
+	res, err := kp.call(&SignerRequest{
+		Method:       SignerMethodSign,
+		AddressIndex: kp.addressIndex,
+		Payload:      iotago.EncodeHex(data),
+	})
+	if err != nil {
+		return nil, err
+	}
+	signature, err := iotago.DecodeHex(res.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if !kp.publicKey.Verify(data, signature) {
+		return nil, errors.New("invalid signature returned by the external signer")
+	}
+	return signature, nil
+}
@@ -0,0 +1,92 @@
+package providers
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+)
+
+// runTestSigner serves the external signer protocol for the given key pair.
+// If tamper is set, the signatures returned are not valid.
+func runTestSigner(t *testing.T, kp *cryptolib.KeyPair, tamper bool) string {
+	socket := filepath.Join(t.TempDir(), "signer.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			var req SignerRequest
+			line, err := bufio.NewReader(conn).ReadBytes('\n')
+			if err == nil {
+				err = json.Unmarshal(line, &req)
+			}
+			var res SignerResponse
+			switch {
+			case err != nil:
+				res.Error = err.Error()
+			case req.Method == SignerMethodPublicKey:
+				res.PublicKey = kp.GetPublicKey().String()
+			case req.Method == SignerMethodSign:
+				payload, err := iotago.DecodeHex(req.Payload)
+				if err != nil {
+					res.Error = err.Error()
+					break
+				}
+				signature := kp.GetPrivateKey().Sign(payload)
+				if tamper {
+					signature[0] ^= 0xff
+				}
+				res.Signature = iotago.EncodeHex(signature)
+			default:
+				res.Error = "unknown method"
+			}
+			data, _ := json.Marshal(&res)
+			_, _ = conn.Write(append(data, '\n'))
+			conn.Close()
+		}
+	}()
+	return socket
+}
+
+func TestExternalSigner(t *testing.T) {
+	kp := cryptolib.NewKeyPair()
+	viper.Set("wallet.signerSocket", runTestSigner(t, kp, false))
+	t.Cleanup(func() { viper.Set("wallet.signerSocket", "") })
+
+	signer := LoadExternalSigner(0)
+	require.Equal(t, kp.GetPublicKey(), signer.GetPublicKey())
+	require.Equal(t, kp.Address(), signer.Address())
+
+	msg := []byte("some message")
+	require.True(t, kp.GetPublicKey().Verify(msg, signer.SignBytes(msg)))
+
+	signature, err := signer.AsAddressSigner().Sign(kp.Address(), msg)
+	require.NoError(t, err)
+	require.NoError(t, signature.(*iotago.Ed25519Signature).Valid(msg, kp.Address()))
+
+	_, err = signer.AsAddressSigner().Sign(cryptolib.NewKeyPair().Address(), msg)
+	require.Error(t, err)
+}
+
+func TestExternalSignerInvalidSignature(t *testing.T) {
+	kp := cryptolib.NewKeyPair()
+	viper.Set("wallet.signerSocket", runTestSigner(t, kp, true))
+	t.Cleanup(func() { viper.Set("wallet.signerSocket", "") })
+
+	signer := LoadExternalSigner(0)
+	_, err := signer.AsAddressSigner().Sign(kp.Address(), []byte("some message"))
+	require.ErrorContains(t, err, "invalid signature returned by the external signer")
+}
@@ -0,0 +1,102 @@
+package providers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+)
+
+const (
+	keystoreVersion = 1
+
+	// KeystorePasswordEnv is the environment variable read for the keystore password,
+	// before prompting for it
+	KeystorePasswordEnv = "WASP_CLI_KEYSTORE_PASSWORD"
+)
+
+// keystoreFile is the content of the keystore file: the master seed of the wallet,
+// encrypted with scrypt and AES-128-CTR like the Ethereum V3 keystore files
+type keystoreFile struct {
+	Version int                 `json:"version"`
+	Crypto  keystore.CryptoJSON `json:"crypto"`
+}
+
+// keystoreSeeds caches the decrypted seeds, so that the password is asked only once per command
+var keystoreSeeds = map[string][]byte{}
+
+// KeystorePath returns the path of the keystore file, which defaults to a file next to the config file
+func KeystorePath() string {
+	if path := viper.GetString("wallet.keystore"); path != "" {
+		return path
+	}
+	return filepath.Join(filepath.Dir(config.ConfigPath), "wasp-cli.keystore.json")
+}
+
+// LoadKeystore returns the key pair derived from the seed stored in the keystore file
+func LoadKeystore(addressIndex uint32) cryptolib.VariantKeyPair {
+	path := KeystorePath()
+	if masterSeed, ok := keystoreSeeds[path]; ok {
+		return keyPairFromMasterSeed(masterSeed, addressIndex)
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		log.Fatalf("keystore %s not found, call `init --provider keystore` first", path)
+	}
+	log.Check(err)
+
+	var ks keystoreFile
+	log.Check(json.Unmarshal(data, &ks))
+	if ks.Version != keystoreVersion {
+		log.Fatalf("unsupported keystore version %d", ks.Version)
+	}
+
+	masterSeed, err := keystore.DecryptDataV3(ks.Crypto, string(readPassword(fmt.Sprintf("Password for %s: ", path))))
+	if err != nil {
+		log.Fatalf("cannot decrypt keystore %s: %v", path, err)
+	}
+	keystoreSeeds[path] = masterSeed
+	return keyPairFromMasterSeed(masterSeed, addressIndex)
+}
+
+// StoreKeystore encrypts the seed with a password and writes it to the keystore file
+func StoreKeystore(seed []byte) string {
+	password := readPassword("New keystore password: ")
+	if os.Getenv(KeystorePasswordEnv) == "" && string(readPassword("Repeat password: ")) != string(password) {
+		log.Fatal("passwords do not match")
+	}
+	if len(password) == 0 {
+		log.Fatal("the keystore password can't be empty")
+	}
+
+	cryptoJSON, err := keystore.EncryptDataV3(seed, password, keystore.StandardScryptN, keystore.StandardScryptP)
+	log.Check(err)
+	data, err := json.MarshalIndent(&keystoreFile{Version: keystoreVersion, Crypto: cryptoJSON}, "", "  ")
+	log.Check(err)
+
+	path := KeystorePath()
+	log.Check(os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func readPassword(prompt string) []byte {
+	if password := os.Getenv(KeystorePasswordEnv); password != "" {
+		return []byte(password)
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	// int cast is needed for windows
+	password, err := term.ReadPassword(int(syscall.Stdin)) //nolint:unconvert
+	fmt.Fprintln(os.Stderr)
+	log.Check(err)
+	return password
+}
@@ -0,0 +1,28 @@
+package providers
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/cryptolib"
+)
+
+func TestKeystoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wasp-cli.keystore.json")
+	viper.Set("wallet.keystore", path)
+	t.Cleanup(func() { viper.Set("wallet.keystore", "") })
+	t.Setenv(KeystorePasswordEnv, "correct horse battery staple")
+
+	seed := cryptolib.NewSeed()
+	require.Equal(t, path, StoreKeystore(seed[:]))
+
+	delete(keystoreSeeds, path)
+	for _, addressIndex := range []uint32{0, 1} {
+		kp := LoadKeystore(addressIndex)
+		require.Equal(t, keyPairFromMasterSeed(seed[:], addressIndex).GetPublicKey(), kp.GetPublicKey())
+	}
+	require.Equal(t, seed[:], keystoreSeeds[path], "the decrypted seed must be cached")
+}
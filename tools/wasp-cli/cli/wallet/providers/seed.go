@@ -0,0 +1,33 @@
+package providers
+
+import (
+	"github.com/spf13/viper"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+)
+
+// LoadSeed returns the key pair derived from the plain seed stored in the config file
+func LoadSeed(addressIndex uint32) cryptolib.VariantKeyPair {
+	seedHex := viper.GetString("wallet.seed")
+	if seedHex == "" {
+		log.Fatal("call `init` first")
+	}
+
+	masterSeed, err := iotago.DecodeHex(seedHex)
+	log.Check(err)
+
+	return keyPairFromMasterSeed(masterSeed, addressIndex)
+}
+
+// StoreSeed stores the seed as plain hex in the config file
+func StoreSeed(seed []byte) {
+	config.Set("wallet.seed", iotago.EncodeHex(seed))
+}
+
+func keyPairFromMasterSeed(masterSeed []byte, addressIndex uint32) *cryptolib.KeyPair {
+	useLegacyDerivation := viper.GetBool("wallet.useLegacyDerivation")
+	return cryptolib.KeyPairFromSeed(cryptolib.SubSeed(masterSeed, addressIndex, useLegacyDerivation))
+}
@@ -1,37 +1,75 @@
 package wallet
 
 import (
+	"fmt"
+
 	"github.com/spf13/viper"
 
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/wallet/providers"
 	"github.com/iotaledger/wasp/tools/wasp-cli/log"
 )
 
 var AddressIndex int
 
-type Wallet struct {
-	KeyPair      *cryptolib.KeyPair
-	AddressIndex int
-}
+// WalletProvider is the source of the keys of the wallet
+type WalletProvider string
 
-func Load() *Wallet {
-	seedHex := viper.GetString("wallet.seed")
-	useLegacyDerivation := viper.GetBool("wallet.useLegacyDerivation")
-	if seedHex == "" {
-		log.Fatal("call `init` first")
+const (
+	// ProviderSeed derives the keys from the plain seed stored in the config file
+	ProviderSeed WalletProvider = "seed"
+	// ProviderKeystore derives the keys from the seed stored in a password-encrypted keystore file
+	ProviderKeystore WalletProvider = "keystore"
+	// ProviderExternal delegates the signatures to an external signer process
+	ProviderExternal WalletProvider = "external"
+)
+
+var AllProviders = []WalletProvider{ProviderSeed, ProviderKeystore, ProviderExternal}
+
+func ParseWalletProvider(s string) (WalletProvider, error) {
+	for _, p := range AllProviders {
+		if string(p) == s {
+			return p, nil
+		}
 	}
+	return "", fmt.Errorf("invalid wallet provider %q, must be one of %v", s, AllProviders)
+}
 
-	masterSeed, err := iotago.DecodeHex(seedHex)
+func GetWalletProvider() WalletProvider {
+	provider := viper.GetString("wallet.provider")
+	if provider == "" {
+		return ProviderSeed
+	}
+	p, err := ParseWalletProvider(provider)
 	log.Check(err)
+	return p
+}
 
-	kp := cryptolib.KeyPairFromSeed(cryptolib.SubSeed(masterSeed, uint32(AddressIndex), useLegacyDerivation))
+type Wallet struct {
+	KeyPair      cryptolib.VariantKeyPair
+	AddressIndex int
+}
 
+func Load() *Wallet {
+	var kp cryptolib.VariantKeyPair
+	switch GetWalletProvider() {
+	case ProviderKeystore:
+		kp = providers.LoadKeystore(uint32(AddressIndex))
+	case ProviderExternal:
+		kp = providers.LoadExternalSigner(uint32(AddressIndex))
+	default:
+		kp = providers.LoadSeed(uint32(AddressIndex))
+	}
 	return &Wallet{KeyPair: kp, AddressIndex: AddressIndex}
 }
 
+// PrivateKey returns the private key of the wallet, or nil if the provider does not expose it
 func (w *Wallet) PrivateKey() *cryptolib.PrivateKey {
-	return w.KeyPair.GetPrivateKey()
+	if kp, ok := w.KeyPair.(*cryptolib.KeyPair); ok {
+		return kp.GetPrivateKey()
+	}
+	return nil
 }
 
 func (w *Wallet) Address() iotago.Address {
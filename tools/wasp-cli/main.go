@@ -19,6 +19,7 @@ import (
 	"github.com/iotaledger/wasp/tools/wasp-cli/decode"
 	"github.com/iotaledger/wasp/tools/wasp-cli/log"
 	"github.com/iotaledger/wasp/tools/wasp-cli/metrics"
+	"github.com/iotaledger/wasp/tools/wasp-cli/node"
 	"github.com/iotaledger/wasp/tools/wasp-cli/peering"
 	"github.com/iotaledger/wasp/tools/wasp-cli/wallet"
 	"github.com/iotaledger/wasp/tools/wasp-cli/waspcmd"
@@ -69,6 +70,7 @@ func init() {
 	decode.Init(rootCmd)
 	peering.Init(rootCmd)
 	metrics.Init(rootCmd)
+	node.Init(rootCmd)
 }
 
 func main() {
@@ -0,0 +1,56 @@
+package node
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/wasp/clients/apiextensions"
+	"github.com/iotaledger/wasp/tools/wasp-cli/chain"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/cliclients"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/tools/wasp-cli/waspcmd"
+)
+
+func initBackupCmd() *cobra.Command {
+	var node string
+	var chainName string
+	cmd := &cobra.Command{
+		Use:   "backup [<output file>]",
+		Short: "Creates a backup of the chain state and registry data of the node, without stopping the chain",
+		Long: `Creates a backup of the chain state and registry data of the node, without stopping the chain.
+The DK shares of the node are included only if the key encryption is enabled on the node, they are
+encrypted with it, so they can only be restored by a node using the same passphrase or key file.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			chainName = chain.DefaultChainFallback(chainName)
+			chainID := config.GetChain(chainName)
+
+			fileName := chainName + ".backup"
+			if len(args) > 0 {
+				fileName = args[0]
+			}
+
+			f, err := os.Create(fileName)
+			log.Check(err)
+			defer f.Close()
+
+			client := cliclients.WaspClient(node)
+			err = apiextensions.BackupChain(context.Background(), client, chainID.String(), f)
+			if err != nil {
+				f.Close()
+				os.Remove(fileName)
+				log.Check(err)
+			}
+
+			log.Printf("Backup of chain %s written to %s\n", chainID.String(), fileName)
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	chain.WithChainFlag(cmd, &chainName)
+	return cmd
+}
@@ -0,0 +1,26 @@
+package node
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+)
+
+func initNodeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "node <command>",
+		Short: "Manage the data of a wasp node",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Check(cmd.Help())
+		},
+	}
+}
+
+func Init(rootCmd *cobra.Command) {
+	nodeCmd := initNodeCmd()
+	rootCmd.AddCommand(nodeCmd)
+
+	nodeCmd.AddCommand(initBackupCmd())
+	nodeCmd.AddCommand(initRestoreCmd())
+}
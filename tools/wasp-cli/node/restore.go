@@ -0,0 +1,76 @@
+package node
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/wasp/clients/apiextensions"
+	"github.com/iotaledger/wasp/tools/wasp-cli/chain"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/cliclients"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/tools/wasp-cli/waspcmd"
+)
+
+func initRestoreCmd() *cobra.Command {
+	var node string
+	var chainName string
+	cmd := &cobra.Command{
+		Use:   "restore <backup file>",
+		Short: "Restores the chain state and registry data of the node from a backup",
+		Long: `Restores the chain state and registry data of the node from a backup.
+The restored state must be the latest state anchored on L1, or an older state linked to it by the blocks
+available on the node; the chain must be active on the node.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			chainName = chain.DefaultChainFallback(chainName)
+			chainID := config.GetChain(chainName)
+
+			f, err := os.Open(args[0])
+			log.Check(err)
+			defer f.Close()
+
+			client := cliclients.WaspClient(node)
+			result, err := apiextensions.RestoreChain(context.Background(), client, chainID.String(), f)
+			log.Check(err)
+
+			log.PrintCLIOutput(&RestoreModel{
+				ChainID:          result.ChainID,
+				StateIndex:       result.StateIndex,
+				L1Commitment:     result.L1Commitment,
+				L1StateIndex:     result.L1StateIndex,
+				UpToDate:         result.UpToDate,
+				RestoredDKShares: strings.Join(result.RestoredDKShares, ", "),
+			})
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	chain.WithChainFlag(cmd, &chainName)
+	return cmd
+}
+
+type RestoreModel struct {
+	ChainID          string
+	StateIndex       uint32
+	L1Commitment     string
+	L1StateIndex     uint32
+	UpToDate         bool
+	RestoredDKShares string
+}
+
+var _ log.CLIOutput = &RestoreModel{}
+
+func (r *RestoreModel) AsText() (string, error) {
+	restoreTemplate := `Chain: {{ .ChainID }}
+Restored state index: {{ .StateIndex }}
+Restored L1 commitment: {{ .L1Commitment }}
+State index anchored on L1: {{ .L1StateIndex }}
+Up to date: {{ .UpToDate }}
+Restored DK shares: {{ .RestoredDKShares }}`
+	return log.ParseCLIOutputTemplate(r, restoreTemplate)
+}
@@ -24,7 +24,9 @@ func initAddressCmd() *cobra.Command {
 
 			if log.VerboseFlag {
 				verboseOutput := make(map[string]string)
-				verboseOutput["Private key"] = myWallet.KeyPair.GetPrivateKey().String()
+				if privateKey := myWallet.PrivateKey(); privateKey != nil {
+					verboseOutput["Private key"] = privateKey.String()
+				}
 				verboseOutput["Public key"] = myWallet.KeyPair.GetPublicKey().String()
 				model.VerboseOutput = verboseOutput
 			}
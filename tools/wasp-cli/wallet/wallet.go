@@ -6,7 +6,10 @@ import (
 
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/parameters"
 	"github.com/iotaledger/wasp/tools/wasp-cli/cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/wallet"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/wallet/providers"
 	"github.com/iotaledger/wasp/tools/wasp-cli/log"
 )
 
@@ -15,38 +18,102 @@ type WalletConfig struct {
 }
 
 func initInitCmd() *cobra.Command {
-	return &cobra.Command{
+	var providerName string
+	var signerSocket string
+	var importSeed bool
+
+	cmd := &cobra.Command{
 		Use:   "init",
 		Short: "Initialize a new wallet",
-		Args:  cobra.NoArgs,
+		Long: `Initialize a new wallet, with one of the following providers:
+  seed:     a new seed is stored as plain text in the config file (default)
+  keystore: a new seed (or the seed in the config file, with --import-seed) is stored in a password-encrypted keystore file
+  external: the keys are kept by an external signer process, listening on --signer-socket`,
+		Args: cobra.NoArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			seed := cryptolib.NewSeed()
-			seedString := iotago.EncodeHex(seed[:])
-			viper.Set("wallet.seed", seedString)
-			log.Check(viper.WriteConfig())
+			provider, err := wallet.ParseWalletProvider(providerName)
+			log.Check(err)
 
 			model := &InitModel{
+				Provider:   string(provider),
 				ConfigPath: config.ConfigPath,
 			}
-			if log.VerboseFlag {
-				model.Seed = seedString
+			switch provider {
+			case wallet.ProviderSeed:
+				seed := cryptolib.NewSeed()
+				providers.StoreSeed(seed[:])
+				if log.VerboseFlag {
+					model.Seed = iotago.EncodeHex(seed[:])
+				}
+			case wallet.ProviderKeystore:
+				var seed []byte
+				if importSeed {
+					seed = currentSeed()
+				} else {
+					newSeed := cryptolib.NewSeed()
+					seed = newSeed[:]
+					// the keystore is useless without the password, so the new seed must be backed up
+					model.Seed = iotago.EncodeHex(seed)
+				}
+				model.KeystorePath = providers.StoreKeystore(seed)
+				// do not keep the seed in clear text
+				config.Set("wallet.seed", "")
+			case wallet.ProviderExternal:
+				if signerSocket == "" {
+					log.Fatal("--signer-socket is required for the external provider")
+				}
+				config.Set("wallet.signerSocket", signerSocket)
+				model.SignerSocket = signerSocket
+				kp := providers.LoadExternalSigner(uint32(wallet.AddressIndex))
+				model.Address = kp.Address().Bech32(parameters.L1().Protocol.Bech32HRP)
 			}
+			config.Set("wallet.provider", string(provider))
 			log.PrintCLIOutput(model)
 		},
 	}
+
+	cmd.Flags().StringVar(&providerName, "provider", string(wallet.ProviderSeed), "wallet provider (seed, keystore, external)")
+	cmd.Flags().StringVar(&signerSocket, "signer-socket", "", "path of the socket of the external signer")
+	cmd.Flags().BoolVar(&importSeed, "import-seed", false, "move the seed from the config file into the keystore")
+	return cmd
+}
+
+func currentSeed() []byte {
+	if wallet.GetWalletProvider() != wallet.ProviderSeed || viper.GetString("wallet.seed") == "" {
+		log.Fatal("there is no seed in the config file to import")
+	}
+	seed, err := iotago.DecodeHex(viper.GetString("wallet.seed"))
+	log.Check(err)
+	return seed
 }
 
 type InitModel struct {
-	ConfigPath string
-	Seed       string
+	Provider     string
+	ConfigPath   string
+	KeystorePath string
+	SignerSocket string
+	Address      string
+	Seed         string
 }
 
 var _ log.CLIOutput = &InitModel{}
 
 func (i *InitModel) AsText() (string, error) {
-	template := `Initialized wallet seed in {{ .ConfigPath }}
+	template := `{{ if eq .Provider "keystore" -}}
+Initialized encrypted wallet keystore in {{ .KeystorePath }}
+{{- if .Seed }}
+
+IMPORTANT: write down the seed below and keep it safe. It is the only way to recover the wallet if the keystore file or its password is lost.
+{{- end }}
+{{- else if eq .Provider "external" -}}
+Initialized wallet with the external signer at {{ .SignerSocket }}
+  Address: {{ .Address }}
+{{- else -}}
+Initialized wallet seed in {{ .ConfigPath }}
 
 IMPORTANT: wasp-cli is alpha phase. The seed is currently being stored in a plain text file which is NOT secure. Do not use this seed to store funds in the mainnet.
+Use 'init --provider keystore' to store the seed in a password-encrypted keystore.
+{{- end }}
 {{ if .Seed }}
   Seed: {{ .Seed -}}
 {{ end }}